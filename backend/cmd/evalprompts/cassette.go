@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Cassette modes
+const (
+	modeReplay = "replay" // Serve only from recordings; fail on a miss (offline)
+	modeRecord = "record" // Always call the API and overwrite recordings
+	modeAuto   = "auto"   // Replay when recorded, otherwise call the API and record
+)
+
+// recording is one stored request/response pair.
+// Request headers are not stored so API keys never end up in the repo.
+type recording struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	RequestBody json.RawMessage `json:"request_body"`
+	Status      int             `json:"status"`
+	ContentType string          `json:"content_type"`
+	Body        string          `json:"body"`
+}
+
+// cassette is an http.RoundTripper that records LLM API calls to disk and replays them,
+// keyed by a hash of method, URL and request body
+type cassette struct {
+	dir   string
+	mode  string
+	next  http.RoundTripper
+	calls int // Requests served (replayed or live)
+	// answers counts successful responses, i.e. model outputs including JSON
+	// repair re-prompts but not requests rejected for their response_format
+	answers int
+}
+
+func newCassette(dir, mode string) (*cassette, error) {
+	switch mode {
+	case modeReplay, modeRecord, modeAuto:
+	default:
+		return nil, fmt.Errorf("unknown cassette mode '%s' (replay, record, auto)", mode)
+	}
+	if mode != modeReplay {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create cassette dir: %w", err)
+		}
+	}
+	return &cassette{dir: dir, mode: mode, next: http.DefaultTransport}, nil
+}
+
+func requestKey(method, url string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + url + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))[:24]
+}
+
+func (c *cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.calls++
+
+	key := requestKey(req.Method, req.URL.String(), body)
+	path := filepath.Join(c.dir, key+".json")
+
+	if c.mode != modeRecord {
+		if rec, err := loadRecording(path); err == nil {
+			return c.serve(rec, req), nil
+		} else if c.mode == modeReplay {
+			return nil, fmt.Errorf("no recording %s for %s (run with -mode=auto or -mode=record): %w", key, req.URL, err)
+		}
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &recording{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(respBody),
+	}
+	if json.Valid(body) {
+		rec.RequestBody = body
+	}
+	// Don't persist transient failures (rate limits, outages) as the expected answer
+	if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		if err := saveRecording(path, rec); err != nil {
+			return nil, err
+		}
+	}
+	return c.serve(rec, req), nil
+}
+
+func (c *cassette) serve(rec *recording, req *http.Request) *http.Response {
+	if rec.Status == http.StatusOK {
+		c.answers++
+	}
+	return rec.response(req)
+}
+
+func loadRecording(path string) (*recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("corrupt recording %s: %w", path, err)
+	}
+	return &rec, nil
+}
+
+func saveRecording(path string, rec *recording) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save recording: %w", err)
+	}
+	return nil
+}
+
+func (rec *recording) response(req *http.Request) *http.Response {
+	header := http.Header{}
+	if rec.ContentType != "" {
+		header.Set("Content-Type", rec.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(rec.Body))),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+func TestCassetteRecordThenReplay(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"recorded answer"}}]}`)
+	}))
+
+	dir := t.TempDir()
+	complete := func(mode string) (string, error) {
+		tape, err := newCassette(dir, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := ai.NewBaseProvider(ai.ProviderConfig{Name: "Test", BaseURL: srv.URL, APIKey: "secret", TextModel: "m"})
+		p.SetTransport(tape)
+		return p.GenerateCompletion("same prompt")
+	}
+
+	if _, err := complete(modeAuto); err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+	srv.Close() // Replay must not touch the network
+
+	got, err := complete(modeReplay)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if got != "recorded answer" || hits != 1 {
+		t.Fatalf("got %q with %d upstream hits, want recorded answer with 1", got, hits)
+	}
+
+	tape, _ := newCassette(dir, modeReplay)
+	p := ai.NewBaseProvider(ai.ProviderConfig{Name: "Test", BaseURL: srv.URL, TextModel: "m"})
+	p.SetTransport(tape)
+	if _, err := p.GenerateCompletion("different prompt"); err == nil {
+		t.Fatal("expected a replay miss for an unrecorded request")
+	}
+}
+
+func TestCassetteSkipsRejectedResponseFormat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "response_format") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"response_format json_schema is not supported"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"{\"title\":\"T\",\"tags\":[\"go\"],\"flashcards\":[{\"question\":\"Q?\",\"answer\":\"A\"}]}"}}]}`)
+	}))
+	defer srv.Close()
+
+	tape, err := newCassette(t.TempDir(), modeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := ai.NewBaseProvider(ai.ProviderConfig{Name: "Test", BaseURL: srv.URL, TextModel: "m", JSONMode: ai.JSONModeSchema})
+	p.SetTransport(tape)
+	r := &runner{tape: tape}
+	v := &variant{Label: "A", flashcardsPrompt: "Make cards: {{.Content}}"}
+
+	s := r.evalFlashcards(p, v, material{Name: "m", Content: "text"})
+	if s.Err != "" || tape.calls != 2 || s.Attempts != 1 || !s.JSONValidFirstTry {
+		t.Fatalf("got %+v after %d calls, want valid first try after the fallback", s, tape.calls)
+	}
+}
+
+func TestScoreFlashcards(t *testing.T) {
+	cards := []*learning.Flashcard{
+		{Question: "What does SYN stand for?", Answer: "Synchronize"},
+		{Question: "what does SYN stand for", Answer: "Synchronize sequence numbers"},
+		{Question: "What is TIME_WAIT?", Answer: strings.Repeat("word ", maxAnswerWords+1)},
+	}
+	s := scoreFlashcards("tcp", cards, 2)
+	if s.Cards != 3 || s.DuplicateQuestions != 1 || s.LongAnswers != 1 || s.JSONValidFirstTry {
+		t.Fatalf("unexpected score: %+v", s)
+	}
+}
@@ -0,0 +1,188 @@
+// evalprompts runs a golden corpus of materials through two prompt/model versions
+// and writes a Markdown comparison report.
+//
+// API calls go through a record/replay cassette, so once recorded the evaluation
+// runs offline and deterministically:
+//
+//	go run ./cmd/evalprompts -mode=auto -b-flashcards=/tmp/flashcards_v2.txt   # record
+//	go run ./cmd/evalprompts -b-flashcards=/tmp/flashcards_v2.txt              # replay offline
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/internal/ai/models"
+	"github.com/amityadav/landr/prompts"
+	"github.com/joho/godotenv"
+)
+
+// variant is one side of the comparison
+type variant struct {
+	Label            string
+	Model            string
+	FlashcardsPath   string // Empty = built-in prompt
+	SummaryPath      string
+	flashcardsPrompt string
+	summaryPrompt    string
+}
+
+func (v *variant) describe() string {
+	name := func(path string) string {
+		if path == "" {
+			return "built-in"
+		}
+		return filepath.Base(path)
+	}
+	return fmt.Sprintf("model `%s`, flashcards prompt `%s`, summary prompt `%s`", v.Model, name(v.FlashcardsPath), name(v.SummaryPath))
+}
+
+// load reads the prompt files and validates them against the prompt data
+func (v *variant) load() error {
+	var err error
+	if v.flashcardsPrompt, err = loadPrompt(prompts.NameFlashcards, v.FlashcardsPath); err != nil {
+		return fmt.Errorf("variant %s: %w", v.Label, err)
+	}
+	if v.summaryPrompt, err = loadPrompt(prompts.NameSummary, v.SummaryPath); err != nil {
+		return fmt.Errorf("variant %s: %w", v.Label, err)
+	}
+	return nil
+}
+
+func loadPrompt(name, path string) (string, error) {
+	if path == "" {
+		body, _, _ := prompts.Builtin(name)
+		return body, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s prompt: %w", name, err)
+	}
+	if err := prompts.Validate(name, string(data)); err != nil {
+		return "", fmt.Errorf("invalid %s prompt %s: %w", name, path, err)
+	}
+	return string(data), nil
+}
+
+// material is one document of the golden corpus
+type material struct {
+	Name    string
+	Content string
+}
+
+func loadCorpus(dir string) ([]material, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %w", err)
+	}
+	var corpus []material
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".txt" && ext != ".md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		corpus = append(corpus, material{Name: strings.TrimSuffix(e.Name(), ext), Content: string(data)})
+	}
+	sort.Slice(corpus, func(i, j int) bool { return corpus[i].Name < corpus[j].Name })
+	if len(corpus) == 0 {
+		return nil, fmt.Errorf("no .txt or .md materials in %s", dir)
+	}
+	return corpus, nil
+}
+
+func main() {
+	var (
+		corpusDir    = flag.String("corpus", "eval/corpus", "directory of golden materials (.txt/.md)")
+		cassetteDir  = flag.String("cassettes", "eval/cassettes", "directory of recorded API responses")
+		mode         = flag.String("mode", modeReplay, "cassette mode: replay (offline), auto, record")
+		providerName = flag.String("provider", "groq", "LLM provider: groq or cerebras")
+		kind         = flag.String("kind", "both", "what to evaluate: flashcards, summary or both")
+		judge        = flag.Bool("judge", false, "score outputs with an LLM-judge rubric")
+		judgeModel   = flag.String("judge-model", models.ModelGroqLlama3_3_70b, "model used as judge")
+		outPath      = flag.String("out", "", "write the report to this file instead of stdout")
+	)
+	a := &variant{Label: "A"}
+	b := &variant{Label: "B"}
+	flag.StringVar(&a.Model, "a-model", models.TaskFlashcardModel, "model for variant A")
+	flag.StringVar(&b.Model, "b-model", models.TaskFlashcardModel, "model for variant B")
+	flag.StringVar(&a.FlashcardsPath, "a-flashcards", "", "flashcards prompt template for A (default: built-in)")
+	flag.StringVar(&b.FlashcardsPath, "b-flashcards", "", "flashcards prompt template for B (default: built-in)")
+	flag.StringVar(&a.SummaryPath, "a-summary", "", "summary prompt template for A (default: built-in)")
+	flag.StringVar(&b.SummaryPath, "b-summary", "", "summary prompt template for B (default: built-in)")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	apiKey := os.Getenv("GROQ_API_KEY")
+	if *providerName == "cerebras" {
+		apiKey = os.Getenv("CEREBRAS_API_KEY")
+	}
+	if apiKey == "" && *mode != modeReplay {
+		log.Fatalf("[EvalPrompts] API key for %s required in %s mode", *providerName, *mode)
+	}
+
+	corpus, err := loadCorpus(*corpusDir)
+	if err != nil {
+		log.Fatalf("[EvalPrompts] %v", err)
+	}
+
+	tape, err := newCassette(*cassetteDir, *mode)
+	if err != nil {
+		log.Fatalf("[EvalPrompts] %v", err)
+	}
+
+	newProvider := func(model string) *ai.BaseProvider {
+		p := ai.NewLLMProvider(*providerName, apiKey, model)
+		p.SetTransport(tape)
+		return p
+	}
+
+	r := &runner{tape: tape}
+	if *judge {
+		r.judge = newProvider(*judgeModel)
+	}
+
+	report := &report{A: a, B: b, Corpus: len(corpus), Judge: *judge}
+	for _, v := range []*variant{a, b} {
+		if err := v.load(); err != nil {
+			log.Fatalf("[EvalPrompts] %v", err)
+		}
+		provider := newProvider(v.Model)
+
+		var flashcards []*flashcardScore
+		var summaries []*summaryScore
+		for _, m := range corpus {
+			if *kind == "flashcards" || *kind == "both" {
+				flashcards = append(flashcards, r.evalFlashcards(provider, v, m))
+			}
+			if *kind == "summary" || *kind == "both" {
+				summaries = append(summaries, r.evalSummary(provider, v, m))
+			}
+		}
+		report.add(v.Label, flashcards, summaries)
+	}
+
+	out := os.Stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatalf("[EvalPrompts] Failed to create report: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	report.write(out)
+	log.Printf("[EvalPrompts] Done: %d materials, %d API calls (%s mode)", len(corpus), tape.calls, *mode)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// report collects scores for both variants and renders a Markdown comparison
+type report struct {
+	A, B       *variant
+	Corpus     int
+	Judge      bool
+	flashcards map[string][]*flashcardScore
+	summaries  map[string][]*summaryScore
+}
+
+func (r *report) add(label string, flashcards []*flashcardScore, summaries []*summaryScore) {
+	if r.flashcards == nil {
+		r.flashcards = map[string][]*flashcardScore{}
+		r.summaries = map[string][]*summaryScore{}
+	}
+	r.flashcards[label] = flashcards
+	r.summaries[label] = summaries
+}
+
+// flashcardTotals aggregates per-material flashcard scores
+type flashcardTotals struct {
+	ok, errors, validFirstTry, cards, duplicates, longAnswers int
+	answerWords                                               float64
+	judge                                                     judgeAverages
+}
+
+type judgeAverages struct {
+	n                           int
+	accuracy, coverage, clarity float64
+}
+
+func (j *judgeAverages) add(s *judgeScore) {
+	if s == nil {
+		return
+	}
+	j.n++
+	j.accuracy += s.Accuracy
+	j.coverage += s.Coverage
+	j.clarity += s.Clarity
+}
+
+func (j judgeAverages) mean(sum float64) float64 {
+	if j.n == 0 {
+		return 0
+	}
+	return sum / float64(j.n)
+}
+
+func totalFlashcards(scores []*flashcardScore) flashcardTotals {
+	var t flashcardTotals
+	for _, s := range scores {
+		if s.Err != "" {
+			t.errors++
+			continue
+		}
+		t.ok++
+		if s.JSONValidFirstTry {
+			t.validFirstTry++
+		}
+		t.cards += s.Cards
+		t.duplicates += s.DuplicateQuestions
+		t.longAnswers += s.LongAnswers
+		t.answerWords += s.AvgAnswerWords
+		t.judge.add(s.Judge)
+	}
+	return t
+}
+
+func (t flashcardTotals) perMaterial(v float64) float64 {
+	if t.ok == 0 {
+		return 0
+	}
+	return v / float64(t.ok)
+}
+
+type summaryTotals struct {
+	ok, errors  int
+	words       int
+	compression float64
+	judge       judgeAverages
+}
+
+func totalSummaries(scores []*summaryScore) summaryTotals {
+	var t summaryTotals
+	for _, s := range scores {
+		if s.Err != "" {
+			t.errors++
+			continue
+		}
+		t.ok++
+		t.words += s.Words
+		t.compression += s.Compression
+		t.judge.add(s.Judge)
+	}
+	return t
+}
+
+func (t summaryTotals) perMaterial(v float64) float64 {
+	if t.ok == 0 {
+		return 0
+	}
+	return v / float64(t.ok)
+}
+
+func (r *report) write(w io.Writer) {
+	fmt.Fprintf(w, "# Prompt evaluation report\n\n")
+	fmt.Fprintf(w, "Corpus: %d materials\n\n", r.Corpus)
+	fmt.Fprintf(w, "- **A**: %s\n", r.A.describe())
+	fmt.Fprintf(w, "- **B**: %s\n\n", r.B.describe())
+
+	if fa, fb := r.flashcards["A"], r.flashcards["B"]; len(fa) > 0 || len(fb) > 0 {
+		r.writeFlashcards(w, fa, fb)
+	}
+	if sa, sb := r.summaries["A"], r.summaries["B"]; len(sa) > 0 || len(sb) > 0 {
+		r.writeSummaries(w, sa, sb)
+	}
+}
+
+func (r *report) writeFlashcards(w io.Writer, a, b []*flashcardScore) {
+	ta, tb := totalFlashcards(a), totalFlashcards(b)
+
+	fmt.Fprintf(w, "## Flashcards\n\n")
+	fmt.Fprintf(w, "| Metric | A | B | Δ (B−A) |\n|---|---|---|---|\n")
+	row(w, "Errors", float64(ta.errors), float64(tb.errors), "%.0f")
+	row(w, "JSON valid first try", float64(ta.validFirstTry), float64(tb.validFirstTry), "%.0f")
+	row(w, "Avg cards per material", ta.perMaterial(float64(ta.cards)), tb.perMaterial(float64(tb.cards)), "%.1f")
+	row(w, "Duplicate questions (total)", float64(ta.duplicates), float64(tb.duplicates), "%.0f")
+	row(w, "Avg answer words", ta.perMaterial(ta.answerWords), tb.perMaterial(tb.answerWords), "%.1f")
+	row(w, fmt.Sprintf("Answers over %d words (total)", maxAnswerWords), float64(ta.longAnswers), float64(tb.longAnswers), "%.0f")
+	if r.Judge {
+		judgeRows(w, ta.judge, tb.judge)
+	}
+
+	fmt.Fprintf(w, "\n### Per material\n\n")
+	fmt.Fprintf(w, "| Material | Cards A | Cards B | Dupes A | Dupes B | Attempts A | Attempts B | Notes |\n|---|---|---|---|---|---|---|---|\n")
+	for i := range a {
+		sa, sb := a[i], at(b, i)
+		fmt.Fprintf(w, "| %s | %d | %d | %d | %d | %d | %d | %s |\n",
+			sa.Material, sa.Cards, sb.Cards, sa.DuplicateQuestions, sb.DuplicateQuestions,
+			sa.Attempts, sb.Attempts, notes(sa.Err, sb.Err, sa.Judge, sb.Judge))
+	}
+	fmt.Fprintln(w)
+}
+
+func (r *report) writeSummaries(w io.Writer, a, b []*summaryScore) {
+	ta, tb := totalSummaries(a), totalSummaries(b)
+
+	fmt.Fprintf(w, "## Summaries\n\n")
+	fmt.Fprintf(w, "| Metric | A | B | Δ (B−A) |\n|---|---|---|---|\n")
+	row(w, "Errors", float64(ta.errors), float64(tb.errors), "%.0f")
+	row(w, "Avg words", ta.perMaterial(float64(ta.words)), tb.perMaterial(float64(tb.words)), "%.0f")
+	row(w, "Avg compression (summary/source words)", ta.perMaterial(ta.compression), tb.perMaterial(tb.compression), "%.2f")
+	if r.Judge {
+		judgeRows(w, ta.judge, tb.judge)
+	}
+
+	fmt.Fprintf(w, "\n### Per material\n\n")
+	fmt.Fprintf(w, "| Material | Words A | Words B | Notes |\n|---|---|---|---|\n")
+	for i := range a {
+		sa, sb := a[i], atSummary(b, i)
+		fmt.Fprintf(w, "| %s | %d | %d | %s |\n", sa.Material, sa.Words, sb.Words, notes(sa.Err, sb.Err, sa.Judge, sb.Judge))
+	}
+	fmt.Fprintln(w)
+}
+
+func row(w io.Writer, name string, a, b float64, format string) {
+	fmt.Fprintf(w, "| %s | "+format+" | "+format+" | %+"+strings.TrimPrefix(format, "%")+" |\n", name, a, b, b-a)
+}
+
+func judgeRows(w io.Writer, a, b judgeAverages) {
+	row(w, "Judge accuracy (1-5)", a.mean(a.accuracy), b.mean(b.accuracy), "%.2f")
+	row(w, "Judge coverage (1-5)", a.mean(a.coverage), b.mean(b.coverage), "%.2f")
+	row(w, "Judge clarity (1-5)", a.mean(a.clarity), b.mean(b.clarity), "%.2f")
+}
+
+func notes(errA, errB string, judgeA, judgeB *judgeScore) string {
+	var parts []string
+	if errA != "" {
+		parts = append(parts, "A error: "+errA)
+	}
+	if errB != "" {
+		parts = append(parts, "B error: "+errB)
+	}
+	if judgeA != nil && judgeA.Comment != "" {
+		parts = append(parts, "A judge: "+judgeA.Comment)
+	}
+	if judgeB != nil && judgeB.Comment != "" {
+		parts = append(parts, "B judge: "+judgeB.Comment)
+	}
+	// Keep the Markdown table intact
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(strings.Join(parts, "; "))
+}
+
+func at(scores []*flashcardScore, i int) *flashcardScore {
+	if i < len(scores) {
+		return scores[i]
+	}
+	return &flashcardScore{}
+}
+
+func atSummary(scores []*summaryScore, i int) *summaryScore {
+	if i < len(scores) {
+		return scores[i]
+	}
+	return &summaryScore{}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/pkg/pb/learning"
+	"github.com/amityadav/landr/prompts"
+)
+
+// judgeSchema describes the LLM-judge rubric response
+var judgeSchema = ai.ObjectSchema(map[string]*ai.Schema{
+	"accuracy": ai.NumberSchema(1, 5),
+	"coverage": ai.NumberSchema(1, 5),
+	"clarity":  ai.NumberSchema(1, 5),
+	"comment":  ai.StringSchema(false),
+})
+
+// runner generates outputs for each material and scores them
+type runner struct {
+	tape  *cassette
+	judge ai.Provider // Optional LLM judge
+}
+
+func (r *runner) evalFlashcards(provider ai.Provider, v *variant, m material) *flashcardScore {
+	prompt, err := prompts.Render(v.flashcardsPrompt, prompts.FlashcardsData{
+		Content: ai.TruncateToLimit(m.Content, provider.MaxContentLen()),
+	})
+	if err != nil {
+		return &flashcardScore{Material: m.Name, Err: err.Error()}
+	}
+
+	before := r.tape.answers
+	_, _, cards, err := provider.GenerateFlashcardsFromPrompt(prompt)
+	attempts := r.tape.answers - before
+	if err != nil {
+		log.Printf("[EvalPrompts] %s/%s flashcards failed: %v", v.Label, m.Name, err)
+		return &flashcardScore{Material: m.Name, Err: err.Error(), Attempts: attempts}
+	}
+
+	score := scoreFlashcards(m.Name, cards, attempts)
+	if r.judge != nil {
+		score.Judge = r.runJudge(m, "flashcards", formatCards(cards))
+	}
+	return score
+}
+
+func (r *runner) evalSummary(provider ai.Provider, v *variant, m material) *summaryScore {
+	prompt, err := prompts.Render(v.summaryPrompt, prompts.SummaryData{
+		Content: ai.TruncateToLimit(m.Content, ai.MaxSummaryContentLen),
+	})
+	if err != nil {
+		return &summaryScore{Material: m.Name, Err: err.Error()}
+	}
+
+	summary, err := provider.GenerateCompletion(prompt)
+	if err != nil {
+		log.Printf("[EvalPrompts] %s/%s summary failed: %v", v.Label, m.Name, err)
+		return &summaryScore{Material: m.Name, Err: err.Error()}
+	}
+
+	score := scoreSummary(m.Name, m.Content, summary)
+	if r.judge != nil {
+		score.Judge = r.runJudge(m, "summary", summary)
+	}
+	return score
+}
+
+func (r *runner) runJudge(m material, kind, output string) *judgeScore {
+	prompt, err := prompts.Render(prompts.EvalJudge, map[string]string{
+		"Content": ai.TruncateToLimit(m.Content, ai.MaxSummaryContentLen),
+		"Kind":    kind,
+		"Output":  output,
+	})
+	if err != nil {
+		log.Printf("[EvalPrompts] Judge prompt failed: %v", err)
+		return nil
+	}
+
+	var score judgeScore
+	if err := r.judge.GenerateJSON(prompt, judgeSchema, &score); err != nil {
+		log.Printf("[EvalPrompts] Judge failed for %s/%s: %v", m.Name, kind, err)
+		return nil
+	}
+	return &score
+}
+
+func formatCards(cards []*learning.Flashcard) string {
+	var sb strings.Builder
+	for i, c := range cards {
+		fmt.Fprintf(&sb, "Q%d: %s\nA: %s\n\n", i+1, c.Question, c.Answer)
+	}
+	return sb.String()
+}
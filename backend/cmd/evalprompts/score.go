@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+// maxAnswerWords is the answer length above which a card is hard to review
+const maxAnswerWords = 60
+
+// judgeScore is the LLM-judge rubric result (1-5 per criterion)
+type judgeScore struct {
+	Accuracy float64 `json:"accuracy"`
+	Coverage float64 `json:"coverage"`
+	Clarity  float64 `json:"clarity"`
+	Comment  string  `json:"comment"`
+}
+
+// flashcardScore holds the metrics for one material's generated cards
+type flashcardScore struct {
+	Material           string
+	Err                string
+	Attempts           int  // Model answers, including JSON repair re-prompts
+	JSONValidFirstTry  bool // Schema-valid output without any repair
+	Cards              int
+	DuplicateQuestions int
+	AvgAnswerWords     float64
+	LongAnswers        int
+	Judge              *judgeScore
+}
+
+// summaryScore holds the metrics for one material's generated summary
+type summaryScore struct {
+	Material    string
+	Err         string
+	Words       int
+	Compression float64 // Summary words / source words
+	Judge       *judgeScore
+}
+
+func scoreFlashcards(material string, cards []*learning.Flashcard, attempts int) *flashcardScore {
+	s := &flashcardScore{
+		Material:          material,
+		Attempts:          attempts,
+		JSONValidFirstTry: attempts == 1,
+		Cards:             len(cards),
+	}
+
+	seen := make(map[string]bool)
+	totalWords := 0
+	for _, card := range cards {
+		q := normalizeQuestion(card.Question)
+		if seen[q] {
+			s.DuplicateQuestions++
+		}
+		seen[q] = true
+
+		words := len(strings.Fields(card.Answer))
+		totalWords += words
+		if words > maxAnswerWords {
+			s.LongAnswers++
+		}
+	}
+	if len(cards) > 0 {
+		s.AvgAnswerWords = float64(totalWords) / float64(len(cards))
+	}
+	return s
+}
+
+func scoreSummary(material, source, summary string) *summaryScore {
+	s := &summaryScore{Material: material, Words: len(strings.Fields(summary))}
+	if sourceWords := len(strings.Fields(source)); sourceWords > 0 {
+		s.Compression = float64(s.Words) / float64(sourceWords)
+	}
+	return s
+}
+
+// normalizeQuestion lowercases and strips punctuation so trivially reworded repeats match
+func normalizeQuestion(q string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(q) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		case unicode.IsSpace(r):
+			sb.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
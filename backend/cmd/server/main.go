@@ -24,14 +24,15 @@ func main() {
 		// Modules group related providers (like Spring @Configuration)
 		appfx.ConfigModule,       // Provides: config.Config
 		appfx.StoreModule,        // Provides: *store.PostgresStore
-		appfx.SettingsModule,     // Provides: *settings.Service (database-backed)
+		appfx.SettingsModule,     // Provides: *settings.Service (database-backed), *quota.Enforcer
+		appfx.PromptModule,       // Provides: *promptregistry.Registry
 		appfx.TokenModule,        // Provides: *token.Manager
 		appfx.ScraperModule,      // Provides: *scraper.Scraper
 		appfx.AIModule,           // Provides: ai.Provider (named: "learning", "feed")
 		appfx.SearchModule,       // Provides: *search.Registry
-		appfx.CoreModule,         // Provides: *core.AuthCore, *core.LearningCore, *core.FeedCore
+		appfx.CoreModule,         // Provides: *core.AuthCore, *core.LearningCore, *core.SourceCore, *core.FeedCore
 		appfx.ServiceModule,      // Provides: *service.AuthService, *service.LearningService, *service.FeedService
-		appfx.NotificationModule, // Provides: *firebase.Sender, *notifications.Worker
+		appfx.NotificationModule, // Provides: *firebase.Sender, *notifications.Worker, *notifications.SourcePoller
 		appfx.PaymentModule,      // Provides: *payment.Service (Razorpay)
 		appfx.ServerModule,       // Starts gRPC + HTTP servers, registers services
 
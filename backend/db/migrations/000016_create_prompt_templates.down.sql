@@ -0,0 +1,3 @@
+ALTER TABLE materials DROP COLUMN IF EXISTS prompt_template_id;
+DROP TABLE IF EXISTS user_prompt_overrides;
+DROP TABLE IF EXISTS prompt_templates;
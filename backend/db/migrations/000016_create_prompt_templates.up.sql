@@ -0,0 +1,28 @@
+-- Versioned prompt templates rendered with Go text/template.
+-- Several versions of the same name may be active at once; weight splits traffic for A/B tests.
+CREATE TABLE IF NOT EXISTS prompt_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,                      -- e.g. 'flashcards'
+    version INT NOT NULL,
+    variant TEXT NOT NULL DEFAULT 'control', -- experiment arm label
+    body TEXT NOT NULL,
+    weight INT NOT NULL DEFAULT 0,           -- share of traffic; 0 = inactive
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (name, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_prompt_templates_active ON prompt_templates(name) WHERE weight > 0;
+
+-- Pin a user to a specific template version (overrides A/B assignment)
+CREATE TABLE IF NOT EXISTS user_prompt_overrides (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    template_id UUID NOT NULL REFERENCES prompt_templates(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, name)
+);
+
+-- Which template generated a material's flashcards (NULL = built-in prompt)
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS prompt_template_id UUID REFERENCES prompt_templates(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_flashcards_embedding;
+ALTER TABLE flashcards DROP COLUMN IF EXISTS embedding;
+DROP TABLE IF EXISTS material_chunks;
+-- Keep the vector extension: other database objects may depend on it
@@ -0,0 +1,19 @@
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- Material content split into passages for semantic search and retrieval
+CREATE TABLE IF NOT EXISTS material_chunks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    material_id UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    chunk_index INT NOT NULL,
+    content TEXT NOT NULL,
+    embedding vector(768),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (material_id, chunk_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_material_chunks_embedding ON material_chunks USING hnsw (embedding vector_cosine_ops);
+
+-- Per-card embedding of "question + answer"; NULL until indexed (or after an edit)
+ALTER TABLE flashcards ADD COLUMN IF NOT EXISTS embedding vector(768);
+
+CREATE INDEX IF NOT EXISTS idx_flashcards_embedding ON flashcards USING hnsw (embedding vector_cosine_ops);
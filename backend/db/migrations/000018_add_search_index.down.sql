@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_flashcards_search_tsv;
+ALTER TABLE flashcards DROP COLUMN IF EXISTS search_tsv;
+DROP INDEX IF EXISTS idx_materials_search_tsv;
+ALTER TABLE materials DROP COLUMN IF EXISTS search_tsv;
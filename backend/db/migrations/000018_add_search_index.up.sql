@@ -0,0 +1,16 @@
+-- Full-text search over material title, summary and content (weighted in that order)
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS search_tsv tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(summary, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(content, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_materials_search_tsv ON materials USING GIN (search_tsv);
+
+-- Full-text search over flashcard question and answer
+ALTER TABLE flashcards ADD COLUMN IF NOT EXISTS search_tsv tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', question), 'A') ||
+    setweight(to_tsvector('english', answer), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_flashcards_search_tsv ON flashcards USING GIN (search_tsv);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_materials_user_canonical_url;
+ALTER TABLE materials DROP COLUMN IF EXISTS fingerprinted_at;
+ALTER TABLE materials DROP COLUMN IF EXISTS content_simhash;
+ALTER TABLE materials DROP COLUMN IF EXISTS canonical_url;
@@ -0,0 +1,7 @@
+-- Duplicate detection: canonical URL (tracking params, AMP/mobile variants removed)
+-- and a 64-bit simhash of the content. Filled at import, and lazily for older rows.
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS canonical_url TEXT;
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS content_simhash BIGINT;
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS fingerprinted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_materials_user_canonical_url ON materials(user_id, canonical_url);
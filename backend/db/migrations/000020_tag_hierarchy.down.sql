@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tag_merge_suggestions;
+DROP TABLE IF EXISTS tag_aliases;
+ALTER TABLE tags ALTER COLUMN name TYPE VARCHAR(50);
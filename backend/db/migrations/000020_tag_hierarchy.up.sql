@@ -0,0 +1,26 @@
+-- Nested tags are stored as paths ("cs/distributed/raft"); allow longer names
+ALTER TABLE tags ALTER COLUMN name TYPE VARCHAR(255);
+
+-- Former names of renamed/merged tags, so AI-generated synonyms resolve to the canonical tag
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    alias VARCHAR(255) NOT NULL, -- lowercase
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, alias)
+);
+
+-- Merge suggestions produced by the background job, reviewed by the user
+CREATE TABLE IF NOT EXISTS tag_merge_suggestions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    target_tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    reason VARCHAR(50) NOT NULL, -- 'spelling', 'embedding' or 'llm'
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'accepted', 'dismissed'
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (user_id, source_tag_id, target_tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_merge_suggestions_user_status ON tag_merge_suggestions(user_id, status);
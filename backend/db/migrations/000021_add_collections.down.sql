@@ -0,0 +1,5 @@
+ALTER TABLE flashcards DROP COLUMN IF EXISTS first_reviewed_at;
+DROP INDEX IF EXISTS idx_materials_collection_id;
+ALTER TABLE materials DROP COLUMN IF EXISTS collection_id;
+DROP INDEX IF EXISTS idx_collections_user_position;
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,24 @@
+-- Collections (decks) group materials above tags, each with its own review settings
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    cover_url TEXT NOT NULL DEFAULT '',
+    position INT NOT NULL DEFAULT 0,
+    new_cards_per_day INT NOT NULL DEFAULT 0, -- 0 = unlimited
+    scheduler_preset VARCHAR(20) NOT NULL DEFAULT 'standard', -- 'standard', 'intensive' or 'relaxed'
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collections_user_position ON collections(user_id, position);
+
+-- A material belongs to at most one collection
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS collection_id UUID REFERENCES collections(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_materials_collection_id ON materials(collection_id);
+
+-- When a card was first reviewed; NULL = new card (counted against new_cards_per_day)
+ALTER TABLE flashcards ADD COLUMN IF NOT EXISTS first_reviewed_at TIMESTAMP WITH TIME ZONE;
+UPDATE flashcards SET first_reviewed_at = updated_at WHERE stage > 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_public_decks_user_id;
+DROP TABLE IF EXISTS public_decks;
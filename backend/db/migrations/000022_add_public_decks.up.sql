@@ -0,0 +1,16 @@
+-- A material or collection published as a read-only deck under a public slug
+CREATE TABLE IF NOT EXISTS public_decks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    slug VARCHAR(80) NOT NULL UNIQUE,
+    material_id UUID UNIQUE REFERENCES materials(id) ON DELETE CASCADE,
+    collection_id UUID UNIQUE REFERENCES collections(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    clone_count INT NOT NULL DEFAULT 0,
+    published_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK ((material_id IS NULL) <> (collection_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_public_decks_user_id ON public_decks(user_id);
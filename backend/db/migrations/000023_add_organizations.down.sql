@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS organization_usage_quotas;
+DROP TABLE IF EXISTS flashcard_progress;
+DROP INDEX IF EXISTS idx_collections_organization_id;
+ALTER TABLE collections DROP COLUMN IF EXISTS organization_id;
+DROP INDEX IF EXISTS idx_organization_members_user_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
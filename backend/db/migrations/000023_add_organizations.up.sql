@@ -0,0 +1,48 @@
+-- Organizations (team workspaces) with pooled Pro billing: seats are bought for the
+-- whole team instead of individual Pro plans
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    seats INT NOT NULL DEFAULT 5, -- Maximum number of members
+    plan TEXT NOT NULL DEFAULT 'FREE', -- 'FREE' or 'PRO' (PRO = every member gets Pro)
+    status TEXT NOT NULL DEFAULT 'ACTIVE',
+    current_period_end TIMESTAMPTZ,
+    razorpay_subscription_id TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'learner', -- 'owner', 'editor' or 'learner'
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);
+
+-- A collection shared with an organization is studied by all of its members
+ALTER TABLE collections ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_collections_organization_id ON collections(organization_id);
+
+-- Personal SRS state of members for cards they don't own (cards of shared collections)
+CREATE TABLE IF NOT EXISTS flashcard_progress (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    flashcard_id UUID NOT NULL REFERENCES flashcards(id) ON DELETE CASCADE,
+    stage INT NOT NULL DEFAULT 0,
+    next_review_at TIMESTAMPTZ NOT NULL,
+    first_reviewed_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, flashcard_id)
+);
+
+-- Daily usage pooled across the members of a Pro organization
+CREATE TABLE IF NOT EXISTS organization_usage_quotas (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    resource TEXT NOT NULL,
+    count INT NOT NULL DEFAULT 0,
+    last_reset_at DATE NOT NULL DEFAULT CURRENT_DATE,
+    PRIMARY KEY (organization_id, resource)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE organization_members DROP COLUMN IF EXISTS reporting_opt_out;
+DROP INDEX IF EXISTS idx_review_events_flashcard_id;
+DROP INDEX IF EXISTS idx_review_events_user_reviewed_at;
+DROP TABLE IF EXISTS review_events;
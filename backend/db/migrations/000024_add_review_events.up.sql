@@ -0,0 +1,14 @@
+-- One row per review, for progress reports (completion, retention, activity)
+CREATE TABLE IF NOT EXISTS review_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    flashcard_id UUID NOT NULL REFERENCES flashcards(id) ON DELETE CASCADE,
+    passed BOOLEAN NOT NULL,
+    reviewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_events_user_reviewed_at ON review_events(user_id, reviewed_at);
+CREATE INDEX IF NOT EXISTS idx_review_events_flashcard_id ON review_events(flashcard_id);
+
+-- Members who opt out only count towards the number of opted-out members in reports
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS reporting_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+ALTER TABLE materials DROP COLUMN IF EXISTS concepts_extracted_at;
+DROP TABLE IF EXISTS concept_relations;
+DROP TABLE IF EXISTS flashcard_concepts;
+DROP TABLE IF EXISTS material_concepts;
+DROP TABLE IF EXISTS concepts;
@@ -0,0 +1,43 @@
+-- Key concepts extracted from a user's materials. The same idea imported from several
+-- sources maps onto one concept (matched by normalized name).
+CREATE TABLE IF NOT EXISTS concepts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, normalized_name)
+);
+
+CREATE TABLE IF NOT EXISTS material_concepts (
+    material_id UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    concept_id UUID NOT NULL REFERENCES concepts(id) ON DELETE CASCADE,
+    PRIMARY KEY (material_id, concept_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_material_concepts_concept_id ON material_concepts(concept_id);
+
+CREATE TABLE IF NOT EXISTS flashcard_concepts (
+    flashcard_id UUID NOT NULL REFERENCES flashcards(id) ON DELETE CASCADE,
+    concept_id UUID NOT NULL REFERENCES concepts(id) ON DELETE CASCADE,
+    PRIMARY KEY (flashcard_id, concept_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_flashcard_concepts_concept_id ON flashcard_concepts(concept_id);
+
+-- Directed edges between concepts; weight counts the materials that stated the relation
+CREATE TABLE IF NOT EXISTS concept_relations (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_concept_id UUID NOT NULL REFERENCES concepts(id) ON DELETE CASCADE,
+    target_concept_id UUID NOT NULL REFERENCES concepts(id) ON DELETE CASCADE,
+    relation VARCHAR(20) NOT NULL, -- 'prerequisite', 'part_of' or 'related'
+    weight INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (source_concept_id, target_concept_id, relation)
+);
+
+CREATE INDEX IF NOT EXISTS idx_concept_relations_user_id ON concept_relations(user_id);
+CREATE INDEX IF NOT EXISTS idx_concept_relations_target ON concept_relations(target_concept_id);
+
+-- NULL = concepts not extracted yet (materials imported before this migration)
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS concepts_extracted_at TIMESTAMPTZ;
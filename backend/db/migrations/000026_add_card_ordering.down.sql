@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS flashcard_dependencies;
+ALTER TABLE flashcards DROP COLUMN IF EXISTS difficulty;
@@ -0,0 +1,13 @@
+-- Difficulty ranked by the model at generation: 1 (easiest) to 5, 0 = unknown.
+-- New cards are introduced easiest-first.
+ALTER TABLE flashcards ADD COLUMN IF NOT EXISTS difficulty SMALLINT NOT NULL DEFAULT 0;
+
+-- Explicit prerequisites: a new card is held back until the cards it depends on are learned
+CREATE TABLE IF NOT EXISTS flashcard_dependencies (
+    flashcard_id UUID NOT NULL REFERENCES flashcards(id) ON DELETE CASCADE,
+    depends_on_id UUID NOT NULL REFERENCES flashcards(id) ON DELETE CASCADE,
+    PRIMARY KEY (flashcard_id, depends_on_id),
+    CHECK (flashcard_id <> depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_flashcard_dependencies_depends_on_id ON flashcard_dependencies(depends_on_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS source_items;
+DROP TABLE IF EXISTS sources;
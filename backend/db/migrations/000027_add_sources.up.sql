@@ -0,0 +1,29 @@
+-- Feeds and pages a user subscribed to; new entries are imported as materials
+CREATE TABLE IF NOT EXISTS sources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,            -- FEED (RSS/Atom) or PAGE (article links of an index page)
+    url TEXT NOT NULL,                    -- As entered by the user
+    feed_url TEXT NOT NULL,               -- What is polled: the discovered feed, or the page itself
+    title TEXT NOT NULL DEFAULT '',
+    tag VARCHAR(255) NOT NULL DEFAULT '', -- Added to every material imported from the source
+    etag TEXT NOT NULL DEFAULT '',        -- Validators of the last response, for conditional GETs
+    last_modified TEXT NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT '',
+    items_imported INT NOT NULL DEFAULT 0,
+    last_polled_at TIMESTAMPTZ,
+    next_poll_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, feed_url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sources_next_poll_at ON sources(next_poll_at);
+
+-- Entries already seen per source (imported, skipped, or present when subscribing)
+CREATE TABLE IF NOT EXISTS source_items (
+    source_id UUID NOT NULL REFERENCES sources(id) ON DELETE CASCADE,
+    item_key TEXT NOT NULL, -- Canonical URL of the entry
+    material_id UUID REFERENCES materials(id) ON DELETE SET NULL,
+    seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source_id, item_key)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS material_refreshes;
+ALTER TABLE materials DROP COLUMN IF EXISTS content_checked_at;
@@ -0,0 +1,11 @@
+-- Linked materials are re-fetched periodically to detect changes to their source
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS content_checked_at TIMESTAMPTZ;
+
+-- A newer extraction of a material's source that differs significantly from
+-- materials.content, waiting for the user to refresh the material
+CREATE TABLE IF NOT EXISTS material_refreshes (
+    material_id UUID PRIMARY KEY REFERENCES materials(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    change_ratio REAL NOT NULL, -- Share of words added or removed
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1 @@
+ALTER TABLE flashcards DROP COLUMN IF EXISTS source_url;
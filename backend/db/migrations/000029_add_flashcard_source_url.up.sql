@@ -0,0 +1,3 @@
+-- Where in the source a card comes from, e.g. https://youtu.be/ID?t=123 for a
+-- video; '' when only the material as a whole applies
+ALTER TABLE flashcards ADD COLUMN IF NOT EXISTS source_url TEXT NOT NULL DEFAULT '';
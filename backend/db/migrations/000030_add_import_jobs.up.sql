@@ -0,0 +1,31 @@
+-- Batch imports of a YouTube playlist or channel, one material per video
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,             -- PLAYLIST or CHANNEL
+    url TEXT NOT NULL,                     -- As entered by the user
+    title TEXT NOT NULL DEFAULT '',
+    collection_id UUID REFERENCES collections(id) ON DELETE SET NULL, -- Where the materials are filed
+    language VARCHAR(16) NOT NULL DEFAULT '', -- Preferred caption language
+    status VARCHAR(16) NOT NULL DEFAULT 'RUNNING', -- RUNNING, DONE or CANCELED
+    last_error TEXT NOT NULL DEFAULT '',   -- Why the job is waiting, e.g. the daily quota is used up
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_user_id ON import_jobs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_running ON import_jobs(next_attempt_at) WHERE status = 'RUNNING';
+
+-- Videos of an import, in the order they are processed
+CREATE TABLE IF NOT EXISTS import_job_items (
+    job_id UUID NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    video_id VARCHAR(16) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING', -- PENDING, DONE, DUPLICATE, FAILED or CANCELED
+    material_id UUID REFERENCES materials(id) ON DELETE SET NULL,
+    error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, position)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE flashcards DROP COLUMN IF EXISTS source_verified;
+ALTER TABLE flashcards DROP COLUMN IF EXISTS source_quote;
@@ -0,0 +1,5 @@
+-- Passage of the material a generated card is based on. source_verified is
+-- NULL for cards that weren't checked (older cards, prompts without quotes),
+-- FALSE when the model's quote wasn't found in the material: the card is flagged
+ALTER TABLE flashcards ADD COLUMN IF NOT EXISTS source_quote TEXT NOT NULL DEFAULT '';
+ALTER TABLE flashcards ADD COLUMN IF NOT EXISTS source_verified BOOLEAN;
@@ -0,0 +1 @@
+ALTER TABLE organization_members ALTER COLUMN reporting_opt_out SET DEFAULT FALSE;
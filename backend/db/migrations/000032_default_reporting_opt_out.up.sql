@@ -0,0 +1,7 @@
+-- Members are added by an owner without being asked, so they stay out of
+-- individual-level reports until they opt in themselves; the creator of an
+-- organization is inserted opted in
+ALTER TABLE organization_members ALTER COLUMN reporting_opt_out SET DEFAULT TRUE;
+UPDATE organization_members om SET reporting_opt_out = TRUE
+FROM organizations o
+WHERE o.id = om.organization_id AND om.user_id <> o.owner_id;
@@ -0,0 +1 @@
+ALTER TABLE source_items DROP COLUMN IF EXISTS failed_attempts;
@@ -0,0 +1,3 @@
+-- Entries that failed to import are retried on later polls; they count as seen
+-- once imported (failed_attempts reset to 0) or after too many failures
+ALTER TABLE source_items ADD COLUMN IF NOT EXISTS failed_attempts INT NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_materials_search_tsv;
+ALTER TABLE materials DROP COLUMN IF EXISTS search_tsv;
+
+ALTER TABLE materials ADD COLUMN search_tsv tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(summary, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(content, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_materials_search_tsv ON materials USING GIN (search_tsv);
@@ -0,0 +1,13 @@
+-- A tsvector can't exceed 1 MB, so indexing the whole content of a large import
+-- made its INSERT or UPDATE fail. Only the first 500,000 characters of the
+-- content are indexed; title and summary are short.
+DROP INDEX IF EXISTS idx_materials_search_tsv;
+ALTER TABLE materials DROP COLUMN IF EXISTS search_tsv;
+
+ALTER TABLE materials ADD COLUMN search_tsv tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(summary, '')), 'B') ||
+    setweight(to_tsvector('english', left(COALESCE(content, ''), 500000)), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_materials_search_tsv ON materials USING GIN (search_tsv);
@@ -13,6 +13,7 @@ require (
 	github.com/robfig/cron/v3 v3.0.1
 	github.com/serpapi/google-search-results-golang v0.0.0-20240325113416-ec93f510648e
 	go.uber.org/fx v1.24.0
+	golang.org/x/net v0.47.0
 	google.golang.org/adk v0.3.0
 	google.golang.org/api v0.256.0
 	google.golang.org/genai v1.40.0
@@ -76,7 +77,6 @@ require (
 	go.uber.org/multierr v1.10.0 // indirect
 	go.uber.org/zap v1.26.0 // indirect
 	golang.org/x/crypto v0.45.0 // indirect
-	golang.org/x/net v0.47.0 // indirect
 	golang.org/x/oauth2 v0.33.0 // indirect
 	golang.org/x/sync v0.18.0 // indirect
 	golang.org/x/sys v0.38.0 // indirect
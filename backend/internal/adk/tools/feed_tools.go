@@ -2,7 +2,6 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -205,7 +204,7 @@ func truncateSnippet(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
-func NewEvaluateURLsBatchTool(ai ai.Provider) tool.Tool {
+func NewEvaluateURLsBatchTool(aiProvider ai.Provider) tool.Tool {
 	handler := func(ctx tool.Context, args EvaluateURLsBatchArgs) (EvaluateURLsBatchResult, error) {
 		log.Printf("[EvaluateURLsBatchTool] Evaluating %d URLs in batches", len(args.URLs))
 
@@ -252,8 +251,14 @@ func NewEvaluateURLsBatchTool(ai ai.Provider) tool.Tool {
 				criteria,
 				urlList.String())
 
-			resp, err := ai.GenerateCompletion(prompt)
-			if err != nil {
+			// Response is schema-validated and re-prompted on malformed output
+			var result struct {
+				Scores []struct {
+					URL   string  `json:"url"`
+					Score float64 `json:"score"`
+				} `json:"scores"`
+			}
+			if err := aiProvider.GenerateJSON(prompt, ai.URLScoresSchema, &result); err != nil {
 				log.Printf("[EvaluateURLsBatchTool] Batch %d failed: %v, using default scores", batchNum, err)
 				// On error, assign default scores for this batch but keep full article data
 				for _, u := range batch {
@@ -266,47 +271,23 @@ func NewEvaluateURLsBatchTool(ai ai.Provider) tool.Tool {
 					})
 				}
 			} else {
-				// Parse JSON response - only contains url and score
-				var batchScores []struct {
-					URL   string  `json:"url"`
-					Score float64 `json:"score"`
+				// Map scores back to original URLs and preserve full article data
+				scoreMap := make(map[string]float64)
+				for _, s := range result.Scores {
+					scoreMap[cleanURL(s.URL)] = s.Score
 				}
-				cleanResp := strings.TrimSpace(resp)
-				cleanResp = strings.TrimPrefix(cleanResp, "```json")
-				cleanResp = strings.TrimPrefix(cleanResp, "```")
-				cleanResp = strings.TrimSuffix(cleanResp, "```")
-				cleanResp = strings.TrimSpace(cleanResp)
-
-				if err := json.Unmarshal([]byte(cleanResp), &batchScores); err != nil {
-					log.Printf("[EvaluateURLsBatchTool] Batch %d: Failed to parse JSON, using defaults", batchNum)
-					for _, u := range batch {
-						allScores = append(allScores, URLScore{
-							URL:      u.URL,
-							Title:    u.Title,
-							Snippet:  u.Snippet,
-							Provider: u.Provider,
-							Score:    0.5,
-						})
-					}
-				} else {
-					// Map scores back to original URLs and preserve full article data
-					scoreMap := make(map[string]float64)
-					for _, s := range batchScores {
-						scoreMap[cleanURL(s.URL)] = s.Score
-					}
-					for _, u := range batch {
-						score, ok := scoreMap[cleanURL(u.URL)]
-						if !ok {
-							score = 0.5 // Default if not found
-						}
-						allScores = append(allScores, URLScore{
-							URL:      u.URL,
-							Title:    u.Title,
-							Snippet:  u.Snippet,
-							Provider: u.Provider,
-							Score:    score,
-						})
+				for _, u := range batch {
+					score, ok := scoreMap[cleanURL(u.URL)]
+					if !ok {
+						score = 0.5 // Default if not found
 					}
+					allScores = append(allScores, URLScore{
+						URL:      u.URL,
+						Title:    u.Title,
+						Snippet:  u.Snippet,
+						Provider: u.Provider,
+						Score:    score,
+					})
 				}
 			}
 
@@ -16,8 +16,10 @@ import (
 
 // Internal types for AI API communication (unexported - implementation detail)
 type chatRequest struct {
-	Model    string        `json:"model"`
-	Messages []interface{} `json:"messages"`
+	Model          string          `json:"model"`
+	Messages       []interface{}   `json:"messages"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
 }
 
 type textMessage struct {
@@ -48,13 +50,11 @@ type choice struct {
 	Message textMessage `json:"message"`
 }
 
-func cleanJSON(s string) string {
-	s = strings.TrimSpace(s)
-	s = strings.TrimPrefix(s, "```json")
-	s = strings.TrimPrefix(s, "```")
-	s = strings.TrimSuffix(s, "```")
-	return strings.TrimSpace(s)
-}
+// DefaultMaxContentLen is the default content cap for flashcard generation (~6000 tokens)
+const DefaultMaxContentLen = 24000
+
+// MaxSummaryContentLen caps the content sent for summarization
+const MaxSummaryContentLen = 12000
 
 // BaseProvider implements common functionality for OpenAI-compatible APIs
 type BaseProvider struct {
@@ -65,7 +65,7 @@ type BaseProvider struct {
 // NewBaseProvider creates a new base provider
 func NewBaseProvider(config ProviderConfig) *BaseProvider {
 	if config.MaxContentLen == 0 {
-		config.MaxContentLen = 24000 // Default: ~6000 tokens
+		config.MaxContentLen = DefaultMaxContentLen
 	}
 	return &BaseProvider{
 		config: config,
@@ -73,6 +73,16 @@ func NewBaseProvider(config ProviderConfig) *BaseProvider {
 	}
 }
 
+// MaxContentLen returns the configured content cap for flashcard generation
+func (p *BaseProvider) MaxContentLen() int {
+	return p.config.MaxContentLen
+}
+
+// SetTransport replaces the HTTP transport (e.g. record/replay for offline evals)
+func (p *BaseProvider) SetTransport(rt http.RoundTripper) {
+	p.client.Transport = rt
+}
+
 func (p *BaseProvider) Name() string {
 	return p.config.Name
 }
@@ -125,34 +135,32 @@ func (p *BaseProvider) SendRequest(reqBody interface{}, operation string) (strin
 	return content, nil
 }
 
-// GenerateFlashcards implements flashcard generation
+// GenerateFlashcards implements flashcard generation using the built-in prompt
 func (p *BaseProvider) GenerateFlashcards(content string, existingTags []string) (string, []string, []*learning.Flashcard, error) {
 	content = TruncateToLimit(content, p.config.MaxContentLen)
 
-	prompt := fmt.Sprintf(prompts.Flashcards, strings.Join(existingTags, ", "), content)
-
-	reqBody := chatRequest{
-		Model: p.config.TextModel,
-		Messages: []interface{}{
-			textMessage{Role: "user", Content: prompt},
-		},
-	}
-
-	rawContent, err := p.SendRequest(reqBody, "Flashcards")
+	prompt, err := prompts.Render(prompts.Flashcards, prompts.FlashcardsData{
+		ExistingTags: strings.Join(existingTags, ", "),
+		Content:      content,
+	})
 	if err != nil {
 		return "", nil, nil, err
 	}
 
-	rawContent = cleanJSON(rawContent)
+	return p.GenerateFlashcardsFromPrompt(prompt)
+}
 
+// GenerateFlashcardsFromPrompt generates flashcards from an already rendered prompt
+// (e.g. a versioned template from the prompt registry)
+func (p *BaseProvider) GenerateFlashcardsFromPrompt(prompt string) (string, []string, []*learning.Flashcard, error) {
 	var result struct {
 		Title      string                `json:"title"`
 		Tags       []string              `json:"tags"`
 		Flashcards []*learning.Flashcard `json:"flashcards"`
 	}
 
-	if err := json.Unmarshal([]byte(rawContent), &result); err != nil {
-		return "", nil, nil, fmt.Errorf("failed to parse json: %w", err)
+	if err := p.generateJSON("Flashcards", prompt, FlashcardsSchema, &result); err != nil {
+		return "", nil, nil, err
 	}
 
 	log.Printf("[%s.Flashcards] Parsed: Title='%s', Tags=%d, Cards=%d",
@@ -160,13 +168,8 @@ func (p *BaseProvider) GenerateFlashcards(content string, existingTags []string)
 	return result.Title, result.Tags, result.Flashcards, nil
 }
 
-// GenerateSummary implements summary generation
-func (p *BaseProvider) GenerateSummary(content string) (string, error) {
-	maxLen := 12000
-	content = TruncateToLimit(content, maxLen)
-
-	prompt := fmt.Sprintf(prompts.Summary, content)
-
+// GenerateSummaryFromPrompt generates a summary from an already rendered prompt
+func (p *BaseProvider) GenerateSummaryFromPrompt(prompt string) (string, error) {
 	reqBody := chatRequest{
 		Model: p.config.TextModel,
 		Messages: []interface{}{
@@ -188,8 +191,8 @@ func (p *BaseProvider) GenerateCompletion(prompt string) (string, error) {
 	return p.SendRequest(reqBody, "Completion")
 }
 
-// ExtractTextFromImage implements OCR using vision model
-func (p *BaseProvider) ExtractTextFromImage(base64Image string) (string, error) {
+// ExtractTextFromImage implements OCR using vision model, with a rendered OCR prompt
+func (p *BaseProvider) ExtractTextFromImage(prompt, base64Image string) (string, error) {
 	if p.config.VisionModel == "" {
 		return "", fmt.Errorf("vision model not configured for %s", p.config.Name)
 	}
@@ -199,8 +202,6 @@ func (p *BaseProvider) ExtractTextFromImage(base64Image string) (string, error)
 		imageDataURL = "data:image/jpeg;base64," + base64Image
 	}
 
-	prompt := prompts.OCR
-
 	reqBody := chatRequest{
 		Model: p.config.VisionModel,
 		Messages: []interface{}{
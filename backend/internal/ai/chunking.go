@@ -3,6 +3,7 @@ package ai
 import (
 	"log"
 	"strings"
+	"unicode/utf8"
 )
 
 // ChunkConfig controls how content is split and processed
@@ -21,7 +22,8 @@ func DefaultChunkConfig() ChunkConfig {
 	}
 }
 
-// SplitIntoChunks splits large content into overlapping chunks
+// SplitIntoChunks splits large content into overlapping chunks, cutting only
+// between runes so every chunk stays valid UTF-8.
 // Returns original content as single-element slice if under MaxTotalChars
 func SplitIntoChunks(content string, config ChunkConfig) []string {
 	if len(content) <= config.MaxTotalChars {
@@ -40,6 +42,7 @@ func SplitIntoChunks(content string, config ChunkConfig) []string {
 		if end > len(content) {
 			end = len(content)
 		}
+		end = runeBoundary(content, end)
 
 		// Try to break at a natural boundary (paragraph or sentence)
 		if end < len(content) {
@@ -61,6 +64,7 @@ func SplitIntoChunks(content string, config ChunkConfig) []string {
 		if start < 0 {
 			start = 0
 		}
+		start = runeBoundary(content, start)
 		// Prevent infinite loop
 		if start >= len(content) || end >= len(content) {
 			break
@@ -75,6 +79,14 @@ func SplitIntoChunks(content string, config ChunkConfig) []string {
 	return chunks
 }
 
+// runeBoundary moves a byte offset in s back to the start of the rune it falls in
+func runeBoundary(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
+
 // TruncateToLimit is a simple truncation for when chunking isn't appropriate
 // (e.g., for agent tool results that must fit in one message)
 func TruncateToLimit(content string, maxChars int) string {
@@ -82,7 +94,7 @@ func TruncateToLimit(content string, maxChars int) string {
 		return content
 	}
 	log.Printf("[Chunking] Truncating from %d to %d chars", len(content), maxChars)
-	return content[:maxChars] + "\n...[truncated]"
+	return content[:runeBoundary(content, maxChars)] + "\n...[truncated]"
 }
 
 // EstimateTokens provides a rough token count (4 chars ≈ 1 token)
@@ -0,0 +1,34 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitIntoChunksKeepsRunesWhole(t *testing.T) {
+	config := ChunkConfig{MaxChunkChars: 100, OverlapChars: 17, MaxTotalChars: 100}
+	// No paragraph or sentence breaks, so every cut falls inside the text
+	for _, content := range []string{
+		strings.Repeat("नमस्ते दुनिया—", 40),
+		strings.Repeat("日本語のテキスト", 50),
+		strings.Repeat("a—", 120),
+	} {
+		chunks := SplitIntoChunks(content, config)
+		if len(chunks) < 2 {
+			t.Fatalf("expected several chunks, got %d", len(chunks))
+		}
+		for i, chunk := range chunks {
+			if !utf8.ValidString(chunk) {
+				t.Errorf("chunk %d is not valid UTF-8: %q", i, chunk)
+			}
+		}
+		if !strings.HasSuffix(content, chunks[len(chunks)-1]) {
+			t.Errorf("last chunk doesn't end the content")
+		}
+	}
+
+	if got := TruncateToLimit("ab—cd", 3); !utf8.ValidString(got) || !strings.HasPrefix(got, "ab\n") {
+		t.Errorf("TruncateToLimit cut a rune: %q", got)
+	}
+}
@@ -0,0 +1,146 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// EmbeddingDimensions is the vector size stored in pgvector columns.
+// Models with larger native output are asked to shorten via the "dimensions" parameter.
+const EmbeddingDimensions = 768
+
+// maxEmbeddingBatch bounds the number of inputs sent per embeddings request
+const maxEmbeddingBatch = 64
+
+// Embedder turns text into vectors for semantic search and similarity
+type Embedder interface {
+	Name() string
+	// Embed returns one EmbeddingDimensions-long vector per input, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbeddingChunkConfig splits material content into passages small enough
+// to embed and to quote back as retrieval context
+func EmbeddingChunkConfig() ChunkConfig {
+	return ChunkConfig{
+		MaxChunkChars: 2000, // ~500 tokens
+		OverlapChars:  200,
+		MaxTotalChars: 2000,
+	}
+}
+
+// EmbedderConfig holds configuration for an OpenAI-compatible embeddings API
+type EmbedderConfig struct {
+	Name    string
+	BaseURL string // Full URL of the /embeddings endpoint
+	APIKey  string
+	Model   string
+}
+
+type embeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// OpenAIEmbedder implements Embedder for OpenAI-compatible /embeddings endpoints
+type OpenAIEmbedder struct {
+	config EmbedderConfig
+	client *http.Client
+}
+
+// NewOpenAIEmbedder creates a new embeddings client
+func NewOpenAIEmbedder(config EmbedderConfig) *OpenAIEmbedder {
+	if config.Name == "" {
+		config.Name = "Embeddings"
+	}
+	return &OpenAIEmbedder{
+		config: config,
+		client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (e *OpenAIEmbedder) Name() string {
+	return e.config.Name
+}
+
+// Embed embeds texts in batches of maxEmbeddingBatch
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += maxEmbeddingBatch {
+		end := start + maxEmbeddingBatch
+		if end > len(texts) {
+			end = len(texts)
+		}
+		batch, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+func (e *OpenAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	jsonBody, err := json.Marshal(embeddingRequest{
+		Model:      e.config.Model,
+		Input:      texts,
+		Dimensions: EmbeddingDimensions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.config.BaseURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("api error: %d %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var embResp embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(embResp.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embResp.Data))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range embResp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		if len(d.Embedding) != EmbeddingDimensions {
+			return nil, fmt.Errorf("expected %d dimensions, got %d (model %s)", EmbeddingDimensions, len(d.Embedding), e.config.Model)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+
+	log.Printf("[%s.Embed] Embedded %d texts", e.config.Name, len(texts))
+	return vectors, nil
+}
@@ -0,0 +1,70 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAIEmbedderBatchesAndOrders(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req embeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("bad request body: %v", err)
+		}
+		if req.Dimensions != EmbeddingDimensions {
+			t.Errorf("dimensions = %d, want %d", req.Dimensions, EmbeddingDimensions)
+		}
+
+		// Respond in reverse order; the client must reorder by index
+		var resp embeddingResponse
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vec := make([]float32, EmbeddingDimensions)
+			vec[0] = float32(len(req.Input[i]))
+			resp.Data = append(resp.Data, struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			}{i, vec})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	texts := make([]string, maxEmbeddingBatch+3)
+	for i := range texts {
+		texts[i] = string(make([]byte, i+1))
+	}
+
+	e := NewOpenAIEmbedder(EmbedderConfig{BaseURL: srv.URL, Model: "m"})
+	vectors, err := e.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	if len(vectors) != len(texts) {
+		t.Fatalf("got %d vectors, want %d", len(vectors), len(texts))
+	}
+	for i, v := range vectors {
+		if int(v[0]) != i+1 {
+			t.Fatalf("vector %d out of order: got marker %v", i, v[0])
+		}
+	}
+}
+
+func TestOpenAIEmbedderRejectsWrongDimensions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"index":0,"embedding":[0.1,0.2]}]}`))
+	}))
+	defer srv.Close()
+
+	e := NewOpenAIEmbedder(EmbedderConfig{BaseURL: srv.URL, Model: "m"})
+	if _, err := e.Embed(context.Background(), []string{"x"}); err == nil {
+		t.Fatal("expected dimension mismatch error")
+	}
+}
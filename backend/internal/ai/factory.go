@@ -18,6 +18,7 @@ func NewLLMProvider(providerName, apiKey, modelID string) *BaseProvider {
 			APIKey:      apiKey,
 			TextModel:   modelID,
 			VisionModel: models.TaskVisionModel,
+			JSONMode:    JSONModeSchema,
 		})
 	case "cerebras":
 		return NewBaseProvider(ProviderConfig{
@@ -26,6 +27,7 @@ func NewLLMProvider(providerName, apiKey, modelID string) *BaseProvider {
 			APIKey:      apiKey,
 			TextModel:   modelID,
 			VisionModel: "", // Cerebras doesn't have vision model
+			JSONMode:    JSONModeSchema,
 		})
 	default:
 		// Fail fast: don't silently default to an unknown provider
@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -34,6 +35,16 @@ func (m *MultiProvider) Name() string {
 	return "Multi[" + strings.Join(names, "+") + "]"
 }
 
+// MaxContentLen is the smallest limit of the providers, since flashcards
+// may fall back to any of them
+func (m *MultiProvider) MaxContentLen() int {
+	limit := m.providers[0].MaxContentLen()
+	for _, p := range m.providers[1:] {
+		limit = min(limit, p.MaxContentLen())
+	}
+	return limit
+}
+
 // GenerateFlashcards uses provider[0] with fallback to others
 func (m *MultiProvider) GenerateFlashcards(content string, existingTags []string) (string, []string, []*learning.Flashcard, error) {
 	for i, provider := range m.providers {
@@ -48,8 +59,22 @@ func (m *MultiProvider) GenerateFlashcards(content string, existingTags []string
 	return "", nil, nil, fmt.Errorf("all providers failed for flashcards")
 }
 
-// GenerateSummary uses provider[1] with fallback (distributes load)
-func (m *MultiProvider) GenerateSummary(content string) (string, error) {
+// GenerateFlashcardsFromPrompt uses provider[0] with fallback to others
+func (m *MultiProvider) GenerateFlashcardsFromPrompt(prompt string) (string, []string, []*learning.Flashcard, error) {
+	for i, provider := range m.providers {
+		log.Printf("[MultiProvider] Trying %s for flashcards (attempt %d/%d)...", provider.Name(), i+1, len(m.providers))
+		title, tags, cards, err := provider.GenerateFlashcardsFromPrompt(prompt)
+		if err == nil {
+			log.Printf("[MultiProvider] %s generated %d flashcards", provider.Name(), len(cards))
+			return title, tags, cards, nil
+		}
+		log.Printf("[MultiProvider] %s failed: %v", provider.Name(), err)
+	}
+	return "", nil, nil, fmt.Errorf("all providers failed for flashcards")
+}
+
+// GenerateSummaryFromPrompt uses provider[1] with fallback (distributes load)
+func (m *MultiProvider) GenerateSummaryFromPrompt(prompt string) (string, error) {
 	// Start with provider 1 if available (Cerebras), else use 0
 	startIdx := 0
 	if len(m.providers) > 1 {
@@ -61,7 +86,7 @@ func (m *MultiProvider) GenerateSummary(content string) (string, error) {
 		idx := (startIdx + i) % len(m.providers)
 		provider := m.providers[idx]
 		log.Printf("[MultiProvider] Trying %s for summary...", provider.Name())
-		summary, err := provider.GenerateSummary(content)
+		summary, err := provider.GenerateSummaryFromPrompt(prompt)
 		if err == nil {
 			log.Printf("[MultiProvider] %s generated summary (length: %d)", provider.Name(), len(summary))
 			return summary, nil
@@ -72,8 +97,8 @@ func (m *MultiProvider) GenerateSummary(content string) (string, error) {
 }
 
 // ExtractTextFromImage uses primary provider (only Groq has vision)
-func (m *MultiProvider) ExtractTextFromImage(base64Image string) (string, error) {
-	return m.primary.ExtractTextFromImage(base64Image)
+func (m *MultiProvider) ExtractTextFromImage(prompt, base64Image string) (string, error) {
+	return m.primary.ExtractTextFromImage(prompt, base64Image)
 }
 
 // OptimizeSearchQuery uses primary provider
@@ -85,3 +110,49 @@ func (m *MultiProvider) OptimizeSearchQuery(userInterests string) (string, error
 func (m *MultiProvider) GenerateCompletion(prompt string) (string, error) {
 	return m.primary.GenerateCompletion(prompt)
 }
+
+// GenerateJSON uses primary provider with fallback to others
+func (m *MultiProvider) GenerateJSON(prompt string, schema *Schema, out interface{}) error {
+	for _, provider := range m.providers {
+		err := provider.GenerateJSON(prompt, schema, out)
+		if err == nil {
+			return nil
+		}
+		log.Printf("[MultiProvider] %s failed for structured output: %v", provider.Name(), err)
+	}
+	return fmt.Errorf("all providers failed for structured output")
+}
+
+// StreamSummary uses provider[1] first like GenerateSummary.
+// Falls back to the next provider only if nothing was streamed yet,
+// since the client has already displayed any earlier deltas.
+func (m *MultiProvider) StreamSummary(ctx context.Context, prompt string, onDelta DeltaFunc) (string, error) {
+	startIdx := 0
+	if len(m.providers) > 1 {
+		startIdx = 1
+	}
+
+	for i := 0; i < len(m.providers); i++ {
+		provider := m.providers[(startIdx+i)%len(m.providers)]
+		log.Printf("[MultiProvider] Trying %s for streamed summary...", provider.Name())
+
+		streamed := false
+		summary, err := provider.StreamSummary(ctx, prompt, func(delta string) error {
+			streamed = true
+			return onDelta(delta)
+		})
+		if err == nil {
+			return summary, nil
+		}
+		log.Printf("[MultiProvider] %s failed: %v", provider.Name(), err)
+		if streamed || ctx.Err() != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("all providers failed for streamed summary")
+}
+
+// StreamCompletion uses primary provider
+func (m *MultiProvider) StreamCompletion(ctx context.Context, prompt string, onDelta DeltaFunc) (string, error) {
+	return m.primary.StreamCompletion(ctx, prompt, onDelta)
+}
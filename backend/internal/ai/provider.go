@@ -1,15 +1,27 @@
 package ai
 
-import "github.com/amityadav/landr/pkg/pb/learning"
+import (
+	"context"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
 
 // Provider defines the interface for AI providers
 type Provider interface {
 	Name() string
+	// MaxContentLen is the most content a flashcards prompt should carry
+	MaxContentLen() int
 	GenerateFlashcards(content string, existingTags []string) (string, []string, []*learning.Flashcard, error)
-	GenerateSummary(content string) (string, error)
-	ExtractTextFromImage(base64Image string) (string, error)
+	GenerateFlashcardsFromPrompt(prompt string) (string, []string, []*learning.Flashcard, error)
+	GenerateSummaryFromPrompt(prompt string) (string, error)
+	ExtractTextFromImage(prompt, base64Image string) (string, error)
 	OptimizeSearchQuery(userInterests string) (string, error)
 	GenerateCompletion(prompt string) (string, error)
+	// GenerateJSON decodes a schema-validated JSON response into out
+	GenerateJSON(prompt string, schema *Schema, out interface{}) error
+	// StreamSummary and StreamCompletion call onDelta as tokens arrive and return the full text
+	StreamSummary(ctx context.Context, prompt string, onDelta DeltaFunc) (string, error)
+	StreamCompletion(ctx context.Context, prompt string, onDelta DeltaFunc) (string, error)
 }
 
 // ProviderConfig holds configuration for a provider
@@ -20,4 +32,5 @@ type ProviderConfig struct {
 	TextModel     string
 	VisionModel   string
 	MaxContentLen int
+	JSONMode      JSONMode // Structured output support (response_format)
 }
@@ -0,0 +1,192 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Schema is a minimal JSON Schema used both to request structured output
+// (response_format) and to validate what the model actually returned.
+// Only the keywords we need are supported.
+type Schema struct {
+	Title       string             `json:"title,omitempty"`
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	MinItems    *int               `json:"minItems,omitempty"`
+	MaxItems    *int               `json:"maxItems,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+
+	// AdditionalProperties is always false for objects (required by strict mode)
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+}
+
+// ObjectSchema builds a strict object schema where every property is required
+func ObjectSchema(props map[string]*Schema) *Schema {
+	required := make([]string, 0, len(props))
+	for name := range props {
+		required = append(required, name)
+	}
+	sort.Strings(required)
+	f := false
+	return &Schema{Type: "object", Properties: props, Required: required, AdditionalProperties: &f}
+}
+
+// ArraySchema builds an array schema with optional item bounds (0 = unbounded)
+func ArraySchema(items *Schema, minItems, maxItems int) *Schema {
+	s := &Schema{Type: "array", Items: items}
+	if minItems > 0 {
+		s.MinItems = &minItems
+	}
+	if maxItems > 0 {
+		s.MaxItems = &maxItems
+	}
+	return s
+}
+
+// StringSchema builds a string schema; nonEmpty enforces minLength 1
+func StringSchema(nonEmpty bool) *Schema {
+	s := &Schema{Type: "string"}
+	if nonEmpty {
+		one := 1
+		s.MinLength = &one
+	}
+	return s
+}
+
+// NumberSchema builds a number schema bounded by [min, max]
+func NumberSchema(min, max float64) *Schema {
+	return &Schema{Type: "number", Minimum: &min, Maximum: &max}
+}
+
+// SchemaError describes a single validation failure with its JSON path
+type SchemaError struct {
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate checks a decoded JSON value (map/slice/float64/string/bool/nil)
+// against the schema and returns the first violation found.
+func (s *Schema) Validate(v interface{}) error {
+	return s.validate("$", v)
+}
+
+func (s *Schema) validate(path string, v interface{}) error {
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return &SchemaError{path, "expected object, got " + jsonTypeName(v)}
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return &SchemaError{path, fmt.Sprintf("missing required property %q", name)}
+			}
+		}
+		// Iterate in a stable order so the reported error is deterministic
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				// Extra properties are tolerated on input; strict mode only matters for the request
+				continue
+			}
+			if err := prop.validate(path+"."+name, obj[name]); err != nil {
+				return err
+			}
+		}
+
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			return &SchemaError{path, "expected array, got " + jsonTypeName(v)}
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			return &SchemaError{path, fmt.Sprintf("expected at least %d items, got %d", *s.MinItems, len(arr))}
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			return &SchemaError{path, fmt.Sprintf("expected at most %d items, got %d", *s.MaxItems, len(arr))}
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return &SchemaError{path, "expected string, got " + jsonTypeName(v)}
+		}
+		if s.MinLength != nil && len([]rune(str)) < *s.MinLength {
+			return &SchemaError{path, fmt.Sprintf("expected at least %d characters", *s.MinLength)}
+		}
+		if len(s.Enum) > 0 {
+			found := false
+			for _, e := range s.Enum {
+				if e == str {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return &SchemaError{path, fmt.Sprintf("value %q not in %v", str, s.Enum)}
+			}
+		}
+
+	case "number", "integer":
+		n, ok := v.(float64)
+		if !ok {
+			return &SchemaError{path, "expected number, got " + jsonTypeName(v)}
+		}
+		if s.Type == "integer" && n != float64(int64(n)) {
+			return &SchemaError{path, "expected integer"}
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			return &SchemaError{path, fmt.Sprintf("value %v below minimum %v", n, *s.Minimum)}
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return &SchemaError{path, fmt.Sprintf("value %v above maximum %v", n, *s.Maximum)}
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return &SchemaError{path, "expected boolean, got " + jsonTypeName(v)}
+		}
+	}
+	return nil
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,134 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// DeltaFunc receives each piece of streamed text; returning an error aborts the stream
+type DeltaFunc func(delta string) error
+
+// streamChunk is one SSE "data:" payload of an OpenAI-compatible streaming response
+type streamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// maxSSELineSize bounds a single SSE line (chunks are small, but be generous)
+const maxSSELineSize = 1024 * 1024
+
+// StreamRequest sends a streaming chat request and calls onDelta for each content delta.
+// Returns the full concatenated text once the stream completes.
+func (p *BaseProvider) StreamRequest(ctx context.Context, reqBody chatRequest, operation string, onDelta DeltaFunc) (string, error) {
+	log.Printf("[%s.%s] Sending streaming request...", p.config.Name, operation)
+
+	reqBody.Stream = true
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.config.BaseURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	log.Printf("[%s.%s] Response status: %d", p.config.Name, operation, resp.StatusCode)
+
+	if resp.StatusCode != 200 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("api error: %d %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// Blank lines separate events; lines starting with ':' are keep-alive comments
+		if line == "" || strings.HasPrefix(line, ":") || !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk streamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return full.String(), fmt.Errorf("failed to decode stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return full.String(), fmt.Errorf("api error during stream: %s", chunk.Error.Message)
+		}
+
+		for _, c := range chunk.Choices {
+			if c.Delta.Content == "" {
+				continue
+			}
+			full.WriteString(c.Delta.Content)
+			if err := onDelta(c.Delta.Content); err != nil {
+				return full.String(), err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("stream read failed: %w", err)
+	}
+
+	content := strings.TrimSpace(full.String())
+	if content == "" {
+		return "", fmt.Errorf("empty stream")
+	}
+	log.Printf("[%s.%s] Stream complete, response length: %d", p.config.Name, operation, len(content))
+	return content, nil
+}
+
+// StreamSummary streams summary generation from a rendered summary prompt,
+// calling onDelta as text arrives
+func (p *BaseProvider) StreamSummary(ctx context.Context, prompt string, onDelta DeltaFunc) (string, error) {
+	reqBody := chatRequest{
+		Model: p.config.TextModel,
+		Messages: []interface{}{
+			textMessage{Role: "user", Content: prompt},
+		},
+	}
+	return p.StreamRequest(ctx, reqBody, "StreamSummary", onDelta)
+}
+
+// StreamCompletion streams a generic chat completion
+func (p *BaseProvider) StreamCompletion(ctx context.Context, prompt string, onDelta DeltaFunc) (string, error) {
+	reqBody := chatRequest{
+		Model: p.config.TextModel,
+		Messages: []interface{}{
+			textMessage{Role: "user", Content: prompt},
+		},
+	}
+	return p.StreamRequest(ctx, reqBody, "StreamCompletion", onDelta)
+}
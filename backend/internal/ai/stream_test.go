@@ -0,0 +1,63 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStreamRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["stream"] != true {
+			t.Errorf("stream flag not set in request: %v", body["stream"])
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		for _, piece := range []string{"## Key", " Points\n", "- Raft elects a leader"} {
+			content, _ := json.Marshal(piece)
+			fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":%s}}]}\n\n", content)
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	p := NewBaseProvider(ProviderConfig{Name: "Test", BaseURL: srv.URL, TextModel: "m"})
+	var deltas []string
+	full, err := p.StreamSummary(context.Background(), "content", func(delta string) error {
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deltas) != 3 {
+		t.Errorf("deltas = %d, want 3", len(deltas))
+	}
+	if full != "## Key Points\n- Raft elects a leader" || strings.Join(deltas, "") != full {
+		t.Errorf("unexpected full text %q", full)
+	}
+}
+
+func TestStreamRequestErrorChunk(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Partial\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"error\":{\"message\":\"rate limit exceeded\"}}\n\n")
+	}))
+	defer srv.Close()
+
+	p := NewBaseProvider(ProviderConfig{Name: "Test", BaseURL: srv.URL, TextModel: "m"})
+	_, err := p.StreamCompletion(context.Background(), "prompt", func(string) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
+		t.Fatalf("expected mid-stream error, got %v", err)
+	}
+}
@@ -0,0 +1,404 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/amityadav/landr/prompts"
+)
+
+// JSONMode controls which response_format a backend accepts
+type JSONMode string
+
+const (
+	JSONModeNone   JSONMode = ""            // Prompt-only, no response_format
+	JSONModeObject JSONMode = "json_object" // Any valid JSON object
+	JSONModeSchema JSONMode = "json_schema" // JSON constrained by a schema
+)
+
+// maxStructuredAttempts bounds the re-prompt loop for invalid JSON (1 initial + 2 repairs)
+const maxStructuredAttempts = 3
+
+// maxEchoedOutput limits how much of a broken response is sent back during repair
+const maxEchoedOutput = 8000
+
+type responseFormat struct {
+	Type       string          `json:"type"`
+	JSONSchema *jsonSchemaSpec `json:"json_schema,omitempty"`
+}
+
+type jsonSchemaSpec struct {
+	Name   string  `json:"name"`
+	Strict bool    `json:"strict"`
+	Schema *Schema `json:"schema"`
+}
+
+// FlashcardsSchema describes the GenerateFlashcards response
+var FlashcardsSchema = func() *Schema {
+	flashcard := ObjectSchema(map[string]*Schema{
+		"question": StringSchema(true),
+		"answer":   StringSchema(true),
+	})
+	// Optional so prompt versions that don't ask for it keep validating
+	difficulty := NumberSchema(1, 5)
+	difficulty.Type = "integer"
+	flashcard.Properties["difficulty"] = difficulty
+	flashcard.Properties["source_quote"] = StringSchema(false)
+	s := ObjectSchema(map[string]*Schema{
+		"title":      StringSchema(true),
+		"tags":       ArraySchema(StringSchema(true), 0, 10),
+		"flashcards": ArraySchema(flashcard, 1, 60),
+	})
+	s.Title = "flashcards"
+	return s
+}()
+
+// URLScoresSchema describes batch URL relevance evaluations
+var URLScoresSchema = func() *Schema {
+	s := ObjectSchema(map[string]*Schema{
+		"scores": ArraySchema(ObjectSchema(map[string]*Schema{
+			"url":   StringSchema(true),
+			"score": NumberSchema(0, 1),
+		}), 0, 0),
+	})
+	s.Title = "url_scores"
+	return s
+}()
+
+// TagMergesSchema describes suggested tag merges
+var TagMergesSchema = func() *Schema {
+	s := ObjectSchema(map[string]*Schema{
+		"merges": ArraySchema(ObjectSchema(map[string]*Schema{
+			"from": StringSchema(true),
+			"into": StringSchema(true),
+		}), 0, 0),
+	})
+	s.Title = "tag_merges"
+	return s
+}()
+
+// ConceptsSchema describes extracted concepts and the relations between them
+var ConceptsSchema = func() *Schema {
+	relation := StringSchema(true)
+	relation.Enum = []string{"prerequisite", "part_of", "related"}
+	s := ObjectSchema(map[string]*Schema{
+		"concepts": ArraySchema(ObjectSchema(map[string]*Schema{
+			"name":        StringSchema(true),
+			"description": StringSchema(false),
+			"cards":       ArraySchema(&Schema{Type: "integer"}, 0, 0),
+		}), 0, 20),
+		"relations": ArraySchema(ObjectSchema(map[string]*Schema{
+			"from": StringSchema(true),
+			"to":   StringSchema(true),
+			"type": relation,
+		}), 0, 0),
+	})
+	s.Title = "concepts"
+	return s
+}()
+
+var thinkBlockRe = regexp.MustCompile(`(?s)<think>.*?</think>`)
+
+// cleanJSON strips reasoning blocks, markdown fences and surrounding prose,
+// returning the first top-level JSON value (possibly truncated).
+// Fences need no special handling: the opening one contains no brackets and
+// the closing one falls after the matching close bracket.
+func cleanJSON(s string) string {
+	s = thinkBlockRe.ReplaceAllString(s, "")
+	s = strings.TrimSpace(s)
+
+	start := strings.IndexAny(s, "{[")
+	if start == -1 {
+		return s
+	}
+	s = s[start:]
+
+	// Cut at the matching close bracket so trailing commentary is ignored
+	depth := 0
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return s[:i+1]
+			}
+		}
+	}
+	return s
+}
+
+var trailingCommaRe = regexp.MustCompile(`,(\s*[}\]])`)
+
+// normalizeJSON fixes mistakes models commonly make that are unambiguous to repair
+func normalizeJSON(s string) string {
+	return escapeControlChars(stripOutsideStrings(s, trailingCommaRe))
+}
+
+// escapeControlChars escapes raw newlines and tabs inside string literals, which
+// models often emit when a value holds code
+func escapeControlChars(s string) string {
+	var sb strings.Builder
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			case c == '\n':
+				sb.WriteString(`\n`)
+				continue
+			case c == '\r':
+				sb.WriteString(`\r`)
+				continue
+			case c == '\t':
+				sb.WriteString(`\t`)
+				continue
+			}
+		} else if c == '"' {
+			inString = true
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// stripOutsideStrings applies a comma-removal regex only to text outside string literals
+func stripOutsideStrings(s string, re *regexp.Regexp) string {
+	var sb strings.Builder
+	inString, escaped := false, false
+	segStart := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+				sb.WriteString(s[segStart : i+1])
+				segStart = i + 1
+			}
+			continue
+		}
+		if c == '"' {
+			sb.WriteString(re.ReplaceAllString(s[segStart:i], "$1"))
+			segStart = i
+			inString = true
+		}
+	}
+	if inString {
+		sb.WriteString(s[segStart:])
+	} else {
+		sb.WriteString(re.ReplaceAllString(s[segStart:], "$1"))
+	}
+	return sb.String()
+}
+
+// cutPoint is a position where everything before it forms complete values,
+// together with the brackets that must be closed to finish the document
+type cutPoint struct {
+	pos    int
+	closer string
+}
+
+// truncationCandidates returns ways to close a truncated document, latest first.
+// Each candidate drops the incomplete tail after a comma and closes open brackets.
+func truncationCandidates(s string) []string {
+	var stack []byte
+	var cuts []cutPoint
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			stack = append(stack, '}')
+		case '[':
+			stack = append(stack, ']')
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) > 0 {
+				cuts = append(cuts, cutPoint{pos: i + 1, closer: reverseClosers(stack)})
+			}
+		case ',':
+			if len(stack) > 0 {
+				cuts = append(cuts, cutPoint{pos: i, closer: reverseClosers(stack)})
+			}
+		}
+	}
+	if len(stack) == 0 && !inString {
+		return nil // Not truncated
+	}
+
+	candidates := make([]string, 0, len(cuts))
+	for i := len(cuts) - 1; i >= 0; i-- {
+		candidates = append(candidates, s[:cuts[i].pos]+cuts[i].closer)
+	}
+	return candidates
+}
+
+func reverseClosers(stack []byte) string {
+	b := make([]byte, len(stack))
+	for i := range stack {
+		b[i] = stack[len(stack)-1-i]
+	}
+	return string(b)
+}
+
+// maxTruncationCandidates bounds how far back the partial parser will search
+const maxTruncationCandidates = 200
+
+// DecodeStructured extracts JSON from a model response, repairs common defects,
+// validates it against the schema and decodes it into out.
+// A truncated response is salvaged by keeping the longest valid prefix.
+func DecodeStructured(raw string, schema *Schema, out interface{}) error {
+	text := normalizeJSON(cleanJSON(raw))
+	if text == "" {
+		return fmt.Errorf("empty response")
+	}
+
+	var generic interface{}
+	parseErr := json.Unmarshal([]byte(text), &generic)
+	if parseErr == nil {
+		if err := schema.Validate(generic); err != nil {
+			return fmt.Errorf("schema validation failed: %w", err)
+		}
+		return json.Unmarshal([]byte(text), out)
+	}
+
+	// Tolerant partial parse for truncated output
+	candidates := truncationCandidates(text)
+	if len(candidates) > maxTruncationCandidates {
+		candidates = candidates[:maxTruncationCandidates]
+	}
+	for _, candidate := range candidates {
+		candidate = normalizeJSON(candidate)
+		if err := json.Unmarshal([]byte(candidate), &generic); err != nil {
+			continue
+		}
+		if err := schema.Validate(generic); err != nil {
+			continue
+		}
+		log.Printf("[Structured] Recovered truncated JSON (%d of %d chars kept)", len(candidate), len(text))
+		return json.Unmarshal([]byte(candidate), out)
+	}
+
+	return fmt.Errorf("invalid json: %w", parseErr)
+}
+
+// responseFormatFor builds the response_format for the configured JSON mode
+func (p *BaseProvider) responseFormatFor(schema *Schema) *responseFormat {
+	switch p.config.JSONMode {
+	case JSONModeSchema:
+		name := schema.Title
+		if name == "" {
+			name = "response"
+		}
+		return &responseFormat{
+			Type: string(JSONModeSchema),
+			// Non-strict: backends reject keywords like minItems in strict mode,
+			// and DecodeStructured enforces the full schema anyway
+			JSONSchema: &jsonSchemaSpec{Name: name, Strict: false, Schema: schema},
+		}
+	case JSONModeObject:
+		if schema.Type != "object" {
+			return nil
+		}
+		return &responseFormat{Type: string(JSONModeObject)}
+	default:
+		return nil
+	}
+}
+
+// isResponseFormatUnsupported detects a backend rejecting response_format for this model
+func isResponseFormatUnsupported(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "api error: 400") &&
+		(strings.Contains(msg, "response_format") || strings.Contains(msg, "json_schema") || strings.Contains(msg, "json mode"))
+}
+
+// GenerateJSON sends a prompt and decodes a schema-valid JSON response into out.
+// Invalid output is re-prompted with the validation error, up to maxStructuredAttempts.
+func (p *BaseProvider) GenerateJSON(prompt string, schema *Schema, out interface{}) error {
+	return p.generateJSON("JSON", prompt, schema, out)
+}
+
+func (p *BaseProvider) generateJSON(operation, prompt string, schema *Schema, out interface{}) error {
+	messages := []interface{}{
+		textMessage{Role: "user", Content: prompt},
+	}
+	format := p.responseFormatFor(schema)
+
+	var lastErr error
+	for attempt := 1; attempt <= maxStructuredAttempts; attempt++ {
+		reqBody := chatRequest{
+			Model:          p.config.TextModel,
+			Messages:       messages,
+			ResponseFormat: format,
+		}
+
+		raw, err := p.SendRequest(reqBody, operation)
+		if err != nil {
+			if format != nil && isResponseFormatUnsupported(err) {
+				log.Printf("[%s.%s] response_format rejected, retrying without it", p.config.Name, operation)
+				format = nil
+				attempt--
+				continue
+			}
+			return err
+		}
+
+		if err := DecodeStructured(raw, schema, out); err != nil {
+			lastErr = err
+			log.Printf("[%s.%s] Invalid structured output (attempt %d/%d): %v", p.config.Name, operation, attempt, maxStructuredAttempts, err)
+			messages = append(messages,
+				textMessage{Role: "assistant", Content: TruncateToLimit(raw, maxEchoedOutput)},
+				textMessage{Role: "user", Content: fmt.Sprintf(prompts.JSONRepair, err.Error())},
+			)
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid structured output after %d attempts: %w", maxStructuredAttempts, lastErr)
+}
@@ -0,0 +1,346 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type flashcardsResult struct {
+	Title      string   `json:"title"`
+	Tags       []string `json:"tags"`
+	Flashcards []struct {
+		Question string `json:"question"`
+		Answer   string `json:"answer"`
+	} `json:"flashcards"`
+}
+
+// Outputs below are trimmed copies of responses seen from Groq/Cerebras models
+func TestDecodeStructuredFlashcards(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantErr   string
+		wantTitle string
+		wantCards int
+	}{
+		{
+			name:      "clean object",
+			raw:       `{"title":"Raft","tags":["distributed"],"flashcards":[{"question":"Who sends heartbeats?","answer":"The leader"}]}`,
+			wantTitle: "Raft",
+			wantCards: 1,
+		},
+		{
+			name: "markdown fence with language tag",
+			raw: "```json\n" +
+				`{"title":"Go GC","tags":["go"],"flashcards":[{"question":"GC type?","answer":"Concurrent mark-sweep"}]}` +
+				"\n```",
+			wantTitle: "Go GC",
+			wantCards: 1,
+		},
+		{
+			name: "leading prose and trailing commentary",
+			raw: "Here are your flashcards:\n\n" +
+				`{"title":"TCP","tags":[],"flashcards":[{"question":"Handshake steps?","answer":"SYN, SYN-ACK, ACK"}]}` +
+				"\n\nLet me know if you need more!",
+			wantTitle: "TCP",
+			wantCards: 1,
+		},
+		{
+			name: "reasoning block before answer",
+			raw: "<think>The user wants flashcards {maybe 6}. Let me pick [key] points.</think>\n" +
+				`{"title":"CAP","tags":["db"],"flashcards":[{"question":"C in CAP?","answer":"Consistency"}]}`,
+			wantTitle: "CAP",
+			wantCards: 1,
+		},
+		{
+			name: "trailing commas",
+			raw: `{"title":"HTTP/2","tags":["web",],"flashcards":[` +
+				`{"question":"Multiplexing?","answer":"Many streams, one connection",},` +
+				`]}`,
+			wantTitle: "HTTP/2",
+			wantCards: 1,
+		},
+		{
+			name: "raw newlines in a code answer",
+			raw: "{\"title\":\"Go\",\"tags\":[],\"flashcards\":[{\"question\":\"What does this print?\\n```go\n" +
+				"fmt.Println(len(\\\"héllo\\\"))\n\tfmt.Println(1)\n```\",\"answer\":\"6\"}]}",
+			wantTitle: "Go",
+			wantCards: 1,
+		},
+		{
+			name:      "comma inside string is preserved",
+			raw:       `{"title":"Lists","tags":[],"flashcards":[{"question":"Example?","answer":"[1, 2, ]"}]}`,
+			wantTitle: "Lists",
+			wantCards: 1,
+		},
+		{
+			name: "truncated mid-card keeps complete cards",
+			raw: `{"title":"Kafka","tags":["streaming"],"flashcards":[` +
+				`{"question":"What is a partition?","answer":"An ordered log"},` +
+				`{"question":"What is an ISR?","answer":"In-sync replicas"},` +
+				`{"question":"What does acks=all mean?","answer":"The leader waits for`,
+			wantTitle: "Kafka",
+			wantCards: 2,
+		},
+		{
+			name: "truncated after a complete card",
+			raw: `{"title":"DNS","tags":["net"],"flashcards":[` +
+				`{"question":"Port?","answer":"53"},`,
+			wantTitle: "DNS",
+			wantCards: 1,
+		},
+		{
+			name:    "truncated before any complete card",
+			raw:     `{"title":"Paxos","tags":[],"flashcards":[{"question":"Phases?","ans`,
+			wantErr: "invalid json",
+		},
+		{
+			name:    "missing flashcards key",
+			raw:     `{"title":"Empty","tags":[]}`,
+			wantErr: `missing required property "flashcards"`,
+		},
+		{
+			name:    "wrong key names",
+			raw:     `{"title":"T","tags":[],"flashcards":[{"q":"a","a":"b"}]}`,
+			wantErr: `$.flashcards[0]: missing required property "answer"`,
+		},
+		{
+			name:    "empty answer",
+			raw:     `{"title":"T","tags":[],"flashcards":[{"question":"Q?","answer":""}]}`,
+			wantErr: "$.flashcards[0].answer: expected at least 1 characters",
+		},
+		{
+			name:      "cards with and without difficulty",
+			raw:       `{"title":"B-trees","tags":[],"flashcards":[{"question":"Fanout?","answer":"High","difficulty":2},{"question":"Split?","answer":"On overflow"}]}`,
+			wantTitle: "B-trees",
+			wantCards: 2,
+		},
+		{
+			name:      "cards with source quotes",
+			raw:       `{"title":"Raft","tags":[],"flashcards":[{"question":"Why random timeouts?","answer":"To avoid split votes","source_quote":"Timeouts are randomized to avoid split votes."}]}`,
+			wantTitle: "Raft",
+			wantCards: 1,
+		},
+		{
+			name:    "source quote not a string",
+			raw:     `{"title":"T","tags":[],"flashcards":[{"question":"Q?","answer":"A","source_quote":["a","b"]}]}`,
+			wantErr: "$.flashcards[0].source_quote: expected string, got array",
+		},
+		{
+			name:    "difficulty out of range",
+			raw:     `{"title":"T","tags":[],"flashcards":[{"question":"Q?","answer":"A","difficulty":7}]}`,
+			wantErr: "$.flashcards[0].difficulty: value 7 above maximum 5",
+		},
+		{
+			name:    "no cards",
+			raw:     `{"title":"T","tags":[],"flashcards":[]}`,
+			wantErr: "$.flashcards: expected at least 1 items, got 0",
+		},
+		{
+			name:    "bare array instead of object",
+			raw:     `[{"question":"Q?","answer":"A"}]`,
+			wantErr: "$: expected object, got array",
+		},
+		{
+			name:    "tags as comma separated string",
+			raw:     `{"title":"T","tags":"go, rust","flashcards":[{"question":"Q?","answer":"A"}]}`,
+			wantErr: "$.tags: expected array, got string",
+		},
+		{
+			name:    "plain text refusal",
+			raw:     "I'm sorry, but I can't help with that.",
+			wantErr: "invalid json",
+		},
+		{
+			name:    "empty response",
+			raw:     "   ",
+			wantErr: "empty response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got flashcardsResult
+			err := DecodeStructured(tt.raw, FlashcardsSchema, &got)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got nil (result %+v)", tt.wantErr, got)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			if len(got.Flashcards) != tt.wantCards {
+				t.Errorf("cards = %d, want %d", len(got.Flashcards), tt.wantCards)
+			}
+		})
+	}
+}
+
+func TestDecodeStructuredURLScores(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		wantErr    string
+		wantScores int
+	}{
+		{
+			name:       "object with scores",
+			raw:        `{"scores":[{"url":"https://a.dev/x","score":0.8},{"url":"https://b.dev/y","score":0.3}]}`,
+			wantScores: 2,
+		},
+		{
+			name:    "score out of range",
+			raw:     `{"scores":[{"url":"https://a.dev/x","score":8}]}`,
+			wantErr: "$.scores[0].score: value 8 above maximum 1",
+		},
+		{
+			name:    "score as string",
+			raw:     `{"scores":[{"url":"https://a.dev/x","score":"0.8"}]}`,
+			wantErr: "$.scores[0].score: expected number, got string",
+		},
+		{
+			name:       "truncated list",
+			raw:        `{"scores":[{"url":"https://a.dev/x","score":0.9},{"url":"https://b.dev/y","sco`,
+			wantScores: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				Scores []struct {
+					URL   string  `json:"url"`
+					Score float64 `json:"score"`
+				} `json:"scores"`
+			}
+			err := DecodeStructured(tt.raw, URLScoresSchema, &got)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got.Scores) != tt.wantScores {
+				t.Errorf("scores = %d, want %d", len(got.Scores), tt.wantScores)
+			}
+		})
+	}
+}
+
+// chatServer replies with the given contents in order and records request bodies
+func chatServer(t *testing.T, replies []string, requests *[]map[string]interface{}) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("bad request body: %v", err)
+		}
+		*requests = append(*requests, body)
+		n := atomic.AddInt32(&calls, 1)
+		reply := replies[len(replies)-1]
+		if int(n) <= len(replies) {
+			reply = replies[n-1]
+		}
+		if strings.HasPrefix(reply, "HTTP400:") {
+			http.Error(w, strings.TrimPrefix(reply, "HTTP400:"), http.StatusBadRequest)
+			return
+		}
+		content, _ := json.Marshal(reply)
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%s}}]}`, content)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestGenerateJSONRepairLoop(t *testing.T) {
+	var requests []map[string]interface{}
+	srv, calls := chatServer(t, []string{
+		`{"title":"T","tags":[],"flashcards":[{"q":"Q?","a":"A"}]}`,
+		`{"title":"T","tags":[],"flashcards":[{"question":"Q?","answer":"A"}]}`,
+	}, &requests)
+
+	p := NewBaseProvider(ProviderConfig{Name: "Test", BaseURL: srv.URL, TextModel: "m", JSONMode: JSONModeSchema})
+	title, _, cards, err := p.GenerateFlashcards("some text", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if title != "T" || len(cards) != 1 || cards[0].Answer != "A" {
+		t.Fatalf("unexpected result: title=%q cards=%v", title, cards)
+	}
+	if *calls != 2 {
+		t.Fatalf("calls = %d, want 2", *calls)
+	}
+
+	// The repair request must echo the bad output and the validation error
+	msgs := requests[1]["messages"].([]interface{})
+	if len(msgs) != 3 {
+		t.Fatalf("repair request has %d messages, want 3", len(msgs))
+	}
+	repair := msgs[2].(map[string]interface{})["content"].(string)
+	if !strings.Contains(repair, `missing required property "answer"`) {
+		t.Errorf("repair prompt does not mention the error: %q", repair)
+	}
+
+	format := requests[0]["response_format"].(map[string]interface{})
+	if format["type"] != "json_schema" {
+		t.Errorf("response_format type = %v, want json_schema", format["type"])
+	}
+}
+
+func TestGenerateJSONGivesUpAfterMaxAttempts(t *testing.T) {
+	var requests []map[string]interface{}
+	srv, calls := chatServer(t, []string{"not json at all"}, &requests)
+
+	p := NewBaseProvider(ProviderConfig{Name: "Test", BaseURL: srv.URL, TextModel: "m"})
+	_, _, _, err := p.GenerateFlashcards("some text", nil)
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Fatalf("expected bounded failure, got %v", err)
+	}
+	if *calls != maxStructuredAttempts {
+		t.Fatalf("calls = %d, want %d", *calls, maxStructuredAttempts)
+	}
+	if _, ok := requests[0]["response_format"]; ok {
+		t.Errorf("response_format sent although JSON mode is disabled")
+	}
+}
+
+func TestGenerateJSONFallsBackWhenResponseFormatRejected(t *testing.T) {
+	var requests []map[string]interface{}
+	srv, calls := chatServer(t, []string{
+		`HTTP400:{"error":{"message":"response_format json_schema is not supported for this model"}}`,
+		`{"scores":[{"url":"https://a.dev","score":0.7}]}`,
+	}, &requests)
+
+	p := NewBaseProvider(ProviderConfig{Name: "Test", BaseURL: srv.URL, TextModel: "m", JSONMode: JSONModeSchema})
+	var out struct {
+		Scores []struct {
+			Score float64 `json:"score"`
+		} `json:"scores"`
+	}
+	if err := p.GenerateJSON("rate these", URLScoresSchema, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *calls != 2 || len(out.Scores) != 1 {
+		t.Fatalf("calls=%d scores=%d, want 2 and 1", *calls, len(out.Scores))
+	}
+	if _, ok := requests[1]["response_format"]; ok {
+		t.Errorf("response_format should be dropped after the backend rejected it")
+	}
+}
@@ -18,8 +18,16 @@ type Config struct {
 	RazorpayWebhookSecret string
 	RazorpayPaymentFlow   string
 	SerpAPIKey            string
+	SupadataAPIKey        string
+	ScraperDomainChains   string // "domain=extractor,extractor;..." (see scraper.ParseDomainChains)
+	ScraperMaxBodyMB      int
+	ScraperMaxPerHost     int
+	ScraperCacheMB        int
 	FeedAPIKey            string
 	FirebaseCredPath      string
+	EmbeddingAPIKey       string
+	EmbeddingURL          string
+	EmbeddingModel        string
 	LimitFreeLink         int
 	LimitFreeText         int
 	LimitProLink          int
@@ -38,8 +46,16 @@ func Load() Config {
 		GoogleClientID:        os.Getenv("GOOGLE_CLIENT_ID"),
 		GroqAPIKey:            os.Getenv("GROQ_API_KEY"),
 		CerebrasAPIKey:        os.Getenv("CEREBRAS_API_KEY"),
+		EmbeddingAPIKey:       os.Getenv("EMBEDDING_API_KEY"),
+		EmbeddingURL:          getEnv("EMBEDDING_URL", "https://api.openai.com/v1/embeddings"),
+		EmbeddingModel:        getEnv("EMBEDDING_MODEL", "text-embedding-3-small"),
 		TavilyAPIKey:          os.Getenv("TAVILY_API_KEY"),
 		SerpAPIKey:            os.Getenv("SERPAPI_API_KEY"),
+		SupadataAPIKey:        os.Getenv("SUPADATA_API_KEY"),
+		ScraperDomainChains:   getEnv("SCRAPER_DOMAIN_CHAINS", "x.com=jina,supadata;twitter.com=jina,supadata;medium.com=jina,readability,supadata"),
+		ScraperMaxBodyMB:      getEnvInt("SCRAPER_MAX_BODY_MB", 10),
+		ScraperMaxPerHost:     getEnvInt("SCRAPER_MAX_PER_HOST", 4),
+		ScraperCacheMB:        getEnvInt("SCRAPER_CACHE_MB", 64),
 		FeedAPIKey:            os.Getenv("FEED_API_KEY"),
 		RazorpayKeyID:         getEnv("RAZORPAY_KEY_ID", ""),
 		RazorpayKeySecret:     getEnv("RAZORPAY_KEY_SECRET", ""),
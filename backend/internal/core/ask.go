@@ -0,0 +1,267 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/prompts"
+)
+
+const (
+	// maxAskSources bounds the passages put into the Q&A prompt (~2000 chars each)
+	maxAskSources = 6
+	// maxPassagesPerMaterial keeps one long material from crowding out the others
+	maxPassagesPerMaterial = 2
+	// askCandidateMaterials is how many full-text matches are scanned for passages
+	askCandidateMaterials = 4
+	// NoLibraryAnswer is returned when no material matches the question
+	NoLibraryAnswer = "I couldn't find anything about this in your library."
+)
+
+// LibrarySource is a passage of one of the user's materials used to answer a question
+type LibrarySource struct {
+	Index      int // 1-based, as cited in the answer ("[1]")
+	MaterialID string
+	Title      string
+	SourceURL  string
+	Text       string
+}
+
+// AskLibrary answers a question from the user's own materials, streaming the answer.
+// onSources is called once with the retrieved passages before any delta.
+// Returns the full answer and the sources it cites.
+func (c *LearningCore) AskLibrary(ctx context.Context, userID, question string, onSources func([]*LibrarySource) error, onDelta ai.DeltaFunc) (string, []*LibrarySource, error) {
+	log.Printf("[Core.AskLibrary] UserID: %s, Question: %q", userID, question)
+
+	sources, err := c.retrieveSources(ctx, userID, question)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to retrieve sources: %w", err)
+	}
+	log.Printf("[Core.AskLibrary] Retrieved %d passages", len(sources))
+
+	if err := onSources(sources); err != nil {
+		return "", nil, err
+	}
+
+	if len(sources) == 0 {
+		if err := onDelta(NoLibraryAnswer); err != nil {
+			return "", nil, err
+		}
+		return NoLibraryAnswer, nil, nil
+	}
+
+	prompt, err := c.renderPrompt(ctx, prompts.NameAskLibrary, userID, prompts.AskLibraryData{
+		Question: question,
+		Sources:  formatSources(sources),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	answer, err := c.ai.StreamCompletion(ctx, prompt, onDelta)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate answer: %w", err)
+	}
+
+	cited := citedSources(answer, sources)
+	log.Printf("[Core.AskLibrary] Answer length: %d, cites %d of %d sources", len(answer), len(cited), len(sources))
+	return answer, cited, nil
+}
+
+// retrieveSources finds passages relevant to the question: by embedding similarity
+// when embeddings are enabled, otherwise (or if that finds nothing) by full-text search
+func (c *LearningCore) retrieveSources(ctx context.Context, userID, question string) ([]*LibrarySource, error) {
+	if c.embeddings != nil {
+		vectors, err := c.embeddings.Embedder().Embed(ctx, []string{question})
+		if err != nil {
+			log.Printf("[Core.AskLibrary] Question embedding failed, using full-text search: %v", err)
+		} else {
+			hits, err := c.store.SearchPassagesByVector(ctx, userID, vectors[0], maxAskSources*2)
+			if err != nil {
+				return nil, err
+			}
+			if sources := selectSources(hits); len(sources) > 0 {
+				return sources, nil
+			}
+		}
+	}
+	return c.retrieveSourcesFullText(ctx, userID, question)
+}
+
+func (c *LearningCore) retrieveSourcesFullText(ctx context.Context, userID, question string) ([]*LibrarySource, error) {
+	terms := queryTerms(question)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	// A question rarely contains all of its terms in one document: match all terms first, then any
+	materials, err := c.store.SearchMaterials(ctx, userID, question, askCandidateMaterials)
+	if err != nil {
+		return nil, err
+	}
+	if len(materials) == 0 {
+		materials, err = c.store.SearchMaterials(ctx, userID, strings.Join(terms, " or "), askCandidateMaterials)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var hits []*store.MaterialHit
+	for _, m := range materials {
+		content, _, _, _, _, err := c.store.GetMaterialContent(ctx, userID, m.MaterialID)
+		if err != nil {
+			log.Printf("[Core.AskLibrary] Failed to load material %s: %v", m.MaterialID, err)
+			continue
+		}
+		for _, passage := range bestPassages(content, terms, maxPassagesPerMaterial) {
+			hits = append(hits, &store.MaterialHit{MaterialID: m.MaterialID, Title: m.Title, SourceURL: m.SourceURL, Snippet: passage})
+		}
+	}
+	return selectSources(hits), nil
+}
+
+// selectSources numbers ranked passages, keeping at most maxPassagesPerMaterial per material
+func selectSources(hits []*store.MaterialHit) []*LibrarySource {
+	perMaterial := make(map[string]int)
+	var sources []*LibrarySource
+	for _, h := range hits {
+		if len(sources) == maxAskSources {
+			break
+		}
+		if perMaterial[h.MaterialID] == maxPassagesPerMaterial {
+			continue
+		}
+		perMaterial[h.MaterialID]++
+		sources = append(sources, &LibrarySource{
+			Index:      len(sources) + 1,
+			MaterialID: h.MaterialID,
+			Title:      h.Title,
+			SourceURL:  h.SourceURL,
+			Text:       h.Snippet,
+		})
+	}
+	return sources
+}
+
+// bestPassages splits content into passages and returns the n that contain the most query terms
+func bestPassages(content string, terms []string, n int) []string {
+	passages := ai.SplitIntoChunks(content, ai.EmbeddingChunkConfig())
+	type scored struct {
+		text  string
+		score int
+	}
+	ranked := make([]scored, 0, len(passages))
+	for _, p := range passages {
+		lower := strings.ToLower(p)
+		score := 0
+		for _, t := range terms {
+			score += strings.Count(lower, t)
+		}
+		if score > 0 {
+			ranked = append(ranked, scored{p, score})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	var best []string
+	for i := 0; i < len(ranked) && i < n; i++ {
+		best = append(best, ranked[i].text)
+	}
+	return best
+}
+
+// stopWords are common question words ignored when matching passages
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "was": true, "what": true, "when": true,
+	"where": true, "which": true, "who": true, "why": true, "how": true, "does": true, "did": true,
+	"can": true, "with": true, "that": true, "this": true, "from": true, "about": true, "into": true,
+	"you": true, "your": true, "explain": true,
+}
+
+// queryTerms extracts lowercase search terms (3+ chars, no stop words) from a question
+func queryTerms(question string) []string {
+	words := strings.FieldsFunc(strings.ToLower(question), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool)
+	var terms []string
+	for _, w := range words {
+		if len(w) < 3 || stopWords[w] || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+func formatSources(sources []*LibrarySource) string {
+	var sb strings.Builder
+	for _, s := range sources {
+		fmt.Fprintf(&sb, "[%d] %s", s.Index, s.Title)
+		if s.SourceURL != "" {
+			fmt.Fprintf(&sb, " (%s)", s.SourceURL)
+		}
+		fmt.Fprintf(&sb, "\n%s\n\n", strings.TrimSpace(s.Text))
+	}
+	return sb.String()
+}
+
+var citationPattern = regexp.MustCompile(`\[(\d+(?:\s*,\s*\d+)*)\]`)
+
+// citedSources returns the sources referenced as [n] (or [n, m]) in the answer, in source order
+func citedSources(answer string, sources []*LibrarySource) []*LibrarySource {
+	cited := make(map[int]bool)
+	for _, m := range citationPattern.FindAllStringSubmatch(answer, -1) {
+		for _, part := range strings.Split(m[1], ",") {
+			if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+				cited[n] = true
+			}
+		}
+	}
+	var result []*LibrarySource
+	for _, s := range sources {
+		if cited[s.Index] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// CreateFlashcardsFromAnswer saves a library answer as a TEXT material (listing the cited
+// materials as sources) and generates flashcards from it through the normal AddMaterial flow
+func (c *LearningCore) CreateFlashcardsFromAnswer(ctx context.Context, userID, question, answer string, materialIDs []string) (*AddMaterialResult, error) {
+	log.Printf("[Core.CreateFlashcardsFromAnswer] UserID: %s, cited materials: %d", userID, len(materialIDs))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Question: %s\n\nAnswer:\n%s\n", strings.TrimSpace(question), strings.TrimSpace(answer))
+
+	var sourceLines []string
+	for _, id := range materialIDs {
+		// Also verifies the material belongs to the user
+		_, _, title, _, sourceURL, err := c.store.GetMaterialContent(ctx, userID, id)
+		if err != nil {
+			log.Printf("[Core.CreateFlashcardsFromAnswer] Skipping source %s: %v", id, err)
+			continue
+		}
+		line := "- " + title
+		if sourceURL != "" {
+			line += " (" + sourceURL + ")"
+		}
+		sourceLines = append(sourceLines, line)
+	}
+	if len(sourceLines) > 0 {
+		fmt.Fprintf(&sb, "\nSources:\n%s\n", strings.Join(sourceLines, "\n"))
+	}
+
+	// The answer restates library content on purpose; don't report it as a duplicate
+	return c.AddMaterial(ctx, userID, "TEXT", sb.String(), nil, "", "", nil, true)
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+func TestCitedSources(t *testing.T) {
+	sources := []*LibrarySource{{Index: 1}, {Index: 2}, {Index: 3}}
+	answer := "Raft elects a leader by majority vote [2]. Terms only increase [1, 2]. See also [7]."
+
+	var got []int
+	for _, s := range citedSources(answer, sources) {
+		got = append(got, s.Index)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("citedSources = %v, want %v", got, want)
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	got := queryTerms("How does Raft elect a leader? Raft, leader-election!")
+	want := []string{"raft", "elect", "leader", "election"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("queryTerms = %v, want %v", got, want)
+	}
+}
+
+func TestSelectSourcesCapsPerMaterial(t *testing.T) {
+	var hits []*store.MaterialHit
+	for i := 0; i < 4; i++ {
+		hits = append(hits, &store.MaterialHit{MaterialID: "a"})
+	}
+	hits = append(hits, &store.MaterialHit{MaterialID: "b"})
+
+	sources := selectSources(hits)
+	if len(sources) != maxPassagesPerMaterial+1 {
+		t.Fatalf("got %d sources, want %d", len(sources), maxPassagesPerMaterial+1)
+	}
+	for i, s := range sources {
+		if s.Index != i+1 {
+			t.Errorf("source %d has index %d", i, s.Index)
+		}
+	}
+	if sources[len(sources)-1].MaterialID != "b" {
+		t.Errorf("material b should be kept after a is capped")
+	}
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+const (
+	// minQuoteWords is the length a supporting quote needs to count; shorter ones
+	// (a term, a name) would be found almost anywhere
+	minQuoteWords = 4
+	// minQuotePartWords is the length each part of a quote with an ellipsis
+	// needs, so that single common words can't be stitched into a quote
+	minQuotePartWords = 3
+	// maxQuoteGapWords bounds the words an ellipsis may leave out, keeping the
+	// parts of a quote within one passage
+	maxQuoteGapWords = 60
+)
+
+// markdownEmphasis are the characters of emphasis and code spans
+const markdownEmphasis = "*_`"
+
+// ellipsisRe splits a quote where the model left words out
+var ellipsisRe = regexp.MustCompile(`\[?(?:\.\.\.|…)\]?`)
+
+// citeSources checks the supporting quote of each generated card (source_quote,
+// which the flashcards prompt asks for) against the material's content. Case,
+// punctuation, Markdown and whitespace are ignored, and a quote with an
+// ellipsis matches when its parts appear in order. A quote that is found is
+// replaced by the passage as written in the material. A card whose quote is
+// missing or can't be found is flagged SourceUnverified, since the model may
+// have made it up. When no card has a quote, the prompt version in use doesn't
+// ask for them and nothing is flagged. Returns the number of flagged cards.
+func citeSources(content string, cards []*learning.Flashcard) int {
+	quoted := false
+	for _, card := range cards {
+		card.SourceUnverified = false // Only ever set here, whatever the model returned
+		if strings.TrimSpace(card.SourceQuote) != "" {
+			quoted = true
+		}
+	}
+	if !quoted {
+		return 0
+	}
+
+	text := normalizeQuoteText(content)
+	flagged := 0
+	for _, card := range cards {
+		if passage, ok := text.find(content, card.SourceQuote); ok {
+			card.SourceQuote, card.SourceUnverified = passage, false
+		} else {
+			card.SourceQuote, card.SourceUnverified = strings.TrimSpace(card.SourceQuote), true
+			flagged++
+		}
+	}
+	return flagged
+}
+
+// quoteText is text reduced to lower-case words separated by single spaces,
+// with the byte offset in the original text each of its bytes comes from
+type quoteText struct {
+	text    string
+	offsets []int
+}
+
+func normalizeQuoteText(s string) quoteText {
+	var sb strings.Builder
+	offsets := make([]int, 0, len(s))
+	gap, wasDigit := false, false
+	for i, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			gap = true
+			continue
+		}
+		// "300ms" and "300 ms" are the same words
+		isDigit := unicode.IsDigit(r)
+		if (gap || isDigit != wasDigit) && sb.Len() > 0 {
+			sb.WriteByte(' ')
+			offsets = append(offsets, i)
+		}
+		gap, wasDigit = false, isDigit
+		n, _ := sb.WriteRune(unicode.ToLower(r))
+		for range n {
+			offsets = append(offsets, i)
+		}
+	}
+	return quoteText{text: sb.String(), offsets: offsets}
+}
+
+// find locates a quote in the text it was normalized from (original) and
+// returns the quoted passage as written there, with " … " between the parts
+// of a quote with an ellipsis
+func (t quoteText) find(original, quote string) (string, bool) {
+	var parts []string
+	words := 0
+	for _, part := range ellipsisRe.Split(quote, -1) {
+		if p := normalizeQuoteText(part).text; p != "" {
+			parts = append(parts, p)
+			words += strings.Count(p, " ") + 1
+		}
+	}
+	if words < minQuoteWords {
+		return "", false
+	}
+	if len(parts) > 1 {
+		for _, p := range parts {
+			if strings.Count(p, " ")+1 < minQuotePartWords {
+				return "", false
+			}
+		}
+	}
+
+	// The first part may occur several times: try each until the rest follows closely
+	for start := t.indexWords(parts[0], 0); start >= 0; start = t.indexWords(parts[0], start+1) {
+		if passage, ok := t.passage(original, parts, start); ok {
+			return passage, true
+		}
+	}
+	return "", false
+}
+
+// passage matches the parts of a quote in order, the first at start and each
+// next one at most maxQuoteGapWords after the previous, and returns them as
+// written in original
+func (t quoteText) passage(original string, parts []string, start int) (string, bool) {
+	passages := make([]string, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 {
+			prevEnd := start
+			if start = t.indexWords(part, prevEnd); start < 0 {
+				return "", false
+			}
+			if strings.Count(t.text[prevEnd:start], " ")-1 > maxQuoteGapWords {
+				return "", false
+			}
+		}
+		end := start + len(part)
+		first, last := t.offsets[start], t.offsets[end-1]
+		_, size := utf8.DecodeRuneInString(original[last:])
+		last += size
+		// Include Markdown emphasis around the first and last words
+		for first > 0 && strings.ContainsRune(markdownEmphasis, rune(original[first-1])) {
+			first--
+		}
+		for last < len(original) && strings.ContainsRune(markdownEmphasis, rune(original[last])) {
+			last++
+		}
+		passages = append(passages, original[first:last])
+		start = end
+	}
+	return strings.Join(passages, " … "), true
+}
+
+// indexWords returns the first index at or after from where part occurs as
+// whole words, or -1
+func (t quoteText) indexWords(part string, from int) int {
+	for from <= len(t.text) {
+		i := strings.Index(t.text[from:], part)
+		if i < 0 {
+			return -1
+		}
+		start, end := from+i, from+i+len(part)
+		if (start == 0 || t.text[start-1] == ' ') && (end == len(t.text) || t.text[end] == ' ') {
+			return start
+		}
+		from = start + 1
+	}
+	return -1
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+func TestCiteSources(t *testing.T) {
+	content := "## Leader election\n\nFollowers start an **election** when the election timeout expires\n" +
+		"without hearing from a leader. Timeouts are randomized (150–300 ms) to avoid split votes.\n\n" +
+		"A candidate wins once it has votes from a majority of the cluster."
+	tests := []struct {
+		quote      string
+		want       string
+		unverified bool
+	}{
+		// Formatting, case and line breaks differ
+		{"followers start an election when the election timeout expires without hearing from a leader",
+			"Followers start an **election** when the election timeout expires\nwithout hearing from a leader", false},
+		{"Timeouts are randomized (150-300ms)", "Timeouts are randomized (150–300 ms", false},
+		{"Followers start an election ... to avoid split votes.", "Followers start an **election** … to avoid split votes", false},
+		// Made up, out of order, too short, or only part of a word
+		{"A candidate needs votes from every server", "A candidate needs votes from every server", true},
+		{"to avoid split votes … Followers start an election", "to avoid split votes … Followers start an election", true},
+		{"Followers … split votes … majority", "Followers … split votes … majority", true},
+		{"split votes", "split votes", true},
+		{"ollowers start an election", "ollowers start an election", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		card := &learning.Flashcard{SourceQuote: tt.quote}
+		citeSources(content, []*learning.Flashcard{card, {SourceQuote: "a majority of the cluster"}})
+		if card.SourceQuote != tt.want || card.SourceUnverified != tt.unverified {
+			t.Errorf("quote %q: got %q unverified=%v, want %q unverified=%v", tt.quote, card.SourceQuote, card.SourceUnverified, tt.want, tt.unverified)
+		}
+	}
+
+	// The parts of a quote must stay within one passage
+	filler := strings.Repeat("Unrelated words fill this sentence about other topics. ", 12)
+	long := "Followers start an election when the timeout expires.\n\n" + filler + "\n\nCandidates need a majority of votes.\n\n" +
+		"Followers start an election again here, and candidates need a majority of votes."
+	card := &learning.Flashcard{SourceQuote: "Followers start an election … candidates need a majority"}
+	citeSources(long, []*learning.Flashcard{card})
+	if want := "Followers start an election … candidates need a majority"; card.SourceUnverified || card.SourceQuote != want {
+		t.Errorf("quote across a long gap: got %q unverified=%v, want the later close passage %q", card.SourceQuote, card.SourceUnverified, want)
+	}
+	card = &learning.Flashcard{SourceQuote: "the timeout expires … Candidates need a majority"}
+	citeSources(long, []*learning.Flashcard{card})
+	if !card.SourceUnverified {
+		t.Errorf("quote with parts a whole passage apart verified as %q", card.SourceQuote)
+	}
+
+	// Prompt versions that don't ask for quotes
+	cards := []*learning.Flashcard{{Question: "Q?"}, {Question: "Q2?"}}
+	if flagged := citeSources(content, cards); flagged != 0 || cards[0].SourceUnverified {
+		t.Errorf("cards without quotes flagged: %d", flagged)
+	}
+}
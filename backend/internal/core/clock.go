@@ -0,0 +1,13 @@
+package core
+
+import "time"
+
+// AppLocation is the time zone the app's schedules follow: cron jobs run at
+// IST times and daily limits reset at IST midnight
+var AppLocation = time.FixedZone("IST", 5*60*60+30*60)
+
+// startOfDay returns midnight of t's day in AppLocation
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.In(AppLocation).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, AppLocation)
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+// Scheduler presets selectable per collection
+const (
+	SchedulerStandard  = "standard"
+	SchedulerIntensive = "intensive"
+	SchedulerRelaxed   = "relaxed"
+)
+
+const day = 24 * time.Hour
+
+// schedulerPreset is a spaced-repetition schedule: the interval after a
+// successful review at each stage, and the delay after a failed one
+type schedulerPreset struct {
+	intervals []time.Duration // intervals[i] = wait after reaching stage i+1
+	relearn   time.Duration
+}
+
+var schedulerPresets = map[string]schedulerPreset{
+	// The original schedule: 1, 3, 7, 15, 30 days
+	SchedulerStandard: {intervals: []time.Duration{1 * day, 3 * day, 7 * day, 15 * day, 30 * day}, relearn: 1 * day},
+	// Interview prep: short intervals, failed cards come back within the session
+	SchedulerIntensive: {intervals: []time.Duration{1 * day, 2 * day, 4 * day, 7 * day, 14 * day}, relearn: 10 * time.Minute},
+	// Casual reading: fewer, longer-spaced reviews
+	SchedulerRelaxed: {intervals: []time.Duration{2 * day, 5 * day, 12 * day, 30 * day, 60 * day}, relearn: 1 * day},
+}
+
+// next returns the stage reached after a successful review and the wait until the next one
+func (p schedulerPreset) next(stage int32) (int32, time.Duration) {
+	nextStage := stage + 1
+	if max := int32(len(p.intervals)); nextStage > max {
+		nextStage = max
+	}
+	if nextStage < 1 {
+		nextStage = 1
+	}
+	return nextStage, p.intervals[nextStage-1]
+}
+
+// ValidSchedulerPreset reports whether name is a known preset ("" = standard)
+func ValidSchedulerPreset(name string) bool {
+	if name == "" {
+		return true
+	}
+	_, ok := schedulerPresets[name]
+	return ok
+}
+
+// schedulerPresetFor returns the preset of the collection a card belongs to,
+// falling back to the standard schedule
+func (c *LearningCore) schedulerPresetFor(ctx context.Context, flashcardID string) schedulerPreset {
+	name, err := c.store.GetFlashcardSchedulerPreset(ctx, flashcardID)
+	if err != nil {
+		log.Printf("[Core.Review] Failed to get scheduler preset, using standard: %v", err)
+	}
+	if preset, ok := schedulerPresets[name]; ok {
+		return preset
+	}
+	return schedulerPresets[SchedulerStandard]
+}
+
+// SaveCollection creates a collection (empty ID) or updates an existing one
+func (c *LearningCore) SaveCollection(ctx context.Context, userID string, col *store.Collection) (*store.Collection, error) {
+	col.Name = strings.TrimSpace(col.Name)
+	if col.Name == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+	if !ValidSchedulerPreset(col.SchedulerPreset) {
+		return nil, fmt.Errorf("unknown scheduler preset '%s'", col.SchedulerPreset)
+	}
+	if col.SchedulerPreset == "" {
+		col.SchedulerPreset = SchedulerStandard
+	}
+	if col.NewCardsPerDay < 0 {
+		col.NewCardsPerDay = 0
+	}
+
+	if col.ID == "" {
+		log.Printf("[Core.SaveCollection] Creating collection %q for userID: %s", col.Name, userID)
+		id, err := c.store.CreateCollection(ctx, userID, col)
+		if err != nil {
+			return nil, err
+		}
+		col.ID = id
+	} else {
+		log.Printf("[Core.SaveCollection] Updating collection %s for userID: %s", col.ID, userID)
+		if err := c.store.UpdateCollection(ctx, userID, col); err != nil {
+			return nil, err
+		}
+	}
+	return c.store.GetCollection(ctx, userID, col.ID)
+}
+
+// DeleteCollection deletes a collection, keeping its materials
+func (c *LearningCore) DeleteCollection(ctx context.Context, userID, collectionID string) error {
+	log.Printf("[Core.DeleteCollection] Deleting collection %s for userID: %s", collectionID, userID)
+	return c.store.DeleteCollection(ctx, userID, collectionID)
+}
+
+// ListCollections returns the user's collections in display order
+func (c *LearningCore) ListCollections(ctx context.Context, userID string) ([]*store.Collection, error) {
+	return c.store.ListCollections(ctx, userID)
+}
+
+// ReorderCollections sets the display order of the user's collections
+func (c *LearningCore) ReorderCollections(ctx context.Context, userID string, collectionIDs []string) error {
+	return c.store.ReorderCollections(ctx, userID, collectionIDs)
+}
+
+// SetMaterialCollection moves a material into a collection ("" = none)
+func (c *LearningCore) SetMaterialCollection(ctx context.Context, userID, materialID, collectionID string) error {
+	log.Printf("[Core.SetMaterialCollection] Material %s -> collection %q", materialID, collectionID)
+	return c.store.SetMaterialCollection(ctx, userID, materialID, collectionID)
+}
+
+// GetCollectionDueFlashcards returns a review session for a collection: all due
+// cards already in review plus new cards up to the collection's daily limit,
+// easiest first and only once their prerequisites are learned.
+// Members of an organization study its shared collections with their own progress.
+func (c *LearningCore) GetCollectionDueFlashcards(ctx context.Context, userID, collectionID string) ([]*learning.Flashcard, error) {
+	col, err := c.store.GetStudyCollection(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	newLimit := -1
+	if col.NewCardsPerDay > 0 {
+		introduced, err := c.store.CountNewCardsReviewedSince(ctx, userID, collectionID, startOfDay(time.Now()))
+		if err != nil {
+			return nil, err
+		}
+		newLimit = remainingNewCards(int(col.NewCardsPerDay), introduced)
+	}
+
+	// The new-card limit is applied after held-back cards are left out
+	cards, err := c.store.GetCollectionDueFlashcards(ctx, userID, collectionID, -1)
+	if err != nil {
+		return nil, err
+	}
+	if cards, err = c.prerequisiteOrder(ctx, userID, cards, newLimit); err != nil {
+		return nil, err
+	}
+	log.Printf("[Core.GetCollectionDueFlashcards] Collection %s: %d cards (new limit %d)", collectionID, len(cards), newLimit)
+	return cards, nil
+}
+
+// remainingNewCards is how many new cards may still be introduced today
+func remainingNewCards(perDay, introducedToday int) int {
+	if introducedToday >= perDay {
+		return 0
+	}
+	return perDay - introducedToday
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedulerPresetNext(t *testing.T) {
+	standard := schedulerPresets[SchedulerStandard]
+	// The standard preset must keep the original schedule
+	tests := []struct {
+		stage     int32
+		wantStage int32
+		wantDays  int
+	}{
+		{0, 1, 1},
+		{1, 2, 3},
+		{2, 3, 7},
+		{3, 4, 15},
+		{4, 5, 30},
+		{5, 5, 30},
+		{9, 5, 30},
+	}
+	for _, tt := range tests {
+		stage, interval := standard.next(tt.stage)
+		if stage != tt.wantStage || interval != time.Duration(tt.wantDays)*day {
+			t.Errorf("next(%d) = %d, %v; want %d, %d days", tt.stage, stage, interval, tt.wantStage, tt.wantDays)
+		}
+	}
+
+	if schedulerPresets[SchedulerIntensive].relearn >= standard.relearn {
+		t.Error("intensive preset should relearn failed cards sooner than standard")
+	}
+}
+
+func TestValidSchedulerPreset(t *testing.T) {
+	for _, name := range []string{"", SchedulerStandard, SchedulerIntensive, SchedulerRelaxed} {
+		if !ValidSchedulerPreset(name) {
+			t.Errorf("ValidSchedulerPreset(%q) = false", name)
+		}
+	}
+	if ValidSchedulerPreset("cram") {
+		t.Error("ValidSchedulerPreset(\"cram\") = true")
+	}
+}
+
+func TestRemainingNewCards(t *testing.T) {
+	if got := remainingNewCards(20, 5); got != 15 {
+		t.Errorf("remainingNewCards(20, 5) = %d, want 15", got)
+	}
+	if got := remainingNewCards(20, 25); got != 0 {
+		t.Errorf("remainingNewCards(20, 25) = %d, want 0", got)
+	}
+}
+
+func TestStartOfDayUsesAppLocation(t *testing.T) {
+	// 20:00 UTC is already the next day in IST
+	now := time.Date(2024, 3, 10, 20, 0, 0, 0, time.UTC)
+	want := time.Date(2024, 3, 10, 18, 30, 0, 0, time.UTC)
+	if got := startOfDay(now); !got.Equal(want) {
+		t.Errorf("startOfDay(%v) = %v, want %v", now, got.UTC(), want)
+	}
+}
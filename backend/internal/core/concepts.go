@@ -0,0 +1,254 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+	"github.com/amityadav/landr/prompts"
+)
+
+const (
+	// maxPromptConcepts bounds the existing concept names offered to the model for reuse
+	maxPromptConcepts = 200
+	defaultGraphSize  = 50
+	maxGraphSize      = 200
+	defaultRelatedMax = 10
+	maxRelatedMax     = 50
+)
+
+// conceptExtraction is the concept prompt's response
+type conceptExtraction struct {
+	Concepts []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Cards       []int  `json:"cards"`
+	} `json:"concepts"`
+	Relations []struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Type string `json:"type"`
+	} `json:"relations"`
+}
+
+// ConceptGraph is a set of concepts with the edges between them. When focused on
+// one concept it also lists the materials covering it.
+type ConceptGraph struct {
+	Concepts  []*store.Concept
+	Relations []*store.ConceptRelation
+	Materials []*store.RelatedMaterial
+}
+
+// normalizeConceptName maps spelling variants of a concept onto one key:
+// "  B-Trees " and "b-tree" -> "b-tree". Only the last word is singularized.
+func normalizeConceptName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_' || r == '"' || r == '\''
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	last := strings.Trim(words[len(words)-1], ".,;:!?()")
+	if len(last) > 3 && strings.HasSuffix(last, "s") &&
+		!strings.HasSuffix(last, "ss") && !strings.HasSuffix(last, "us") && !strings.HasSuffix(last, "is") {
+		last = strings.TrimSuffix(last, "s")
+	}
+	words[len(words)-1] = last
+	return strings.Trim(strings.Join(words, " "), " .,;:!?()")
+}
+
+// validRelation reports whether the model returned a known relation type
+func validRelation(relation string) bool {
+	switch relation {
+	case store.RelationPrerequisite, store.RelationPartOf, store.RelationRelated:
+		return true
+	}
+	return false
+}
+
+func (c *LearningCore) extractConceptsAsync(userID, materialID, content string) {
+	go func() {
+		if err := c.extractConcepts(context.Background(), userID, materialID, content); err != nil {
+			log.Printf("[Core.AddMaterial] Concept extraction failed: %v", err)
+		}
+	}()
+}
+
+// extractConcepts asks the LLM for the key concepts of a material and links them
+// (and the flashcards testing them) into the user's concept graph. Concepts the
+// user already has are reused, so the same idea from several sources becomes one node.
+func (c *LearningCore) extractConcepts(ctx context.Context, userID, materialID, content string) error {
+	cards, err := c.store.GetMaterialFlashcards(ctx, materialID)
+	if err != nil {
+		return err
+	}
+	existing, err := c.store.ListConceptNames(ctx, userID, maxPromptConcepts)
+	if err != nil {
+		return err
+	}
+
+	var numbered strings.Builder
+	for i, card := range cards {
+		fmt.Fprintf(&numbered, "[%d] Q: %s A: %s\n", i+1, card.Question, card.Answer)
+	}
+	prompt, err := c.renderPrompt(ctx, prompts.NameConcepts, userID, prompts.ConceptsData{
+		ExistingConcepts: strings.Join(existing, "\n"),
+		Flashcards:       numbered.String(),
+		Content:          ai.TruncateToLimit(content, c.ai.MaxContentLen()),
+	})
+	if err != nil {
+		return err
+	}
+	var result conceptExtraction
+	if err := c.ai.GenerateJSON(prompt, ai.ConceptsSchema, &result); err != nil {
+		return fmt.Errorf("LLM call failed: %w", err)
+	}
+
+	// Relations may point at existing concepts that aren't in this material
+	existingNames := make(map[string]string, len(existing))
+	for _, name := range existing {
+		existingNames[normalizeConceptName(name)] = name
+	}
+	conceptIDs := make(map[string]string)
+	conceptID := func(name, description string) (string, error) {
+		key := normalizeConceptName(name)
+		if id, ok := conceptIDs[key]; ok || key == "" {
+			return id, nil
+		}
+		id, err := c.store.UpsertConcept(ctx, userID, strings.TrimSpace(name), key, strings.TrimSpace(description))
+		if err != nil {
+			return "", err
+		}
+		conceptIDs[key] = id
+		return id, nil
+	}
+
+	for _, concept := range result.Concepts {
+		id, err := conceptID(concept.Name, concept.Description)
+		if err != nil {
+			return err
+		}
+		if id == "" {
+			continue
+		}
+		var cardIDs []string
+		for _, n := range concept.Cards {
+			if n >= 1 && n <= len(cards) {
+				cardIDs = append(cardIDs, cards[n-1].ID)
+			}
+		}
+		if err := c.store.LinkMaterialConcept(ctx, materialID, id, cardIDs); err != nil {
+			return err
+		}
+	}
+
+	linked := 0
+	for _, r := range result.Relations {
+		from, to := normalizeConceptName(r.From), normalizeConceptName(r.To)
+		if !validRelation(r.Type) || from == to {
+			continue
+		}
+		ids := [2]string{conceptIDs[from], conceptIDs[to]}
+		for i, key := range []string{from, to} {
+			if name, ok := existingNames[key]; ok && ids[i] == "" {
+				if ids[i], err = conceptID(name, ""); err != nil {
+					return err
+				}
+			}
+		}
+		// Ignore relations to concepts the model invented on the side
+		if ids[0] == "" || ids[1] == "" {
+			continue
+		}
+		if err := c.store.AddConceptRelation(ctx, userID, ids[0], ids[1], r.Type); err != nil {
+			return err
+		}
+		linked++
+	}
+
+	if err := c.store.SetMaterialConceptsExtracted(ctx, materialID); err != nil {
+		return err
+	}
+	log.Printf("[Core.ExtractConcepts] Material %s: %d concepts, %d relations", materialID, len(result.Concepts), linked)
+	return nil
+}
+
+// GetConceptGraph returns the user's most covered concepts and the edges between
+// them. With a focus concept it returns that concept, its neighbours and the
+// materials covering it.
+func (c *LearningCore) GetConceptGraph(ctx context.Context, userID, focusID string, limit int) (*ConceptGraph, error) {
+	if limit <= 0 {
+		limit = defaultGraphSize
+	}
+	if limit > maxGraphSize {
+		limit = maxGraphSize
+	}
+	log.Printf("[Core.GetConceptGraph] UserID: %s, focus: %q, limit: %d", userID, focusID, limit)
+
+	concepts, err := c.store.GetConcepts(ctx, userID, focusID, limit)
+	if err != nil {
+		return nil, err
+	}
+	graph := &ConceptGraph{Concepts: concepts}
+	if len(concepts) == 0 {
+		return graph, nil
+	}
+
+	ids := make([]string, len(concepts))
+	for i, concept := range concepts {
+		ids[i] = concept.ID
+	}
+	if graph.Relations, err = c.store.GetConceptRelations(ctx, userID, ids); err != nil {
+		return nil, err
+	}
+	if focusID != "" {
+		if graph.Materials, err = c.store.GetConceptMaterials(ctx, userID, focusID); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+// GetRelatedMaterials returns the user's materials sharing concepts with a
+// material. Concepts of materials imported before the concept graph existed are
+// extracted on first use.
+func (c *LearningCore) GetRelatedMaterials(ctx context.Context, userID, materialID string, limit int) ([]*store.RelatedMaterial, error) {
+	if limit <= 0 {
+		limit = defaultRelatedMax
+	}
+	if limit > maxRelatedMax {
+		limit = maxRelatedMax
+	}
+
+	extracted, err := c.store.MaterialConceptsExtracted(ctx, userID, materialID)
+	if err != nil {
+		return nil, err
+	}
+	if !extracted {
+		content, _, _, _, _, err := c.store.GetMaterialContent(ctx, userID, materialID)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[Core.GetRelatedMaterials] Extracting concepts of %s first", materialID)
+		if err := c.extractConcepts(ctx, userID, materialID, content); err != nil {
+			return nil, err
+		}
+	}
+	return c.store.GetRelatedMaterials(ctx, userID, materialID, limit)
+}
+
+// GetConceptFlashcards returns the cards testing a concept across all of the
+// user's materials, so the concept can be reviewed as one deck
+func (c *LearningCore) GetConceptFlashcards(ctx context.Context, userID, conceptID string) ([]*learning.Flashcard, error) {
+	log.Printf("[Core.GetConceptFlashcards] UserID: %s, concept: %s", userID, conceptID)
+	cards, err := c.store.GetConceptFlashcards(ctx, userID, conceptID)
+	if err != nil {
+		return nil, err
+	}
+	return c.prerequisiteOrder(ctx, userID, cards, -1)
+}
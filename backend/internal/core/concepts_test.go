@@ -0,0 +1,27 @@
+package core
+
+import "testing"
+
+func TestNormalizeConceptName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"  B-Trees ", "b-tree"},
+		{"b-tree", "b-tree"},
+		{"Consistent  Hashing", "consistent hashing"},
+		{"Write-ahead logs.", "write-ahead log"},
+		{"Redis", "redis"},
+		{"Paxos", "paxo"}, // Singularized, but consistently so
+		{"Process", "process"},
+		{"Raft consensus", "raft consensus"},
+		{"CAP", "cap"},
+		{"\"Idempotency\"", "idempotency"},
+		{"   ", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeConceptName(tt.name); got != tt.want {
+			t.Errorf("normalizeConceptName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+const (
+	// maxSlugBaseLength bounds the title-derived part of generated slugs
+	maxSlugBaseLength = 48
+	slugSuffixLength  = 6
+	slugAlphabet      = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// ErrSharedCollectionDeck is returned when publishing a collection shared with
+// an organization, whose materials belong to other members too
+var ErrSharedCollectionDeck = errors.New("collections shared with an organization can't be published")
+
+// validSlug matches custom slugs: lowercase words joined by single dashes
+var validSlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidDeckSlug reports whether a user-chosen slug is acceptable
+func ValidDeckSlug(slug string) bool {
+	return len(slug) >= 3 && len(slug) <= 80 && validSlug.MatchString(slug)
+}
+
+// slugify turns a title into a URL slug base: "System Design: Interview!" -> "system-design-interview"
+func slugify(title string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			if sb.Len() >= maxSlugBaseLength {
+				break
+			}
+		} else {
+			dash = true
+		}
+	}
+	return strings.Trim(sb.String(), "-")
+}
+
+// newDeckSlug derives a unique-enough slug from a title with a random suffix
+func newDeckSlug(title string) string {
+	suffix := make([]byte, slugSuffixLength)
+	rand.Read(suffix)
+	for i, b := range suffix {
+		suffix[i] = slugAlphabet[int(b)%len(slugAlphabet)]
+	}
+	if base := slugify(title); base != "" {
+		return base + "-" + string(suffix)
+	}
+	return "deck-" + string(suffix)
+}
+
+// uniqueName returns base, or "base (n)" with the smallest n not in taken
+func uniqueName(base string, taken map[string]bool) string {
+	name := base
+	for n := 2; taken[name]; n++ {
+		name = fmt.Sprintf("%s (%d)", base, n)
+	}
+	return name
+}
+
+// PublishDeck publishes a material or a collection (exactly one) as a read-only
+// public deck. An empty slug is generated from the title. Collections shared
+// with an organization can't be published.
+func (c *LearningCore) PublishDeck(ctx context.Context, userID, materialID, collectionID, slug, description string) (*store.PublicDeck, error) {
+	if (materialID == "") == (collectionID == "") {
+		return nil, fmt.Errorf("exactly one of material_id or collection_id is required")
+	}
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug != "" && !ValidDeckSlug(slug) {
+		return nil, fmt.Errorf("invalid slug '%s'", slug)
+	}
+
+	var title string
+	if materialID != "" {
+		_, _, materialTitle, _, _, err := c.store.GetMaterialContent(ctx, userID, materialID)
+		if err != nil {
+			return nil, err
+		}
+		title = materialTitle
+	} else {
+		col, err := c.store.GetCollection(ctx, userID, collectionID)
+		if err != nil {
+			return nil, err
+		}
+		if col.OrganizationID != "" {
+			return nil, ErrSharedCollectionDeck
+		}
+		title = col.Name
+		if description == "" {
+			description = col.Description
+		}
+	}
+
+	deck := &store.PublicDeck{
+		Slug:         slug,
+		MaterialID:   materialID,
+		CollectionID: collectionID,
+		Title:        title,
+		Description:  description,
+	}
+
+	log.Printf("[Core.PublishDeck] UserID: %s, Material: %q, Collection: %q, Slug: %q", userID, materialID, collectionID, slug)
+	published, err := c.store.PublishDeck(ctx, userID, deck, newDeckSlug(title))
+	if errors.Is(err, store.ErrSlugTaken) && slug == "" {
+		// Generated slug collided: retry once with a new suffix
+		published, err = c.store.PublishDeck(ctx, userID, deck, newDeckSlug(title))
+	}
+	return published, err
+}
+
+// UnpublishDeck removes a public deck
+func (c *LearningCore) UnpublishDeck(ctx context.Context, userID, slug string) error {
+	log.Printf("[Core.UnpublishDeck] UserID: %s, Slug: %s", userID, slug)
+	return c.store.UnpublishDeck(ctx, userID, slug)
+}
+
+// ListPublicDecks returns the decks the user has published
+func (c *LearningCore) ListPublicDecks(ctx context.Context, userID string) ([]*store.PublicDeck, error) {
+	return c.store.ListPublicDecks(ctx, userID)
+}
+
+// GetPublicDeck returns a public deck with its materials and cards (no auth)
+func (c *LearningCore) GetPublicDeck(ctx context.Context, slug string) (*store.PublicDeck, []*store.PublicDeckMaterial, error) {
+	deck, err := c.store.GetPublicDeck(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	materials, err := c.store.GetPublicDeckMaterials(ctx, deck)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deck, materials, nil
+}
+
+// CloneDeckResult is the outcome of CloneDeck
+type CloneDeckResult struct {
+	CollectionID      string
+	MaterialIDs       []string
+	FlashcardsCreated int32
+}
+
+// CloneDeck copies the cards of a public deck into the user's account with fresh
+// SRS state. No AI calls are made, so no import quota is used. Collection decks
+// are cloned into a new collection (with the source's review settings) unless
+// collectionID names one of the user's collections.
+func (c *LearningCore) CloneDeck(ctx context.Context, userID, slug, collectionID string) (*CloneDeckResult, error) {
+	deck, materials, err := c.GetPublicDeck(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[Core.CloneDeck] UserID: %s, Slug: %s, Materials: %d", userID, slug, len(materials))
+
+	if collectionID != "" {
+		if _, err := c.store.GetCollection(ctx, userID, collectionID); err != nil {
+			return nil, err
+		}
+	} else if deck.CollectionID != "" {
+		collectionID, err = c.cloneCollection(ctx, userID, deck)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CloneDeckResult{CollectionID: collectionID}
+	for _, m := range materials {
+		newID, cards, err := c.store.CloneMaterial(ctx, m.ID, userID, collectionID)
+		if err != nil {
+			return result, fmt.Errorf("failed to clone '%s': %w", m.Title, err)
+		}
+		if tags, err := c.store.GetMaterialTags(ctx, m.ID); err == nil {
+			c.linkTags(ctx, userID, newID, tags)
+		}
+		result.MaterialIDs = append(result.MaterialIDs, newID)
+		result.FlashcardsCreated += int32(cards)
+	}
+
+	if err := c.store.IncrementDeckCloneCount(ctx, deck.ID); err != nil {
+		log.Printf("[Core.CloneDeck] %v", err)
+	}
+	log.Printf("[Core.CloneDeck] Cloned %d materials, %d cards", len(result.MaterialIDs), result.FlashcardsCreated)
+	return result, nil
+}
+
+// cloneCollection creates a collection for a cloned collection deck, copying the
+// source's review settings under a name not yet used by the user
+func (c *LearningCore) cloneCollection(ctx context.Context, userID string, deck *store.PublicDeck) (string, error) {
+	col := &store.Collection{Name: deck.Title, Description: deck.Description, SchedulerPreset: SchedulerStandard}
+	if src, err := c.store.GetCollection(ctx, deck.UserID, deck.CollectionID); err == nil {
+		col.CoverURL = src.CoverURL
+		col.NewCardsPerDay = src.NewCardsPerDay
+		col.SchedulerPreset = src.SchedulerPreset
+	}
+
+	existing, err := c.store.ListCollections(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		taken[e.Name] = true
+	}
+	col.Name = uniqueName(col.Name, taken)
+
+	return c.store.CreateCollection(ctx, userID, col)
+}
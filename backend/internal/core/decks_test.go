@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"System Design: Interview!": "system-design-interview",
+		"  Raft -- Consensus  ":     "raft-consensus",
+		"Go 1.22 generics":          "go-1-22-generics",
+		"日本語":                       "",
+	}
+	for in, want := range tests {
+		if got := slugify(in); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := slugify(strings.Repeat("word ", 40)); len(got) > maxSlugBaseLength || strings.HasSuffix(got, "-") {
+		t.Errorf("slugify(long title) = %q", got)
+	}
+}
+
+func TestNewDeckSlug(t *testing.T) {
+	for _, title := range []string{"System Design", "日本語"} {
+		slug := newDeckSlug(title)
+		if !ValidDeckSlug(slug) {
+			t.Errorf("newDeckSlug(%q) = %q is not a valid slug", title, slug)
+		}
+	}
+}
+
+func TestValidDeckSlug(t *testing.T) {
+	for _, slug := range []string{"raft", "system-design-2024"} {
+		if !ValidDeckSlug(slug) {
+			t.Errorf("ValidDeckSlug(%q) = false", slug)
+		}
+	}
+	for _, slug := range []string{"", "ab", "Raft", "raft--notes", "-raft", "raft/notes"} {
+		if ValidDeckSlug(slug) {
+			t.Errorf("ValidDeckSlug(%q) = true", slug)
+		}
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	taken := map[string]bool{"Raft": true, "Raft (2)": true}
+	if got := uniqueName("Raft", taken); got != "Raft (3)" {
+		t.Errorf("uniqueName = %q, want %q", got, "Raft (3)")
+	}
+	if got := uniqueName("Paxos", taken); got != "Paxos" {
+		t.Errorf("uniqueName = %q, want %q", got, "Paxos")
+	}
+}
+
+// deckStore serves one collection and records published decks
+type deckStore struct {
+	store.Store
+	collection *store.Collection
+	published  []*store.PublicDeck
+}
+
+func (s *deckStore) GetCollection(ctx context.Context, userID, collectionID string) (*store.Collection, error) {
+	return s.collection, nil
+}
+
+func (s *deckStore) PublishDeck(ctx context.Context, userID string, d *store.PublicDeck, fallbackSlug string) (*store.PublicDeck, error) {
+	s.published = append(s.published, d)
+	return d, nil
+}
+
+func TestPublishDeckRefusesSharedCollection(t *testing.T) {
+	s := &deckStore{collection: &store.Collection{ID: "c1", Name: "Team onboarding", OrganizationID: "org1"}}
+	c := &LearningCore{store: s}
+
+	if _, err := c.PublishDeck(context.Background(), "u1", "", "c1", "", ""); !errors.Is(err, ErrSharedCollectionDeck) {
+		t.Fatalf("PublishDeck(shared collection) error = %v, want ErrSharedCollectionDeck", err)
+	}
+	if len(s.published) != 0 {
+		t.Errorf("shared collection was published: %+v", s.published[0])
+	}
+
+	s.collection.OrganizationID = ""
+	if _, err := c.PublishDeck(context.Background(), "u1", "", "c1", "", ""); err != nil {
+		t.Fatalf("PublishDeck(private collection) error = %v", err)
+	}
+	if len(s.published) != 1 || s.published[0].Title != "Team onboarding" {
+		t.Errorf("published = %+v, want one deck titled after the collection", s.published)
+	}
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/amityadav/landr/internal/dedupe"
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+// dedupeCandidatesPerCard bounds the existing cards a generated card is compared with
+const dedupeCandidatesPerCard = 20
+
+// ErrNoNewCards is returned when every card generated for an import repeats a
+// card the user already has, so the import would add nothing to review
+var ErrNoNewCards = errors.New("every flashcard of this material repeats one you already have")
+
+// Reasons reported in DuplicateMatch
+const (
+	DuplicateSameURL        = "same_url"
+	DuplicateSimilarContent = "similar_content"
+)
+
+// DuplicateMatch is an existing material that already covers an import
+type DuplicateMatch struct {
+	MaterialID string
+	Title      string
+	Reason     string  // DuplicateSameURL or DuplicateSimilarContent
+	Similarity float64 // 1 for same URL, simhash similarity for content
+}
+
+// materialFingerprints loads the user's material fingerprints, computing and
+// saving them for materials imported before fingerprinting existed
+func (c *LearningCore) materialFingerprints(ctx context.Context, userID string) ([]*store.MaterialFingerprint, error) {
+	fps, err := c.store.GetMaterialFingerprints(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, fp := range fps {
+		if fp.Fingerprinted {
+			continue
+		}
+		fp.CanonicalURL = canonicalSourceURL(fp.SourceURL)
+		fp.Simhash, fp.HasSimhash = dedupe.Simhash(fp.Content)
+		fp.Content = ""
+		if err := c.store.SetMaterialFingerprint(ctx, fp.ID, fp.CanonicalURL, simhashPtr(fp.Simhash, fp.HasSimhash)); err != nil {
+			log.Printf("[Core.AddMaterial] Failed to backfill fingerprint for %s: %v", fp.ID, err)
+		}
+	}
+	return fps, nil
+}
+
+func findDuplicateByURL(fps []*store.MaterialFingerprint, sourceURL string) *DuplicateMatch {
+	canonical := canonicalSourceURL(sourceURL)
+	for _, fp := range fps {
+		if fp.SourceURL == sourceURL || (canonical != "" && fp.CanonicalURL == canonical) {
+			return &DuplicateMatch{MaterialID: fp.ID, Title: fp.Title, Reason: DuplicateSameURL, Similarity: 1}
+		}
+	}
+	return nil
+}
+
+// findDuplicateByContent returns the closest material within dedupe.NearDuplicateDistance
+func findDuplicateByContent(fps []*store.MaterialFingerprint, simhash uint64) *DuplicateMatch {
+	var best *store.MaterialFingerprint
+	bestDistance := dedupe.NearDuplicateDistance + 1
+	for _, fp := range fps {
+		if !fp.HasSimhash {
+			continue
+		}
+		if d := dedupe.Distance(fp.Simhash, simhash); d < bestDistance {
+			best, bestDistance = fp, d
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return &DuplicateMatch{
+		MaterialID: best.ID,
+		Title:      best.Title,
+		Reason:     DuplicateSimilarContent,
+		Similarity: dedupe.Similarity(best.Simhash, simhash),
+	}
+}
+
+// duplicateResult reports an existing material in place of a new import
+func (c *LearningCore) duplicateResult(ctx context.Context, match *DuplicateMatch) *AddMaterialResult {
+	tags, _ := c.store.GetMaterialTags(ctx, match.MaterialID)
+	return &AddMaterialResult{
+		MaterialID:  match.MaterialID,
+		Title:       match.Title,
+		Tags:        tags,
+		DuplicateOf: match,
+	}
+}
+
+// filterDuplicateCards drops generated cards whose question repeats one of the
+// user's existing cards (or an earlier card in the same batch). Each card is
+// compared with the existing cards that best match its question's terms
+// rather than with the whole library.
+func (c *LearningCore) filterDuplicateCards(ctx context.Context, userID string, cards []*learning.Flashcard) ([]*learning.Flashcard, int) {
+	var queries []string
+	for _, card := range cards {
+		if terms := queryTerms(card.Question); len(terms) > 0 {
+			queries = append(queries, strings.Join(terms, " or "))
+		}
+	}
+	var existing []store.FlashcardText
+	var err error
+	if len(queries) > 0 {
+		existing, err = c.store.GetSimilarFlashcards(ctx, userID, queries, dedupeCandidatesPerCard)
+	}
+	if err != nil {
+		log.Printf("[Core.AddMaterial] Failed to load existing cards, skipping card dedupe: %v", err)
+		return cards, 0
+	}
+
+	var index dedupe.CardIndex
+	for _, e := range existing {
+		index.Add(e.ID, e.Question)
+	}
+
+	kept := make([]*learning.Flashcard, 0, len(cards))
+	for _, card := range cards {
+		if id, similarity, ok := index.Match(card.Question); ok {
+			log.Printf("[Core.AddMaterial] Skipping duplicate card %q (%.0f%% similar to %s)", card.Question, similarity*100, id)
+			continue
+		}
+		index.Add("", card.Question)
+		kept = append(kept, card)
+	}
+	return kept, len(cards) - len(kept)
+}
+
+func canonicalSourceURL(sourceURL string) string {
+	if sourceURL == "" {
+		return ""
+	}
+	return dedupe.CanonicalURL(sourceURL)
+}
+
+func simhashPtr(hash uint64, ok bool) *uint64 {
+	if !ok {
+		return nil
+	}
+	return &hash
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/internal/store"
+)
+
+const (
+	// backfillBatchSize is the number of materials/cards embedded per backfill round
+	backfillBatchSize = 50
+	// backfillPause throttles the backfill between rounds to stay under rate limits
+	backfillPause = 2 * time.Second
+	// maxBackfillFailures consecutive failed materials stop the backfill, as
+	// the embedding API is then likely down rather than one material broken
+	maxBackfillFailures = 5
+)
+
+// EmbeddingIndexer computes and stores embeddings for material chunks and flashcards
+type EmbeddingIndexer struct {
+	store    *store.PostgresStore
+	embedder ai.Embedder
+}
+
+func NewEmbeddingIndexer(st *store.PostgresStore, embedder ai.Embedder) *EmbeddingIndexer {
+	return &EmbeddingIndexer{
+		store:    st,
+		embedder: embedder,
+	}
+}
+
+// Embedder returns the underlying embedder (used to embed search queries)
+func (x *EmbeddingIndexer) Embedder() ai.Embedder {
+	return x.embedder
+}
+
+// IndexMaterial embeds the content chunks and flashcards of a material
+func (x *EmbeddingIndexer) IndexMaterial(ctx context.Context, materialID string) error {
+	content, err := x.store.GetMaterialText(ctx, materialID)
+	if err != nil {
+		return err
+	}
+	if err := x.indexChunks(ctx, materialID, content); err != nil {
+		return err
+	}
+	_, err = x.indexFlashcards(ctx, materialID)
+	return err
+}
+
+func (x *EmbeddingIndexer) indexChunks(ctx context.Context, materialID, content string) error {
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	texts := ai.SplitIntoChunks(content, ai.EmbeddingChunkConfig())
+
+	vectors, err := x.embedder.Embed(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed to embed chunks: %w", err)
+	}
+
+	chunks := make([]store.MaterialChunk, len(texts))
+	for i, text := range texts {
+		chunks[i] = store.MaterialChunk{Index: i, Content: text, Embedding: vectors[i]}
+	}
+	if err := x.store.ReplaceMaterialChunks(ctx, materialID, chunks); err != nil {
+		return err
+	}
+	log.Printf("[EmbeddingIndexer.IndexMaterial] Material %s: %d chunks indexed", materialID, len(chunks))
+	return nil
+}
+
+// indexFlashcards embeds flashcards without an embedding ("" = any material) and
+// returns how many were indexed
+func (x *EmbeddingIndexer) indexFlashcards(ctx context.Context, materialID string) (int, error) {
+	cards, err := x.store.GetFlashcardsMissingEmbeddings(ctx, materialID, backfillBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(cards) == 0 {
+		return 0, nil
+	}
+
+	texts := make([]string, len(cards))
+	for i, c := range cards {
+		texts[i] = FlashcardEmbeddingText(c.Question, c.Answer)
+	}
+	vectors, err := x.embedder.Embed(ctx, texts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to embed flashcards: %w", err)
+	}
+
+	for i, c := range cards {
+		if err := x.store.UpdateFlashcardEmbedding(ctx, c.ID, vectors[i]); err != nil {
+			return i, err
+		}
+	}
+	log.Printf("[EmbeddingIndexer.indexFlashcards] %d flashcards indexed", len(cards))
+	return len(cards), nil
+}
+
+// IndexFlashcard embeds a single flashcard (e.g. after an edit)
+func (x *EmbeddingIndexer) IndexFlashcard(ctx context.Context, flashcardID, question, answer string) error {
+	vectors, err := x.embedder.Embed(ctx, []string{FlashcardEmbeddingText(question, answer)})
+	if err != nil {
+		return fmt.Errorf("failed to embed flashcard: %w", err)
+	}
+	return x.store.UpdateFlashcardEmbedding(ctx, flashcardID, vectors[0])
+}
+
+// FlashcardEmbeddingText is the text embedded for a flashcard
+func FlashcardEmbeddingText(question, answer string) string {
+	return question + "\n" + answer
+}
+
+// Backfill indexes existing materials and flashcards that have no embeddings yet.
+// A material that fails is logged and skipped; after maxBackfillFailures in a
+// row the remaining materials wait for the next start and flashcards go next.
+// It runs until everything is indexed, a flashcard batch fails, or ctx is
+// cancelled.
+func (x *EmbeddingIndexer) Backfill(ctx context.Context) {
+	log.Printf("[EmbeddingIndexer.Backfill] Starting")
+	materials, cards := 0, 0
+	var failed []string
+	consecutive := 0
+
+	for ctx.Err() == nil && consecutive < maxBackfillFailures {
+		ids, err := x.store.GetMaterialsMissingChunks(ctx, failed, backfillBatchSize)
+		if err != nil {
+			log.Printf("[EmbeddingIndexer.Backfill] Failed to list materials: %v", err)
+			return
+		}
+		if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			content, err := x.store.GetMaterialText(ctx, id)
+			if err == nil {
+				err = x.indexChunks(ctx, id, content)
+			}
+			if err != nil {
+				log.Printf("[EmbeddingIndexer.Backfill] Skipping material %s: %v", id, err)
+				failed = append(failed, id)
+				if consecutive++; consecutive >= maxBackfillFailures {
+					log.Printf("[EmbeddingIndexer.Backfill] %d materials failed in a row, skipping the rest", consecutive)
+					break
+				}
+				continue
+			}
+			consecutive = 0
+			materials++
+		}
+		sleepCtx(ctx, backfillPause)
+	}
+
+	for ctx.Err() == nil {
+		n, err := x.indexFlashcards(ctx, "")
+		if err != nil {
+			log.Printf("[EmbeddingIndexer.Backfill] Stopping flashcards: %v", err)
+			return
+		}
+		if n == 0 {
+			break
+		}
+		cards += n
+		sleepCtx(ctx, backfillPause)
+	}
+
+	log.Printf("[EmbeddingIndexer.Backfill] Done: %d materials, %d flashcards indexed, %d materials failed", materials, cards, len(failed))
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
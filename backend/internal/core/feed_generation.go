@@ -2,7 +2,6 @@ package core
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -289,30 +288,20 @@ func (g *FeedGenerator) evaluateBatch(ctx context.Context, articles []Article, i
 
 	prompt := fmt.Sprintf(prompts.URLBatchEvaluation, interests, criteria, articleList.String())
 
-	// Call LLM
-	resp, err := g.aiProvider.GenerateCompletion(prompt)
-	if err != nil {
-		return nil, fmt.Errorf("LLM call failed: %w", err)
-	}
-
-	// Parse response
-	cleanResp := strings.TrimSpace(resp)
-	cleanResp = strings.TrimPrefix(cleanResp, "```json")
-	cleanResp = strings.TrimPrefix(cleanResp, "```")
-	cleanResp = strings.TrimSuffix(cleanResp, "```")
-	cleanResp = strings.TrimSpace(cleanResp)
-
-	var scores []struct {
-		URL   string  `json:"url"`
-		Score float64 `json:"score"`
+	// Call LLM (schema-validated, re-prompted on malformed output)
+	var result struct {
+		Scores []struct {
+			URL   string  `json:"url"`
+			Score float64 `json:"score"`
+		} `json:"scores"`
 	}
-	if err := json.Unmarshal([]byte(cleanResp), &scores); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	if err := g.aiProvider.GenerateJSON(prompt, ai.URLScoresSchema, &result); err != nil {
+		return nil, fmt.Errorf("LLM call failed: %w", err)
 	}
 
 	// Map scores back to articles
 	scoreMap := make(map[string]float64)
-	for _, s := range scores {
+	for _, s := range result.Scores {
 		scoreMap[cleanFeedURL(s.URL)] = s.Score
 	}
 
@@ -0,0 +1,225 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amityadav/landr/internal/quota"
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/internal/youtube"
+)
+
+const (
+	// maxVideosPerImport bounds the videos listed for one playlist or channel
+	maxVideosPerImport = 200
+	// maxActiveImportsPerUser bounds the running imports of one user
+	maxActiveImportsPerUser = 3
+	// importItemInterval spaces out video imports, keeping transcript
+	// fetching and card generation at a steady pace across all jobs
+	importItemInterval = 10 * time.Second
+	// importQuotaRetry is the wait of a job whose user is out of daily quota
+	importQuotaRetry = time.Hour
+)
+
+var (
+	ErrTooManyImports = fmt.Errorf("you can run at most %d imports at once", maxActiveImportsPerUser)
+	ErrNoVideos       = errors.New("no videos found in this playlist or channel")
+)
+
+// ImportCore imports YouTube playlists and channels as batch jobs: one
+// material per video, filed in a shared collection. Videos are imported one
+// at a time across all jobs by ProcessImports, each charged to the user's
+// YouTube import quota.
+type ImportCore struct {
+	store      store.Store
+	learning   *LearningCore
+	lister     *youtube.ListExtractor
+	quotas     *quota.Enforcer
+	processing sync.Mutex // Held by a ProcessImports run
+}
+
+func NewImportCore(s store.Store, learning *LearningCore, quotas *quota.Enforcer) *ImportCore {
+	return &ImportCore{store: s, learning: learning, lister: youtube.NewListExtractor(), quotas: quotas}
+}
+
+// StartImport lists the videos of a playlist (in playlist order) or channel
+// (oldest first) and queues them. The materials go to collectionID, or to a
+// new collection named after the list when it's empty. language is the
+// preferred caption language.
+func (c *ImportCore) StartImport(ctx context.Context, userID, rawURL, collectionID, language string) (*store.ImportJob, error) {
+	log.Printf("[Core.StartImport] UserID: %s, URL: %s", userID, rawURL)
+
+	ref, err := youtube.ParseListURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	active, err := c.store.CountActiveImportJobs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if active >= maxActiveImportsPerUser {
+		return nil, ErrTooManyImports
+	}
+	if collectionID != "" {
+		if _, err := c.store.GetCollection(ctx, userID, collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := c.lister.ListVideos(ctx, ref, maxVideosPerImport)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Videos) == 0 {
+		return nil, ErrNoVideos
+	}
+	videos := list.Videos
+	if ref.Kind == youtube.ListChannel {
+		// Channels list the newest first; a channel is learned in publishing order
+		videos = make([]youtube.Video, len(list.Videos))
+		for i, v := range list.Videos {
+			videos[len(videos)-1-i] = v
+		}
+	}
+
+	title := list.Title
+	if title == "" {
+		title = ref.ID
+	}
+	if collectionID == "" {
+		col, err := c.learning.SaveCollection(ctx, userID, &store.Collection{Name: title})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create collection: %w", err)
+		}
+		collectionID = col.ID
+	}
+
+	items := make([]*store.ImportItem, len(videos))
+	for i, v := range videos {
+		items[i] = &store.ImportItem{VideoID: v.ID, Title: v.Title}
+	}
+	job, err := c.store.CreateImportJob(ctx, &store.ImportJob{
+		UserID:       userID,
+		Kind:         ref.Kind,
+		URL:          rawURL,
+		Title:        title,
+		CollectionID: collectionID,
+		Language:     strings.TrimSpace(language),
+	}, items)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[Core.StartImport] Queued import %s: %d videos of %q", job.ID, len(items), title)
+
+	// Start right away rather than at the next scheduled run
+	go c.ProcessImports(context.Background())
+	return job, nil
+}
+
+func (c *ImportCore) ListImports(ctx context.Context, userID string) ([]*store.ImportJob, error) {
+	return c.store.ListImportJobs(ctx, userID)
+}
+
+// GetImport returns an import with the status of each video
+func (c *ImportCore) GetImport(ctx context.Context, userID, jobID string) (*store.ImportJob, []*store.ImportItem, error) {
+	job, err := c.store.GetImportJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := c.store.GetImportItems(ctx, job.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, items, nil
+}
+
+// CancelImport stops an import; videos already imported are kept
+func (c *ImportCore) CancelImport(ctx context.Context, userID, jobID string) (*store.ImportJob, error) {
+	log.Printf("[Core.CancelImport] UserID: %s, JobID: %s", userID, jobID)
+	if err := c.store.CancelImportJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+	return c.store.GetImportJob(ctx, userID, jobID)
+}
+
+// ProcessImports imports pending videos, one every importItemInterval, until
+// no job has a video due. Runs don't overlap: a run started while another is
+// going returns at once.
+func (c *ImportCore) ProcessImports(ctx context.Context) {
+	if !c.processing.TryLock() {
+		return
+	}
+	defer c.processing.Unlock()
+
+	processed := 0
+	for {
+		job, item, err := c.store.NextImportItem(ctx)
+		if err != nil {
+			log.Printf("[Core.ProcessImports] Failed to get next video: %v", err)
+			break
+		}
+		if item == nil {
+			break
+		}
+		if processed > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(importItemInterval):
+			}
+		}
+		c.importItem(ctx, job, item)
+		processed++
+	}
+	if processed > 0 {
+		log.Printf("[Core.ProcessImports] Processed %d videos", processed)
+	}
+}
+
+// importItem imports one video of a job. When the user is out of quota the
+// video stays pending and the job waits for importQuotaRetry; any other
+// failure is recorded on the video and the job moves on.
+func (c *ImportCore) importItem(ctx context.Context, job *store.ImportJob, item *store.ImportItem) {
+	reservation, err := c.quotas.Reserve(ctx, job.UserID, quota.ResourceYoutubeImport)
+	if err != nil {
+		log.Printf("[Core.ImportItem] Import %s waits: %v", job.ID, err)
+		if err := c.store.DeferImportJob(ctx, job.ID, err.Error(), time.Now().Add(importQuotaRetry)); err != nil {
+			log.Printf("[Core.ImportItem] %v", err)
+		}
+		return
+	}
+
+	status, materialID, errMsg := store.ImportItemDone, "", ""
+	result, err := c.learning.AddMaterial(ctx, job.UserID, "YOUTUBE", youtube.WatchURL(item.VideoID), nil, "", job.Language, nil, false)
+	switch {
+	case errors.Is(err, ErrNoNewCards):
+		status = store.ImportItemDuplicate
+	case err != nil:
+		log.Printf("[Core.ImportItem] Failed to import video %s of import %s: %v", item.VideoID, job.ID, err)
+		status, errMsg = store.ImportItemFailed, err.Error()
+		if result != nil {
+			// Saved without all of its cards
+			materialID = result.MaterialID
+			c.quotas.Commit(ctx, reservation)
+		}
+	case result.DuplicateOf != nil:
+		status, materialID = store.ImportItemDuplicate, result.MaterialID
+	default:
+		materialID = result.MaterialID
+		c.quotas.Commit(ctx, reservation)
+	}
+
+	if materialID != "" && status != store.ImportItemDuplicate && job.CollectionID != "" {
+		if err := c.learning.SetMaterialCollection(ctx, job.UserID, materialID, job.CollectionID); err != nil {
+			log.Printf("[Core.ImportItem] Failed to file material %s: %v", materialID, err)
+		}
+	}
+	if err := c.store.SetImportItemStatus(ctx, job.ID, item.Position, status, materialID, errMsg); err != nil {
+		log.Printf("[Core.ImportItem] %v", err)
+	}
+}
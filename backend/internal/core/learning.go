@@ -4,107 +4,106 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/internal/dedupe"
+	"github.com/amityadav/landr/internal/promptregistry"
 	"github.com/amityadav/landr/internal/scraper"
 	"github.com/amityadav/landr/internal/store"
 	"github.com/amityadav/landr/internal/youtube"
 	"github.com/amityadav/landr/pkg/pb/learning"
+	"github.com/amityadav/landr/prompts"
 )
 
 type LearningCore struct {
-	store   store.Store
-	scraper *scraper.Scraper
-	ai      ai.Provider
-	youtube *youtube.TranscriptExtractor
+	store          store.Store
+	scraper        *scraper.Scraper
+	ai             ai.Provider
+	youtube        *youtube.TranscriptExtractor
+	promptRegistry *promptregistry.Registry
+	embeddings     *EmbeddingIndexer // Optional - nil when no embedding API is configured
+	checking       sync.Mutex        // Held by a CheckMaterialChanges run
 }
 
-func NewLearningCore(s store.Store, scraper *scraper.Scraper, aiProvider ai.Provider) *LearningCore {
+func NewLearningCore(s store.Store, scraper *scraper.Scraper, aiProvider ai.Provider, registry *promptregistry.Registry) *LearningCore {
 	return &LearningCore{
-		store:   s,
-		scraper: scraper,
-		ai:      aiProvider,
-		youtube: youtube.NewTranscriptExtractor(),
+		store:          s,
+		scraper:        scraper,
+		ai:             aiProvider,
+		youtube:        youtube.NewTranscriptExtractor(),
+		promptRegistry: registry,
 	}
 }
 
-func (c *LearningCore) AddMaterial(ctx context.Context, userID, matType, content, imageData string, existingTags []string) (string, int32, string, []string, error) {
+// SetEmbeddingIndexer enables semantic indexing of new materials and flashcards
+func (c *LearningCore) SetEmbeddingIndexer(indexer *EmbeddingIndexer) {
+	c.embeddings = indexer
+}
+
+// AddMaterialResult is the outcome of AddMaterial / MergeIntoMaterial
+type AddMaterialResult struct {
+	MaterialID        string
+	FlashcardsCreated int32
+	Title             string
+	Tags              []string
+	// DuplicateOf is set when the import is already covered by an existing material;
+	// nothing was created and MaterialID is the existing material
+	DuplicateOf *DuplicateMatch
+	// DuplicateCardsSkipped counts generated cards not saved because they repeat existing cards
+	DuplicateCardsSkipped int32
+	// UnverifiedCards counts saved cards whose supporting quote isn't in the material
+	UnverifiedCards int32
+}
+
+func (c *LearningCore) AddMaterial(ctx context.Context, userID, matType, content string, images []string, html, language string, existingTags []string, allowDuplicate bool) (*AddMaterialResult, error) {
 	log.Printf("[Core.AddMaterial] Starting - UserID: %s, Type: %s", userID, matType)
 
-	// 0. Check for duplicates if it's a LINK
+	// 0. Check for duplicates by canonical URL before fetching anything
 	sourceURL := ""
-	if matType == "LINK" {
+	if matType == "LINK" || matType == "YOUTUBE" {
 		sourceURL = content
-		existingID, err := c.store.GetMaterialBySourceURL(ctx, userID, sourceURL)
-		if err == nil && existingID != "" {
-			log.Printf("[Core.AddMaterial] Duplicate detected: %s. Returning existing material.", existingID)
-			// We still need to return the title and tags, let's fetch them
-			_, _, title, _, _, err := c.store.GetMaterialContent(ctx, userID, existingID)
-			if err != nil {
-				return existingID, 0, "", nil, nil
+	}
+	var fingerprints []*store.MaterialFingerprint
+	if !allowDuplicate {
+		var err error
+		fingerprints, err = c.materialFingerprints(ctx, userID)
+		if err != nil {
+			// Non-critical: import without duplicate detection
+			log.Printf("[Core.AddMaterial] Failed to load fingerprints: %v", err)
+		}
+		if sourceURL != "" {
+			if match := findDuplicateByURL(fingerprints, sourceURL); match != nil {
+				log.Printf("[Core.AddMaterial] Duplicate URL detected: %s. Returning existing material.", match.MaterialID)
+				return c.duplicateResult(ctx, match), nil
 			}
-			tags, _ := c.store.GetMaterialTags(ctx, existingID)
-			return existingID, 0, title, tags, nil
 		}
 	}
 
 	// 1. Process Content based on type
-	finalContent := content
-
-	switch matType {
-	case "LINK":
-		log.Printf("[Core.AddMaterial] Scraping URL: %s", content)
-		scraped, err := c.scraper.Scrape(content)
-		if err != nil {
-			log.Printf("[Core.AddMaterial] Scraping failed: %v", err)
-			return "", 0, "", nil, fmt.Errorf("failed to scrape url: %w", err)
-		}
-		finalContent = scraped
-		log.Printf("[Core.AddMaterial] Scraped content length: %d", len(finalContent))
-
-	case "IMAGE":
-		log.Printf("[Core.AddMaterial] Extracting text from image, base64 length: %d", len(imageData))
-		if imageData == "" {
-			return "", 0, "", nil, fmt.Errorf("image_data required for IMAGE type")
-		}
-		extractedText, err := c.ai.ExtractTextFromImage(imageData)
-		if err != nil {
-			log.Printf("[Core.AddMaterial] OCR extraction failed: %v", err)
-			return "", 0, "", nil, fmt.Errorf("failed to extract text from image: %w", err)
-		}
-		finalContent = extractedText
-		log.Printf("[Core.AddMaterial] OCR extracted text length: %d", len(finalContent))
+	finalContent, err := c.extractContent(ctx, userID, matType, content, images, html, language)
+	if err != nil {
+		return nil, err
+	}
 
-	case "YOUTUBE":
-		log.Printf("[Core.AddMaterial] Extracting YouTube transcript: %s", content)
-		transcript, err := c.youtube.GetTranscript(ctx, content)
-		if err != nil {
-			log.Printf("[Core.AddMaterial] YouTube transcript failed: %v", err)
-			return "", 0, "", nil, fmt.Errorf("failed to get youtube transcript: %w", err)
+	// 1b. Same content behind a different URL, or pasted text of an imported page
+	simhash, hasSimhash := dedupe.Simhash(finalContent)
+	if !allowDuplicate && hasSimhash {
+		if match := findDuplicateByContent(fingerprints, simhash); match != nil {
+			log.Printf("[Core.AddMaterial] Similar content detected: %s (%.0f%% similar). Returning existing material.", match.MaterialID, match.Similarity*100)
+			return c.duplicateResult(ctx, match), nil
 		}
-		finalContent = transcript
-		log.Printf("[Core.AddMaterial] YouTube transcript length: %d", len(finalContent))
-
-	case "TEXT":
-		log.Printf("[Core.AddMaterial] Using provided text content, length: %d", len(content))
-
-	default:
-		log.Printf("[Core.AddMaterial] Unknown type: %s, treating as TEXT", matType)
 	}
 
-	// 2. Fetch existing tags for AI context
-	userTags, err := c.store.GetTags(ctx, userID)
-	if err != nil {
-		log.Printf("[Core.AddMaterial] Failed to fetch tags: %v", err)
-	}
+	// 2. Fetch the user's canonical tags for AI context
+	userTags := c.promptTags(ctx, userID)
 
 	// 3. Generate Flashcards + Summary in PARALLEL (MultiProvider races Groq vs Cerebras)
 	log.Printf("[Core.AddMaterial] Starting AI generation with %s...", c.ai.Name())
 
-	var title string
-	var tags []string
-	var cards []*learning.Flashcard
+	var generated *generatedCards
 	var summary string
 	var flashcardErr, summaryErr error
 
@@ -113,19 +112,13 @@ func (c *LearningCore) AddMaterial(ctx context.Context, userID, matType, content
 
 	go func() {
 		defer func() { done <- struct{}{} }()
-		log.Printf("[Core.AddMaterial] Generating flashcards...")
-		title, tags, cards, flashcardErr = c.ai.GenerateFlashcards(finalContent, userTags)
-		if flashcardErr != nil {
-			log.Printf("[Core.AddMaterial] Flashcard generation failed: %v", flashcardErr)
-		} else {
-			log.Printf("[Core.AddMaterial] Flashcards generated: %d cards", len(cards))
-		}
+		generated, flashcardErr = c.generateFlashcards(ctx, userID, finalContent, userTags)
 	}()
 
 	go func() {
 		defer func() { done <- struct{}{} }()
 		log.Printf("[Core.AddMaterial] Generating summary...")
-		summary, summaryErr = c.ai.GenerateSummary(finalContent)
+		summary, summaryErr = c.generateSummary(ctx, userID, finalContent)
 		if summaryErr != nil {
 			log.Printf("[Core.AddMaterial] Summary generation failed: %v", summaryErr)
 		} else {
@@ -140,25 +133,46 @@ func (c *LearningCore) AddMaterial(ctx context.Context, userID, matType, content
 
 	// Check for flashcard error (critical)
 	if flashcardErr != nil {
-		return "", 0, "", nil, fmt.Errorf("failed to generate flashcards: %w", flashcardErr)
+		return nil, fmt.Errorf("failed to generate flashcards: %w", flashcardErr)
 	}
 	// Summary error is non-critical - we can continue without it
 
-	log.Printf("[Core.AddMaterial] AI generated Title: %s, Tags: %v, Cards: %d", title, tags, len(cards))
+	title, tags := generated.title, generated.tags
+	log.Printf("[Core.AddMaterial] AI generated Title: %s, Tags: %v, Cards: %d", title, tags, len(generated.cards))
 
 	// Use background context for DB operations - don't let client disconnect cancel saves
 	// AI is already done, we MUST save the results even if client disconnects
 	saveCtx := context.Background()
 
+	// 3b. Drop cards that repeat cards the user already has; a material left
+	// without any is not saved unless duplicates were explicitly allowed
+	cards, skipped := c.filterDuplicateCards(saveCtx, userID, generated.cards)
+	if len(cards) == 0 && skipped > 0 && !allowDuplicate {
+		log.Printf("[Core.AddMaterial] All %d generated cards repeat existing cards, not saving", skipped)
+		return nil, ErrNoNewCards
+	}
+
 	// 4. Save Material with Title
 	log.Printf("[Core.AddMaterial] Saving material to database...")
 	materialID, err := c.store.CreateMaterial(saveCtx, userID, matType, finalContent, title, sourceURL)
 	if err != nil {
 		log.Printf("[Core.AddMaterial] Failed to save material: %v", err)
-		return "", 0, "", nil, fmt.Errorf("failed to create material: %w", err)
+		return nil, fmt.Errorf("failed to create material: %w", err)
 	}
 	log.Printf("[Core.AddMaterial] Material saved with ID: %s", materialID)
 
+	// Record the prompt version for A/B analysis (built-in prompt has no ID)
+	if generated.prompt.TemplateID != "" {
+		if err := c.store.SetMaterialPromptTemplate(saveCtx, materialID, generated.prompt.TemplateID); err != nil {
+			log.Printf("[Core.AddMaterial] Failed to record prompt version: %v", err)
+		}
+	}
+
+	// Record the fingerprint for future duplicate checks
+	if err := c.store.SetMaterialFingerprint(saveCtx, materialID, canonicalSourceURL(sourceURL), simhashPtr(simhash, hasSimhash)); err != nil {
+		log.Printf("[Core.AddMaterial] Failed to save fingerprint: %v", err)
+	}
+
 	// 5. Save Summary if generated
 	if summary != "" && summaryErr == nil {
 		if err := c.store.UpdateMaterialSummary(saveCtx, materialID, summary); err != nil {
@@ -170,34 +184,223 @@ func (c *LearningCore) AddMaterial(ctx context.Context, userID, matType, content
 	}
 
 	// 6. Save Tags and Link to Material
-	var tagIDs []string
-	for _, tagName := range tags {
-		tagID, err := c.store.CreateTag(saveCtx, userID, tagName)
-		if err != nil {
-			log.Printf("[Core.AddMaterial] Failed to create tag %s: %v", tagName, err)
-			continue
-		}
-		tagIDs = append(tagIDs, tagID)
-	}
+	tags = c.linkTags(saveCtx, userID, materialID, tags)
 
-	if len(tagIDs) > 0 {
-		if err := c.store.AddMaterialTags(saveCtx, materialID, tagIDs); err != nil {
-			log.Printf("[Core.AddMaterial] Failed to link tags: %v", err)
-		}
+	// 7. Save Flashcards, easiest first so they are introduced in that order
+	unverified := citeSources(finalContent, cards)
+	if matType == "YOUTUBE" {
+		linkCardsToVideo(sourceURL, finalContent, cards)
 	}
-
-	// 7. Save Flashcards
+	sortByDifficulty(cards)
 	if len(cards) > 0 {
 		log.Printf("[Core.AddMaterial] Saving %d flashcards to database...", len(cards))
 		if err := c.store.CreateFlashcards(saveCtx, materialID, cards); err != nil {
 			log.Printf("[Core.AddMaterial] Failed to save flashcards: %v", err)
-			return materialID, 0, title, tags, fmt.Errorf("failed to save flashcards: %w", err)
+			return &AddMaterialResult{MaterialID: materialID, Title: title, Tags: tags}, fmt.Errorf("failed to save flashcards: %w", err)
 		}
 		log.Printf("[Core.AddMaterial] Flashcards saved successfully")
 	}
 
-	log.Printf("[Core.AddMaterial] Complete - MaterialID: %s, Cards: %d", materialID, len(cards))
-	return materialID, int32(len(cards)), title, tags, nil
+	// 8. Index embeddings and extract concepts in the background (non-critical)
+	c.indexMaterialAsync(materialID)
+	c.extractConceptsAsync(userID, materialID, finalContent)
+
+	log.Printf("[Core.AddMaterial] Complete - MaterialID: %s, Cards: %d, Duplicate cards skipped: %d, Unverified sources: %d", materialID, len(cards), skipped, unverified)
+	return &AddMaterialResult{
+		MaterialID:            materialID,
+		FlashcardsCreated:     int32(len(cards)),
+		Title:                 title,
+		Tags:                  tags,
+		DuplicateCardsSkipped: int32(skipped),
+		UnverifiedCards:       int32(unverified),
+	}, nil
+}
+
+// MergeIntoMaterial adds the cards of new content to an existing material instead of
+// creating a duplicate one. Only cards that don't repeat existing cards are added.
+func (c *LearningCore) MergeIntoMaterial(ctx context.Context, userID, materialID, matType, content string, images []string, html, language string) (*AddMaterialResult, error) {
+	log.Printf("[Core.MergeIntoMaterial] Starting - UserID: %s, Target: %s, Type: %s", userID, materialID, matType)
+
+	// Also verifies the target belongs to the user
+	_, _, title, _, _, err := c.store.GetMaterialContent(ctx, userID, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("material not found: %w", err)
+	}
+
+	finalContent, err := c.extractContent(ctx, userID, matType, content, images, html, language)
+	if err != nil {
+		return nil, err
+	}
+
+	userTags := c.promptTags(ctx, userID)
+	generated, err := c.generateFlashcards(ctx, userID, finalContent, userTags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate flashcards: %w", err)
+	}
+
+	saveCtx := context.Background()
+	cards, skipped := c.filterDuplicateCards(saveCtx, userID, generated.cards)
+	unverified := citeSources(finalContent, cards)
+	if matType == "YOUTUBE" {
+		linkCardsToVideo(content, finalContent, cards)
+	}
+	sortByDifficulty(cards)
+	if len(cards) > 0 {
+		if err := c.store.CreateFlashcards(saveCtx, materialID, cards); err != nil {
+			log.Printf("[Core.MergeIntoMaterial] Failed to save flashcards: %v", err)
+			return nil, fmt.Errorf("failed to save flashcards: %w", err)
+		}
+	}
+	c.linkTags(saveCtx, userID, materialID, generated.tags)
+	c.indexMaterialAsync(materialID)
+	c.extractConceptsAsync(userID, materialID, finalContent)
+
+	tags, _ := c.store.GetMaterialTags(saveCtx, materialID)
+	log.Printf("[Core.MergeIntoMaterial] Complete - MaterialID: %s, Cards added: %d, Duplicate cards skipped: %d, Unverified sources: %d", materialID, len(cards), skipped, unverified)
+	return &AddMaterialResult{
+		MaterialID:            materialID,
+		FlashcardsCreated:     int32(len(cards)),
+		Title:                 title,
+		Tags:                  tags,
+		DuplicateCardsSkipped: int32(skipped),
+		UnverifiedCards:       int32(unverified),
+	}, nil
+}
+
+// extractContent turns the submitted material into text (scrape, OCR, transcript).
+// For LINK, html is the page as rendered by the client; when set it's extracted
+// instead of fetching the URL. For YOUTUBE, language is the preferred caption language.
+func (c *LearningCore) extractContent(ctx context.Context, userID, matType, content string, images []string, html, language string) (string, error) {
+	switch matType {
+	case "LINK":
+		if html != "" {
+			log.Printf("[Core.AddMaterial] Extracting uploaded HTML for URL: %s", content)
+			extracted, err := c.scraper.ScrapeHTML(content, html)
+			if err != nil {
+				log.Printf("[Core.AddMaterial] HTML extraction failed: %v", err)
+				return "", fmt.Errorf("failed to extract uploaded html: %w", err)
+			}
+			log.Printf("[Core.AddMaterial] Extracted content length: %d", len(extracted))
+			return extracted, nil
+		}
+		log.Printf("[Core.AddMaterial] Scraping URL: %s", content)
+		scraped, err := c.scraper.Scrape(ctx, content)
+		if err != nil {
+			log.Printf("[Core.AddMaterial] Scraping failed: %v", err)
+			return "", fmt.Errorf("failed to scrape url: %w", err)
+		}
+		log.Printf("[Core.AddMaterial] Scraped content length: %d", len(scraped))
+		return scraped, nil
+
+	case "IMAGE":
+		log.Printf("[Core.AddMaterial] Extracting text from %d images", len(images))
+		extractedText, err := c.extractImages(ctx, userID, images)
+		if err != nil {
+			log.Printf("[Core.AddMaterial] OCR extraction failed: %v", err)
+			return "", err
+		}
+		log.Printf("[Core.AddMaterial] OCR extracted text length: %d", len(extractedText))
+		return extractedText, nil
+
+	case "YOUTUBE":
+		log.Printf("[Core.AddMaterial] Extracting YouTube transcript: %s", content)
+		transcript, err := c.youtube.GetTranscript(ctx, content, language)
+		if err != nil {
+			log.Printf("[Core.AddMaterial] YouTube transcript failed: %v", err)
+			return "", fmt.Errorf("failed to get youtube transcript: %w", err)
+		}
+		text := transcript.Text()
+		log.Printf("[Core.AddMaterial] YouTube transcript length: %d, language: %q, chapters: %d", len(text), transcript.Language, len(transcript.Chapters))
+		return text, nil
+
+	case "TEXT":
+		log.Printf("[Core.AddMaterial] Using provided text content, length: %d", len(content))
+
+	default:
+		log.Printf("[Core.AddMaterial] Unknown type: %s, treating as TEXT", matType)
+	}
+	return content, nil
+}
+
+// generatedCards is the flashcard generation output and the prompt version that produced it
+type generatedCards struct {
+	prompt *promptregistry.Rendered
+	title  string
+	tags   []string
+	cards  []*learning.Flashcard
+}
+
+func (c *LearningCore) generateFlashcards(ctx context.Context, userID, content string, userTags []string) (*generatedCards, error) {
+	log.Printf("[Core.AddMaterial] Generating flashcards...")
+	prompt, err := c.promptRegistry.Render(ctx, prompts.NameFlashcards, userID, prompts.FlashcardsData{
+		ExistingTags: strings.Join(userTags, ", "),
+		Content:      ai.TruncateToLimit(content, c.ai.MaxContentLen()),
+	})
+	if err != nil {
+		log.Printf("[Core.AddMaterial] Prompt rendering failed: %v", err)
+		return nil, err
+	}
+	log.Printf("[Core.AddMaterial] Using flashcards prompt v%d (%s)", prompt.Version, prompt.Variant)
+
+	title, tags, cards, err := c.ai.GenerateFlashcardsFromPrompt(prompt.Text)
+	if err != nil {
+		log.Printf("[Core.AddMaterial] Flashcard generation failed: %v", err)
+		return nil, err
+	}
+	log.Printf("[Core.AddMaterial] Flashcards generated: %d cards", len(cards))
+	return &generatedCards{prompt: prompt, title: title, tags: tags, cards: cards}, nil
+}
+
+// renderPrompt renders a registry-managed prompt in the version picked for the user
+func (c *LearningCore) renderPrompt(ctx context.Context, name, userID string, data interface{}) (string, error) {
+	prompt, err := c.promptRegistry.Render(ctx, name, userID, data)
+	if err != nil {
+		return "", err
+	}
+	if prompt.TemplateID != "" {
+		log.Printf("[Core.RenderPrompt] Using %s prompt v%d (%s)", name, prompt.Version, prompt.Variant)
+	}
+	return prompt.Text, nil
+}
+
+// generateSummary summarizes content with the user's summary prompt version
+func (c *LearningCore) generateSummary(ctx context.Context, userID, content string) (string, error) {
+	prompt, err := c.renderPrompt(ctx, prompts.NameSummary, userID, prompts.SummaryData{
+		Content: ai.TruncateToLimit(content, ai.MaxSummaryContentLen),
+	})
+	if err != nil {
+		return "", err
+	}
+	return c.ai.GenerateSummaryFromPrompt(prompt)
+}
+
+// linkTags attaches tags to a material, mapping generated names onto the user's
+// canonical tags (renamed and merged names resolve through aliases).
+// Returns the canonical names.
+func (c *LearningCore) linkTags(ctx context.Context, userID, materialID string, tags []string) []string {
+	tagIDs, canonical, err := c.resolveTags(ctx, userID, tags)
+	if err != nil {
+		log.Printf("[Core.AddMaterial] Failed to resolve tags: %v", err)
+		return tags
+	}
+
+	if len(tagIDs) > 0 {
+		if err := c.store.AddMaterialTags(ctx, materialID, tagIDs); err != nil {
+			log.Printf("[Core.AddMaterial] Failed to link tags: %v", err)
+		}
+	}
+	return canonical
+}
+
+func (c *LearningCore) indexMaterialAsync(materialID string) {
+	if c.embeddings == nil {
+		return
+	}
+	go func() {
+		if err := c.embeddings.IndexMaterial(context.Background(), materialID); err != nil {
+			log.Printf("[Core.AddMaterial] Embedding indexing failed: %v", err)
+		}
+	}()
 }
 
 func (c *LearningCore) DeleteMaterial(ctx context.Context, userID, materialID string) error {
@@ -217,13 +420,17 @@ func (c *LearningCore) GetDueFlashcards(ctx context.Context, userID, materialID
 		log.Printf("[Core.GetDueFlashcards] Query failed: %v", err)
 		return nil, err
 	}
+	if cards, err = c.prerequisiteOrder(ctx, userID, cards, -1); err != nil {
+		log.Printf("[Core.GetDueFlashcards] Ordering failed: %v", err)
+		return nil, err
+	}
 	log.Printf("[Core.GetDueFlashcards] Found %d cards", len(cards))
 	return cards, nil
 }
 
-func (c *LearningCore) GetDueMaterials(ctx context.Context, userID string, page, pageSize int32, searchQuery string, tags []string, onlyDue bool) ([]*learning.MaterialSummary, int32, error) {
-	log.Printf("[Core.GetDueMaterials] Querying for userID: %s, page: %d, pageSize: %d, search: %s, tags: %v, onlyDue: %v", userID, page, pageSize, searchQuery, tags, onlyDue)
-	materials, totalCount, err := c.store.GetDueMaterials(ctx, userID, page, pageSize, searchQuery, tags, onlyDue)
+func (c *LearningCore) GetDueMaterials(ctx context.Context, userID string, page, pageSize int32, searchQuery string, tags []string, collectionID string, onlyDue bool) ([]*learning.MaterialSummary, int32, error) {
+	log.Printf("[Core.GetDueMaterials] Querying for userID: %s, page: %d, pageSize: %d, search: %s, tags: %v, collection: %s, onlyDue: %v", userID, page, pageSize, searchQuery, tags, collectionID, onlyDue)
+	materials, totalCount, err := c.store.GetDueMaterials(ctx, userID, page, pageSize, searchQuery, tags, collectionID, onlyDue)
 	if err != nil {
 		log.Printf("[Core.GetDueMaterials] Query failed: %v", err)
 		return nil, 0, err
@@ -232,52 +439,29 @@ func (c *LearningCore) GetDueMaterials(ctx context.Context, userID string, page,
 	return materials, totalCount, nil
 }
 
-func (c *LearningCore) CompleteReview(ctx context.Context, flashcardID string) error {
+func (c *LearningCore) CompleteReview(ctx context.Context, userID, flashcardID string) error {
 	log.Printf("[Core.CompleteReview] Updating flashcard: %s", flashcardID)
 
-	// Fetch the current flashcard to get its stage
-	card, err := c.store.GetFlashcard(ctx, flashcardID)
+	// Fetch the user's current stage for the card (their own progress on shared cards)
+	currentStage, own, err := c.store.GetFlashcardReviewStage(ctx, userID, flashcardID)
 	if err != nil {
 		log.Printf("[Core.CompleteReview] Failed to get flashcard: %v", err)
 		return fmt.Errorf("failed to get flashcard: %w", err)
 	}
 
-	// Implement SRS logic: increment stage and calculate next review time
-	// Stage 0: New card -> 1 day
-	// Stage 1: 1 day -> 3 days
-	// Stage 2: 3 days -> 7 days
-	// Stage 3: 7 days -> 15 days
-	// Stage 4: 15 days -> 30 days
-	// Stage 5+: 30 days (max)
-
-	currentStage := card.Stage
-	nextStage := currentStage + 1
-
-	// Calculate next review interval based on new stage
-	var intervalDays int
-	switch nextStage {
-	case 1:
-		intervalDays = 1
-	case 2:
-		intervalDays = 3
-	case 3:
-		intervalDays = 7
-	case 4:
-		intervalDays = 15
-	default:
-		// Stage 5 and above: 30 days
-		intervalDays = 30
-		if nextStage > 5 {
-			nextStage = 5 // Cap at stage 5
-		}
-	}
+	// Implement SRS logic: increment stage and calculate next review time.
+	// Intervals per stage come from the scheduler preset of the card's collection
+	// (standard: 1, 3, 7, 15, then 30 days at stage 5).
+	preset := c.schedulerPresetFor(ctx, flashcardID)
+
+	nextStage, interval := preset.next(currentStage)
 
-	nextReviewAt := time.Now().Add(time.Duration(intervalDays) * 24 * time.Hour)
+	nextReviewAt := time.Now().Add(interval)
 
-	log.Printf("[Core.CompleteReview] Advancing from stage %d to %d (next review in %d days)",
-		currentStage, nextStage, intervalDays)
+	log.Printf("[Core.CompleteReview] Advancing from stage %d to %d (next review in %v)",
+		currentStage, nextStage, interval)
 
-	err = c.store.UpdateFlashcard(ctx, flashcardID, nextStage, nextReviewAt)
+	err = c.saveReview(ctx, userID, flashcardID, own, true, nextStage, nextReviewAt)
 	if err != nil {
 		log.Printf("[Core.CompleteReview] Update failed: %v", err)
 		return err
@@ -287,30 +471,30 @@ func (c *LearningCore) CompleteReview(ctx context.Context, flashcardID string) e
 	return nil
 }
 
-func (c *LearningCore) FailReview(ctx context.Context, flashcardID string) error {
+func (c *LearningCore) FailReview(ctx context.Context, userID, flashcardID string) error {
 	log.Printf("[Core.FailReview] Failing flashcard: %s", flashcardID)
 
-	// Fetch the current flashcard to get its stage
-	card, err := c.store.GetFlashcard(ctx, flashcardID)
+	// Fetch the user's current stage for the card (their own progress on shared cards)
+	currentStage, own, err := c.store.GetFlashcardReviewStage(ctx, userID, flashcardID)
 	if err != nil {
 		log.Printf("[Core.FailReview] Failed to get flashcard: %v", err)
 		return fmt.Errorf("failed to get flashcard: %w", err)
 	}
 
 	// Decrease stage by 1, minimum 0
-	currentStage := card.Stage
 	nextStage := currentStage - 1
 	if nextStage < 0 {
 		nextStage = 0
 	}
 
-	// Reset to review in 1 day (back to basics)
-	nextReviewAt := time.Now().Add(24 * time.Hour)
+	// Reset to review soon (back to basics): 1 day, or sooner for intensive collections
+	relearn := c.schedulerPresetFor(ctx, flashcardID).relearn
+	nextReviewAt := time.Now().Add(relearn)
 
-	log.Printf("[Core.FailReview] Decreasing from stage %d to %d (next review in 1 day)",
-		currentStage, nextStage)
+	log.Printf("[Core.FailReview] Decreasing from stage %d to %d (next review in %v)",
+		currentStage, nextStage, relearn)
 
-	err = c.store.UpdateFlashcard(ctx, flashcardID, nextStage, nextReviewAt)
+	err = c.saveReview(ctx, userID, flashcardID, own, false, nextStage, nextReviewAt)
 	if err != nil {
 		log.Printf("[Core.FailReview] Update failed: %v", err)
 		return err
@@ -320,12 +504,51 @@ func (c *LearningCore) FailReview(ctx context.Context, flashcardID string) error
 	return nil
 }
 
-func (c *LearningCore) UpdateFlashcard(ctx context.Context, flashcardID, question, answer string) error {
+// saveReview stores a review on the card itself, or as the user's personal
+// progress for cards of other members in a shared collection, and logs it for
+// progress reports
+func (c *LearningCore) saveReview(ctx context.Context, userID, flashcardID string, own, passed bool, stage int32, nextReviewAt time.Time) error {
+	var err error
+	if own {
+		err = c.store.UpdateFlashcard(ctx, flashcardID, stage, nextReviewAt)
+	} else {
+		err = c.store.UpdateFlashcardProgress(ctx, userID, flashcardID, stage, nextReviewAt)
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.store.RecordReview(ctx, userID, flashcardID, passed); err != nil {
+		log.Printf("[Core.Review] Failed to record review: %v", err)
+	}
+	return nil
+}
+
+// UpdateFlashcard edits the text of a card. Members study the cards of shared
+// collections, but only owners and editors of the organization may edit cards
+// of other members.
+func (c *LearningCore) UpdateFlashcard(ctx context.Context, userID, flashcardID, question, answer string) error {
 	log.Printf("[Core.UpdateFlashcard] Updating flashcard: %s", flashcardID)
-	if err := c.store.UpdateFlashcardContent(ctx, flashcardID, question, answer); err != nil {
+	own, orgID, err := c.store.GetFlashcardOwnership(ctx, userID, flashcardID)
+	if err != nil {
+		return err
+	}
+	if !own {
+		if err := c.requireOrgRole(ctx, userID, orgID, orgActionShare); err != nil {
+			return err
+		}
+	}
+	if err := c.store.UpdateFlashcardContent(ctx, userID, flashcardID, question, answer); err != nil {
 		log.Printf("[Core.UpdateFlashcard] Failed: %v", err)
 		return err
 	}
+	// The stored embedding was cleared by the edit; re-embed the new text
+	if c.embeddings != nil {
+		go func() {
+			if err := c.embeddings.IndexFlashcard(context.Background(), flashcardID, question, answer); err != nil {
+				log.Printf("[Core.UpdateFlashcard] Embedding indexing failed: %v", err)
+			}
+		}()
+	}
 	log.Printf("[Core.UpdateFlashcard] Successfully updated")
 	return nil
 }
@@ -381,7 +604,7 @@ func (c *LearningCore) GetMaterialSummary(ctx context.Context, userID, materialI
 
 	// 3. Generate summary via AI
 	log.Printf("[Core.GetMaterialSummary] No summary found, generating via AI...")
-	summary, err = c.ai.GenerateSummary(content)
+	summary, err = c.generateSummary(ctx, userID, content)
 	if err != nil {
 		log.Printf("[Core.GetMaterialSummary] AI generation failed: %v", err)
 		return result, fmt.Errorf("failed to generate summary: %w", err)
@@ -397,3 +620,55 @@ func (c *LearningCore) GetMaterialSummary(ctx context.Context, userID, materialI
 	result.Summary = summary
 	return result, nil
 }
+
+// StreamMaterialSummary streams the summary for a material.
+// onMeta is called once before any text; a stored summary is sent as a single delta.
+// A freshly generated summary is saved only if the stream completes.
+func (c *LearningCore) StreamMaterialSummary(ctx context.Context, userID, materialID string, onMeta func(result *MaterialSummaryResult, cached bool) error, onDelta ai.DeltaFunc) (string, error) {
+	log.Printf("[Core.StreamMaterialSummary] Streaming summary for materialID: %s, userID: %s", materialID, userID)
+
+	content, summary, title, materialType, sourceURL, err := c.store.GetMaterialContent(ctx, userID, materialID)
+	if err != nil {
+		log.Printf("[Core.StreamMaterialSummary] Failed to get material: %v", err)
+		return "", fmt.Errorf("failed to get material: %w", err)
+	}
+
+	result := &MaterialSummaryResult{
+		Summary:      summary,
+		Title:        title,
+		Content:      content,
+		MaterialType: materialType,
+		SourceURL:    sourceURL,
+	}
+
+	if summary != "" {
+		log.Printf("[Core.StreamMaterialSummary] Returning existing summary, length: %d", len(summary))
+		if err := onMeta(result, true); err != nil {
+			return "", err
+		}
+		return summary, onDelta(summary)
+	}
+
+	if err := onMeta(result, false); err != nil {
+		return "", err
+	}
+
+	prompt, err := c.renderPrompt(ctx, prompts.NameSummary, userID, prompts.SummaryData{
+		Content: ai.TruncateToLimit(content, ai.MaxSummaryContentLen),
+	})
+	if err != nil {
+		return "", err
+	}
+	summary, err = c.ai.StreamSummary(ctx, prompt, onDelta)
+	if err != nil {
+		log.Printf("[Core.StreamMaterialSummary] AI streaming failed: %v", err)
+		return "", fmt.Errorf("failed to generate summary: %w", err)
+	}
+
+	if err := c.store.UpdateMaterialSummary(ctx, materialID, summary); err != nil {
+		log.Printf("[Core.StreamMaterialSummary] Failed to save summary: %v", err)
+	}
+
+	log.Printf("[Core.StreamMaterialSummary] Summary streamed and saved, length: %d", len(summary))
+	return summary, nil
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/amityadav/landr/internal/imaging"
+	"github.com/amityadav/landr/prompts"
+)
+
+const (
+	// maxImagesPerMaterial bounds the pages of one IMAGE material
+	maxImagesPerMaterial = 20
+	// ocrConcurrency bounds the vision model calls of one import
+	ocrConcurrency = 4
+)
+
+var ErrTooManyImages = fmt.Errorf("at most %d images per material", maxImagesPerMaterial)
+
+// extractImages reads the text of ordered page images (book pages,
+// whiteboard shots) and merges it into one document. Every image is validated
+// and downscaled before any is sent to the vision model; pages are read in
+// parallel, and a page that fails fails the import rather than leaving a gap.
+func (c *LearningCore) extractImages(ctx context.Context, userID string, images []string) (string, error) {
+	if len(images) == 0 {
+		return "", fmt.Errorf("image_data or images required for IMAGE type")
+	}
+	if len(images) > maxImagesPerMaterial {
+		return "", ErrTooManyImages
+	}
+
+	prepared := make([]string, len(images))
+	for i, img := range images {
+		p, err := imaging.Prepare(img)
+		if err != nil {
+			return "", fmt.Errorf("image %d: %w", i+1, err)
+		}
+		prepared[i] = p
+	}
+
+	prompt, err := c.renderPrompt(ctx, prompts.NameOCR, userID, prompts.OCRData{})
+	if err != nil {
+		return "", err
+	}
+
+	pages := make([]string, len(prepared))
+	errs := make([]error, len(prepared))
+	sem := make(chan struct{}, ocrConcurrency)
+	var wg sync.WaitGroup
+	for i, img := range prepared {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			pages[i], errs[i] = c.ai.ExtractTextFromImage(prompt, img)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return "", fmt.Errorf("failed to extract text from image %d: %w", i+1, err)
+		}
+	}
+	log.Printf("[Core.AddMaterial] OCR read %d images", len(pages))
+	return mergePages(pages), nil
+}
+
+var (
+	// pageNumberRe matches a line that is only a page number: "12", "- 12 -", "Page 12", "12 / 40"
+	pageNumberRe = regexp.MustCompile(`(?i)^[-–—\s]*(?:page\s+)?\d{1,4}(?:\s*(?:/|of)\s*\d{1,4})?[-–—\s]*$`)
+	// fenceRe matches a code fence the model wrapped a whole page in
+	fenceRe = regexp.MustCompile("^```[a-z]*\\n([\\s\\S]*?)\\n```$")
+)
+
+// mergePages joins the text of consecutive pages. Page numbers at the top or
+// bottom of a page are dropped, and a sentence or word broken across a page
+// boundary is joined back together instead of being split into paragraphs.
+func mergePages(pages []string) string {
+	var sb strings.Builder
+	for _, page := range pages {
+		page = cleanPage(page)
+		if page == "" {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString(page)
+			continue
+		}
+		prev := sb.String()
+		switch {
+		case strings.HasSuffix(prev, "-") && continuesSentence(prev[:len(prev)-1], page):
+			// A hyphenated word split across pages
+			merged := strings.TrimSuffix(prev, "-")
+			sb.Reset()
+			sb.WriteString(merged)
+		case continuesSentence(prev, page):
+			sb.WriteString(" ")
+		default:
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(page)
+	}
+	return sb.String()
+}
+
+// cleanPage trims a page's text, unwraps a code fence around it and drops
+// page numbers on its first and last lines
+func cleanPage(page string) string {
+	page = strings.TrimSpace(strings.ReplaceAll(page, "\r\n", "\n"))
+	if m := fenceRe.FindStringSubmatch(page); m != nil {
+		page = strings.TrimSpace(m[1])
+	}
+	lines := strings.Split(page, "\n")
+	for len(lines) > 0 && pageNumberRe.MatchString(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && pageNumberRe.MatchString(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// continuesSentence reports whether next carries on the sentence that prev
+// ends in: prev's last line is running text without closing punctuation and
+// next starts with a lower-case letter
+func continuesSentence(prev, next string) bool {
+	lastLine := prev[strings.LastIndex(prev, "\n")+1:]
+	if lastLine == "" || strings.HasPrefix(lastLine, "#") || strings.HasPrefix(lastLine, "|") {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(lastLine)
+	if strings.ContainsRune(".!?:;\"'”’)]}", last) {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(next)
+	return unicode.IsLower(first)
+}
@@ -0,0 +1,42 @@
+package core
+
+import "testing"
+
+func TestMergePages(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages []string
+		want  string
+	}{
+		{
+			name:  "sentence across pages",
+			pages: []string{"# Raft\n\nA leader sends heartbeats to all\n\n12", "- 13 -\nfollowers at a fixed interval."},
+			want:  "# Raft\n\nA leader sends heartbeats to all followers at a fixed interval.",
+		},
+		{
+			name:  "hyphenated word",
+			pages: []string{"Elections use random-\nized timeouts that pre-", "vent split votes."},
+			want:  "Elections use random-\nized timeouts that prevent split votes.",
+		},
+		{
+			name:  "new section",
+			pages: []string{"The log is replicated.", "## Snapshots\n\nLogs are compacted.", "Page 3 of 3\n```\nsnapshot := take()\n```"},
+			want:  "The log is replicated.\n\n## Snapshots\n\nLogs are compacted.\n\n```\nsnapshot := take()\n```",
+		},
+		{
+			name:  "capitalized next page",
+			pages: []string{"Terms act as a logical clock", "Each server stores a current term."},
+			want:  "Terms act as a logical clock\n\nEach server stores a current term.",
+		},
+		{
+			name:  "fenced page and empty page",
+			pages: []string{"```markdown\n# Notes\n\nvote once per term\n```", "  ", "7"},
+			want:  "# Notes\n\nvote once per term",
+		},
+	}
+	for _, tt := range tests {
+		if got := mergePages(tt.pages); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
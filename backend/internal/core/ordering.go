@@ -0,0 +1,145 @@
+package core
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+// unrankedDifficulty is used for cards generated without a difficulty (1-5 scale)
+const unrankedDifficulty = 3
+
+func cardDifficulty(card *learning.Flashcard) int32 {
+	if card.Difficulty <= 0 {
+		return unrankedDifficulty
+	}
+	return card.Difficulty
+}
+
+// sortByDifficulty orders cards easiest-first, keeping the original order among
+// cards of equal difficulty
+func sortByDifficulty(cards []*learning.Flashcard) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cardDifficulty(cards[i]) < cardDifficulty(cards[j])
+	})
+}
+
+// orderSession orders review cards so prerequisites come before the cards that
+// build on them. Cards in review keep their order; new cards follow, easiest first.
+// New cards are held back when an unlearned prerequisite isn't in the session, when
+// they exceed newLimit (< 0 = unlimited), or when they build on a held-back card.
+// Cards in review are never held back. Cycles are broken by the original order.
+func orderSession(cards []*learning.Flashcard, info map[string]*store.CardOrdering, newLimit int) []*learning.Flashcard {
+	isNew := func(card *learning.Flashcard) bool {
+		return info[card.Id] != nil && info[card.Id].New
+	}
+	prerequisites := func(card *learning.Flashcard) []string {
+		if info[card.Id] == nil {
+			return nil
+		}
+		return info[card.Id].Prerequisites
+	}
+
+	var queue, fresh []*learning.Flashcard
+	for _, card := range cards {
+		if isNew(card) {
+			fresh = append(fresh, card)
+		} else {
+			queue = append(queue, card)
+		}
+	}
+	sortByDifficulty(fresh)
+	queue = append(queue, fresh...)
+
+	// Hold back new cards missing a prerequisite, until no more cards drop out
+	inSession := make(map[string]bool, len(queue))
+	for _, card := range queue {
+		inSession[card.Id] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, card := range fresh {
+			if !inSession[card.Id] {
+				continue
+			}
+			for _, p := range prerequisites(card) {
+				if !inSession[p] {
+					delete(inSession, card.Id)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	var remaining []*learning.Flashcard
+	for _, card := range queue {
+		if inSession[card.Id] {
+			remaining = append(remaining, card)
+		}
+	}
+
+	placed := make(map[string]bool, len(remaining))
+	dropped := make(map[string]bool)
+	ordered := make([]*learning.Flashcard, 0, len(remaining))
+	introduced := 0
+	for len(remaining) > 0 {
+		// First card whose prerequisites are placed (the first card if all wait on a cycle)
+		next := 0
+		for i, card := range remaining {
+			ready := true
+			for _, p := range prerequisites(card) {
+				if inSession[p] && !placed[p] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		card := remaining[next]
+		remaining = append(remaining[:next], remaining[next+1:]...)
+		placed[card.Id] = true
+
+		if isNew(card) {
+			blocked := newLimit >= 0 && introduced >= newLimit
+			for _, p := range prerequisites(card) {
+				blocked = blocked || dropped[p]
+			}
+			if blocked {
+				dropped[card.Id] = true
+				continue
+			}
+			introduced++
+		}
+		ordered = append(ordered, card)
+	}
+	return ordered
+}
+
+// prerequisiteOrder applies orderSession to the cards fetched for a review session
+func (c *LearningCore) prerequisiteOrder(ctx context.Context, userID string, cards []*learning.Flashcard, newLimit int) ([]*learning.Flashcard, error) {
+	ids := make([]string, len(cards))
+	for i, card := range cards {
+		ids[i] = card.Id
+	}
+	info, err := c.store.GetCardOrdering(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	ordered := orderSession(cards, info, newLimit)
+	if left := len(cards) - len(ordered); left > 0 {
+		log.Printf("[Core.OrderSession] Left out %d new cards (prerequisites or daily limit)", left)
+	}
+	return ordered, nil
+}
+
+// SetFlashcardDependencies replaces the cards a card depends on
+func (c *LearningCore) SetFlashcardDependencies(ctx context.Context, userID, flashcardID string, dependsOnIDs []string) error {
+	log.Printf("[Core.SetFlashcardDependencies] Card %s depends on %d cards", flashcardID, len(dependsOnIDs))
+	return c.store.SetFlashcardDependencies(ctx, userID, flashcardID, dependsOnIDs)
+}
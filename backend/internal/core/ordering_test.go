@@ -0,0 +1,108 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+func TestSortByDifficulty(t *testing.T) {
+	cards := []*learning.Flashcard{
+		{Id: "hard", Difficulty: 5},
+		{Id: "unranked"},
+		{Id: "easy", Difficulty: 1},
+		{Id: "medium", Difficulty: 3},
+	}
+	sortByDifficulty(cards)
+	if got, want := cardIDs(cards), []string{"easy", "unranked", "medium", "hard"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestOrderSession(t *testing.T) {
+	card := func(id string, difficulty int32) *learning.Flashcard {
+		return &learning.Flashcard{Id: id, Difficulty: difficulty}
+	}
+	tests := []struct {
+		name     string
+		cards    []*learning.Flashcard
+		info     map[string]*store.CardOrdering
+		newLimit int
+		want     []string
+	}{
+		{
+			name:     "review cards first, new cards easiest first",
+			cards:    []*learning.Flashcard{card("n-hard", 4), card("r1", 0), card("n-easy", 1), card("r2", 0)},
+			info:     map[string]*store.CardOrdering{"n-hard": {New: true}, "n-easy": {New: true}, "r1": {}, "r2": {}},
+			newLimit: -1,
+			want:     []string{"r1", "r2", "n-easy", "n-hard"},
+		},
+		{
+			name:  "prerequisite in session moves ahead",
+			cards: []*learning.Flashcard{card("a", 1), card("b", 4)},
+			info: map[string]*store.CardOrdering{
+				"a": {New: true, Prerequisites: []string{"b"}},
+				"b": {New: true},
+			},
+			newLimit: -1,
+			want:     []string{"b", "a"},
+		},
+		{
+			name:  "new card with prerequisite outside the session is held back, with its dependents",
+			cards: []*learning.Flashcard{card("a", 1), card("b", 2), card("c", 3)},
+			info: map[string]*store.CardOrdering{
+				"a": {New: true, Prerequisites: []string{"elsewhere"}},
+				"b": {New: true, Prerequisites: []string{"a"}},
+				"c": {New: true},
+			},
+			newLimit: -1,
+			want:     []string{"c"},
+		},
+		{
+			name:     "cards in review are never held back",
+			cards:    []*learning.Flashcard{card("r", 0)},
+			info:     map[string]*store.CardOrdering{"r": {Prerequisites: []string{"elsewhere"}}},
+			newLimit: -1,
+			want:     []string{"r"},
+		},
+		{
+			name:  "daily limit counts introduced cards, dependents of dropped cards wait",
+			cards: []*learning.Flashcard{card("a", 1), card("b", 2), card("c", 3)},
+			info: map[string]*store.CardOrdering{
+				"a": {New: true, Prerequisites: []string{"b"}},
+				"b": {New: true},
+				"c": {New: true},
+			},
+			newLimit: 1,
+			want:     []string{"b"},
+		},
+		{
+			name:  "cycle falls back to the original order",
+			cards: []*learning.Flashcard{card("a", 1), card("b", 2)},
+			info: map[string]*store.CardOrdering{
+				"a": {New: true, Prerequisites: []string{"b"}},
+				"b": {New: true, Prerequisites: []string{"a"}},
+			},
+			newLimit: -1,
+			want:     []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cardIDs(orderSession(tt.cards, tt.info, tt.newLimit))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func cardIDs(cards []*learning.Flashcard) []string {
+	ids := make([]string, len(cards))
+	for i, c := range cards {
+		ids[i] = c.Id
+	}
+	return ids
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+var (
+	// ErrRoleNotAllowed is returned when a member's role does not permit an action
+	ErrRoleNotAllowed = errors.New("not allowed for your role in this organization")
+	// ErrMemberNotAdded is returned for emails that can't be added, without
+	// telling whether they belong to an account
+	ErrMemberNotAdded = errors.New("this email can't be added to the organization")
+)
+
+// orgAction is something a member may do in an organization
+type orgAction int
+
+const (
+	orgActionStudy  orgAction = iota // Study shared collections
+	orgActionShare                   // Share collections and add materials to shared ones
+	orgActionManage                  // Manage members and billing
+)
+
+// ValidOrgRole reports whether role is a known member role
+func ValidOrgRole(role string) bool {
+	switch role {
+	case store.RoleOwner, store.RoleEditor, store.RoleLearner:
+		return true
+	}
+	return false
+}
+
+// roleAllows reports whether a member with role may perform action
+func roleAllows(role string, action orgAction) bool {
+	switch role {
+	case store.RoleOwner:
+		return true
+	case store.RoleEditor:
+		return action <= orgActionShare
+	case store.RoleLearner:
+		return action == orgActionStudy
+	}
+	return false
+}
+
+// requireOrgRole returns an error unless the user is a member allowed to perform action
+func (c *LearningCore) requireOrgRole(ctx context.Context, userID, orgID string, action orgAction) error {
+	role, err := c.store.GetOrganizationRole(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !roleAllows(role, action) {
+		return fmt.Errorf("%w (%s)", ErrRoleNotAllowed, role)
+	}
+	return nil
+}
+
+// CreateOrganization creates an organization owned by the user
+func (c *LearningCore) CreateOrganization(ctx context.Context, userID, name string) (*store.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("organization name is required")
+	}
+	log.Printf("[Core.CreateOrganization] Creating %q for userID: %s", name, userID)
+	id, err := c.store.CreateOrganization(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	return c.store.GetOrganization(ctx, userID, id)
+}
+
+// ListOrganizations returns the organizations the user belongs to
+func (c *LearningCore) ListOrganizations(ctx context.Context, userID string) ([]*store.Organization, error) {
+	return c.store.ListOrganizations(ctx, userID)
+}
+
+// DeleteOrganization deletes an organization; only its creator may do this
+func (c *LearningCore) DeleteOrganization(ctx context.Context, userID, orgID string) error {
+	log.Printf("[Core.DeleteOrganization] Deleting %s for userID: %s", orgID, userID)
+	return c.store.DeleteOrganization(ctx, userID, orgID)
+}
+
+// GetOrganizationMembers returns the members of an organization the user belongs to
+func (c *LearningCore) GetOrganizationMembers(ctx context.Context, userID, orgID string) ([]*store.OrganizationMember, error) {
+	if err := c.requireOrgRole(ctx, userID, orgID, orgActionStudy); err != nil {
+		return nil, err
+	}
+	return c.store.GetOrganizationMembers(ctx, orgID)
+}
+
+// AddOrganizationMember adds an existing user by email (default role: learner)
+// and returns the updated member list. The new member is left out of
+// individual-level reports until they opt in with SetReportingOptOut.
+func (c *LearningCore) AddOrganizationMember(ctx context.Context, userID, orgID, email, role string) ([]*store.OrganizationMember, error) {
+	if role == "" {
+		role = store.RoleLearner
+	}
+	if !ValidOrgRole(role) {
+		return nil, fmt.Errorf("unknown role '%s'", role)
+	}
+	org, err := c.store.GetOrganization(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !roleAllows(org.Role, orgActionManage) {
+		return nil, fmt.Errorf("%w (%s)", ErrRoleNotAllowed, org.Role)
+	}
+	memberID, err := c.store.GetUserByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		log.Printf("[Core.AddOrganizationMember] Org %s: no account for the email: %v", orgID, err)
+		return nil, ErrMemberNotAdded
+	}
+	if memberID == org.OwnerID {
+		return nil, fmt.Errorf("the organization's creator is already an owner")
+	}
+
+	log.Printf("[Core.AddOrganizationMember] Org %s: adding %s as %s", orgID, memberID, role)
+	if err := c.store.AddOrganizationMember(ctx, orgID, memberID, role); err != nil {
+		return nil, err
+	}
+	return c.store.GetOrganizationMembers(ctx, orgID)
+}
+
+// SetOrganizationMemberRole changes a member's role. The organization's creator
+// always stays an owner.
+func (c *LearningCore) SetOrganizationMemberRole(ctx context.Context, userID, orgID, memberID, role string) error {
+	if !ValidOrgRole(role) {
+		return fmt.Errorf("unknown role '%s'", role)
+	}
+	org, err := c.store.GetOrganization(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !roleAllows(org.Role, orgActionManage) {
+		return fmt.Errorf("%w (%s)", ErrRoleNotAllowed, org.Role)
+	}
+	if memberID == org.OwnerID && role != store.RoleOwner {
+		return fmt.Errorf("the organization's creator must stay an owner")
+	}
+	log.Printf("[Core.SetOrganizationMemberRole] Org %s: %s -> %s", orgID, memberID, role)
+	return c.store.SetOrganizationMemberRole(ctx, orgID, memberID, role)
+}
+
+// RemoveOrganizationMember removes a member; any member may remove themselves.
+// The organization's creator cannot leave (delete the organization instead).
+func (c *LearningCore) RemoveOrganizationMember(ctx context.Context, userID, orgID, memberID string) error {
+	org, err := c.store.GetOrganization(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && !roleAllows(org.Role, orgActionManage) {
+		return fmt.Errorf("%w (%s)", ErrRoleNotAllowed, org.Role)
+	}
+	if memberID == org.OwnerID {
+		return fmt.Errorf("the organization's creator cannot be removed")
+	}
+	log.Printf("[Core.RemoveOrganizationMember] Org %s: removing %s", orgID, memberID)
+	return c.store.RemoveOrganizationMember(ctx, orgID, memberID)
+}
+
+// ShareCollection shares one of the user's collections with an organization
+// where they are an owner or editor ("" = make it private again)
+func (c *LearningCore) ShareCollection(ctx context.Context, userID, collectionID, orgID string) error {
+	if orgID != "" {
+		if err := c.requireOrgRole(ctx, userID, orgID, orgActionShare); err != nil {
+			return err
+		}
+	}
+	log.Printf("[Core.ShareCollection] Collection %s -> organization %q", collectionID, orgID)
+	return c.store.ShareCollection(ctx, userID, collectionID, orgID)
+}
+
+// ListOrganizationCollections returns the collections shared with an organization
+func (c *LearningCore) ListOrganizationCollections(ctx context.Context, userID, orgID string) ([]*store.Collection, error) {
+	if err := c.requireOrgRole(ctx, userID, orgID, orgActionStudy); err != nil {
+		return nil, err
+	}
+	return c.store.ListOrganizationCollections(ctx, userID, orgID)
+}
+
+// DowngradeExpiredOrganizations moves organizations whose Pro period ended back to FREE
+func (c *LearningCore) DowngradeExpiredOrganizations(ctx context.Context) {
+	count, err := c.store.DowngradeExpiredOrganizations(ctx)
+	if err != nil {
+		log.Printf("[Core.DowngradeExpiredOrganizations] Failed: %v", err)
+		return
+	}
+	log.Printf("[Core.DowngradeExpiredOrganizations] Downgraded %d expired organizations to FREE", count)
+}
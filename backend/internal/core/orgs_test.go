@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role                 string
+		study, share, manage bool
+	}{
+		{store.RoleOwner, true, true, true},
+		{store.RoleEditor, true, true, false},
+		{store.RoleLearner, true, false, false},
+		{"admin", false, false, false},
+		{"", false, false, false},
+	}
+	for _, tt := range tests {
+		got := [3]bool{roleAllows(tt.role, orgActionStudy), roleAllows(tt.role, orgActionShare), roleAllows(tt.role, orgActionManage)}
+		want := [3]bool{tt.study, tt.share, tt.manage}
+		if got != want {
+			t.Errorf("roleAllows(%q) study/share/manage = %v, want %v", tt.role, got, want)
+		}
+	}
+}
+
+func TestValidOrgRole(t *testing.T) {
+	for _, role := range []string{store.RoleOwner, store.RoleEditor, store.RoleLearner} {
+		if !ValidOrgRole(role) {
+			t.Errorf("ValidOrgRole(%q) = false", role)
+		}
+	}
+	for _, role := range []string{"", "Owner", "admin"} {
+		if ValidOrgRole(role) {
+			t.Errorf("ValidOrgRole(%q) = true", role)
+		}
+	}
+}
+
+// sharedCardStore serves one card of another member in a collection shared with
+// org1, where the viewing user has role, and records content edits
+type sharedCardStore struct {
+	store.Store
+	role   string
+	edited []string
+}
+
+func (s *sharedCardStore) GetFlashcardOwnership(ctx context.Context, userID, flashcardID string) (bool, string, error) {
+	return false, "org1", nil
+}
+
+func (s *sharedCardStore) GetOrganizationRole(ctx context.Context, orgID, userID string) (string, error) {
+	return s.role, nil
+}
+
+func (s *sharedCardStore) UpdateFlashcardContent(ctx context.Context, userID, id, question, answer string) error {
+	s.edited = append(s.edited, id)
+	return nil
+}
+
+func TestUpdateFlashcardOfAnotherMember(t *testing.T) {
+	s := &sharedCardStore{role: store.RoleLearner}
+	c := &LearningCore{store: s}
+
+	if err := c.UpdateFlashcard(context.Background(), "learner", "card1", "Q?", "A."); !errors.Is(err, ErrRoleNotAllowed) {
+		t.Fatalf("learner UpdateFlashcard error = %v, want ErrRoleNotAllowed", err)
+	}
+	if len(s.edited) != 0 {
+		t.Errorf("learner edited another member's card")
+	}
+
+	s.role = store.RoleEditor
+	if err := c.UpdateFlashcard(context.Background(), "editor", "card1", "Q?", "A."); err != nil {
+		t.Fatalf("editor UpdateFlashcard error = %v", err)
+	}
+	if len(s.edited) != 1 {
+		t.Errorf("editor's edit was not saved")
+	}
+}
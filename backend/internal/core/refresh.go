@@ -0,0 +1,314 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/amityadav/landr/internal/dedupe"
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+var (
+	// ErrNotRefreshable is returned by RefreshMaterial for materials without a source page
+	ErrNotRefreshable = errors.New("only materials imported from a link can be refreshed")
+	// ErrPageReplaced is returned by RefreshMaterial when the page now holds a
+	// different document, so refreshing would throw away the material's cards
+	ErrPageReplaced = errors.New("the page now shows a different document (login wall, paywall or moved content); import it as a new material instead")
+)
+
+const (
+	// MaterialRecheckInterval is how often the page of a LINK material is re-fetched
+	MaterialRecheckInterval = 7 * 24 * time.Hour
+	materialsPerCheck       = 100
+
+	// A change is significant, and the material flagged, when at least this many
+	// words and this share of the text were added or removed
+	minRefreshChangedWords = 40
+	minRefreshChangeRatio  = 0.02
+	// Above this share the page is a different document (login wall, paywall,
+	// moved content), not an update
+	maxRefreshChangeRatio = 0.9
+
+	// Paragraphs shorter than this (headings, dates, "Updated 3 hours ago") are
+	// left out of the diff
+	minDiffParagraphWords = 5
+	// A card is tied to the paragraph sharing the largest share of its words,
+	// if that share reaches this
+	minSupportScore = 0.3
+)
+
+// RefreshResult is the outcome of RefreshMaterial
+type RefreshResult struct {
+	Changed      bool
+	ChangeRatio  float64
+	CardsKept    int32
+	CardsRemoved int32
+	CardsAdded   int32
+}
+
+// CheckMaterialChanges re-fetches the pages of LINK materials not checked for
+// MaterialRecheckInterval and flags the ones whose content changed significantly.
+// Runs don't overlap: a run started while another is going returns at once.
+func (c *LearningCore) CheckMaterialChanges(ctx context.Context) {
+	if !c.checking.TryLock() {
+		log.Printf("[Core.CheckMaterialChanges] Previous run still going, skipping")
+		return
+	}
+	defer c.checking.Unlock()
+
+	materials, err := c.store.GetMaterialsToRecheck(ctx, time.Now().Add(-MaterialRecheckInterval), materialsPerCheck)
+	if err != nil {
+		log.Printf("[Core.CheckMaterialChanges] Failed to get materials: %v", err)
+		return
+	}
+
+	flagged := 0
+	for _, m := range materials {
+		if ctx.Err() != nil {
+			return
+		}
+		current, err := c.scraper.Scrape(ctx, m.SourceURL)
+		if err != nil {
+			// Gone, blocked or unreachable: tried again next interval
+			log.Printf("[Core.CheckMaterialChanges] Material %s: failed to fetch %s: %v", m.ID, m.SourceURL, err)
+		} else if diff := diffContent(m.Content, current); diff.significant() {
+			log.Printf("[Core.CheckMaterialChanges] Material %s changed: %d words (%.0f%%)", m.ID, diff.changedWords, diff.ratio*100)
+			if err := c.store.SaveMaterialRefresh(ctx, m.ID, current, diff.ratio); err != nil {
+				log.Printf("[Core.CheckMaterialChanges] Failed to flag material %s: %v", m.ID, err)
+			} else {
+				flagged++
+			}
+			continue
+		}
+		if err := c.store.SetMaterialChecked(ctx, m.ID); err != nil {
+			log.Printf("[Core.CheckMaterialChanges] Failed to update material %s: %v", m.ID, err)
+		}
+	}
+	log.Printf("[Core.CheckMaterialChanges] Checked %d materials, %d changed", len(materials), flagged)
+}
+
+// RefreshMaterial updates a LINK material to the current version of its page,
+// as found by CheckMaterialChanges or fetched now. Cards whose supporting
+// paragraph changed are replaced by cards generated from the new and changed
+// paragraphs; all other cards keep their review state. A page that changed by
+// more than maxRefreshChangeRatio is refused with ErrPageReplaced.
+func (c *LearningCore) RefreshMaterial(ctx context.Context, userID, materialID string) (*RefreshResult, error) {
+	log.Printf("[Core.RefreshMaterial] Starting - UserID: %s, MaterialID: %s", userID, materialID)
+	content, _, _, matType, sourceURL, err := c.store.GetMaterialContent(ctx, userID, materialID)
+	if err != nil {
+		return nil, err
+	}
+	if matType != "LINK" || sourceURL == "" {
+		return nil, ErrNotRefreshable
+	}
+
+	current, err := c.currentContent(ctx, materialID, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use background context for DB operations - don't let client disconnect cancel saves
+	saveCtx := context.Background()
+	diff := diffContent(content, current)
+	if diff.changedWords == 0 {
+		// Clears a pending refresh that no longer applies
+		if err := c.store.ApplyMaterialRefresh(saveCtx, materialID, content, nil); err != nil {
+			return nil, err
+		}
+		log.Printf("[Core.RefreshMaterial] Complete - MaterialID: %s unchanged", materialID)
+		return &RefreshResult{}, nil
+	}
+	if diff.ratio > maxRefreshChangeRatio {
+		log.Printf("[Core.RefreshMaterial] MaterialID: %s: page replaced (%.0f%% changed), refusing", materialID, diff.ratio*100)
+		return nil, ErrPageReplaced
+	}
+
+	existing, err := c.store.GetMaterialFlashcards(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+	stale := staleCards(existing, diff)
+
+	// Generate before changing anything, so a failure leaves the material as it was
+	var generated []*learning.Flashcard
+	if len(diff.added) > 0 {
+		result, err := c.generateFlashcards(ctx, userID, strings.Join(diff.added, "\n\n"), c.promptTags(ctx, userID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate flashcards: %w", err)
+		}
+		generated = result.cards
+	}
+
+	if err := c.store.ApplyMaterialRefresh(saveCtx, materialID, current, stale); err != nil {
+		return nil, err
+	}
+
+	// The stale cards are gone, so their replacements aren't dropped as duplicates of them
+	cards, skipped := c.filterDuplicateCards(saveCtx, userID, generated)
+	citeSources(current, cards)
+	sortByDifficulty(cards)
+	if len(cards) > 0 {
+		if err := c.store.CreateFlashcards(saveCtx, materialID, cards); err != nil {
+			log.Printf("[Core.RefreshMaterial] Failed to save flashcards: %v", err)
+			return nil, fmt.Errorf("failed to save flashcards: %w", err)
+		}
+	}
+
+	simhash, hasSimhash := dedupe.Simhash(current)
+	if err := c.store.SetMaterialFingerprint(saveCtx, materialID, canonicalSourceURL(sourceURL), simhashPtr(simhash, hasSimhash)); err != nil {
+		log.Printf("[Core.RefreshMaterial] Failed to save fingerprint: %v", err)
+	}
+	c.indexMaterialAsync(materialID)
+
+	log.Printf("[Core.RefreshMaterial] Complete - MaterialID: %s, changed %.0f%%, cards removed: %d, added: %d, duplicate cards skipped: %d",
+		materialID, diff.ratio*100, len(stale), len(cards), skipped)
+	return &RefreshResult{
+		Changed:      true,
+		ChangeRatio:  diff.ratio,
+		CardsKept:    int32(len(existing) - len(stale)),
+		CardsRemoved: int32(len(stale)),
+		CardsAdded:   int32(len(cards)),
+	}, nil
+}
+
+// currentContent returns the extraction saved by CheckMaterialChanges, or scrapes the page now
+func (c *LearningCore) currentContent(ctx context.Context, materialID, sourceURL string) (string, error) {
+	pending, err := c.store.GetMaterialRefresh(ctx, materialID)
+	if err != nil {
+		log.Printf("[Core.RefreshMaterial] Failed to get pending refresh, fetching the page: %v", err)
+	}
+	if pending != nil {
+		return pending.Content, nil
+	}
+
+	log.Printf("[Core.RefreshMaterial] Scraping URL: %s", sourceURL)
+	scraped, err := c.scraper.Scrape(ctx, sourceURL)
+	if err != nil {
+		log.Printf("[Core.RefreshMaterial] Scraping failed: %v", err)
+		return "", fmt.Errorf("failed to scrape url: %w", err)
+	}
+	return scraped, nil
+}
+
+// contentDiff compares two extractions of a page paragraph by paragraph
+type contentDiff struct {
+	oldParas     []string // Normalized paragraphs of each version
+	newParas     []string
+	removed      map[string]bool
+	added        []string // Paragraphs of the new version missing from the old one, in order
+	changedWords int      // Words of the removed and added paragraphs
+	ratio        float64  // changedWords over the words of both versions
+}
+
+func diffContent(oldText, newText string) contentDiff {
+	d := contentDiff{oldParas: diffParagraphs(oldText), newParas: diffParagraphs(newText), removed: map[string]bool{}}
+
+	// Paragraphs are compared as multisets: a repeated paragraph counts once per copy
+	remaining := map[string]int{}
+	for _, p := range d.newParas {
+		remaining[p]++
+	}
+	totalWords := 0
+	for _, p := range d.oldParas {
+		totalWords += len(strings.Fields(p))
+		if remaining[p] > 0 {
+			remaining[p]--
+			continue
+		}
+		d.removed[p] = true
+		d.changedWords += len(strings.Fields(p))
+	}
+	for _, p := range d.newParas {
+		totalWords += len(strings.Fields(p))
+		if remaining[p] > 0 {
+			remaining[p]--
+			d.added = append(d.added, p)
+			d.changedWords += len(strings.Fields(p))
+		}
+	}
+	if totalWords > 0 {
+		d.ratio = float64(d.changedWords) / float64(totalWords)
+	}
+	return d
+}
+
+// significant reports whether the change is worth flagging to the user
+func (d contentDiff) significant() bool {
+	return d.changedWords >= minRefreshChangedWords && d.ratio >= minRefreshChangeRatio && d.ratio <= maxRefreshChangeRatio
+}
+
+// diffParagraphs splits text on blank lines, normalizing whitespace and
+// dropping paragraphs too short to matter
+func diffParagraphs(text string) []string {
+	var paragraphs []string
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		words := strings.Fields(p)
+		if len(words) >= minDiffParagraphWords {
+			paragraphs = append(paragraphs, strings.Join(words, " "))
+		}
+	}
+	return paragraphs
+}
+
+// staleCards returns the IDs of the cards whose supporting paragraph was removed
+// or changed, unless the new version still supports them as well. Cards without
+// a clear supporting paragraph are kept.
+func staleCards(cards []store.FlashcardText, d contentDiff) []string {
+	var stale []string
+	for _, card := range cards {
+		words := supportWords(card.Question + " " + card.Answer)
+		if len(words) == 0 {
+			continue
+		}
+		oldScore, removed := 0.0, false
+		for _, p := range d.oldParas {
+			score := supportScore(words, p)
+			// On a tie an unchanged paragraph wins
+			if score > oldScore || (score == oldScore && removed && !d.removed[p]) {
+				oldScore, removed = score, d.removed[p]
+			}
+		}
+		if oldScore < minSupportScore || !removed {
+			continue
+		}
+		newScore := 0.0
+		for _, p := range d.newParas {
+			newScore = max(newScore, supportScore(words, p))
+		}
+		if newScore < oldScore {
+			stale = append(stale, card.ID)
+		}
+	}
+	return stale
+}
+
+// supportScore is the share of a card's words that appear in a paragraph
+func supportScore(cardWords map[string]bool, paragraph string) float64 {
+	found := 0
+	for w := range supportWords(paragraph) {
+		if cardWords[w] {
+			found++
+		}
+	}
+	return float64(found) / float64(len(cardWords))
+}
+
+// supportWords returns the distinct content words of a text: lowercased words of
+// four or more letters, and numbers
+func supportWords(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(w)) >= 4 || strings.IndexFunc(w, unicode.IsDigit) >= 0 {
+			words[w] = true
+		}
+	}
+	return words
+}
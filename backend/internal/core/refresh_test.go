@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+const raftPage = `# Raft
+
+Raft elects a leader that accepts every client request and replicates it to followers.
+
+Election timeouts are randomized between 150 and 300 milliseconds to avoid split votes.
+
+Log compaction uses snapshots so followers that fall behind can catch up quickly.`
+
+func TestDiffContent(t *testing.T) {
+	updated := strings.Replace(raftPage, "between 150 and 300 milliseconds", "between 300 and 600 milliseconds", 1) +
+		"\n\nMembership changes use joint consensus, where both configurations must agree on decisions."
+
+	d := diffContent(raftPage, updated)
+	wantRemoved := []string{"Election timeouts are randomized between 150 and 300 milliseconds to avoid split votes."}
+	wantAdded := []string{
+		"Election timeouts are randomized between 300 and 600 milliseconds to avoid split votes.",
+		"Membership changes use joint consensus, where both configurations must agree on decisions.",
+	}
+	var removed []string
+	for p := range d.removed {
+		removed = append(removed, p)
+	}
+	if !reflect.DeepEqual(removed, wantRemoved) {
+		t.Errorf("removed = %q, want %q", removed, wantRemoved)
+	}
+	if !reflect.DeepEqual(d.added, wantAdded) {
+		t.Errorf("added = %q, want %q", d.added, wantAdded)
+	}
+	if d.changedWords != 38 {
+		t.Errorf("changedWords = %d, want 38", d.changedWords)
+	}
+
+	// Whitespace and heading changes don't count
+	reflowed := strings.ReplaceAll(strings.Replace(raftPage, "# Raft", "# The Raft protocol", 1), "leader that", "leader\n  that")
+	if d := diffContent(raftPage, reflowed); d.changedWords != 0 || len(d.added) != 0 {
+		t.Errorf("reflowed page: changedWords = %d, added = %q", d.changedWords, d.added)
+	}
+}
+
+func TestDiffSignificant(t *testing.T) {
+	tests := []struct {
+		name string
+		d    contentDiff
+		want bool
+	}{
+		{"small edit", contentDiff{changedWords: 12, ratio: 0.1}, false},
+		{"large edit in a long page", contentDiff{changedWords: 300, ratio: 0.03}, true},
+		{"tiny share of a huge page", contentDiff{changedWords: 50, ratio: 0.005}, false},
+		{"different document", contentDiff{changedWords: 2000, ratio: 0.97}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.d.significant(); got != tt.want {
+			t.Errorf("%s: significant() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStaleCards(t *testing.T) {
+	updated := strings.Replace(raftPage, "between 150 and 300 milliseconds", "between 300 and 600 milliseconds", 1)
+	cards := []store.FlashcardText{
+		{ID: "leader", Question: "What does the Raft leader do with client requests?", Answer: "Accepts them and replicates them to followers"},
+		{ID: "timeout", Question: "What range are Raft election timeouts randomized in?", Answer: "150 to 300 milliseconds"},
+		{ID: "purpose", Question: "Why are election timeouts randomized?", Answer: "To avoid split votes"},
+		{ID: "unrelated", Question: "What is a Merkle tree?", Answer: "A tree of hashes"},
+	}
+
+	got := staleCards(cards, diffContent(raftPage, updated))
+	// The purpose card was supported by the changed paragraph, but the new version supports it as well
+	if want := []string{"timeout"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("staleCards = %v, want %v", got, want)
+	}
+}
+
+// refreshStore serves one LINK material with a pending refresh and records applied refreshes
+type refreshStore struct {
+	store.Store
+	content, pending string
+	applied          []string
+}
+
+func (s *refreshStore) GetMaterialContent(ctx context.Context, userID, materialID string) (string, string, string, string, string, error) {
+	return s.content, "", "Raft", "LINK", "https://example.com/raft", nil
+}
+
+func (s *refreshStore) GetMaterialRefresh(ctx context.Context, materialID string) (*store.MaterialRefresh, error) {
+	return &store.MaterialRefresh{Content: s.pending}, nil
+}
+
+func (s *refreshStore) ApplyMaterialRefresh(ctx context.Context, materialID, content string, staleCardIDs []string) error {
+	s.applied = append(s.applied, content)
+	return nil
+}
+
+func TestRefreshMaterialRefusesReplacedPage(t *testing.T) {
+	loginWall := `# Sign in
+
+Please sign in with your account to continue reading this article and many others.
+
+Subscribers get unlimited access to every article, newsletter and the full archive.`
+	s := &refreshStore{content: raftPage, pending: loginWall}
+	c := &LearningCore{store: s}
+
+	if _, err := c.RefreshMaterial(context.Background(), "u1", "m1"); !errors.Is(err, ErrPageReplaced) {
+		t.Fatalf("RefreshMaterial error = %v, want ErrPageReplaced", err)
+	}
+	if len(s.applied) != 0 {
+		t.Errorf("replaced page was applied to the material")
+	}
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+const (
+	defaultReportDays = 30
+	maxReportDays     = 365
+)
+
+// DeckProgressReport shows how the members of an organization study one of its
+// shared collections. Members who opted out of reporting are only counted.
+type DeckProgressReport struct {
+	Collection   *store.Collection
+	Days         int
+	Members      []*store.MemberProgress // Members who did not opt out
+	OptedOut     int
+	Completion   float64 // Average share of cards studied by reported members (0-1)
+	Retention    float64 // Share of passed reviews of reported members in the window (0-1)
+	OverdueCards int32
+}
+
+// summarizeProgress drops opted-out members and computes the team averages
+// over the remaining ones, so opted-out members can't be inferred from them
+func summarizeProgress(report *DeckProgressReport, progress []*store.MemberProgress) {
+	var completionSum float64
+	var reviews, passed int32
+	for _, p := range progress {
+		if p.OptedOut {
+			report.OptedOut++
+			continue
+		}
+		report.Members = append(report.Members, p)
+		completionSum += p.Completion()
+		reviews += p.Reviews
+		passed += p.PassedReviews
+		report.OverdueCards += p.OverdueCards
+	}
+	if n := len(report.Members); n > 0 {
+		report.Completion = completionSum / float64(n)
+	}
+	if reviews > 0 {
+		report.Retention = float64(passed) / float64(reviews)
+	}
+}
+
+// GetDeckProgressReport reports per-member completion, retention (over the last
+// days, default 30) and overdue cards for a shared collection. Available to the
+// collection's owner and to owners of the organization it is shared with.
+func (c *LearningCore) GetDeckProgressReport(ctx context.Context, userID, collectionID string, days int) (*DeckProgressReport, error) {
+	col, err := c.store.GetCollection(ctx, userID, collectionID)
+	if errors.Is(err, store.ErrCollectionNotFound) {
+		if col, err = c.store.GetStudyCollection(ctx, userID, collectionID); err != nil {
+			return nil, err
+		}
+		if err := c.requireOrgRole(ctx, userID, col.OrganizationID, orgActionManage); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if col.OrganizationID == "" {
+		return nil, fmt.Errorf("collection is not shared with an organization")
+	}
+
+	if days <= 0 {
+		days = defaultReportDays
+	}
+	if days > maxReportDays {
+		days = maxReportDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	log.Printf("[Core.GetDeckProgressReport] Collection %s, org %s, last %d days", collectionID, col.OrganizationID, days)
+	progress, err := c.store.GetCollectionMemberProgress(ctx, col.OrganizationID, collectionID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DeckProgressReport{Collection: col, Days: days}
+	summarizeProgress(report, progress)
+	return report, nil
+}
+
+// SetReportingOptOut sets whether the user is left out of individual-level
+// progress reports of an organization
+func (c *LearningCore) SetReportingOptOut(ctx context.Context, userID, orgID string, optOut bool) error {
+	log.Printf("[Core.SetReportingOptOut] Org %s, userID: %s, optOut: %v", orgID, userID, optOut)
+	return c.store.SetReportingOptOut(ctx, orgID, userID, optOut)
+}
+
+// csvText keeps user-provided text from being run as a formula when the CSV is
+// opened in a spreadsheet: cells starting with a formula character get a
+// leading quote
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// WriteDeckProgressCSV writes one row per reported member
+func WriteDeckProgressCSV(w io.Writer, report *DeckProgressReport) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"name", "email", "total_cards", "studied_cards", "completion_pct", "overdue_cards",
+		"reviews", "passed_reviews", "retention_pct", "last_reviewed_at",
+	})
+	for _, p := range report.Members {
+		lastReviewed := ""
+		if p.LastReviewedAt != nil {
+			lastReviewed = p.LastReviewedAt.UTC().Format(time.RFC3339)
+		}
+		cw.Write([]string{
+			csvText(p.Name),
+			csvText(p.Email),
+			strconv.Itoa(int(p.TotalCards)),
+			strconv.Itoa(int(p.StudiedCards)),
+			strconv.FormatFloat(p.Completion()*100, 'f', 1, 64),
+			strconv.Itoa(int(p.OverdueCards)),
+			strconv.Itoa(int(p.Reviews)),
+			strconv.Itoa(int(p.PassedReviews)),
+			strconv.FormatFloat(p.Retention()*100, 'f', 1, 64),
+			lastReviewed,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+func TestSummarizeProgress(t *testing.T) {
+	progress := []*store.MemberProgress{
+		{Name: "Asha", TotalCards: 10, StudiedCards: 10, OverdueCards: 1, Reviews: 8, PassedReviews: 6},
+		{Name: "Ravi", TotalCards: 10, StudiedCards: 0},
+		{Name: "Hidden", TotalCards: 10, StudiedCards: 5, OverdueCards: 4, Reviews: 10, PassedReviews: 0, OptedOut: true},
+	}
+	report := &DeckProgressReport{}
+	summarizeProgress(report, progress)
+
+	if len(report.Members) != 2 || report.OptedOut != 1 {
+		t.Fatalf("members = %d, opted out = %d; want 2, 1", len(report.Members), report.OptedOut)
+	}
+	// Opted-out members must not affect the team figures
+	if report.Completion != 0.5 {
+		t.Errorf("Completion = %v, want 0.5", report.Completion)
+	}
+	if report.Retention != 0.75 {
+		t.Errorf("Retention = %v, want 0.75", report.Retention)
+	}
+	if report.OverdueCards != 1 {
+		t.Errorf("OverdueCards = %d, want 1", report.OverdueCards)
+	}
+}
+
+func TestWriteDeckProgressCSV(t *testing.T) {
+	last := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	report := &DeckProgressReport{Members: []*store.MemberProgress{
+		{Name: "Doe, Jane", Email: "jane@example.com", TotalCards: 4, StudiedCards: 3, OverdueCards: 1, Reviews: 3, PassedReviews: 2, LastReviewedAt: &last},
+		{Name: "Raj", Email: "raj@example.com", TotalCards: 4},
+		{Name: "=HYPERLINK(\"http://evil.example\")", Email: "@sum@example.com", TotalCards: 4},
+	}}
+
+	var sb strings.Builder
+	if err := WriteDeckProgressCSV(&sb, report); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,email,total_cards,studied_cards,completion_pct,overdue_cards,reviews,passed_reviews,retention_pct,last_reviewed_at\n" +
+		"\"Doe, Jane\",jane@example.com,4,3,75.0,1,3,2,66.7,2026-03-01T09:30:00Z\n" +
+		"Raj,raj@example.com,4,0,0.0,0,0,0,0.0,\n" +
+		"\"'=HYPERLINK(\"\"http://evil.example\"\")\",'@sum@example.com,4,0,0.0,0,0,0,0.0,\n"
+	if sb.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	// rrfK dampens the weight of top ranks in reciprocal rank fusion (standard value)
+	rrfK = 60
+	// maxVectorSnippetChars bounds snippets taken from raw chunk text
+	maxVectorSnippetChars = 300
+)
+
+// SearchLibrary finds materials by full-text match on content, summary and cards.
+// With semantic set (and embeddings enabled) the results are fused with vector
+// similarity of chunks and cards using reciprocal rank fusion.
+func (c *LearningCore) SearchLibrary(ctx context.Context, userID, query string, limit int, semantic bool) ([]*learning.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	log.Printf("[Core.SearchLibrary] UserID: %s, Query: %q, Limit: %d, Semantic: %v", userID, query, limit, semantic)
+
+	// Fetch more candidates than needed so fusion has room to reorder
+	candidates := limit * 2
+
+	materials, err := c.store.SearchMaterials(ctx, userID, query, candidates)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := c.store.SearchFlashcards(ctx, userID, query, candidates)
+	if err != nil {
+		return nil, err
+	}
+	lists := searchLists{materials: [][]*store.MaterialHit{materials}, cards: [][]*store.FlashcardHit{cards}}
+
+	if semantic && c.embeddings != nil {
+		vectors, err := c.embeddings.Embedder().Embed(ctx, []string{query})
+		if err != nil {
+			// Degrade to full-text only
+			log.Printf("[Core.SearchLibrary] Query embedding failed: %v", err)
+		} else {
+			chunkHits, err := c.store.SearchChunksByVector(ctx, userID, vectors[0], candidates)
+			if err != nil {
+				return nil, err
+			}
+			cardHits, err := c.store.SearchFlashcardsByVector(ctx, userID, vectors[0], candidates)
+			if err != nil {
+				return nil, err
+			}
+			for _, h := range chunkHits {
+				h.Snippet = truncateSnippet(h.Snippet, maxVectorSnippetChars)
+			}
+			lists.materials = append(lists.materials, chunkHits)
+			lists.cards = append(lists.cards, cardHits)
+		}
+	}
+
+	results := fuseSearchResults(lists, limit)
+	log.Printf("[Core.SearchLibrary] Found %d results", len(results))
+	return results, nil
+}
+
+// searchLists holds ranked hit lists, ordered by preference: earlier lists win
+// when choosing a material's snippet and card (full-text before vector)
+type searchLists struct {
+	materials [][]*store.MaterialHit
+	cards     [][]*store.FlashcardHit
+}
+
+// fuseSearchResults merges ranked lists per material with reciprocal rank fusion:
+// score = Σ 1/(rrfK + rank) over every list the material appears in.
+// A card list contributes once per material, at the rank of its best card.
+func fuseSearchResults(lists searchLists, limit int) []*learning.SearchResult {
+	byID := make(map[string]*learning.SearchResult)
+	get := func(id, title, sourceURL string) *learning.SearchResult {
+		r, ok := byID[id]
+		if !ok {
+			r = &learning.SearchResult{MaterialId: id, Title: title, SourceUrl: sourceURL}
+			byID[id] = r
+		}
+		return r
+	}
+
+	for _, list := range lists.materials {
+		for rank, h := range list {
+			r := get(h.MaterialID, h.Title, h.SourceURL)
+			r.Score += 1.0 / float64(rrfK+rank+1)
+			if r.Snippet == "" {
+				r.Snippet = h.Snippet
+				r.MatchedIn = h.MatchedIn
+			}
+		}
+	}
+
+	for _, list := range lists.cards {
+		seen := make(map[string]bool)
+		rank := 0
+		for _, h := range list {
+			if seen[h.MaterialID] {
+				continue
+			}
+			seen[h.MaterialID] = true
+			r := get(h.MaterialID, h.Card.MaterialTitle, h.SourceURL)
+			r.Score += 1.0 / float64(rrfK+rank+1)
+			rank++
+			if r.Card == nil {
+				r.Card = h.Card
+			}
+			if r.Snippet == "" {
+				r.Snippet = h.Snippet
+				r.MatchedIn = "card"
+			}
+		}
+	}
+
+	results := make([]*learning.SearchResult, 0, len(byID))
+	for _, r := range byID {
+		r.Snippet = snippetHTML(r.Snippet)
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].MaterialId < results[j].MaterialId
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// snippetMarkup turns the escaped highlight markers of a snippet into <mark> tags
+var snippetMarkup = strings.NewReplacer(store.HighlightStart, "<mark>", store.HighlightStop, "</mark>")
+
+// snippetHTML makes a snippet safe to render as HTML: its text (scraped pages
+// included) is escaped and only the matched terms are wrapped in <mark>
+func snippetHTML(snippet string) string {
+	return snippetMarkup.Replace(html.EscapeString(snippet))
+}
+
+// truncateSnippet shortens text to about maxChars, cutting at a word boundary
+func truncateSnippet(text string, maxChars int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= maxChars {
+		return text
+	}
+	cut := maxChars
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	if i := strings.LastIndex(text[:cut], " "); i > maxChars/2 {
+		cut = i
+	}
+	return text[:cut] + " …"
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+func cardHit(materialID, cardID, snippet string) *store.FlashcardHit {
+	return &store.FlashcardHit{
+		Card:       &learning.Flashcard{Id: cardID, MaterialTitle: "title " + materialID},
+		MaterialID: materialID,
+		Snippet:    snippet,
+	}
+}
+
+// marked wraps a term in the highlight markers of full-text snippets
+func marked(term string) string {
+	return store.HighlightStart + term + store.HighlightStop
+}
+
+func TestFuseSearchResults(t *testing.T) {
+	lists := searchLists{
+		materials: [][]*store.MaterialHit{
+			// Full-text
+			{
+				{MaterialID: "raft", Title: "Raft", Snippet: marked("leader") + " election", MatchedIn: "content"},
+				{MaterialID: "paxos", Title: "Paxos", Snippet: "a " + marked("leader"), MatchedIn: "summary"},
+			},
+			// Vector
+			{
+				{MaterialID: "paxos", Title: "Paxos", Snippet: "raw <b>chunk</b>", MatchedIn: "content"},
+			},
+		},
+		cards: [][]*store.FlashcardHit{
+			{
+				cardHit("raft", "c1", "How is a "+marked("leader")+" elected?"),
+				cardHit("raft", "c2", "second raft card"),
+				cardHit("gossip", "c3", marked("leader")+"less <script>alert(1)</script> gossip"),
+			},
+		},
+	}
+
+	results := fuseSearchResults(lists, 10)
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	// raft: material rank 1 + card rank 1; paxos: material rank 2 + vector rank 1
+	if results[0].MaterialId != "raft" || results[1].MaterialId != "paxos" || results[2].MaterialId != "gossip" {
+		t.Fatalf("unexpected order: %s, %s, %s", results[0].MaterialId, results[1].MaterialId, results[2].MaterialId)
+	}
+
+	raft := results[0]
+	if raft.Card == nil || raft.Card.Id != "c1" {
+		t.Errorf("raft card = %v, want best card c1", raft.Card)
+	}
+	if raft.MatchedIn != "content" || !strings.Contains(raft.Snippet, "<mark>") {
+		t.Errorf("raft snippet should come from full-text content, got %q (%s)", raft.Snippet, raft.MatchedIn)
+	}
+
+	// Full-text snippet wins over the vector chunk
+	if results[1].Snippet != "a <mark>leader</mark>" || results[1].MatchedIn != "summary" {
+		t.Errorf("paxos snippet = %q (%s)", results[1].Snippet, results[1].MatchedIn)
+	}
+
+	// Card-only match takes its title and snippet from the card
+	gossip := results[2]
+	if gossip.Title != "title gossip" || gossip.MatchedIn != "card" || gossip.Card.Id != "c3" {
+		t.Errorf("gossip result = %+v", gossip)
+	}
+	// Snippet text is escaped; only the highlights are markup
+	if want := "<mark>leader</mark>less &lt;script&gt;alert(1)&lt;/script&gt; gossip"; gossip.Snippet != want {
+		t.Errorf("gossip snippet = %q, want %q", gossip.Snippet, want)
+	}
+
+	if got := fuseSearchResults(lists, 1); len(got) != 1 {
+		t.Errorf("limit not applied: got %d results", len(got))
+	}
+}
+
+func TestTruncateSnippet(t *testing.T) {
+	text := strings.Repeat("word ", 100)
+	got := truncateSnippet(text, 50)
+	if len(got) > 50+len(" …") || !strings.HasSuffix(got, " …") {
+		t.Errorf("truncateSnippet = %q", got)
+	}
+	if got := truncateSnippet("short  text\n", 50); got != "short text" {
+		t.Errorf("truncateSnippet short = %q", got)
+	}
+}
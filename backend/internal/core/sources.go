@@ -0,0 +1,386 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amityadav/landr/internal/dedupe"
+	"github.com/amityadav/landr/internal/egress"
+	"github.com/amityadav/landr/internal/quota"
+	"github.com/amityadav/landr/internal/scraper"
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/internal/youtube"
+)
+
+const (
+	// SourcePollInterval is how often a subscription is checked for new entries
+	SourcePollInterval = time.Hour
+	// sourceRetryInterval is the wait after a poll that couldn't fetch the source
+	sourceRetryInterval = 6 * time.Hour
+	// maxSourcesPerUser bounds the subscriptions of one user
+	maxSourcesPerUser = 50
+	// maxSourceImportsPerPoll bounds the entries imported from one source per poll;
+	// the rest are imported by later polls
+	maxSourceImportsPerPoll = 5
+	// maxSourceItemAttempts is how often an entry that fails to import is tried
+	// before it is skipped
+	maxSourceItemAttempts = 3
+	// sourcesPerRun bounds the sources polled by one PollDueSources run
+	sourcesPerRun = 200
+	// sourceTagRoot is the parent of the default per-source tags
+	sourceTagRoot = "Sources"
+)
+
+var (
+	ErrTooManySources = fmt.Errorf("you can subscribe to at most %d sources", maxSourcesPerUser)
+	ErrNoSourceFeed   = errors.New("no rss/atom feed or article links found at this url")
+)
+
+// SourceCore manages subscriptions to feeds and pages, importing their new
+// entries through LearningCore.AddMaterial
+type SourceCore struct {
+	store    store.Store
+	learning *LearningCore
+	fetcher  *egress.Fetcher
+	robots   *egress.Robots
+	quotas   *quota.Enforcer
+	polling  sync.Mutex // Held by a PollDueSources run
+}
+
+func NewSourceCore(s store.Store, learning *LearningCore, fetcher *egress.Fetcher, robots *egress.Robots, quotas *quota.Enforcer) *SourceCore {
+	return &SourceCore{store: s, learning: learning, fetcher: fetcher, robots: robots, quotas: quotas}
+}
+
+// CreateSource subscribes the user to a feed, a page that links to one (blogs,
+// YouTube channels) or an index page of article links. Entries present now are
+// not imported; later ones are, tagged with tag (default "Sources/<title>").
+func (c *SourceCore) CreateSource(ctx context.Context, userID, rawURL, tag string) (*store.Source, error) {
+	log.Printf("[Core.CreateSource] UserID: %s, URL: %s", userID, rawURL)
+
+	count, err := c.store.CountSources(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSourcesPerUser {
+		return nil, ErrTooManySources
+	}
+
+	src, feed, err := c.discoverSource(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	src.UserID = userID
+	src.Tag = NormalizeTagName(tag)
+	if src.Tag == "" {
+		src.Tag = defaultSourceTag(src)
+	}
+
+	keys := make([]string, 0, len(feed.Entries))
+	for _, e := range feed.Entries {
+		keys = append(keys, dedupe.CanonicalURL(e.URL))
+	}
+	created, err := c.store.CreateSource(ctx, src, keys, time.Now().Add(SourcePollInterval))
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[Core.CreateSource] Subscribed to %s %s (%d existing entries)", created.Kind, created.FeedURL, len(keys))
+	return created, nil
+}
+
+func (c *SourceCore) ListSources(ctx context.Context, userID string) ([]*store.Source, error) {
+	return c.store.ListSources(ctx, userID)
+}
+
+func (c *SourceCore) DeleteSource(ctx context.Context, userID, sourceID string) error {
+	return c.store.DeleteSource(ctx, userID, sourceID)
+}
+
+// discoverSource works out what to poll for a URL: the URL itself when it is a
+// feed, the feed a page advertises, or else the page's article links
+func (c *SourceCore) discoverSource(ctx context.Context, rawURL string) (*store.Source, *scraper.Feed, error) {
+	if err := egress.CheckURL(ctx, rawURL); err != nil {
+		return nil, nil, err
+	}
+	src := &store.Source{URL: rawURL, FeedURL: rawURL, Kind: store.SourceFeed}
+	if feedURL := youtubeFeedURL(rawURL); feedURL != "" {
+		src.FeedURL = feedURL
+	}
+
+	page, err := c.fetch(ctx, src)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !scraper.LooksLikeFeed(page.ContentType, page.Body) {
+		links := scraper.FeedLinks(page.URL, page.Body)
+		if len(links) == 0 {
+			feed, err := scraper.ArticleLinks(page.URL, page.Body)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(feed.Entries) == 0 {
+				return nil, nil, ErrNoSourceFeed
+			}
+			src.Kind, src.FeedURL, src.Title = store.SourcePage, page.URL, feed.Title
+			src.ETag, src.LastModified = page.ETag, page.LastModified
+			return src, feed, nil
+		}
+		src.FeedURL = links[0]
+		if page, err = c.fetch(ctx, src); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	feed, err := scraper.ParseFeed(src.FeedURL, page.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrNoSourceFeed, err)
+	}
+	src.Title, src.ETag, src.LastModified = feed.Title, page.ETag, page.LastModified
+	return src, feed, nil
+}
+
+// fetch GETs a source's feed URL, conditionally when it has validators from
+// an earlier poll. Returns a *egress.StatusError with 304 when nothing changed.
+func (c *SourceCore) fetch(ctx context.Context, src *store.Source) (*egress.Page, error) {
+	if !c.robots.Allowed(ctx, src.FeedURL) {
+		return nil, &egress.DisallowedError{URL: src.FeedURL}
+	}
+	header := http.Header{}
+	header.Set("User-Agent", egress.RobotsAgent+"/1.0")
+	header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.9, text/html;q=0.8, */*;q=0.5")
+	if src.ETag != "" {
+		header.Set("If-None-Match", src.ETag)
+	}
+	if src.LastModified != "" {
+		header.Set("If-Modified-Since", src.LastModified)
+	}
+	return c.fetcher.Fetch(ctx, src.FeedURL, header)
+}
+
+// PollDueSources imports the new entries of every subscription due for polling.
+// Runs don't overlap: a run started while another is going returns at once.
+func (c *SourceCore) PollDueSources(ctx context.Context) {
+	if !c.polling.TryLock() {
+		log.Printf("[Core.PollDueSources] Previous run still going, skipping")
+		return
+	}
+	defer c.polling.Unlock()
+
+	sources, err := c.store.GetDueSources(ctx, sourcesPerRun)
+	if err != nil {
+		log.Printf("[Core.PollDueSources] Failed to get due sources: %v", err)
+		return
+	}
+
+	total := 0
+	for _, src := range sources {
+		imported, err := c.pollSource(ctx, src)
+		total += imported
+		if err != nil {
+			log.Printf("[Core.PollDueSources] Source %s (%s): %v", src.ID, src.FeedURL, err)
+		}
+	}
+	log.Printf("[Core.PollDueSources] Polled %d sources, imported %d entries", len(sources), total)
+}
+
+// pollSource imports a source's unseen entries, oldest first, and records the
+// outcome. Entries left over (per-poll cap or daily quota) stay unseen for the
+// next poll. Entries that fail to import are retried on later polls until they
+// have failed maxSourceItemAttempts times.
+func (c *SourceCore) pollSource(ctx context.Context, src *store.Source) (int, error) {
+	next := time.Now().Add(SourcePollInterval)
+	page, err := c.fetch(ctx, src)
+	var statusErr *egress.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotModified {
+		return 0, c.store.SetSourcePolled(ctx, src.ID, src.ETag, src.LastModified, "", next)
+	}
+	if err != nil {
+		c.recordPoll(ctx, src, src.ETag, src.LastModified, err, time.Now().Add(sourceRetryInterval))
+		return 0, err
+	}
+
+	var feed *scraper.Feed
+	if src.Kind == store.SourcePage {
+		feed, err = scraper.ArticleLinks(page.URL, page.Body)
+	} else {
+		feed, err = scraper.ParseFeed(src.FeedURL, page.Body)
+	}
+	if err != nil {
+		c.recordPoll(ctx, src, page.ETag, page.LastModified, err, time.Now().Add(sourceRetryInterval))
+		return 0, err
+	}
+
+	entries, err := c.unseenEntries(ctx, src.ID, feed.Entries)
+	if err != nil {
+		return 0, err
+	}
+
+	imported, pending := 0, 0
+	var pollErr error
+	for i, e := range entries {
+		if i == maxSourceImportsPerPoll {
+			pending += len(entries) - i
+			break
+		}
+		matType := entryMaterialType(e.URL)
+		reservation, err := c.quotas.Reserve(ctx, src.UserID, quota.ResourceForType(matType))
+		if err != nil {
+			// Over the daily quota (or unable to tell): the rest waits for a later poll
+			pollErr = err
+			pending += len(entries) - i
+			break
+		}
+		key := dedupe.CanonicalURL(e.URL)
+		materialID, err := c.importEntry(ctx, src, e, matType, reservation)
+		if err != nil {
+			// Retried by later polls until it fails maxSourceItemAttempts times
+			log.Printf("[Core.PollSource] Failed to import %s: %v", e.URL, err)
+			pending++
+			if err := c.store.MarkSourceItemFailed(ctx, src.ID, key); err != nil {
+				log.Printf("[Core.PollSource] %v", err)
+			}
+			continue
+		}
+		if materialID != "" {
+			imported++
+		}
+		if err := c.store.MarkSourceItem(ctx, src.ID, key, materialID); err != nil {
+			log.Printf("[Core.PollSource] %v", err)
+		}
+	}
+
+	// Entries left for later polls must be fetched again, so the validators are
+	// only kept when nothing is pending (a 304 skips the entries)
+	etag, lastModified := page.ETag, page.LastModified
+	if pending > 0 {
+		etag, lastModified = "", ""
+	}
+	c.recordPoll(ctx, src, etag, lastModified, pollErr, next)
+	log.Printf("[Core.PollSource] Source %s: %d new entries, %d imported", src.ID, len(entries), imported)
+	return imported, pollErr
+}
+
+// unseenEntries returns the entries the source hasn't seen, oldest first
+func (c *SourceCore) unseenEntries(ctx context.Context, sourceID string, entries []scraper.FeedEntry) ([]scraper.FeedEntry, error) {
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = dedupe.CanonicalURL(e.URL)
+	}
+	seen, err := c.store.GetSeenSourceItems(ctx, sourceID, keys, maxSourceItemAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	var unseen []scraper.FeedEntry
+	for i, e := range entries {
+		if !seen[keys[i]] {
+			seen[keys[i]] = true
+			unseen = append(unseen, e)
+		}
+	}
+	sortEntriesOldestFirst(unseen)
+	return unseen, nil
+}
+
+// importEntry imports one entry as the source's user and tags it with the
+// source's tag. The quota reserved for it is only used when a new material is
+// created. Returns the new material's ID, or "" when it was already in the library.
+func (c *SourceCore) importEntry(ctx context.Context, src *store.Source, e scraper.FeedEntry, matType string, reservation *quota.Reservation) (string, error) {
+	result, err := c.learning.AddMaterial(ctx, src.UserID, matType, e.URL, nil, "", "", nil, false)
+	if errors.Is(err, ErrNoNewCards) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if result.DuplicateOf != nil {
+		return "", nil
+	}
+	c.quotas.Commit(ctx, reservation)
+	c.learning.linkTags(ctx, src.UserID, result.MaterialID, []string{src.Tag})
+	return result.MaterialID, nil
+}
+
+func (c *SourceCore) recordPoll(ctx context.Context, src *store.Source, etag, lastModified string, pollErr error, next time.Time) {
+	lastError := ""
+	if pollErr != nil {
+		lastError = pollErr.Error()
+	}
+	if err := c.store.SetSourcePolled(ctx, src.ID, etag, lastModified, lastError, next); err != nil {
+		log.Printf("[Core.PollSource] %v", err)
+	}
+}
+
+// sortEntriesOldestFirst orders entries by date when all have one; otherwise
+// reverses document order, since feeds and index pages list the newest first
+func sortEntriesOldestFirst(entries []scraper.FeedEntry) {
+	for _, e := range entries {
+		if e.Published.IsZero() {
+			for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+				entries[i], entries[j] = entries[j], entries[i]
+			}
+			return
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Published.Before(entries[j].Published) })
+}
+
+// entryMaterialType imports YouTube videos by transcript and everything else as a link
+func entryMaterialType(entryURL string) string {
+	u, err := url.Parse(entryURL)
+	if err != nil {
+		return "LINK"
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host == "youtube.com" || host == "m.youtube.com" || host == "youtu.be" {
+		if _, err := youtube.ExtractVideoID(entryURL); err == nil {
+			return "YOUTUBE"
+		}
+	}
+	return "LINK"
+}
+
+// youtubeFeedURL returns the video feed of a YouTube channel or playlist URL
+// given by ID, or "". Channel handles (/@name) are found through the page's feed link.
+func youtubeFeedURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host != "youtube.com" && host != "m.youtube.com" {
+		return ""
+	}
+	if id, ok := strings.CutPrefix(u.Path, "/channel/"); ok {
+		if id, _, _ = strings.Cut(id, "/"); id != "" {
+			return "https://www.youtube.com/feeds/videos.xml?channel_id=" + url.QueryEscape(id)
+		}
+	}
+	if list := u.Query().Get("list"); u.Path == "/playlist" && list != "" {
+		return "https://www.youtube.com/feeds/videos.xml?playlist_id=" + url.QueryEscape(list)
+	}
+	return ""
+}
+
+// defaultSourceTag is "Sources/<title>", or the host for untitled sources
+func defaultSourceTag(src *store.Source) string {
+	name := src.Title
+	if name == "" {
+		if u, err := url.Parse(src.URL); err == nil {
+			name = strings.TrimPrefix(u.Hostname(), "www.")
+		}
+	}
+	// A "/" in the title would nest the tag
+	name = strings.ReplaceAll(name, "/", "-")
+	if runes := []rune(name); len(runes) > 60 {
+		name = string(runes[:60])
+	}
+	return NormalizeTagName(sourceTagRoot + "/" + name)
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amityadav/landr/internal/scraper"
+	"github.com/amityadav/landr/internal/store"
+)
+
+func TestSortEntriesOldestFirst(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	urls := func(entries []scraper.FeedEntry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.URL)
+		}
+		return out
+	}
+
+	dated := []scraper.FeedEntry{{URL: "b", Published: day(2)}, {URL: "c", Published: day(3)}, {URL: "a", Published: day(1)}}
+	sortEntriesOldestFirst(dated)
+	if got := urls(dated); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("dated entries = %v", got)
+	}
+
+	// Without dates, document order is newest first
+	undated := []scraper.FeedEntry{{URL: "c", Published: day(3)}, {URL: "b"}, {URL: "a"}}
+	sortEntriesOldestFirst(undated)
+	if got := urls(undated); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("undated entries = %v", got)
+	}
+}
+
+func TestEntryMaterialType(t *testing.T) {
+	tests := map[string]string{
+		"https://www.youtube.com/watch?v=abcdefghijk": "YOUTUBE",
+		"https://youtu.be/abcdefghijk":                "YOUTUBE",
+		"https://www.youtube.com/@channel":            "LINK",
+		"https://blog.example.com/raft":               "LINK",
+		"https://example.com/?v=abcdefghijk":          "LINK",
+	}
+	for in, want := range tests {
+		if got := entryMaterialType(in); got != want {
+			t.Errorf("entryMaterialType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestYoutubeFeedURL(t *testing.T) {
+	tests := map[string]string{
+		"https://www.youtube.com/channel/UC123/videos":       "https://www.youtube.com/feeds/videos.xml?channel_id=UC123",
+		"https://youtube.com/playlist?list=PL456":            "https://www.youtube.com/feeds/videos.xml?playlist_id=PL456",
+		"https://www.youtube.com/@handle":                    "",
+		"https://www.youtube.com/watch?v=abcdefghijk&list=1": "",
+		"https://example.com/channel/UC123":                  "",
+	}
+	for in, want := range tests {
+		if got := youtubeFeedURL(in); got != want {
+			t.Errorf("youtubeFeedURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDefaultSourceTag(t *testing.T) {
+	tests := []struct {
+		src  store.Source
+		want string
+	}{
+		{store.Source{Title: "Systems Notes", URL: "https://blog.example.com/"}, "Sources/Systems Notes"},
+		{store.Source{Title: "Go / Rust weekly", URL: "https://example.com/"}, "Sources/Go - Rust weekly"},
+		{store.Source{URL: "https://www.example.com/blog"}, "Sources/example.com"},
+	}
+	for _, tt := range tests {
+		if got := defaultSourceTag(&tt.src); got != tt.want {
+			t.Errorf("defaultSourceTag(%+v) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
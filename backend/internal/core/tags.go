@@ -0,0 +1,412 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/amityadav/landr/internal/ai"
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/prompts"
+)
+
+const (
+	// tagSimilarityThreshold is the cosine similarity of tag name embeddings
+	// above which two tags are suggested for merging
+	tagSimilarityThreshold = 0.88
+	// maxTagsForSuggestions bounds the tags sent to the embedder / LLM per user
+	maxTagsForSuggestions = 300
+	// minTagsForSuggestions is the smallest tag list worth tidying
+	minTagsForSuggestions = 5
+	// maxPromptTags bounds the existing tags offered to the flashcard prompt
+	maxPromptTags = 100
+)
+
+// Tag merge suggestion reasons
+const (
+	TagMergeSpelling  = "spelling"
+	TagMergeEmbedding = "embedding"
+	TagMergeLLM       = "llm"
+)
+
+// NormalizeTagName cleans a tag path: trims whitespace around every "/"-separated
+// segment, collapses inner whitespace and drops empty segments. Case is kept.
+// "  CS / Distributed  Systems/ " -> "CS/Distributed Systems"
+func NormalizeTagName(name string) string {
+	var segments []string
+	for _, seg := range strings.Split(name, "/") {
+		if seg = strings.Join(strings.Fields(seg), " "); seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// tagSpellingKey reduces a tag name to a key shared by trivial variants:
+// case, punctuation, separators and a plural "s" are ignored
+// ("Machine-Learning", "machine learning", "MachineLearnings" -> "machinelearning")
+func tagSpellingKey(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '/' {
+			sb.WriteRune(r)
+		}
+	}
+	key := sb.String()
+	if len(key) > 3 && strings.HasSuffix(key, "s") && !strings.HasSuffix(key, "ss") {
+		key = strings.TrimSuffix(key, "s")
+	}
+	return key
+}
+
+// isTagAncestor reports whether a is a parent path of b ("cs" of "cs/raft")
+func isTagAncestor(a, b string) bool {
+	return strings.HasPrefix(b, a+"/")
+}
+
+// mergeDirection orders a pair of tags as (source, target): the tag attached to
+// more materials is kept, then the shorter name
+func mergeDirection(a, b *store.TagInfo) (source, target *store.TagInfo) {
+	if a.MaterialCount != b.MaterialCount {
+		if a.MaterialCount > b.MaterialCount {
+			return b, a
+		}
+		return a, b
+	}
+	if len(a.Name) != len(b.Name) {
+		if len(a.Name) < len(b.Name) {
+			return b, a
+		}
+		return a, b
+	}
+	if a.Name < b.Name {
+		return b, a
+	}
+	return a, b
+}
+
+// ListTags returns the user's tags with material counts
+func (c *LearningCore) ListTags(ctx context.Context, userID string) ([]*store.TagInfo, error) {
+	return c.store.ListTags(ctx, userID)
+}
+
+// RenameTag renames a tag path, moving its nested tags along. Renaming onto an
+// existing tag merges the two.
+func (c *LearningCore) RenameTag(ctx context.Context, userID, from, to string) (int, error) {
+	from, to = NormalizeTagName(from), NormalizeTagName(to)
+	if from == "" || to == "" {
+		return 0, fmt.Errorf("tag names cannot be empty")
+	}
+	log.Printf("[Core.RenameTag] UserID: %s, %q -> %q", userID, from, to)
+	moved, err := c.store.MoveTag(ctx, userID, from, to)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("[Core.RenameTag] Moved %d tags", moved)
+	return moved, nil
+}
+
+// MergeTags merges each source tag (with its nested tags) into target
+func (c *LearningCore) MergeTags(ctx context.Context, userID string, sources []string, target string) (int, error) {
+	target = NormalizeTagName(target)
+	if target == "" {
+		return 0, fmt.Errorf("target tag cannot be empty")
+	}
+	log.Printf("[Core.MergeTags] UserID: %s, %v -> %q", userID, sources, target)
+
+	total := 0
+	for _, source := range sources {
+		source = NormalizeTagName(source)
+		if source == "" || source == target {
+			continue
+		}
+		moved, err := c.store.MoveTag(ctx, userID, source, target)
+		if err != nil {
+			return total, fmt.Errorf("failed to merge '%s': %w", source, err)
+		}
+		total += moved
+	}
+	log.Printf("[Core.MergeTags] Merged %d tags", total)
+	return total, nil
+}
+
+// DeleteTag removes a tag (and optionally its nested tags) from all materials
+func (c *LearningCore) DeleteTag(ctx context.Context, userID, name string, recursive bool) (int, error) {
+	name = NormalizeTagName(name)
+	log.Printf("[Core.DeleteTag] UserID: %s, Tag: %q, Recursive: %v", userID, name, recursive)
+	return c.store.DeleteTag(ctx, userID, name, recursive)
+}
+
+// SetMaterialTags replaces the tags of a material, resolving names to the
+// user's canonical tags. Returns the canonical tag names.
+func (c *LearningCore) SetMaterialTags(ctx context.Context, userID, materialID string, names []string) ([]string, error) {
+	log.Printf("[Core.SetMaterialTags] UserID: %s, MaterialID: %s, Tags: %v", userID, materialID, names)
+	tagIDs, canonical, err := c.resolveTags(ctx, userID, names)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.store.SetMaterialTags(ctx, userID, materialID, tagIDs); err != nil {
+		return nil, err
+	}
+	return canonical, nil
+}
+
+// promptTags returns the user's canonical tags, most used first, for the
+// flashcard prompt to reuse instead of inventing variants
+func (c *LearningCore) promptTags(ctx context.Context, userID string) []string {
+	tags, err := c.store.ListTags(ctx, userID)
+	if err != nil {
+		log.Printf("[Core.AddMaterial] Failed to fetch tags: %v", err)
+		return nil
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].MaterialCount > tags[j].MaterialCount })
+	if len(tags) > maxPromptTags {
+		tags = tags[:maxPromptTags]
+	}
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// resolveTags normalises tag names and maps them to the user's canonical tags
+// (creating new ones), dropping duplicates
+func (c *LearningCore) resolveTags(ctx context.Context, userID string, names []string) (ids, canonical []string, err error) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name == "" {
+			continue
+		}
+		id, canonicalName, err := c.store.ResolveTag(ctx, userID, name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve tag '%s': %w", name, err)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		canonical = append(canonical, canonicalName)
+	}
+	return ids, canonical, nil
+}
+
+// GetTagMergeSuggestions returns pending merge suggestions, optionally
+// recomputing them first
+func (c *LearningCore) GetTagMergeSuggestions(ctx context.Context, userID string, refresh bool) ([]*store.TagMergeSuggestion, error) {
+	if refresh {
+		if _, err := c.SuggestTagMerges(ctx, userID); err != nil {
+			log.Printf("[Core.GetTagMergeSuggestions] Refresh failed: %v", err)
+		}
+	}
+	return c.store.GetTagMergeSuggestions(ctx, userID)
+}
+
+// ResolveTagMergeSuggestion applies (accept) or dismisses a suggestion.
+// Dismissed pairs are not suggested again.
+func (c *LearningCore) ResolveTagMergeSuggestion(ctx context.Context, userID, suggestionID string, accept bool) error {
+	sg, err := c.store.GetTagMergeSuggestion(ctx, userID, suggestionID)
+	if err != nil {
+		return err
+	}
+	log.Printf("[Core.ResolveTagMergeSuggestion] %q -> %q, Accept: %v", sg.SourceTag, sg.TargetTag, accept)
+
+	status := "dismissed"
+	if accept {
+		if _, err := c.store.MoveTag(ctx, userID, sg.SourceTag, sg.TargetTag); err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+		status = "accepted"
+	}
+	return c.store.SetTagMergeSuggestionStatus(ctx, userID, suggestionID, status)
+}
+
+// tagMergeCandidate is a suggested (source -> target) pair before it is stored
+type tagMergeCandidate struct {
+	source, target *store.TagInfo
+	reason         string
+	score          float64
+}
+
+// SuggestTagMerges finds tags that look like the same topic and stores them as
+// pending suggestions: spelling variants always, plus semantic matches from
+// embeddings when configured, else from the LLM. Returns the number found.
+func (c *LearningCore) SuggestTagMerges(ctx context.Context, userID string) (int, error) {
+	tags, err := c.store.ListTags(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(tags) < 2 {
+		return 0, nil
+	}
+	if len(tags) > maxTagsForSuggestions {
+		// Keep the most used tags
+		sort.SliceStable(tags, func(i, j int) bool { return tags[i].MaterialCount > tags[j].MaterialCount })
+		tags = tags[:maxTagsForSuggestions]
+	}
+
+	candidates := spellingMergeCandidates(tags)
+
+	var semantic []tagMergeCandidate
+	if c.embeddings != nil {
+		semantic, err = c.embeddingMergeCandidates(ctx, tags)
+	} else {
+		semantic, err = c.llmMergeCandidates(tags)
+	}
+	if err != nil {
+		// Non-critical: spelling matches are still useful
+		log.Printf("[Core.SuggestTagMerges] Semantic matching failed: %v", err)
+	}
+	candidates = append(candidates, semantic...)
+
+	saved := 0
+	seen := make(map[string]bool)
+	for _, cand := range candidates {
+		pair := cand.source.ID + ":" + cand.target.ID
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+		if err := c.store.SaveTagMergeSuggestion(ctx, userID, cand.source.ID, cand.target.ID, cand.reason, cand.score); err != nil {
+			log.Printf("[Core.SuggestTagMerges] %v", err)
+			continue
+		}
+		saved++
+	}
+	log.Printf("[Core.SuggestTagMerges] UserID: %s, %d tags, %d suggestions", userID, len(tags), saved)
+	return saved, nil
+}
+
+// SuggestTagMergesForAllUsers runs SuggestTagMerges for every user with enough tags
+func (c *LearningCore) SuggestTagMergesForAllUsers(ctx context.Context) {
+	userIDs, err := c.store.GetUsersWithTags(ctx, minTagsForSuggestions)
+	if err != nil {
+		log.Printf("[Core.SuggestTagMergesForAllUsers] Failed to get users: %v", err)
+		return
+	}
+	log.Printf("[Core.SuggestTagMergesForAllUsers] Processing %d users", len(userIDs))
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := c.SuggestTagMerges(ctx, userID); err != nil {
+			log.Printf("[Core.SuggestTagMergesForAllUsers] UserID %s: %v", userID, err)
+		}
+	}
+}
+
+// spellingMergeCandidates pairs tags whose names differ only in case,
+// punctuation, separators or a plural "s"
+func spellingMergeCandidates(tags []*store.TagInfo) []tagMergeCandidate {
+	byKey := make(map[string][]*store.TagInfo)
+	for _, t := range tags {
+		key := tagSpellingKey(t.Name)
+		byKey[key] = append(byKey[key], t)
+	}
+
+	var candidates []tagMergeCandidate
+	for _, group := range byKey {
+		if len(group) < 2 {
+			continue
+		}
+		// Merge every variant into the best one
+		best := group[0]
+		for _, t := range group[1:] {
+			if _, target := mergeDirection(best, t); target == t {
+				best = t
+			}
+		}
+		for _, t := range group {
+			if t != best {
+				candidates = append(candidates, tagMergeCandidate{source: t, target: best, reason: TagMergeSpelling, score: 1})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].source.Name < candidates[j].source.Name })
+	return candidates
+}
+
+// embeddingMergeCandidates pairs tags whose name embeddings are nearly identical
+func (c *LearningCore) embeddingMergeCandidates(ctx context.Context, tags []*store.TagInfo) ([]tagMergeCandidate, error) {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		// Embed the path as words so nesting contributes context
+		names[i] = strings.ReplaceAll(t.Name, "/", " ")
+	}
+	vectors, err := c.embeddings.Embedder().Embed(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed tags: %w", err)
+	}
+
+	var candidates []tagMergeCandidate
+	for i := range tags {
+		for j := i + 1; j < len(tags); j++ {
+			if isTagAncestor(tags[i].Name, tags[j].Name) || isTagAncestor(tags[j].Name, tags[i].Name) {
+				continue
+			}
+			if sim := cosineSimilarity(vectors[i], vectors[j]); sim >= tagSimilarityThreshold {
+				source, target := mergeDirection(tags[i], tags[j])
+				candidates = append(candidates, tagMergeCandidate{source: source, target: target, reason: TagMergeEmbedding, score: sim})
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// llmMergeCandidates asks the LLM which tags are synonyms
+func (c *LearningCore) llmMergeCandidates(tags []*store.TagInfo) ([]tagMergeCandidate, error) {
+	byName := make(map[string]*store.TagInfo, len(tags))
+	var list strings.Builder
+	for _, t := range tags {
+		byName[strings.ToLower(t.Name)] = t
+		fmt.Fprintf(&list, "%s (%d materials)\n", t.Name, t.MaterialCount)
+	}
+
+	prompt, err := prompts.Render(prompts.TagMerges, prompts.TagMergesData{Tags: list.String()})
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Merges []struct {
+			From string `json:"from"`
+			Into string `json:"into"`
+		} `json:"merges"`
+	}
+	if err := c.ai.GenerateJSON(prompt, ai.TagMergesSchema, &result); err != nil {
+		return nil, fmt.Errorf("LLM call failed: %w", err)
+	}
+
+	var candidates []tagMergeCandidate
+	for _, m := range result.Merges {
+		// Ignore names the model invented and parent/child pairs
+		source, target := byName[strings.ToLower(m.From)], byName[strings.ToLower(m.Into)]
+		if source == nil || target == nil || source == target ||
+			isTagAncestor(source.Name, target.Name) || isTagAncestor(target.Name, source.Name) {
+			continue
+		}
+		candidates = append(candidates, tagMergeCandidate{source: source, target: target, reason: TagMergeLLM, score: 0.5})
+	}
+	return candidates, nil
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/amityadav/landr/internal/store"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	tests := map[string]string{
+		"go":                               "go",
+		"  CS / Distributed  Systems/ ":    "CS/Distributed Systems",
+		"/cs//raft/":                       "cs/raft",
+		"   ":                              "",
+		"machine learning / deep learning": "machine learning/deep learning",
+	}
+	for in, want := range tests {
+		if got := NormalizeTagName(in); got != want {
+			t.Errorf("NormalizeTagName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTagSpellingKey(t *testing.T) {
+	same := [][2]string{
+		{"Machine-Learning", "machine learning"},
+		{"databases", "Database"},
+		{"CS/Raft", "cs/raft"},
+	}
+	for _, p := range same {
+		if tagSpellingKey(p[0]) != tagSpellingKey(p[1]) {
+			t.Errorf("expected %q and %q to share a spelling key", p[0], p[1])
+		}
+	}
+	different := [][2]string{
+		{"css", "cs"},
+		{"go", "rust"},
+		{"cs/raft", "raft"},
+	}
+	for _, p := range different {
+		if tagSpellingKey(p[0]) == tagSpellingKey(p[1]) {
+			t.Errorf("expected %q and %q to differ", p[0], p[1])
+		}
+	}
+}
+
+func TestSpellingMergeCandidates(t *testing.T) {
+	tags := []*store.TagInfo{
+		{ID: "1", Name: "Databases", MaterialCount: 1},
+		{ID: "2", Name: "database", MaterialCount: 5},
+		{ID: "3", Name: "data-base", MaterialCount: 0},
+		{ID: "4", Name: "go", MaterialCount: 3},
+	}
+	candidates := spellingMergeCandidates(tags)
+	if len(candidates) != 2 {
+		t.Fatalf("got %d candidates, want 2", len(candidates))
+	}
+	for _, c := range candidates {
+		if c.target.Name != "database" {
+			t.Errorf("%q merged into %q, want the most used tag", c.source.Name, c.target.Name)
+		}
+	}
+}
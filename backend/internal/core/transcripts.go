@@ -0,0 +1,46 @@
+package core
+
+import (
+	"strings"
+	"time"
+
+	"github.com/amityadav/landr/internal/youtube"
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+// linkCardsToVideo points each card of a YouTube material at the moment the
+// video covers it: the transcript paragraph (see youtube.Transcript.Text)
+// sharing the largest share of the card's words. Cards without a clear
+// paragraph keep an empty SourceUrl.
+func linkCardsToVideo(videoURL, transcript string, cards []*learning.Flashcard) {
+	videoID, err := youtube.ExtractVideoID(videoURL)
+	if err != nil {
+		return
+	}
+
+	var paragraphs []string
+	var starts []time.Duration
+	for _, p := range strings.Split(transcript, "\n\n") {
+		if at, ok := youtube.ParagraphTimestamp(p); ok {
+			paragraphs = append(paragraphs, p)
+			starts = append(starts, at)
+		}
+	}
+
+	for _, card := range cards {
+		words := supportWords(card.Question + " " + card.Answer)
+		if len(words) == 0 {
+			continue
+		}
+		// On a tie the earliest paragraph wins, where the topic is introduced
+		best, bestScore := -1, 0.0
+		for i, p := range paragraphs {
+			if score := supportScore(words, p); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 && bestScore >= minSupportScore {
+			card.SourceUrl = youtube.TimestampURL(videoID, starts[best])
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+)
+
+func TestLinkCardsToVideo(t *testing.T) {
+	transcript := "# Raft explained\n\n## Intro\n\n[0:00] welcome back, today we look at consensus.\n\n" +
+		"## Leader election\n\n[1:05] followers start an election when the election timeout expires without heartbeats.\n\n" +
+		"[1:38] timeouts are randomized between 150 and 300 milliseconds to avoid split votes."
+	cards := []*learning.Flashcard{
+		{Question: "When does a follower start an election?", Answer: "When its election timeout expires without heartbeats"},
+		{Question: "Why are election timeouts randomized?", Answer: "To avoid split votes"},
+		{Question: "What is a Merkle tree?", Answer: "A tree of hashes"},
+	}
+
+	linkCardsToVideo("https://www.youtube.com/watch?v=abcdefghijk", transcript, cards)
+	want := []string{"https://youtu.be/abcdefghijk?t=65", "https://youtu.be/abcdefghijk?t=98", ""}
+	for i, card := range cards {
+		if card.SourceUrl != want[i] {
+			t.Errorf("card %d: SourceUrl = %q, want %q", i, card.SourceUrl, want[i])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package dedupe
+
+// CardDuplicateThreshold is the question similarity above which a new card
+// is considered a repeat of an existing one
+const CardDuplicateThreshold = 0.8
+
+// cardStopWords are ignored when comparing questions
+var cardStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "is": true, "are": true, "was": true, "of": true,
+	"in": true, "on": true, "to": true, "for": true, "and": true, "or": true, "what": true,
+	"which": true, "how": true, "why": true, "does": true, "do": true, "it": true, "its": true,
+}
+
+// CardIndex answers "does this question repeat a known card?" by word-set (Jaccard) similarity
+type CardIndex struct {
+	cards []indexedCard
+}
+
+type indexedCard struct {
+	id    string
+	words map[string]bool
+}
+
+// Add indexes a card question under the given ID
+func (x *CardIndex) Add(id, question string) {
+	x.cards = append(x.cards, indexedCard{id: id, words: wordSet(question)})
+}
+
+// Match returns the most similar indexed card with similarity >= CardDuplicateThreshold
+func (x *CardIndex) Match(question string) (id string, similarity float64, ok bool) {
+	words := wordSet(question)
+	if len(words) == 0 {
+		return "", 0, false
+	}
+	for _, c := range x.cards {
+		if s := jaccard(words, c.words); s > similarity {
+			id, similarity = c.id, s
+		}
+	}
+	return id, similarity, similarity >= CardDuplicateThreshold
+}
+
+func wordSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range normalizeWords(text) {
+		if !cardStopWords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for w := range a {
+		if b[w] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
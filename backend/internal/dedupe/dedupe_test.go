@@ -0,0 +1,77 @@
+package dedupe
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"https://www.example.com/post/?utm_source=x&utm_medium=y", "http://example.com/post"},
+		{"https://m.example.com/post#comments", "https://example.com/post"},
+		{"https://example.com/post/amp", "https://example.com/post"},
+		{"https://example.com/amp/post", "https://example.com/post"},
+		{"https://example.com/post.amp.html", "https://example.com/post.html"},
+		{"https://example.com/p?b=2&a=1&fbclid=abc", "https://EXAMPLE.com/p?a=1&b=2"},
+		{"https://youtu.be/dQw4w9WgXcQ?si=share", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42"},
+		{"https://m.youtube.com/shorts/dQw4w9WgXcQ", "https://youtube.com/embed/dQw4w9WgXcQ"},
+	}
+	for _, tt := range tests {
+		if ca, cb := CanonicalURL(tt.a), CanonicalURL(tt.b); ca != cb {
+			t.Errorf("CanonicalURL(%q) = %q, CanonicalURL(%q) = %q; want equal", tt.a, ca, tt.b, cb)
+		}
+	}
+
+	// Different pages stay different
+	if CanonicalURL("https://example.com/p?id=1") == CanonicalURL("https://example.com/p?id=2") {
+		t.Error("distinct query values must not collapse")
+	}
+	if CanonicalURL("https://youtu.be/dQw4w9WgXcQ") == CanonicalURL("https://youtu.be/aaaaaaaaaaa") {
+		t.Error("distinct videos must not collapse")
+	}
+}
+
+const article = `Raft is a consensus algorithm designed to be easy to understand. It is equivalent
+to Paxos in fault-tolerance and performance. The difference is that it is decomposed into relatively
+independent subproblems, and it cleanly addresses all major pieces needed for practical systems.
+A server starts as a follower; if it hears nothing from a leader for an election timeout it becomes a
+candidate, increments its term and requests votes from the other servers in the cluster. A candidate
+that receives votes from a majority of servers becomes the leader for that term and sends heartbeats.`
+
+func TestSimhashNearDuplicates(t *testing.T) {
+	a, ok := Simhash(article)
+	if !ok {
+		t.Fatal("article should be long enough to fingerprint")
+	}
+
+	// Same article with different whitespace, case, punctuation and a trailing share line
+	b, _ := Simhash(strings.ToUpper(strings.ReplaceAll(article, "\n", "  ")) + " Share this post!")
+	if d := Distance(a, b); d > NearDuplicateDistance {
+		t.Errorf("near-duplicate distance = %d, want <= %d", d, NearDuplicateDistance)
+	}
+
+	other := strings.Repeat("Kubernetes schedules pods onto nodes based on resource requests and affinity rules. ", 4)
+	c, _ := Simhash(other)
+	if d := Distance(a, c); d <= NearDuplicateDistance {
+		t.Errorf("unrelated distance = %d, want > %d", d, NearDuplicateDistance)
+	}
+
+	if _, ok := Simhash("too short to fingerprint"); ok {
+		t.Error("short text should not be fingerprinted")
+	}
+}
+
+func TestCardIndex(t *testing.T) {
+	var idx CardIndex
+	idx.Add("c1", "How does a Raft candidate become the leader?")
+	idx.Add("c2", "What is an election timeout?")
+
+	if id, _, ok := idx.Match("How does a candidate become leader in Raft?"); !ok || id != "c1" {
+		t.Errorf("reworded question should match c1, got %q ok=%v", id, ok)
+	}
+	if _, _, ok := idx.Match("What does a leader send to followers?"); ok {
+		t.Error("different question should not match")
+	}
+}
@@ -0,0 +1,67 @@
+package dedupe
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+const (
+	// shingleSize is the number of consecutive words hashed together
+	shingleSize = 3
+	// NearDuplicateDistance is the max Hamming distance between the simhashes of
+	// two texts considered the same content (~95% similar)
+	NearDuplicateDistance = 3
+	// minFingerprintWords is the shortest text worth fingerprinting; below it
+	// unrelated snippets collide too easily
+	minFingerprintWords = 30
+)
+
+// Simhash returns a 64-bit locality-sensitive fingerprint of text built from
+// word shingles: similar texts get fingerprints with a small Hamming distance.
+// ok is false when the text is too short to fingerprint reliably.
+func Simhash(text string) (hash uint64, ok bool) {
+	words := normalizeWords(text)
+	if len(words) < minFingerprintWords {
+		return 0, false
+	}
+
+	var weights [64]int
+	for i := 0; i+shingleSize <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+shingleSize], " ")))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			hash |= 1 << uint(bit)
+		}
+	}
+	return hash, true
+}
+
+// Distance is the Hamming distance between two simhashes
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Similarity maps the Hamming distance of two simhashes to 0..1
+func Similarity(a, b uint64) float64 {
+	return 1 - float64(Distance(a, b))/64
+}
+
+// normalizeWords lowercases text and splits it into words, dropping punctuation
+func normalizeWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,101 @@
+// Package dedupe detects materials and flashcards the user has already imported:
+// canonical URLs, content fingerprints (simhash) and card text similarity.
+package dedupe
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// trackingParams are query parameters that never change the page content
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true,
+	"mc_cid": true, "mc_eid": true, "igshid": true, "ref": true, "ref_src": true,
+	"ref_url": true, "si": true, "amp": true, "_ga": true, "_gl": true, "cmpid": true, "spm": true,
+}
+
+// hostPrefixes are subdomains that serve the same content as the bare domain
+var hostPrefixes = []string{"www.", "m.", "mobile.", "amp.", "amp-"}
+
+var youtubeID = regexp.MustCompile(`^[a-zA-Z0-9_-]{11}$`)
+
+// CanonicalURL normalises a URL so different links to the same page compare equal:
+// lowercase scheme/host, no www/mobile/AMP variants, no tracking parameters or fragment,
+// sorted query, no trailing slash. YouTube links are reduced to youtube.com/watch?v=ID.
+// Unparseable input is returned trimmed.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, p := range hostPrefixes {
+		host = strings.TrimPrefix(host, p)
+	}
+
+	if id := youtubeVideoID(host, u); id != "" {
+		return "https://youtube.com/watch?v=" + id
+	}
+
+	path := u.EscapedPath()
+	// AMP variants: /story/amp, /amp/story, /story.amp.html
+	path = strings.TrimSuffix(path, "/")
+	path = strings.TrimSuffix(path, "/amp")
+	path = strings.TrimPrefix(path, "/amp/")
+	path = strings.Replace(path, ".amp.html", ".html", 1)
+	path = strings.TrimSuffix(path, "/")
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		lk := strings.ToLower(k)
+		if strings.HasPrefix(lk, "utm_") || trackingParams[lk] {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+
+	canonical := "https://" + host + path
+	if len(params) > 0 {
+		canonical += "?" + strings.Join(params, "&")
+	}
+	return canonical
+}
+
+func youtubeVideoID(host string, u *url.URL) string {
+	var id string
+	switch host {
+	case "youtu.be":
+		id = strings.Trim(u.Path, "/")
+	case "youtube.com", "music.youtube.com", "youtube-nocookie.com":
+		switch {
+		case u.Path == "/watch":
+			id = u.Query().Get("v")
+		case strings.HasPrefix(u.Path, "/embed/"), strings.HasPrefix(u.Path, "/shorts/"), strings.HasPrefix(u.Path, "/live/"):
+			parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+			if len(parts) >= 2 {
+				id = parts[1]
+			}
+		}
+	}
+	if youtubeID.MatchString(id) {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,299 @@
+// Package egress is how the backend talks to arbitrary web hosts on behalf of
+// users: an HTTP client that refuses internal addresses and bounds every
+// response, robots.txt checks, and a revalidating page cache.
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrBlockedAddress    = errors.New("destination address is not allowed")
+	ErrBodyTooLarge      = errors.New("response body too large")
+	ErrInvalidURL        = errors.New("invalid url")
+	ErrTooManyRedirects  = errors.New("too many redirects")
+	ErrUnsupportedScheme = errors.New("only http and https urls are allowed")
+)
+
+// Options bounds what a client may fetch
+type Options struct {
+	Timeout      time.Duration // Whole request, including redirects and reading the body
+	MaxBodyBytes int64         // Larger responses fail with ErrBodyTooLarge
+	MaxRedirects int
+	MaxPerHost   int // Concurrent requests to one host; further requests wait
+}
+
+// DefaultOptions are the limits used for scraping user-supplied URLs
+var DefaultOptions = Options{
+	Timeout:      60 * time.Second,
+	MaxBodyBytes: 10 << 20,
+	MaxRedirects: 5,
+	MaxPerHost:   4,
+}
+
+// NewClient creates an HTTP client for user-supplied URLs. It connects only to
+// public addresses, checked after DNS resolution on the address actually dialed
+// (so redirects and DNS rebinding can't reach internal services), ignores proxy
+// environment variables, and caps body size, redirects and per-host concurrency.
+func NewClient(opts Options) *http.Client {
+	return newClient(opts, IsPublicAddr)
+}
+
+// newClient creates the client with a custom address check (tests allow loopback)
+func newClient(opts Options, allowed func(netip.Addr) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkDialAddress(address, allowed)
+		},
+	}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+	}
+	return &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &limitTransport{
+			next:    transport,
+			maxBody: opts.MaxBodyBytes,
+			hosts:   newHostLimiter(opts.MaxPerHost),
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= opts.MaxRedirects {
+				return ErrTooManyRedirects
+			}
+			return nil
+		},
+	}
+}
+
+// CheckURL rejects URLs that NewClient would refuse to fetch: schemes other than
+// http(s), and hosts that resolve to a non-public address. The client checks the
+// dialed address again, so this is for failing early with a clear error.
+func CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrUnsupportedScheme
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ErrInvalidURL)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !IsPublicAddr(ip) {
+			return fmt.Errorf("%w: %s", ErrBlockedAddress, ip)
+		}
+		return nil
+	}
+
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, ip := range ips {
+		if !IsPublicAddr(ip) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrBlockedAddress, host, ip)
+		}
+	}
+	return nil
+}
+
+// checkDialAddress rejects connections to addresses that aren't allowed
+func checkDialAddress(address string, allowed func(netip.Addr) bool) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	if !allowed(ip) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, ip)
+	}
+	return nil
+}
+
+// nonPublicPrefixes are special-purpose ranges not covered by the netip predicates
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "This network"
+	netip.MustParsePrefix("100.64.0.0/10"),   // Carrier-grade NAT, also some cloud metadata services
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // Documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // Benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // Documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // Documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // Reserved, including broadcast
+	netip.MustParsePrefix("100::/64"),        // Discard
+	netip.MustParsePrefix("2001::/32"),       // Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // Documentation
+	netip.MustParsePrefix("fec0::/10"),       // Deprecated site-local
+}
+
+// embeddedV4Prefixes are IPv6 ranges that carry an IPv4 address, which must be public too
+var embeddedV4Prefixes = []netip.Prefix{
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, IPv4 in the last 32 bits
+	netip.MustParsePrefix("2002::/16"),    // 6to4, IPv4 in bits 16-48
+}
+
+// IsPublicAddr reports whether ip is a globally routable unicast address:
+// not loopback, private, link-local (e.g. 169.254.169.254), multicast or reserved
+func IsPublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	if ip.Is6() {
+		b := ip.As16()
+		if embeddedV4Prefixes[0].Contains(ip) {
+			return IsPublicAddr(netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]}))
+		}
+		if embeddedV4Prefixes[1].Contains(ip) {
+			return IsPublicAddr(netip.AddrFrom4([4]byte{b[2], b[3], b[4], b[5]}))
+		}
+	}
+	return true
+}
+
+// limitTransport enforces the scheme, body size and per-host concurrency limits
+type limitTransport struct {
+	next    http.RoundTripper
+	maxBody int64
+	hosts   *hostLimiter
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, ErrUnsupportedScheme
+	}
+
+	// The slot is held until the body is closed, since reading it uses the connection
+	release, err := t.hosts.acquire(req.Context(), strings.ToLower(req.URL.Hostname()))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if t.maxBody > 0 && resp.ContentLength > t.maxBody {
+		resp.Body.Close()
+		release()
+		return nil, fmt.Errorf("%w: %d bytes", ErrBodyTooLarge, resp.ContentLength)
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: t.maxBody, limited: t.maxBody > 0, release: release}
+	return resp, nil
+}
+
+// limitedBody fails reads past the size limit and frees the host slot on close
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limited   bool
+	release   func()
+	once      sync.Once
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if !b.limited {
+		return b.ReadCloser.Read(p)
+	}
+	if b.remaining <= 0 {
+		// At the limit: only a clean EOF is acceptable
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// hostLimiter bounds concurrent requests per host
+type hostLimiter struct {
+	limit int
+	mu    sync.Mutex
+	slots map[string]*hostSlots
+}
+
+type hostSlots struct {
+	sem  chan struct{}
+	refs int // Requests holding or waiting for a slot; the entry is dropped at 0
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	return &hostLimiter{limit: limit, slots: map[string]*hostSlots{}}
+}
+
+// acquire waits for a free slot for host, returning the function that frees it
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if l.limit <= 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	s, ok := l.slots[host]
+	if !ok {
+		s = &hostSlots{sem: make(chan struct{}, l.limit)}
+		l.slots[host] = s
+	}
+	s.refs++
+	l.mu.Unlock()
+
+	done := func() {
+		l.mu.Lock()
+		if s.refs--; s.refs == 0 {
+			delete(l.slots, host)
+		}
+		l.mu.Unlock()
+	}
+
+	select {
+	case s.sem <- struct{}{}:
+		return func() {
+			<-s.sem
+			done()
+		}, nil
+	case <-ctx.Done():
+		done()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,204 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false}, // Cloud metadata
+		{"100.100.100.200", false}, // Carrier-grade NAT range, Alibaba metadata
+		{"0.0.0.0", false},
+		{"255.255.255.255", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00:ec2::254", false},      // AWS IPv6 metadata (unique local)
+		{"::ffff:127.0.0.1", false},   // IPv4-mapped loopback
+		{"64:ff9b::a9fe:a9fe", false}, // NAT64 of 169.254.169.254
+		{"64:ff9b::5db8:d822", true},  // NAT64 of a public address
+		{"2002:0a00:0001::1", false},  // 6to4 of 10.0.0.1
+	}
+	for _, tt := range tests {
+		if got := IsPublicAddr(netip.MustParseAddr(tt.ip)); got != tt.want {
+			t.Errorf("IsPublicAddr(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestClientBlocksInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "internal")
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(DefaultOptions).Get(srv.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Get(%s) error = %v, want ErrBlockedAddress", srv.URL, err)
+	}
+
+	for _, u := range []string{"http://127.0.0.1/", "http://[::1]:8080/", "http://169.254.169.254/latest/meta-data/", "file:///etc/passwd"} {
+		if err := CheckURL(context.Background(), u); err == nil {
+			t.Errorf("CheckURL(%s) = nil, want an error", u)
+		}
+	}
+	if err := CheckURL(context.Background(), "https://93.184.216.34/page"); err != nil {
+		t.Errorf("CheckURL(public ip) = %v", err)
+	}
+}
+
+func allowAll(netip.Addr) bool { return true }
+
+func TestClientLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/big":
+			w.Write([]byte(strings.Repeat("x", 2048)))
+		case "/stream":
+			// Flushing first makes the body chunked, so its size is only known while reading
+			w.Write([]byte(strings.Repeat("x", 512)))
+			w.(http.Flusher).Flush()
+			w.Write([]byte(strings.Repeat("x", 1536)))
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+	client := newClient(Options{Timeout: 5 * time.Second, MaxBodyBytes: 1024, MaxRedirects: 3, MaxPerHost: 2}, allowAll)
+
+	if _, err := client.Get(srv.URL + "/big"); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("2 KB Content-Length with a 1 KB cap: err = %v, want ErrBodyTooLarge", err)
+	}
+	resp, err := client.Get(srv.URL + "/stream")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	_, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("reading a 2 KB chunked body with a 1 KB cap: err = %v, want ErrBodyTooLarge", err)
+	}
+
+	if _, err := client.Get(srv.URL + "/loop"); !errors.Is(err, ErrTooManyRedirects) {
+		t.Errorf("redirect loop: err = %v, want ErrTooManyRedirects", err)
+	}
+
+	resp, err = client.Get(srv.URL + "/small")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("body = %q, want ok", body)
+	}
+}
+
+func TestHostLimiter(t *testing.T) {
+	l := newHostLimiter(2)
+	ctx := context.Background()
+	r1, _ := l.acquire(ctx, "a.com")
+	r2, _ := l.acquire(ctx, "a.com")
+	if _, err := l.acquire(ctx, "b.com"); err != nil {
+		t.Fatalf("other host should not wait: %v", err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(timeout, "a.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("third request to a.com: err = %v, want it to wait", err)
+	}
+
+	r1()
+	r3, err := l.acquire(ctx, "a.com")
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	r2()
+	r3()
+	if _, ok := l.slots["a.com"]; ok {
+		t.Error("idle host should be dropped")
+	}
+}
+
+func TestFetcherRevalidates(t *testing.T) {
+	var requests, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, "<p>hello</p>")
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	fresh := NewFetcher(newClient(DefaultOptions, allowAll), 1<<20, time.Hour)
+	first, err := fresh.Fetch(ctx, srv.URL+"/post?utm_source=x", nil)
+	if err != nil || first.FromCache {
+		t.Fatalf("first fetch: %+v, %v", first, err)
+	}
+	// Same canonical URL, within the fresh window: no request
+	second, err := fresh.Fetch(ctx, srv.URL+"/post", nil)
+	if err != nil || !second.FromCache || string(second.Body) != "<p>hello</p>" {
+		t.Fatalf("second fetch: %+v, %v", second, err)
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("requests = %d, want 1", requests.Load())
+	}
+
+	stale := NewFetcher(newClient(DefaultOptions, allowAll), 1<<20, 0)
+	stale.Fetch(ctx, srv.URL+"/post", nil)
+	page, err := stale.Fetch(ctx, srv.URL+"/post", nil)
+	if err != nil || !page.FromCache || string(page.Body) != "<p>hello</p>" {
+		t.Fatalf("revalidated fetch: %+v, %v", page, err)
+	}
+	if notModified.Load() != 1 {
+		t.Errorf("304 responses = %d, want 1", notModified.Load())
+	}
+}
+
+func TestPageCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newPageCache(40)
+	page := func(n int) *Page { return &Page{Body: make([]byte, n)} }
+	c.put("a", page(10))
+	c.put("b", page(10))
+	c.put("c", page(10))
+	c.get("a") // a is now the most recently used
+	c.put("d", page(10))
+	c.put("e", page(10))
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "d": true, "e": true} {
+		if got := c.get(key) != nil; got != want {
+			t.Errorf("cached %s = %v, want %v", key, got, want)
+		}
+	}
+	c.put("huge", page(30))
+	if c.get("huge") != nil {
+		t.Error("a page over a quarter of the cache should not be cached")
+	}
+}
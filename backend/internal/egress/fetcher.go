@@ -0,0 +1,178 @@
+package egress
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amityadav/landr/internal/dedupe"
+)
+
+// Page is a fetched response body with its cache validators. Body is shared
+// with the cache and must not be modified.
+type Page struct {
+	URL          string // Final URL, after redirects
+	ContentType  string
+	Body         []byte
+	ETag         string
+	LastModified string
+	FetchedAt    time.Time // When the body was last fetched or revalidated
+	FromCache    bool      // Served from the cache, fresh or after a 304
+}
+
+// StatusError is returned for responses other than 200 and 304
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status code error: %d", e.StatusCode)
+}
+
+// Fetcher GETs pages through an in-memory cache keyed by canonical URL. Pages
+// fetched within the fresh window are served as is; older ones are revalidated
+// with If-None-Match / If-Modified-Since and reused on 304 Not Modified.
+type Fetcher struct {
+	client *http.Client
+	cache  *pageCache
+	fresh  time.Duration
+}
+
+// NewFetcher creates a fetcher whose cache holds up to cacheBytes of bodies
+func NewFetcher(client *http.Client, cacheBytes int64, fresh time.Duration) *Fetcher {
+	return &Fetcher{client: client, cache: newPageCache(cacheBytes), fresh: fresh}
+}
+
+// Fetch GETs pageURL with the given request headers
+func (f *Fetcher) Fetch(ctx context.Context, pageURL string, header http.Header) (*Page, error) {
+	key := cacheKey(pageURL)
+	cached := f.cache.get(key)
+	if cached != nil && time.Since(cached.FetchedAt) < f.fresh {
+		log.Printf("[Fetcher] Cache hit: %s", key)
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch url: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		log.Printf("[Fetcher] Not modified: %s", key)
+		page := *cached
+		page.FetchedAt = time.Now()
+		f.cache.put(key, &page)
+		return &page, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	page := &Page{
+		URL:          resp.Request.URL.String(),
+		ContentType:  resp.Header.Get("Content-Type"),
+		Body:         body,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+	}
+	if !strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store") {
+		f.cache.put(key, page)
+	}
+
+	out := *page
+	return &out, nil
+}
+
+// cacheKey is the canonical URL, plus the port when it isn't the default one
+// (canonical URLs drop it, but another port is another server)
+func cacheKey(pageURL string) string {
+	key := dedupe.CanonicalURL(pageURL)
+	if u, err := url.Parse(pageURL); err == nil && u.Port() != "" && u.Port() != "80" && u.Port() != "443" {
+		key += " port=" + u.Port()
+	}
+	return key
+}
+
+// pageCache is a least-recently-used cache of pages bounded by total body size
+type pageCache struct {
+	maxBytes int64
+	mu       sync.Mutex
+	size     int64
+	order    *list.List // Front is most recently used; values are *cacheEntry
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	page *Page
+}
+
+func newPageCache(maxBytes int64) *pageCache {
+	return &pageCache{maxBytes: maxBytes, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// get returns a copy of the cached page marked FromCache, or nil
+func (c *pageCache) get(key string) *Page {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(el)
+	page := *el.Value.(*cacheEntry).page
+	page.FromCache = true
+	return &page
+}
+
+func (c *pageCache) put(key string, page *Page) {
+	size := int64(len(page.Body))
+	if size > c.maxBytes/4 {
+		return // One page mustn't evict most of the cache
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.size -= int64(len(el.Value.(*cacheEntry).page.Body))
+		c.order.Remove(el)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, page: page})
+	c.size += size
+	for c.size > c.maxBytes {
+		oldest := c.order.Back()
+		entry := oldest.Value.(*cacheEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.size -= int64(len(entry.page.Body))
+	}
+}
@@ -0,0 +1,181 @@
+package egress
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RobotsAgent is the product token matched against robots.txt user-agent groups
+const RobotsAgent = "LandRBot"
+
+const (
+	robotsTTL     = 12 * time.Hour
+	robotsMaxSize = 512 << 10 // Larger files are cut, as Google does
+)
+
+// Robots answers robots.txt checks, caching each host's rules. Hosts whose
+// robots.txt is missing or can't be fetched allow everything: imports are
+// started by a user, so an unreachable robots.txt shouldn't block them.
+type Robots struct {
+	client *http.Client
+	mu     sync.Mutex
+	hosts  map[string]*robotsEntry // scheme://host -> rules
+}
+
+type robotsEntry struct {
+	rules     []robotsRule
+	fetchedAt time.Time
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+func NewRobots(client *http.Client) *Robots {
+	return &Robots{client: client, hosts: map[string]*robotsEntry{}}
+}
+
+// Allowed reports whether robots.txt lets RobotsAgent fetch pageURL
+func (r *Robots) Allowed(ctx context.Context, pageURL string) bool {
+	u, err := url.Parse(pageURL)
+	if err != nil || u.Host == "" {
+		return true
+	}
+	origin := strings.ToLower(u.Scheme + "://" + u.Host)
+
+	r.mu.Lock()
+	entry, ok := r.hosts[origin]
+	r.mu.Unlock()
+	if !ok || time.Since(entry.fetchedAt) > robotsTTL {
+		entry = &robotsEntry{rules: r.fetch(ctx, origin), fetchedAt: time.Now()}
+		r.mu.Lock()
+		r.hosts[origin] = entry
+		r.mu.Unlock()
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return robotsAllowed(entry.rules, path)
+}
+
+// fetch downloads and parses an origin's robots.txt, returning no rules when
+// it can't be read
+func (r *Robots) fetch(ctx context.Context, origin string) []robotsRule {
+	req, err := http.NewRequestWithContext(ctx, "GET", origin+"/robots.txt", nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", RobotsAgent)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		log.Printf("[Robots] Fetching %s/robots.txt failed, allowing all: %v", origin, err)
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	return parseRobots(io.LimitReader(resp.Body, robotsMaxSize), RobotsAgent)
+}
+
+// parseRobots returns the rules of the group for agent, or of the "*" group
+// when no group names the agent. Groups naming the same agent are merged.
+func parseRobots(r io.Reader, agent string) []robotsRule {
+	agent = strings.ToLower(agent)
+	var specific, wildcard []robotsRule
+	var groupAgents []string
+	inRules := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent line after rules starts a new group
+			if inRules {
+				groupAgents, inRules = nil, false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // "Disallow:" with no path allows everything
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value, re: robotsPattern(value)}
+			for _, a := range groupAgents {
+				if a == "*" {
+					wildcard = append(wildcard, rule)
+				} else if a == agent || strings.HasPrefix(a, agent+"/") {
+					specific = append(specific, rule)
+				}
+			}
+		}
+	}
+	if specific != nil {
+		return specific
+	}
+	return wildcard
+}
+
+// robotsPattern compiles a path pattern: "*" matches any characters and a
+// trailing "$" anchors the end
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// robotsAllowed applies the most specific (longest) matching rule; Allow wins ties
+func robotsAllowed(rules []robotsRule, path string) bool {
+	allowed, best := true, -1
+	for _, rule := range rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		if n := len(rule.pattern); n > best || (n == best && rule.allow) {
+			allowed, best = rule.allow, n
+		}
+	}
+	return allowed
+}
+
+// DisallowedError is returned for pages robots.txt doesn't let us fetch
+type DisallowedError struct {
+	URL string
+}
+
+func (e *DisallowedError) Error() string {
+	return fmt.Sprintf("robots.txt disallows fetching %s", e.URL)
+}
@@ -0,0 +1,57 @@
+package egress
+
+import (
+	"strings"
+	"testing"
+)
+
+const robotsTxt = `
+# Example robots.txt
+User-agent: Googlebot
+Disallow: /
+
+User-agent: *
+Disallow: /private/
+Disallow: /*.pdf$
+Allow: /private/public-notes
+Disallow: /search?q=
+
+User-agent: LandRBot
+User-agent: OtherBot
+Disallow: /drafts/
+Disallow:
+
+Sitemap: https://example.com/sitemap.xml
+`
+
+func TestRobots(t *testing.T) {
+	wildcard := parseRobots(strings.NewReader(robotsTxt), "SomeBot")
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/blog/post", true},
+		{"/private/keys", false},
+		{"/private/public-notes/1", true}, // Longer Allow wins
+		{"/files/report.pdf", false},
+		{"/files/report.pdf?dl=1", true}, // "$" anchors the end
+		{"/search?q=go", false},
+		{"/search", true},
+	}
+	for _, tt := range tests {
+		if got := robotsAllowed(wildcard, tt.path); got != tt.want {
+			t.Errorf("* group: allowed(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	// A group naming the agent replaces the * group
+	own := parseRobots(strings.NewReader(robotsTxt), RobotsAgent)
+	if robotsAllowed(own, "/drafts/x") || !robotsAllowed(own, "/private/keys") {
+		t.Errorf("LandRBot group not applied: %+v", own)
+	}
+
+	if !robotsAllowed(parseRobots(strings.NewReader(""), RobotsAgent), "/anything") {
+		t.Error("empty robots.txt should allow everything")
+	}
+}
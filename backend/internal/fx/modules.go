@@ -3,14 +3,19 @@ package fx
 import (
 	"context"
 	"log"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/amityadav/landr/internal/ai"
 	"github.com/amityadav/landr/internal/ai/models"
 	"github.com/amityadav/landr/internal/config"
 	"github.com/amityadav/landr/internal/core"
+	"github.com/amityadav/landr/internal/egress"
 	"github.com/amityadav/landr/internal/firebase"
 	"github.com/amityadav/landr/internal/notifications"
+	"github.com/amityadav/landr/internal/promptregistry"
+	"github.com/amityadav/landr/internal/quota"
 	"github.com/amityadav/landr/internal/scraper"
 	"github.com/amityadav/landr/internal/search"
 	"github.com/amityadav/landr/internal/serpapi"
@@ -38,9 +43,17 @@ var StoreModule = fx.Module("store",
 	fx.Provide(NewPostgresStore),
 )
 
-// SettingsModule provides database-based settings service
+// SettingsModule provides database-based settings service and the quotas they set
 var SettingsModule = fx.Module("settings",
-	fx.Provide(NewSettingsService),
+	fx.Provide(
+		NewSettingsService,
+		NewQuotaEnforcer,
+	),
+)
+
+// PromptModule provides the versioned prompt registry
+var PromptModule = fx.Module("prompts",
+	fx.Provide(NewPromptRegistry),
 )
 
 // TokenModule provides JWT token management
@@ -50,7 +63,13 @@ var TokenModule = fx.Module("token",
 
 // ScraperModule provides web scraping capabilities
 var ScraperModule = fx.Module("scraper",
-	fx.Provide(scraper.NewScraper),
+	fx.Provide(
+		NewEgressClient,
+		NewPageFetcher,
+		egress.NewRobots,
+		NewExtractorRegistry,
+		scraper.NewScraper,
+	),
 )
 
 // AIModule provides AI/LLM providers
@@ -58,6 +77,7 @@ var AIModule = fx.Module("ai",
 	fx.Provide(
 		NewLearningAIProvider,
 		NewFeedAIProvider,
+		NewEmbedder,
 	),
 )
 
@@ -70,7 +90,10 @@ var SearchModule = fx.Module("search",
 var CoreModule = fx.Module("core",
 	fx.Provide(
 		NewAuthCore,
+		NewEmbeddingIndexer,
 		NewLearningCore,
+		NewSourceCore,
+		NewImportCore,
 		NewFeedCore,
 	),
 )
@@ -90,6 +113,7 @@ var NotificationModule = fx.Module("notification",
 	fx.Provide(
 		NewFirebaseSender,
 		NewNotificationWorker,
+		notifications.NewSourcePoller,
 	),
 )
 
@@ -128,6 +152,18 @@ func NewSettingsService(st *store.PostgresStore) (*settings.Service, error) {
 	return svc, nil
 }
 
+// NewQuotaEnforcer creates the daily quota checks shared by the gRPC interceptor and background imports
+func NewQuotaEnforcer(st *store.PostgresStore, settingsSvc *settings.Service) *quota.Enforcer {
+	return quota.NewEnforcer(st, settingsSvc)
+}
+
+// NewPromptRegistry creates prompt registry backed by the prompt_templates table
+func NewPromptRegistry(st *store.PostgresStore) *promptregistry.Registry {
+	r := promptregistry.NewRegistry(context.Background(), st)
+	log.Printf("[FX] PromptRegistry initialized")
+	return r
+}
+
 // NewTokenManager creates JWT token manager
 func NewTokenManager(cfg config.Config) *token.Manager {
 	tm := token.NewManager(cfg.JWTSecret)
@@ -188,6 +224,63 @@ func NewFeedAIProvider(cfg config.Config) FeedAIProvider {
 	return FeedAIProvider{Provider: provider}
 }
 
+// NewEmbedder creates the embeddings client (optional - returns nil if no API key)
+func NewEmbedder(cfg config.Config) ai.Embedder {
+	if cfg.EmbeddingAPIKey == "" {
+		log.Printf("[FX] Embedder disabled (no EMBEDDING_API_KEY)")
+		return nil
+	}
+	e := ai.NewOpenAIEmbedder(ai.EmbedderConfig{
+		BaseURL: cfg.EmbeddingURL,
+		APIKey:  cfg.EmbeddingAPIKey,
+		Model:   cfg.EmbeddingModel,
+	})
+	log.Printf("[FX] Embedder initialized (%s)", cfg.EmbeddingModel)
+	return e
+}
+
+// NewEgressClient creates the HTTP client for user-supplied URLs: public
+// addresses only, with body size, redirect and per-host concurrency limits
+func NewEgressClient(cfg config.Config) *http.Client {
+	opts := egress.DefaultOptions
+	opts.MaxBodyBytes = int64(cfg.ScraperMaxBodyMB) << 20
+	opts.MaxPerHost = cfg.ScraperMaxPerHost
+	return egress.NewClient(opts)
+}
+
+// NewPageFetcher creates the revalidating page fetcher; cached pages are
+// reused without a request for pageFreshFor
+func NewPageFetcher(cfg config.Config, client *http.Client) *egress.Fetcher {
+	const pageFreshFor = 10 * time.Minute
+	return egress.NewFetcher(client, int64(cfg.ScraperCacheMB)<<20, pageFreshFor)
+}
+
+// NewExtractorRegistry creates the content extractor registry. Registration
+// order is the default chain: readability first, hosted renderers as fallbacks.
+func NewExtractorRegistry(cfg config.Config, client *http.Client, fetcher *egress.Fetcher) *scraper.Registry {
+	registry := scraper.NewRegistry()
+
+	registry.Register(scraper.NewReadabilityExtractor(fetcher))
+	registry.Register(scraper.NewJinaExtractor(client))
+	if cfg.SupadataAPIKey != "" {
+		registry.Register(scraper.NewSupadataExtractor(client, cfg.SupadataAPIKey))
+		log.Printf("[FX] ExtractorRegistry: Supadata registered")
+	}
+
+	chains, err := scraper.ParseDomainChains(cfg.ScraperDomainChains)
+	if err != nil {
+		log.Printf("[FX] ExtractorRegistry: Ignoring SCRAPER_DOMAIN_CHAINS: %v", err)
+	}
+	for domain, names := range chains {
+		if err := registry.SetDomainChain(domain, names...); err != nil {
+			log.Printf("[FX] ExtractorRegistry: %v", err)
+		}
+	}
+
+	log.Printf("[FX] ExtractorRegistry initialized with %d extractors and %d domain chains", registry.Count(), len(chains))
+	return registry
+}
+
 // NewSearchRegistry creates search registry with all available providers
 func NewSearchRegistry(cfg config.Config) *search.Registry {
 	registry := search.NewRegistry()
@@ -219,15 +312,45 @@ type LearningCoreParams struct {
 	Store            *store.PostgresStore
 	Scraper          *scraper.Scraper
 	LearningProvider ai.Provider `name:"learning"`
+	PromptRegistry   *promptregistry.Registry
+	Embeddings       *core.EmbeddingIndexer `optional:"true"`
+}
+
+// NewEmbeddingIndexer creates the embedding indexer (optional - returns nil if no embedder)
+func NewEmbeddingIndexer(st *store.PostgresStore, embedder ai.Embedder) *core.EmbeddingIndexer {
+	if embedder == nil {
+		log.Printf("[FX] EmbeddingIndexer disabled (no embedder)")
+		return nil
+	}
+	x := core.NewEmbeddingIndexer(st, embedder)
+	log.Printf("[FX] EmbeddingIndexer initialized")
+	return x
 }
 
 // NewLearningCore creates learning business logic
 func NewLearningCore(p LearningCoreParams) *core.LearningCore {
-	c := core.NewLearningCore(p.Store, p.Scraper, p.LearningProvider)
+	c := core.NewLearningCore(p.Store, p.Scraper, p.LearningProvider, p.PromptRegistry)
+	if p.Embeddings != nil {
+		c.SetEmbeddingIndexer(p.Embeddings)
+	}
 	log.Printf("[FX] LearningCore initialized")
 	return c
 }
 
+// NewSourceCore creates feed/page subscriptions, fetched through the scraper's egress client
+func NewSourceCore(st *store.PostgresStore, lc *core.LearningCore, fetcher *egress.Fetcher, robots *egress.Robots, quotas *quota.Enforcer) *core.SourceCore {
+	c := core.NewSourceCore(st, lc, fetcher, robots, quotas)
+	log.Printf("[FX] SourceCore initialized")
+	return c
+}
+
+// NewImportCore creates YouTube playlist and channel imports
+func NewImportCore(st *store.PostgresStore, lc *core.LearningCore, quotas *quota.Enforcer) *core.ImportCore {
+	c := core.NewImportCore(st, lc, quotas)
+	log.Printf("[FX] ImportCore initialized")
+	return c
+}
+
 // FeedCoreParams groups dependencies for FeedCore
 type FeedCoreParams struct {
 	fx.In
@@ -251,8 +374,8 @@ func NewFeedCore(p FeedCoreParams) *core.FeedCore {
 }
 
 // NewLearningService creates learning gRPC service
-func NewLearningService(c *core.LearningCore, st *store.PostgresStore) *service.LearningService {
-	svc := service.NewLearningService(c, st)
+func NewLearningService(c *core.LearningCore, sources *core.SourceCore, imports *core.ImportCore, st *store.PostgresStore) *service.LearningService {
+	svc := service.NewLearningService(c, sources, imports, st)
 	log.Printf("[FX] LearningService initialized")
 	return svc
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/amityadav/landr/internal/core"
 	"github.com/amityadav/landr/internal/middleware"
 	"github.com/amityadav/landr/internal/notifications"
+	"github.com/amityadav/landr/internal/promptregistry"
 	"github.com/amityadav/landr/internal/quota"
 	"github.com/amityadav/landr/internal/server"
 	"github.com/amityadav/landr/internal/service"
@@ -32,19 +33,30 @@ var ServerModule = fx.Module("server",
 		RegisterGRPCServices,
 		StartServers,
 		StartNotificationWorker,
+		StartSourcePoller,
+		StartEmbeddingBackfill,
 	),
 )
 
+// maxRequestSize bounds one gRPC request
+const maxRequestSize = 64 << 20
+
 // NewGRPCServer creates configured gRPC server with auth interceptor
-func NewGRPCServer(tm *token.Manager, s *store.PostgresStore, settingsSvc *settings.Service) *grpc.Server {
+func NewGRPCServer(tm *token.Manager, s *store.PostgresStore, quotas *quota.Enforcer) *grpc.Server {
 	authInterceptor := middleware.NewAuthInterceptor(tm, s)
-	quotaInterceptor := quota.NewInterceptor(s, settingsSvc)
+	quotaInterceptor := quota.NewInterceptor(quotas)
 
 	srv := grpc.NewServer(
+		// Above the 4 MB default: IMAGE imports carry several photos, LINK imports whole pages
+		grpc.MaxRecvMsgSize(maxRequestSize),
 		grpc.ChainUnaryInterceptor(
 			authInterceptor.Unary(),
 			quotaInterceptor.Unary(),
 		),
+		grpc.ChainStreamInterceptor(
+			authInterceptor.Stream(),
+			quotaInterceptor.Stream(),
+		),
 	)
 	reflection.Register(srv)
 	log.Printf("[FX] gRPC Server created")
@@ -86,6 +98,7 @@ type ServerParams struct {
 	Store           *store.PostgresStore
 	AuthService     *service.AuthService
 	LearningService *service.LearningService
+	LearningCore    *core.LearningCore
 	FeedService     *service.FeedService    `optional:"true"`
 	PaymentService  *service.PaymentService `optional:"true"`
 	FeedCore        *core.FeedCore          `optional:"true"`
@@ -93,6 +106,7 @@ type ServerParams struct {
 	TokenManager    *token.Manager
 	Config          config.Config
 	SettingsService *settings.Service
+	PromptRegistry  *promptregistry.Registry
 }
 
 // StartServers starts gRPC and HTTP servers with lifecycle management
@@ -121,12 +135,14 @@ func StartServers(p ServerParams) {
 				Store:           p.Store,
 				AuthService:     p.AuthService,
 				LearningService: p.LearningService,
+				LearningCore:    p.LearningCore,
 				FeedService:     p.FeedService,
 				PaymentService:  p.PaymentService,
 				FeedCore:        p.FeedCore,
 				NotifWorker:     p.NotifWorker,
 				TokenManager:    p.TokenManager,
 				SettingsService: p.SettingsService,
+				PromptRegistry:  p.PromptRegistry,
 			}
 			restHandler := server.CreateRESTHandler(serverServices, p.Config)
 			combinedHandler := server.CreateCombinedHandler(httpHandler, restHandler)
@@ -174,3 +190,45 @@ func StartNotificationWorker(p WorkerStartParams) {
 		},
 	})
 }
+
+// StartSourcePoller starts polling subscribed feeds and pages, and checking material pages for changes
+func StartSourcePoller(lc fx.Lifecycle, poller *notifications.SourcePoller) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			poller.Start()
+			log.Printf("[FX] SourcePoller started")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			poller.Stop()
+			return nil
+		},
+	})
+}
+
+// EmbeddingBackfillParams for optional indexer injection
+type EmbeddingBackfillParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Indexer   *core.EmbeddingIndexer `optional:"true"`
+}
+
+// StartEmbeddingBackfill embeds existing materials and flashcards in the background
+func StartEmbeddingBackfill(p EmbeddingBackfillParams) {
+	if p.Indexer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go p.Indexer.Backfill(ctx)
+			log.Printf("[FX] Embedding backfill started")
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
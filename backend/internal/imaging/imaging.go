@@ -0,0 +1,289 @@
+// Package imaging validates uploaded images and shrinks them before they are
+// sent to a vision model. Phone photos are often 4000px wide and several MB;
+// OCR needs far less, and the smaller request is faster and cheaper.
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	_ "image/png" // Registers the PNG decoder
+	"net/http"
+	"strings"
+)
+
+const (
+	// MaxImageBytes caps one uploaded image
+	MaxImageBytes = 15 << 20
+	// maxPixels guards against decompression bombs: small files declaring huge images
+	maxPixels = 50_000_000
+	// MaxDimension is the longest side of the image sent to the vision model
+	MaxDimension = 2048
+	// passthroughBytes is the size up to which an image that needs no resizing
+	// or rotation is sent as uploaded
+	passthroughBytes = 2 << 20
+	jpegQuality      = 85
+)
+
+var (
+	ErrInvalidImage     = errors.New("invalid image data")
+	ErrUnsupportedImage = errors.New("unsupported image format, use JPEG, PNG, GIF or WebP")
+	ErrImageTooLarge    = fmt.Errorf("image is too large (at most %d MB and %d megapixels)", MaxImageBytes>>20, maxPixels/1_000_000)
+)
+
+// Prepare validates a base64 image, optionally given as a data URL, and
+// returns it as a data URL for the vision model. JPEG, PNG and GIF images
+// are downscaled to MaxDimension, turned upright according to their EXIF
+// orientation and re-encoded as JPEG; small upright images are kept as is.
+// WebP can't be decoded here, so it's only size-checked.
+func Prepare(encoded string) (string, error) {
+	data, err := decodeBase64(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	mimeType := http.DetectContentType(data)
+	switch mimeType {
+	case "image/jpeg", "image/png", "image/gif":
+	case "image/webp":
+		if len(data) > passthroughBytes {
+			return "", fmt.Errorf("%w: WebP images can't be resized, send at most %d MB or use JPEG", ErrImageTooLarge, passthroughBytes>>20)
+		}
+		return dataURL(mimeType, data), nil
+	default:
+		return "", ErrUnsupportedImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return "", ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return "", ErrImageTooLarge
+	}
+
+	orientation := 1
+	if mimeType == "image/jpeg" {
+		orientation = jpegOrientation(data)
+	}
+	if max(cfg.Width, cfg.Height) <= MaxDimension && orientation == 1 && len(data) <= passthroughBytes {
+		return dataURL(mimeType, data), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	w, h := fitWithin(cfg.Width, cfg.Height, MaxDimension)
+	out := orient(downscale(img, w, h), orientation)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, out, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return "", fmt.Errorf("failed to encode image: %w", err)
+	}
+	return dataURL("image/jpeg", buf.Bytes()), nil
+}
+
+// decodeBase64 decodes standard base64 with or without padding, optionally
+// wrapped in a data URL, checking the size before decoding
+func decodeBase64(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if rest, ok := strings.CutPrefix(encoded, "data:"); ok {
+		meta, payload, found := strings.Cut(rest, ",")
+		if !found || !strings.HasSuffix(meta, ";base64") {
+			return nil, ErrInvalidImage
+		}
+		encoded = payload
+	}
+	encoded = strings.Join(strings.Fields(encoded), "")
+	if encoded == "" {
+		return nil, ErrInvalidImage
+	}
+	if base64.StdEncoding.DecodedLen(len(encoded)) > MaxImageBytes+2 {
+		return nil, ErrImageTooLarge
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		if data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "=")); err != nil {
+			return nil, fmt.Errorf("%w: not base64", ErrInvalidImage)
+		}
+	}
+	if len(data) > MaxImageBytes {
+		return nil, ErrImageTooLarge
+	}
+	return data, nil
+}
+
+func dataURL(mimeType string, data []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// fitWithin scales w×h down so its longer side is at most limit
+func fitWithin(w, h, limit int) (int, int) {
+	if w <= limit && h <= limit {
+		return w, h
+	}
+	if w >= h {
+		return limit, max(1, h*limit/w)
+	}
+	return max(1, w*limit/h), limit
+}
+
+// downscale resizes src to w×h (no larger than src) by averaging the source
+// pixels that fall into each target pixel. Transparent areas become white.
+// Source rows are read one at a time, so memory stays at the size of the result.
+func downscale(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	ycc, isYCbCr := src.(*image.YCbCr)
+
+	// Per target pixel of the current target row: sums of r, g, b and the pixel count
+	acc := make([]uint64, w*4)
+	flush := func(dy int) {
+		for dx := 0; dx < w; dx++ {
+			a := acc[dx*4 : dx*4+4]
+			if a[3] > 0 {
+				i := dst.PixOffset(dx, dy)
+				dst.Pix[i] = uint8(a[0] / a[3])
+				dst.Pix[i+1] = uint8(a[1] / a[3])
+				dst.Pix[i+2] = uint8(a[2] / a[3])
+				dst.Pix[i+3] = 0xff
+			}
+			a[0], a[1], a[2], a[3] = 0, 0, 0, 0
+		}
+	}
+
+	dy := 0
+	for sy := 0; sy < sh; sy++ {
+		if ty := sy * h / sh; ty != dy {
+			flush(dy)
+			dy = ty
+		}
+		for sx := 0; sx < sw; sx++ {
+			var r, g, bl uint8
+			if isYCbCr {
+				c := ycc.YCbCrAt(b.Min.X+sx, b.Min.Y+sy)
+				r, g, bl = color.YCbCrToRGB(c.Y, c.Cb, c.Cr)
+			} else {
+				pr, pg, pb, pa := src.At(b.Min.X+sx, b.Min.Y+sy).RGBA()
+				// Composite the premultiplied color over white
+				r, g, bl = uint8((pr+0xffff-pa)>>8), uint8((pg+0xffff-pa)>>8), uint8((pb+0xffff-pa)>>8)
+			}
+			a := acc[(sx*w/sw)*4:]
+			a[0] += uint64(r)
+			a[1] += uint64(g)
+			a[2] += uint64(bl)
+			a[3]++
+		}
+	}
+	flush(dy)
+	return dst
+}
+
+// orient turns an image upright according to its EXIF orientation (1-8)
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Mirrored
+				dx, dy = w-1-x, y
+			case 3: // Upside down
+				dx, dy = w-1-x, h-1-y
+			case 4: // Mirrored upside down
+				dx, dy = x, h-1-y
+			case 5: // Transposed
+				dx, dy = y, x
+			case 6: // Rotated 90° counterclockwise: turn clockwise
+				dx, dy = h-1-y, x
+			case 7: // Transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // Rotated 90° clockwise: turn counterclockwise
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):][:4], src.Pix[src.PixOffset(x, y):][:4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation reads the EXIF orientation of a JPEG, 1 (upright) when it has none
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xda || marker == 0xd9 { // Image data starts: no EXIF before it
+			return 1
+		}
+		size := int(data[i+2])<<8 | int(data[i+3])
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+size]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag (0x0112) of IFD0 from a TIFF structure
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var u16 func([]byte) int
+	var u32 func([]byte) int
+	switch string(tiff[:2]) {
+	case "II":
+		u16 = func(b []byte) int { return int(b[0]) | int(b[1])<<8 }
+		u32 = func(b []byte) int { return u16(b) | u16(b[2:])<<16 }
+	case "MM":
+		u16 = func(b []byte) int { return int(b[0])<<8 | int(b[1]) }
+		u32 = func(b []byte) int { return u16(b)<<16 | u16(b[2:]) }
+	default:
+		return 1
+	}
+	ifd := u32(tiff[4:])
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := u16(tiff[ifd:])
+	for n := 0; n < entries; n++ {
+		e := ifd + 2 + n*12
+		if e+12 > len(tiff) {
+			return 1
+		}
+		if u16(tiff[e:]) == 0x0112 {
+			if o := u16(tiff[e+8:]); o >= 1 && o <= 8 {
+				return o
+			}
+			return 1
+		}
+	}
+	return 1
+}
@@ -0,0 +1,135 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// halves is a w×h image whose left half is red and right half is blue
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// withOrientation inserts an EXIF segment with the orientation tag after the JPEG SOI marker
+func withOrientation(jpg []byte, orientation byte) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, // Big endian, IFD0 at 8
+		0, 1, // One entry
+		0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, orientation, 0, 0, // Orientation, SHORT, count 1
+		0, 0, 0, 0} // No next IFD
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	size := len(payload) + 2
+	segment := append([]byte{0xff, 0xe1, byte(size >> 8), byte(size)}, payload...)
+	return append(append(append([]byte{}, jpg[:2]...), segment...), jpg[2:]...)
+}
+
+func decodeDataURL(t *testing.T, url string) (string, image.Image) {
+	t.Helper()
+	meta, payload, _ := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSuffix(meta, ";base64"), img
+}
+
+func TestPrepareDownscalesAndRotates(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, halves(4000, 1000), &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	jpg := withOrientation(buf.Bytes(), 6)
+	if got := jpegOrientation(jpg); got != 6 {
+		t.Fatalf("jpegOrientation = %d, want 6", got)
+	}
+
+	url, err := Prepare(base64.StdEncoding.EncodeToString(jpg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mimeType, img := decodeDataURL(t, url)
+	if mimeType != "image/jpeg" || img.Bounds().Dx() != 512 || img.Bounds().Dy() != 2048 {
+		t.Fatalf("got %s %v, want image/jpeg 512x2048", mimeType, img.Bounds())
+	}
+	// Turned clockwise: the left (red) half is now on top
+	if r, _, b, _ := img.At(256, 100).RGBA(); r < 0xc000 || b > 0x4000 {
+		t.Errorf("top should be red, got r=%x b=%x", r, b)
+	}
+	if r, _, b, _ := img.At(256, 1900).RGBA(); b < 0xc000 || r > 0x4000 {
+		t.Errorf("bottom should be blue, got r=%x b=%x", r, b)
+	}
+}
+
+func TestPrepareKeepsSmallImages(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, halves(300, 200)); err != nil {
+		t.Fatal(err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+	url, err := Prepare("data:image/png;base64," + encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "data:image/png;base64,"+encoded {
+		t.Errorf("small upright PNG should be kept as is")
+	}
+}
+
+func TestPrepareRejects(t *testing.T) {
+	// A GIF header declaring a 60000×60000 image
+	bomb := []byte("GIF89a\x60\xea\x60\xea\x00\x00\x00;")
+	tests := []struct {
+		name    string
+		encoded string
+		want    error
+	}{
+		{"empty", "  ", ErrInvalidImage},
+		{"not base64", "!!!not base64!!!", ErrInvalidImage},
+		{"text", base64.StdEncoding.EncodeToString([]byte("just some text, not an image")), ErrUnsupportedImage},
+		{"data url without base64", "data:image/png,abc", ErrInvalidImage},
+		{"decompression bomb", base64.StdEncoding.EncodeToString(bomb), ErrImageTooLarge},
+		{"too many bytes", strings.Repeat("A", MaxImageBytes/3*4+100), ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		if _, err := Prepare(tt.encoded); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestDownscaleAveragesAndFlattensAlpha(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	src.SetNRGBA(0, 0, color.NRGBA{A: 255})                         // Black
+	src.SetNRGBA(1, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 255}) // White
+	src.SetNRGBA(0, 1, color.NRGBA{A: 255})
+	src.SetNRGBA(1, 1, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	// The right half stays fully transparent
+
+	got := downscale(src, 2, 1)
+	if c := got.RGBAAt(0, 0); c.R < 126 || c.R > 128 {
+		t.Errorf("left pixel = %v, want mid grey", c)
+	}
+	if c := got.RGBAAt(1, 0); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("transparent pixel = %v, want white", c)
+	}
+}
@@ -42,52 +42,85 @@ func (interceptor *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// Check if this method requires authentication
-		if interceptor.publicMethods[info.FullMethod] {
-			return handler(ctx, req)
+		ctx, err := interceptor.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		// Extract token from metadata
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+// Stream returns a server interceptor function to authenticate and authorize streaming RPC
+func (interceptor *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := interceptor.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
 		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		values := md["authorization"]
-		if len(values) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
-		}
+// authenticatedStream overrides the stream context so handlers can read the user ID
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-		// Token format: "Bearer <token>"
-		accessToken := values[0]
-		if !strings.HasPrefix(accessToken, "Bearer ") {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid authorization format")
-		}
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
 
-		accessToken = strings.TrimPrefix(accessToken, "Bearer ")
+// authorize verifies the JWT for non-public methods and returns a context carrying the user ID
+func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	// Check if this method requires authentication
+	if interceptor.publicMethods[method] {
+		return ctx, nil
+	}
 
-		// Verify token and extract user ID
-		userID, err := interceptor.tokenManager.Verify(accessToken)
-		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
-		}
+	// Extract token from metadata
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+	}
 
-		// Check if user is blocked
-		user, err := interceptor.store.GetUserByID(ctx, userID)
-		if err != nil {
-			// If user not found, they shouldn't access
-			return nil, status.Errorf(codes.Unauthenticated, "user not found")
-		}
+	values := md["authorization"]
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+	}
 
-		if user.IsBlocked {
-			return nil, status.Errorf(codes.PermissionDenied, "account blocked")
-		}
+	// Token format: "Bearer <token>"
+	accessToken := values[0]
+	if !strings.HasPrefix(accessToken, "Bearer ") {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid authorization format")
+	}
 
-		// Add user ID to context
-		ctx = context.WithValue(ctx, UserIDKey, userID)
+	accessToken = strings.TrimPrefix(accessToken, "Bearer ")
 
-		return handler(ctx, req)
+	// Verify token and extract user ID
+	userID, err := interceptor.tokenManager.Verify(accessToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	// Check if user is blocked
+	user, err := interceptor.store.GetUserByID(ctx, userID)
+	if err != nil {
+		// If user not found, they shouldn't access
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
 	}
+
+	if user.IsBlocked {
+		return nil, status.Errorf(codes.PermissionDenied, "account blocked")
+	}
+
+	// Add user ID to context
+	return context.WithValue(ctx, UserIDKey, userID), nil
 }
 
 // GetUserID extracts the user ID from context
@@ -0,0 +1,82 @@
+package notifications
+
+import (
+	"context"
+	"log"
+
+	"github.com/amityadav/landr/internal/core"
+	"github.com/robfig/cron/v3"
+)
+
+// SourcePoller imports new entries of subscribed feeds and pages, resumes
+// playlist and channel imports, checks the pages of LINK materials for changes,
+// suggests tag merges and downgrades lapsed organizations. Unlike the
+// notification Worker it doesn't need Firebase, so it runs on its own schedule.
+type SourcePoller struct {
+	sources  *core.SourceCore
+	learning *core.LearningCore
+	imports  *core.ImportCore
+	cron     *cron.Cron
+}
+
+// NewSourcePoller creates the subscription poller
+func NewSourcePoller(sources *core.SourceCore, learning *core.LearningCore, imports *core.ImportCore) *SourcePoller {
+	return &SourcePoller{
+		sources:  sources,
+		learning: learning,
+		imports:  imports,
+		cron:     cron.New(cron.WithLocation(core.AppLocation)),
+	}
+}
+
+// Start checks for due sources every 15 minutes (each source is polled hourly),
+// for imports to resume (after a restart or once quota is back) every 5
+// minutes, and for changed material pages daily (each page is re-fetched
+// weekly). Expired organizations are downgraded daily at 5:50 AM and tag merge
+// suggestions are computed weekly on Sunday at 4 AM; all times are IST.
+func (p *SourcePoller) Start() {
+	// Run async to not block the scheduler; overlapping runs return at once
+	_, err := p.cron.AddFunc("*/15 * * * *", func() {
+		go p.sources.PollDueSources(context.Background())
+	})
+	if err != nil {
+		log.Printf("[SourcePoller] Failed to schedule source polling: %v", err)
+		return
+	}
+	_, err = p.cron.AddFunc("*/5 * * * *", func() {
+		go p.imports.ProcessImports(context.Background())
+	})
+	if err != nil {
+		log.Printf("[SourcePoller] Failed to schedule imports: %v", err)
+		return
+	}
+	_, err = p.cron.AddFunc("30 3 * * *", func() {
+		go p.learning.CheckMaterialChanges(context.Background())
+	})
+	if err != nil {
+		log.Printf("[SourcePoller] Failed to schedule material change checks: %v", err)
+		return
+	}
+	_, err = p.cron.AddFunc("0 4 * * 0", func() {
+		go p.learning.SuggestTagMergesForAllUsers(context.Background())
+	})
+	if err != nil {
+		log.Printf("[SourcePoller] Failed to schedule tag merge suggestions: %v", err)
+		return
+	}
+	_, err = p.cron.AddFunc("50 5 * * *", func() {
+		go p.learning.DowngradeExpiredOrganizations(context.Background())
+	})
+	if err != nil {
+		log.Printf("[SourcePoller] Failed to schedule organization downgrades: %v", err)
+		return
+	}
+	p.cron.Start()
+	log.Println("[SourcePoller] Scheduled source polling every 15 minutes, imports every 5 minutes, material change checks daily at 03:30, organization downgrades daily at 05:50, tag merge suggestions Sundays at 04:00")
+}
+
+// Stop stops the poller
+func (p *SourcePoller) Stop() {
+	p.cron.Stop()
+	log.Println("[SourcePoller] Stopped")
+}
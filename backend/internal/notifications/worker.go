@@ -29,7 +29,7 @@ func NewWorker(store *store.PostgresStore, learningCore *core.LearningCore, fcm
 		store:        store,
 		learningCore: learningCore,
 		fcm:          fcm,
-		cron:         cron.New(cron.WithLocation(time.FixedZone("IST", 5*60*60+30*60))), // IST timezone
+		cron:         cron.New(cron.WithLocation(core.AppLocation)),
 	}
 }
 
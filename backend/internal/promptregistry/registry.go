@@ -0,0 +1,155 @@
+package promptregistry
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/prompts"
+)
+
+// BuiltinVariant labels prompts served from the embedded .txt files
+const BuiltinVariant = "builtin"
+
+// cacheTTL bounds how stale the active template cache can get between refreshes
+const cacheTTL = 5 * time.Minute
+
+// Store defines the interface for prompt template persistence
+type Store interface {
+	GetActivePromptTemplates(ctx context.Context) ([]*store.PromptTemplate, error)
+	GetUserPromptOverride(ctx context.Context, userID, name string) (*store.PromptTemplate, error)
+}
+
+// Rendered is a prompt ready to send, with the template version that produced it
+type Rendered struct {
+	Text       string
+	TemplateID string // Empty for the built-in prompt
+	Version    int    // 0 for the built-in prompt
+	Variant    string
+}
+
+// Registry serves versioned prompt templates from the DB with the embedded
+// prompts as fallback. Users are assigned to A/B variants by a stable hash,
+// unless pinned to a specific version by an override.
+type Registry struct {
+	store    Store
+	active   map[string][]*store.PromptTemplate // name -> active versions
+	loadedAt time.Time
+	mu       sync.RWMutex
+}
+
+// NewRegistry creates a registry and loads active templates from DB
+func NewRegistry(ctx context.Context, s Store) *Registry {
+	r := &Registry{store: s, active: map[string][]*store.PromptTemplate{}}
+	if err := r.Refresh(ctx); err != nil {
+		log.Printf("[PromptRegistry] Warning: Failed to load from DB, using built-in prompts: %v", err)
+	}
+	return r
+}
+
+// Refresh reloads active templates from the database
+func (r *Registry) Refresh(ctx context.Context) error {
+	templates, err := r.store.GetActivePromptTemplates(ctx)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[string][]*store.PromptTemplate)
+	for _, t := range templates {
+		active[t.Name] = append(active[t.Name], t)
+	}
+
+	r.mu.Lock()
+	r.active = active
+	r.loadedAt = time.Now()
+	r.mu.Unlock()
+
+	log.Printf("[PromptRegistry] Loaded %d active templates across %d prompts", len(templates), len(active))
+	return nil
+}
+
+// Render picks the template version for this user and renders it with data.
+// Falls back to the built-in prompt if no version is active or rendering fails.
+func (r *Registry) Render(ctx context.Context, name, userID string, data interface{}) (*Rendered, error) {
+	if t := r.selectTemplate(ctx, name, userID); t != nil {
+		text, err := prompts.Render(t.Body, data)
+		if err == nil {
+			return &Rendered{Text: text, TemplateID: t.ID, Version: t.Version, Variant: t.Variant}, nil
+		}
+		log.Printf("[PromptRegistry] Template %s v%d failed to render, using built-in: %v", name, t.Version, err)
+	}
+
+	body, _, ok := prompts.Builtin(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown prompt '%s'", name)
+	}
+	text, err := prompts.Render(body, data)
+	if err != nil {
+		return nil, err
+	}
+	return &Rendered{Text: text, Variant: BuiltinVariant}, nil
+}
+
+// selectTemplate returns the user's override, else their A/B assignment, else nil
+func (r *Registry) selectTemplate(ctx context.Context, name, userID string) *store.PromptTemplate {
+	if userID != "" {
+		override, err := r.store.GetUserPromptOverride(ctx, userID, name)
+		if err != nil {
+			log.Printf("[PromptRegistry] Failed to get override for %s: %v", name, err)
+		} else if override != nil {
+			return override
+		}
+	}
+
+	r.refreshIfStale(ctx)
+
+	r.mu.RLock()
+	candidates := r.active[name]
+	r.mu.RUnlock()
+
+	return assign(candidates, userID, name)
+}
+
+func (r *Registry) refreshIfStale(ctx context.Context) {
+	r.mu.RLock()
+	stale := time.Since(r.loadedAt) > cacheTTL
+	r.mu.RUnlock()
+
+	if stale {
+		if err := r.Refresh(ctx); err != nil {
+			log.Printf("[PromptRegistry] Refresh failed, keeping cached templates: %v", err)
+			// Avoid hammering the DB on every render while it is unavailable
+			r.mu.Lock()
+			r.loadedAt = time.Now()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// assign deterministically maps a user to one of the weighted candidates,
+// so a user keeps seeing the same variant while the weights are unchanged
+func assign(candidates []*store.PromptTemplate, userID, name string) *store.PromptTemplate {
+	total := 0
+	for _, t := range candidates {
+		total += t.Weight
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(userID + ":" + name))
+	bucket := int(h.Sum32() % uint32(total))
+
+	for _, t := range candidates {
+		if bucket < t.Weight {
+			return t
+		}
+		bucket -= t.Weight
+	}
+	return candidates[len(candidates)-1]
+}
@@ -0,0 +1,104 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/amityadav/landr/internal/settings"
+	"github.com/amityadav/landr/internal/store"
+)
+
+// Enforcer checks and counts the daily quotas of users. Members of a Pro
+// organization share a pool of the per-seat Pro limit times the organization's seats.
+type Enforcer struct {
+	store    store.Store
+	settings *settings.Service
+}
+
+func NewEnforcer(s store.Store, settingsSvc *settings.Service) *Enforcer {
+	return &Enforcer{store: s, settings: settingsSvc}
+}
+
+// Reservation is an allowed use of a resource, counted by Commit once it succeeded
+type Reservation struct {
+	userID   string
+	orgID    string
+	resource string
+}
+
+// ExceededError is returned by Reserve when the daily limit is reached
+type ExceededError struct {
+	Resource     string
+	Limit        int
+	Organization bool // The limit is the organization's shared pool
+}
+
+func (e *ExceededError) Error() string {
+	if e.Organization {
+		return fmt.Sprintf("Your organization has reached its daily limit of %d %s. Add seats for more!",
+			e.Limit, ResourceDisplayName(e.Resource))
+	}
+	return fmt.Sprintf("You've reached your daily limit of %d %s. Upgrade to Pro for more!",
+		e.Limit, ResourceDisplayName(e.Resource))
+}
+
+// Reserve checks that the user may use resource once more today, returning
+// an *ExceededError when not
+func (e *Enforcer) Reserve(ctx context.Context, userID, resource string) (*Reservation, error) {
+	sub, err := e.store.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	limit := e.limit(sub.Plan, resource)
+	var allowed bool
+	if sub.OrganizationID != "" {
+		limit *= int(sub.Seats)
+		allowed, err = e.store.CheckOrganizationQuota(ctx, sub.OrganizationID, resource, limit)
+	} else {
+		allowed, err = e.store.CheckQuota(ctx, userID, resource, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check quota: %w", err)
+	}
+	if !allowed {
+		return nil, &ExceededError{Resource: resource, Limit: limit, Organization: sub.OrganizationID != ""}
+	}
+	return &Reservation{userID: userID, orgID: sub.OrganizationID, resource: resource}, nil
+}
+
+// Commit counts a reserved use
+func (e *Enforcer) Commit(ctx context.Context, r *Reservation) {
+	var err error
+	if r.orgID != "" {
+		err = e.store.IncrementOrganizationQuota(ctx, r.orgID, r.resource)
+	} else {
+		err = e.store.IncrementQuota(ctx, r.userID, r.resource)
+	}
+	if err != nil {
+		log.Printf("Failed to increment quota for user %s: %v", r.userID, err)
+	}
+}
+
+func (e *Enforcer) limit(plan store.SubscriptionPlan, resource string) int {
+	planStr := "free"
+	if plan == store.PlanPro {
+		planStr = "pro"
+	}
+	return e.settings.GetQuotaLimit(planStr, resource)
+}
+
+// ResourceForType returns the resource a material import of the given type uses
+func ResourceForType(matType string) string {
+	switch matType {
+	case "LINK":
+		return ResourceLinkImport
+	case "IMAGE":
+		return ResourceImageImport
+	case "YOUTUBE":
+		return ResourceYoutubeImport
+	default:
+		return ResourceTextImport
+	}
+}
@@ -2,26 +2,40 @@ package quota
 
 import (
 	"context"
+	"errors"
 	"log"
+	"time"
 
 	"github.com/amityadav/landr/internal/middleware"
-	"github.com/amityadav/landr/internal/settings"
-	"github.com/amityadav/landr/internal/store"
 	"github.com/amityadav/landr/pkg/pb/learning"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// Streamed LLM calls (summaries, library answers) aren't daily quota
+// resources; each user may start at most streamRateLimit of them per
+// streamRateWindow
+const (
+	streamRateLimit  = 20
+	streamRateWindow = 10 * time.Minute
+)
+
+// rateLimitedStreams are the streaming methods that make LLM calls
+var rateLimitedStreams = map[string]bool{
+	"/learning.LearningService/StreamMaterialSummary": true,
+	"/learning.LearningService/AskLibrary":            true,
+}
+
 type Interceptor struct {
-	store    store.Store
-	settings *settings.Service
+	enforcer *Enforcer
+	streams  *RateLimiter
 }
 
-func NewInterceptor(s store.Store, settingsSvc *settings.Service) *Interceptor {
+func NewInterceptor(enforcer *Enforcer) *Interceptor {
 	return &Interceptor{
-		store:    s,
-		settings: settingsSvc,
+		enforcer: enforcer,
+		streams:  NewRateLimiter(streamRateLimit, streamRateWindow),
 	}
 }
 
@@ -45,64 +59,76 @@ func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
 			return nil, status.Error(codes.Unauthenticated, "user not authenticated")
 		}
 
-		// 3. Get User's Subscription
-		sub, err := i.store.GetSubscription(ctx, userID)
-		if err != nil {
-			log.Printf("Failed to get subscription for user %s: %v", userID, err)
-			return nil, status.Error(codes.Internal, "failed to check subscription status")
+		// 3. Check Quota
+		reservation, err := i.enforcer.Reserve(ctx, userID, resource)
+		var exceeded *ExceededError
+		if errors.As(err, &exceeded) {
+			return nil, status.Error(codes.ResourceExhausted, exceeded.Error())
 		}
-
-		// 4. Check Quota
-		limit := i.getLimit(sub.Plan, resource)
-		allowed, err := i.store.CheckQuota(ctx, userID, resource, limit)
 		if err != nil {
 			log.Printf("Failed to check quota for user %s: %v", userID, err)
 			return nil, status.Error(codes.Internal, "failed to check quota")
 		}
 
-		if !allowed {
-			return nil, status.Errorf(codes.ResourceExhausted,
-				"You've reached your daily limit of %d %s. Upgrade to Pro for more!",
-				limit, ResourceDisplayName(resource))
-		}
-
-		// 5. Execute Handler
+		// 4. Execute Handler
 		resp, err := handler(ctx, req)
 
-		// 6. If successful, increment quota
-		if err == nil {
-			if incErr := i.store.IncrementQuota(ctx, userID, resource); incErr != nil {
-				log.Printf("Failed to increment quota for user %s: %v", userID, incErr)
-			}
+		// 5. If successful, increment quota; an import that found an existing
+		// material created nothing and isn't charged
+		if err == nil && !isDuplicateImport(resp) {
+			i.enforcer.Commit(ctx, reservation)
 		}
 
 		return resp, err
 	}
 }
 
-func (i *Interceptor) getResourceForRequest(method string, req interface{}) string {
-	// Only checking AddMaterial for now
-	if method == "/learning.LearningService/AddMaterial" {
-		if r, ok := req.(*learning.AddMaterialRequest); ok {
-			switch r.Type {
-			case "LINK":
-				return ResourceLinkImport
-			case "IMAGE":
-				return ResourceImageImport
-			case "YOUTUBE":
-				return ResourceYoutubeImport
-			default:
-				return ResourceTextImport
-			}
+// Stream rate limits the streaming methods that make LLM calls per user
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if !rateLimitedStreams[info.FullMethod] {
+			return handler(srv, ss)
 		}
+
+		userID, err := middleware.GetUserID(ss.Context())
+		if err != nil {
+			return status.Error(codes.Unauthenticated, "user not authenticated")
+		}
+		if !i.streams.Allow(userID + info.FullMethod) {
+			log.Printf("Rate limited %s for user %s", info.FullMethod, userID)
+			return status.Error(codes.ResourceExhausted, "Too many requests, please try again in a few minutes")
+		}
+		return handler(srv, ss)
 	}
-	return ""
 }
 
-func (i *Interceptor) getLimit(plan store.SubscriptionPlan, resource string) int {
-	planStr := "free"
-	if plan == store.PlanPro {
-		planStr = "pro"
+// isDuplicateImport reports whether resp answers an import with an existing material
+func isDuplicateImport(resp interface{}) bool {
+	r, ok := resp.(*learning.AddMaterialResponse)
+	return ok && r.DuplicateOf != nil
+}
+
+func (i *Interceptor) getResourceForRequest(method string, req interface{}) string {
+	switch method {
+	case "/learning.LearningService/CreateFlashcardsFromAnswer":
+		// Answers saved as flashcards count as text imports
+		return ResourceTextImport
+	case "/learning.LearningService/RefreshMaterial":
+		// Only LINK materials can be refreshed; it re-fetches the page
+		return ResourceForType("LINK")
+	case "/learning.LearningService/AddMaterial":
+		if r, ok := req.(*learning.AddMaterialRequest); ok {
+			return ResourceForType(r.Type)
+		}
+	case "/learning.LearningService/MergeIntoMaterial":
+		if r, ok := req.(*learning.MergeIntoMaterialRequest); ok {
+			return ResourceForType(r.Type)
+		}
 	}
-	return i.settings.GetQuotaLimit(planStr, resource)
+	return ""
 }
@@ -0,0 +1,58 @@
+package quota
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter bounds how often one user may call a method within a window.
+// It guards calls that aren't counted against the daily quotas but still cost
+// LLM tokens, such as streamed summaries and answers.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	windows map[string]rateWindow
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{limit: limit, window: window, now: time.Now, windows: make(map[string]rateWindow)}
+}
+
+// Allow counts a call of key (a user and method) and reports whether it is
+// within the limit of the current window
+func (l *RateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		if !ok && len(l.windows) >= 10000 {
+			l.evict(now)
+		}
+		w = rateWindow{start: now}
+	}
+	if w.count >= l.limit {
+		return false
+	}
+	w.count++
+	l.windows[key] = w
+	return true
+}
+
+// evict drops the windows that have ended
+func (l *RateLimiter) evict(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
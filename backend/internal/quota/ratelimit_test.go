@@ -0,0 +1,26 @@
+package quota
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	for i, want := range []bool{true, true, false} {
+		if got := l.Allow("u1"); got != want {
+			t.Errorf("call %d: Allow = %v, want %v", i+1, got, want)
+		}
+	}
+	if !l.Allow("u2") {
+		t.Errorf("another key should have its own window")
+	}
+
+	now = now.Add(time.Minute)
+	if !l.Allow("u1") {
+		t.Errorf("a new window should allow calls again")
+	}
+}
@@ -0,0 +1,143 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Extractor turns a web page into article text (Markdown where possible)
+type Extractor interface {
+	// Name returns the extractor identifier used in domain chains (e.g. "readability", "jina")
+	Name() string
+
+	// Extract fetches the page and returns its main content
+	Extract(ctx context.Context, pageURL string) (string, error)
+}
+
+// HTMLExtractor is an extractor that can also work on HTML the client already
+// has, such as a page rendered behind a login
+type HTMLExtractor interface {
+	Extractor
+
+	// ExtractHTML returns the main content of the given page HTML
+	ExtractHTML(r io.Reader) (string, error)
+}
+
+// Registry holds the registered extractors and the chain to try for each domain
+type Registry struct {
+	extractors map[string]Extractor
+	order      []string            // Registration order, the default chain
+	domains    map[string][]string // Domain -> extractor names, tried in order
+}
+
+// NewRegistry creates an empty extractor registry
+func NewRegistry() *Registry {
+	return &Registry{
+		extractors: map[string]Extractor{},
+		domains:    map[string][]string{},
+	}
+}
+
+// Register adds an extractor to the registry and to the end of the default chain
+func (r *Registry) Register(e Extractor) {
+	if _, ok := r.extractors[e.Name()]; !ok {
+		r.order = append(r.order, e.Name())
+	}
+	r.extractors[e.Name()] = e
+}
+
+// SetDomainChain sets the extractors tried for a domain and its subdomains.
+// Names that aren't registered (e.g. Supadata without an API key) are skipped.
+func (r *Registry) SetDomainChain(domain string, names ...string) error {
+	var chain []string
+	for _, name := range names {
+		if _, ok := r.extractors[name]; ok {
+			chain = append(chain, name)
+		}
+	}
+	if len(chain) == 0 {
+		return fmt.Errorf("no registered extractor in chain %v for %s", names, domain)
+	}
+	r.domains[strings.ToLower(strings.TrimPrefix(domain, "www."))] = chain
+	return nil
+}
+
+// Chain returns the extractors to try for a host, in order. The most specific
+// configured domain wins ("docs.example.com" before "example.com"); hosts
+// without one use the default chain.
+func (r *Registry) Chain(host string) []Extractor {
+	names := r.order
+	host = strings.ToLower(strings.TrimPrefix(host, "www."))
+	for domain := host; domain != ""; {
+		if chain, ok := r.domains[domain]; ok {
+			names = chain
+			break
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			break
+		}
+		domain = parent
+	}
+
+	chain := make([]Extractor, 0, len(names))
+	for _, name := range names {
+		chain = append(chain, r.extractors[name])
+	}
+	return chain
+}
+
+// HTMLChain returns the extractors of the host's chain that accept uploaded
+// HTML. Chains made only of hosted renderers (which fetch the URL themselves)
+// fall back to every such extractor in registration order.
+func (r *Registry) HTMLChain(host string) []HTMLExtractor {
+	var chain []HTMLExtractor
+	for _, e := range r.Chain(host) {
+		if he, ok := e.(HTMLExtractor); ok {
+			chain = append(chain, he)
+		}
+	}
+	if len(chain) > 0 {
+		return chain
+	}
+	for _, name := range r.order {
+		if he, ok := r.extractors[name].(HTMLExtractor); ok {
+			chain = append(chain, he)
+		}
+	}
+	return chain
+}
+
+// Count returns the number of registered extractors
+func (r *Registry) Count() int {
+	return len(r.extractors)
+}
+
+// ParseDomainChains parses "domain=name,name;domain=name" into chains
+func ParseDomainChains(spec string) (map[string][]string, error) {
+	chains := map[string][]string{}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		domain, list, ok := strings.Cut(entry, "=")
+		domain = strings.TrimSpace(domain)
+		if !ok || domain == "" {
+			return nil, fmt.Errorf("invalid domain chain '%s'", entry)
+		}
+		var names []string
+		for _, name := range strings.Split(list, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("empty extractor chain for %s", domain)
+		}
+		chains[domain] = names
+	}
+	return chains, nil
+}
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type stubExtractor string
+
+func (e stubExtractor) Name() string                                    { return string(e) }
+func (e stubExtractor) Extract(context.Context, string) (string, error) { return "", nil }
+
+func TestRegistryChain(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubExtractor("readability"))
+	r.Register(stubExtractor("jina"))
+	if err := r.SetDomainChain("example.com", "jina", "supadata"); err != nil {
+		t.Fatalf("SetDomainChain: %v", err)
+	}
+	if err := r.SetDomainChain("docs.example.com", "readability"); err != nil {
+		t.Fatalf("SetDomainChain: %v", err)
+	}
+	if err := r.SetDomainChain("x.com", "supadata"); err == nil {
+		t.Error("SetDomainChain with no registered extractor should fail")
+	}
+
+	tests := []struct {
+		host string
+		want []string
+	}{
+		{"other.org", []string{"readability", "jina"}},
+		{"example.com", []string{"jina"}},
+		{"www.Example.com", []string{"jina"}},
+		{"blog.example.com", []string{"jina"}},
+		{"docs.example.com", []string{"readability"}},
+		{"api.docs.example.com", []string{"readability"}},
+		{"notexample.com", []string{"readability", "jina"}},
+		{"x.com", []string{"readability", "jina"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, e := range r.Chain(tt.host) {
+			got = append(got, e.Name())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Chain(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestParseDomainChains(t *testing.T) {
+	got, err := ParseDomainChains(" x.com = jina, supadata ;medium.com=readability;")
+	if err != nil {
+		t.Fatalf("ParseDomainChains: %v", err)
+	}
+	want := map[string][]string{"x.com": {"jina", "supadata"}, "medium.com": {"readability"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDomainChains = %v, want %v", got, want)
+	}
+
+	for _, spec := range []string{"x.com", "=jina", "x.com= , "} {
+		if _, err := ParseDomainChains(spec); err == nil {
+			t.Errorf("ParseDomainChains(%q) should fail", spec)
+		}
+	}
+}
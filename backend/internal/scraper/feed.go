@@ -0,0 +1,259 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/amityadav/landr/internal/dedupe"
+	"golang.org/x/net/html/charset"
+)
+
+// ErrNotFeed is returned by ParseFeed for documents that aren't RSS or Atom
+var ErrNotFeed = errors.New("not an rss or atom feed")
+
+// Feed is a parsed RSS/Atom feed, or the article links of an index page
+type Feed struct {
+	Title   string
+	Entries []FeedEntry // In document order, usually newest first
+}
+
+// FeedEntry is one post of a feed
+type FeedEntry struct {
+	Title     string
+	URL       string
+	Published time.Time // Zero when the feed doesn't say
+}
+
+// xmlFeed holds the elements of RSS 0.9x/2.0, RSS 1.0 (RDF) and Atom documents
+type xmlFeed struct {
+	XMLName xml.Name
+	Title   string `xml:"title"` // Atom
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []xmlItem `xml:"item"`
+	} `xml:"channel"`
+	Items   []xmlItem `xml:"item"`  // RSS 1.0 items are siblings of the channel
+	Entries []xmlItem `xml:"entry"` // Atom
+}
+
+type xmlItem struct {
+	Title string    `xml:"title"`
+	Links []xmlLink `xml:"link"`
+	GUID  struct {
+		IsPermaLink string `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	} `xml:"guid"`
+	PubDate   string `xml:"pubDate"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Date      string `xml:"date"` // Dublin Core, used by RSS 1.0
+}
+
+// xmlLink is an RSS <link>URL</link> or an Atom <link href="URL" rel="..."/>
+type xmlLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Text string `xml:",chardata"`
+}
+
+// feedDateLayouts are the date formats found in feeds (RFC 822 variants and RFC 3339)
+var feedDateLayouts = []string{
+	time.RFC1123Z, time.RFC1123, time.RFC3339, time.RFC3339Nano,
+	"Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST", "Mon, 2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700", "2006-01-02T15:04:05", "2006-01-02",
+}
+
+// ParseFeed parses an RSS or Atom feed, resolving entry links against feedURL.
+// Entries without a link are dropped.
+func ParseFeed(feedURL string, body []byte) (*Feed, error) {
+	base, err := url.Parse(feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feed url: %w", err)
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = charset.NewReaderLabel
+	// Feeds in the wild carry HTML entities and stray markup. (No HTML auto-close:
+	// it would empty RSS <link> elements.)
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	var doc xmlFeed
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotFeed, err)
+	}
+
+	feed := &Feed{}
+	var items []xmlItem
+	switch strings.ToLower(doc.XMLName.Local) {
+	case "rss":
+		feed.Title, items = doc.Channel.Title, doc.Channel.Items
+	case "rdf":
+		feed.Title, items = doc.Channel.Title, doc.Items
+	case "feed":
+		feed.Title, items = doc.Title, doc.Entries
+	default:
+		return nil, ErrNotFeed
+	}
+	feed.Title = strings.Join(strings.Fields(feed.Title), " ")
+
+	for _, item := range items {
+		link := item.link()
+		if link == "" {
+			continue
+		}
+		ref, err := base.Parse(link)
+		if err != nil || (ref.Scheme != "http" && ref.Scheme != "https") {
+			continue
+		}
+		feed.Entries = append(feed.Entries, FeedEntry{
+			Title:     strings.Join(strings.Fields(item.Title), " "),
+			URL:       ref.String(),
+			Published: parseFeedDate(item.PubDate, item.Published, item.Date, item.Updated),
+		})
+	}
+	return feed, nil
+}
+
+// link returns the entry's page: the Atom alternate link, the RSS link, or a permalink GUID
+func (item xmlItem) link() string {
+	for _, l := range item.Links {
+		if l.Href != "" && (l.Rel == "" || l.Rel == "alternate") {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	for _, l := range item.Links {
+		if text := strings.TrimSpace(l.Text); text != "" {
+			return text
+		}
+	}
+	if guid := strings.TrimSpace(item.GUID.Value); item.GUID.IsPermaLink != "false" &&
+		(strings.HasPrefix(guid, "http://") || strings.HasPrefix(guid, "https://")) {
+		return guid
+	}
+	return ""
+}
+
+// parseFeedDate returns the first of the values that parses as a date
+func parseFeedDate(values ...string) time.Time {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		for _, layout := range feedDateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// LooksLikeFeed reports whether a response is an RSS or Atom document rather than a web page
+func LooksLikeFeed(contentType string, body []byte) bool {
+	contentType = strings.ToLower(contentType)
+	if strings.Contains(contentType, "html") {
+		return false
+	}
+	if strings.Contains(contentType, "rss") || strings.Contains(contentType, "atom") {
+		return true
+	}
+	head := strings.ToLower(string(body[:min(len(body), 1024)]))
+	return strings.Contains(head, "<rss") || strings.Contains(head, "<feed") || strings.Contains(head, "<rdf:rdf")
+}
+
+// FeedLinks returns the RSS/Atom feeds a page advertises with <link rel="alternate">
+func FeedLinks(pageURL string, body []byte) []string {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	var links []string
+	doc.Find(`link[rel~='alternate'][href]`).Each(func(_ int, s *goquery.Selection) {
+		typ := strings.ToLower(s.AttrOr("type", ""))
+		if typ != "application/rss+xml" && typ != "application/atom+xml" {
+			return
+		}
+		if ref, err := base.Parse(s.AttrOr("href", "")); err == nil {
+			links = append(links, ref.String())
+		}
+	})
+	return links
+}
+
+// nonArticlePathRe matches paths of listing, account and site pages linked from blog indexes
+var nonArticlePathRe = regexp.MustCompile(`(?i)/(tags?|categor(y|ies)|topics?|authors?|page|archives?|search|` +
+	`login|log-in|signin|sign-in|signup|sign-up|register|about|contact|privacy|terms|feed|rss|subscribe|cart|account)(/|$)`)
+
+// articleExtensions are the file extensions an article page may have (most have none)
+var articleExtensions = map[string]bool{"": true, ".html": true, ".htm": true, ".php": true, ".aspx": true}
+
+// ArticleLinks returns the links of an index page (e.g. a blog without a feed)
+// that look like articles: same site, not navigation, tag, author or account
+// pages. Links in <main> or <article> are preferred when the page has them.
+func ArticleLinks(pageURL string, body []byte) (*Feed, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page url: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	feed := &Feed{Title: strings.Join(strings.Fields(doc.Find("title").First().Text()), " ")}
+	doc.Find("nav, footer, aside, [role='navigation'], [role='banner'], [role='contentinfo']").Remove()
+	// Site headers go, but an article's own header holds its title link
+	doc.Find("header").Each(func(_ int, s *goquery.Selection) {
+		if s.Closest("article").Length() == 0 {
+			s.Remove()
+		}
+	})
+	anchors := doc.Find("main a[href], article a[href]")
+	if anchors.Length() == 0 {
+		anchors = doc.Find("body a[href]")
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(base.Hostname()), "www.")
+	indexKey := dedupe.CanonicalURL(base.String())
+	seen := map[string]bool{}
+	anchors.Each(func(_ int, s *goquery.Selection) {
+		ref, err := base.Parse(s.AttrOr("href", ""))
+		if err != nil || (ref.Scheme != "http" && ref.Scheme != "https") {
+			return
+		}
+		ref.Fragment = ""
+		if strings.TrimPrefix(strings.ToLower(ref.Hostname()), "www.") != host {
+			return
+		}
+		if p := strings.TrimSuffix(ref.Path, "/"); p == "" || nonArticlePathRe.MatchString(p) ||
+			!articleExtensions[strings.ToLower(path.Ext(p))] {
+			return
+		}
+		// Article links carry their title; "Read more" and icon links repeat one
+		title := strings.Join(strings.Fields(s.Text()), " ")
+		if len(strings.Fields(title)) < 2 && s.Closest("h1, h2, h3, h4").Length() == 0 {
+			return
+		}
+		key := dedupe.CanonicalURL(ref.String())
+		if key == indexKey || seen[key] {
+			return
+		}
+		seen[key] = true
+		feed.Entries = append(feed.Entries, FeedEntry{Title: title, URL: ref.String()})
+	})
+	return feed, nil
+}
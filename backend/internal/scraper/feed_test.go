@@ -0,0 +1,160 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+<channel>
+  <title>Systems  Notes</title>
+  <atom:link href="https://blog.example.com/feed.xml" rel="self" type="application/rss+xml"/>
+  <item>
+    <title>Raft &amp; log compaction</title>
+    <link>https://blog.example.com/raft-compaction</link>
+    <pubDate>Tue, 06 Oct 2026 09:00:00 +0000</pubDate>
+  </item>
+  <item>
+    <title>Relative link</title>
+    <link>/posts/lsm-trees</link>
+  </item>
+  <item>
+    <title>Permalink GUID only</title>
+    <guid>https://blog.example.com/bloom-filters</guid>
+  </item>
+  <item>
+    <title>Opaque GUID only</title>
+    <guid isPermaLink="false">post-1234</guid>
+  </item>
+</channel>
+</rss>`
+
+const atomFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:yt="http://www.youtube.com/xml/schemas/2015">
+  <title>Distributed Systems Channel</title>
+  <link rel="self" href="https://www.youtube.com/feeds/videos.xml?channel_id=UC123"/>
+  <entry>
+    <title>Consistent hashing</title>
+    <link rel="self" href="https://www.youtube.com/feeds/entry/1"/>
+    <link rel="alternate" href="https://www.youtube.com/watch?v=abcdefghijk"/>
+    <published>2026-10-01T12:00:00+00:00</published>
+  </entry>
+</feed>`
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name      string
+		feedURL   string
+		body      string
+		wantTitle string
+		want      []FeedEntry
+	}{
+		{
+			name:      "rss",
+			feedURL:   "https://blog.example.com/feed.xml",
+			body:      rssFeed,
+			wantTitle: "Systems Notes",
+			want: []FeedEntry{
+				{Title: "Raft & log compaction", URL: "https://blog.example.com/raft-compaction", Published: time.Date(2026, 10, 6, 9, 0, 0, 0, time.UTC)},
+				{Title: "Relative link", URL: "https://blog.example.com/posts/lsm-trees"},
+				{Title: "Permalink GUID only", URL: "https://blog.example.com/bloom-filters"},
+			},
+		},
+		{
+			name:      "atom",
+			feedURL:   "https://www.youtube.com/feeds/videos.xml?channel_id=UC123",
+			body:      atomFeed,
+			wantTitle: "Distributed Systems Channel",
+			want: []FeedEntry{
+				{Title: "Consistent hashing", URL: "https://www.youtube.com/watch?v=abcdefghijk", Published: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		feed, err := ParseFeed(tt.feedURL, []byte(tt.body))
+		if err != nil {
+			t.Fatalf("%s: ParseFeed: %v", tt.name, err)
+		}
+		if feed.Title != tt.wantTitle {
+			t.Errorf("%s: title = %q, want %q", tt.name, feed.Title, tt.wantTitle)
+		}
+		if len(feed.Entries) != len(tt.want) {
+			t.Fatalf("%s: entries = %+v, want %+v", tt.name, feed.Entries, tt.want)
+		}
+		for i, e := range feed.Entries {
+			if e.Title != tt.want[i].Title || e.URL != tt.want[i].URL || !e.Published.Equal(tt.want[i].Published) {
+				t.Errorf("%s: entry %d = %+v, want %+v", tt.name, i, e, tt.want[i])
+			}
+		}
+	}
+
+	if _, err := ParseFeed("https://example.com/", []byte("<html><body>Not a feed</body></html>")); err == nil {
+		t.Error("ParseFeed of an HTML page should fail")
+	}
+}
+
+func TestLooksLikeFeed(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        bool
+	}{
+		{"application/rss+xml", rssFeed, true},
+		{"text/xml; charset=utf-8", atomFeed, true},
+		{"", rssFeed, true},
+		{"text/html", "<html><head><title>Blog</title></head></html>", false},
+		{"application/xml", "<sitemap></sitemap>", false},
+	}
+	for _, tt := range tests {
+		if got := LooksLikeFeed(tt.contentType, []byte(tt.body)); got != tt.want {
+			t.Errorf("LooksLikeFeed(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestFeedLinks(t *testing.T) {
+	page := `<html><head>
+		<link rel="stylesheet" href="/style.css">
+		<link rel="alternate" type="application/rss+xml" title="RSS" href="/feed.xml">
+		<link rel="alternate" hreflang="de" href="/de/">
+	</head><body></body></html>`
+	got := FeedLinks("https://blog.example.com/posts/", []byte(page))
+	if want := []string{"https://blog.example.com/feed.xml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FeedLinks = %v, want %v", got, want)
+	}
+}
+
+func TestArticleLinks(t *testing.T) {
+	page := `<html><head><title>Engineering Blog</title></head><body>
+		<header><a href="/about">About us</a> <a href="/careers/open-roles">Open roles here</a></header>
+		<nav><a href="/tags/go">Go posts</a></nav>
+		<main>
+			<article><header><h2><a href="/2026/10/raft">Raft</a></h2></header>
+				<p>Leader election explained. <a href="/2026/10/raft">Read more</a></p></article>
+			<article><h2><a href="https://www.blog.example.com/2026/09/lsm-trees/">LSM trees in practice</a></h2></article>
+			<a href="/tag/databases">More database posts</a>
+			<a href="/page/2">Older posts</a>
+			<a href="https://other.example.org/post">An external post</a>
+			<a href="/files/slides.pdf">Conference slides deck</a>
+			<a href="/blog/">Back to the blog</a>
+		</main>
+		<footer><a href="/privacy">Privacy policy</a></footer>
+	</body></html>`
+	feed, err := ArticleLinks("https://blog.example.com/blog/", []byte(page))
+	if err != nil {
+		t.Fatalf("ArticleLinks: %v", err)
+	}
+	if feed.Title != "Engineering Blog" {
+		t.Errorf("title = %q", feed.Title)
+	}
+	var got []string
+	for _, e := range feed.Entries {
+		got = append(got, e.URL)
+	}
+	want := []string{"https://blog.example.com/2026/10/raft", "https://www.blog.example.com/2026/09/lsm-trees/"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ArticleLinks = %v, want %v", got, want)
+	}
+}
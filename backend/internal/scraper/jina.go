@@ -0,0 +1,75 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// JinaExtractor uses Jina AI Reader to render JS-heavy pages as Markdown
+type JinaExtractor struct {
+	client *http.Client
+}
+
+func NewJinaExtractor(client *http.Client) *JinaExtractor {
+	return &JinaExtractor{client: client}
+}
+
+func (e *JinaExtractor) Name() string {
+	return "jina"
+}
+
+func (e *JinaExtractor) Extract(ctx context.Context, pageURL string) (string, error) {
+	jinaURL := "https://r.jina.ai/" + pageURL
+	log.Printf("[Scraper.Jina] Fetching via Jina Reader: %s", jinaURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", jinaURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create jina request: %w", err)
+	}
+	req.Header.Set("Accept", "text/plain")
+	req.Header.Set("X-Return-Format", "markdown")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		log.Printf("[Scraper.Jina] Request failed: %v", err)
+		return "", fmt.Errorf("jina request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	log.Printf("[Scraper.Jina] Response status: %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("jina status code error: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read jina response: %w", err)
+	}
+
+	content := stripJinaPreamble(string(body))
+	log.Printf("[Scraper.Jina] Successfully extracted %d characters", len(content))
+	return content, nil
+}
+
+// stripJinaPreamble replaces Jina's "Title: / URL Source: / Markdown Content:"
+// header with a Markdown title
+func stripJinaPreamble(content string) string {
+	_, body, found := strings.Cut(content, "Markdown Content:")
+	if !found {
+		return strings.TrimSpace(content)
+	}
+	body = strings.TrimSpace(body)
+	for _, line := range strings.Split(content, "\n") {
+		if title, ok := strings.CutPrefix(line, "Title:"); ok {
+			if title = strings.TrimSpace(title); title != "" && !strings.HasPrefix(body, "# ") {
+				body = "# " + title + "\n\n" + body
+			}
+			break
+		}
+	}
+	return body
+}
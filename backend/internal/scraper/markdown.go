@@ -0,0 +1,428 @@
+package scraper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockTags are rendered as separate Markdown blocks; everything else is inline
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "dd": true, "details": true,
+	"div": true, "dl": true, "dt": true, "fieldset": true, "figcaption": true, "figure": true,
+	"footer": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "ol": true, "p": true, "pre": true,
+	"section": true, "summary": true, "table": true, "ul": true,
+}
+
+var whitespaceRe = regexp.MustCompile(`\s+`)
+
+func isBlock(n *html.Node) bool {
+	return n.Type == html.ElementNode && blockTags[n.Data]
+}
+
+// renderMarkdown renders HTML subtrees as Markdown: headings, paragraphs, lists,
+// block quotes, fenced code blocks with their language and GFM tables. Math is
+// expected to be converted to LaTeX text beforehand (convertMath). Links keep only
+// their text and images are dropped.
+func renderMarkdown(nodes ...*html.Node) string {
+	var blocks []string
+	for _, n := range nodes {
+		if isBlock(n) {
+			blocks = append(blocks, renderBlock(n)...)
+		} else if text := strings.TrimSpace(renderInline(n)); text != "" {
+			blocks = append(blocks, text)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// renderChildren renders the children of n as blocks, grouping runs of inline
+// content into paragraphs
+func renderChildren(n *html.Node) []string {
+	var blocks []string
+	var inline strings.Builder
+	flush := func() {
+		if text := cleanInline(inline.String()); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline.Reset()
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if isBlock(c) {
+			flush()
+			blocks = append(blocks, renderBlock(c)...)
+		} else {
+			inline.WriteString(renderInline(c))
+		}
+	}
+	flush()
+	return blocks
+}
+
+func renderBlock(n *html.Node) []string {
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		if text := cleanInline(renderInlineChildren(n)); text != "" {
+			return []string{strings.Repeat("#", int(n.Data[1]-'0')) + " " + strings.ReplaceAll(text, "\n", " ")}
+		}
+		return nil
+	case "p", "dt", "dd", "summary", "figcaption":
+		if text := cleanInline(renderInlineChildren(n)); text != "" {
+			return []string{text}
+		}
+		return nil
+	case "pre":
+		if code := renderCodeBlock(n); code != "" {
+			return []string{code}
+		}
+		return nil
+	case "ul", "ol":
+		if list := renderList(n, 0); list != "" {
+			return []string{list}
+		}
+		return nil
+	case "li":
+		// List item outside a list
+		if text := cleanInline(renderInlineChildren(n)); text != "" {
+			return []string{"- " + text}
+		}
+		return nil
+	case "blockquote":
+		inner := strings.Join(renderChildren(n), "\n\n")
+		if inner == "" {
+			return nil
+		}
+		return []string{"> " + strings.ReplaceAll(inner, "\n", "\n> ")}
+	case "table":
+		return renderTable(n)
+	case "hr":
+		return nil
+	}
+	return renderChildren(n)
+}
+
+var (
+	// codeLangRe finds a code block's language in class names: Prism/highlight.js
+	// ("language-go"), GitHub ("highlight-source-go"), Sphinx/Hugo ("highlight-go")
+	// and SyntaxHighlighter ("brush: go")
+	codeLangRe = regexp.MustCompile(`(?:^|\s)(?:language|lang|highlight-source|highlight)-([\w+#.-]+)|brush:\s*([\w+#.-]+)`)
+	// noLanguage are class-declared languages that mean plain text
+	noLanguage = map[string]bool{"none": true, "text": true, "plain": true, "plaintext": true, "txt": true, "nohighlight": true, "default": true}
+	// lineNumberClasses mark line-number gutters inside highlighted code
+	lineNumberClasses = map[string]bool{"lineno": true, "linenos": true, "lnt": true, "ln": true, "gutter": true, "line-numbers-rows": true}
+)
+
+// renderCodeBlock renders a <pre> element as a fenced code block tagged with its language
+func renderCodeBlock(n *html.Node) string {
+	code := strings.Trim(codeText(n), "\n")
+	if strings.TrimSpace(code) == "" {
+		return ""
+	}
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + codeLanguage(n) + "\n" + code + "\n" + fence
+}
+
+// codeLanguage returns the language declared on a <pre>, its <code> child or the
+// highlighter wrappers around it, or ""
+func codeLanguage(pre *html.Node) string {
+	candidates := []*html.Node{pre}
+	for c := pre.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "code" {
+			candidates = append(candidates, c)
+			break
+		}
+	}
+	for p, i := pre.Parent, 0; p != nil && i < 2; p, i = p.Parent, i+1 {
+		candidates = append(candidates, p)
+	}
+
+	for _, n := range candidates {
+		for _, key := range []string{"data-lang", "data-language"} {
+			if lang := strings.ToLower(strings.TrimSpace(attr(n, key))); lang != "" && !noLanguage[lang] {
+				return lang
+			}
+		}
+		for _, m := range codeLangRe.FindAllStringSubmatch(attr(n, "class"), -1) {
+			lang := strings.ToLower(strings.TrimRight(m[1]+m[2], ";"))
+			if lang != "" && !noLanguage[lang] {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// codeText returns the text of a code block without line-number gutters
+func codeText(n *html.Node) string {
+	var sb strings.Builder
+	walk(n, func(c *html.Node) bool {
+		switch c.Type {
+		case html.TextNode:
+			sb.WriteString(c.Data)
+		case html.ElementNode:
+			if c.Data == "br" {
+				sb.WriteString("\n")
+			}
+			for _, class := range strings.Fields(attr(c, "class")) {
+				if lineNumberClasses[class] {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return sb.String()
+}
+
+// renderTable renders a table as a GFM table, with the first row as its header.
+// Layout tables (a single column, or cells holding code or other tables) can't be
+// expressed in GFM and are rendered cell by cell as blocks instead.
+func renderTable(n *html.Node) []string {
+	var rows [][]*html.Node
+	var caption string
+	walk(n, func(c *html.Node) bool {
+		if c.Type != html.ElementNode {
+			return true
+		}
+		switch c.Data {
+		case "caption":
+			caption = cleanInline(renderInlineChildren(c))
+			return false
+		case "tr":
+			var cells []*html.Node
+			for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					cells = append(cells, cell)
+				}
+			}
+			if len(cells) > 0 {
+				rows = append(rows, cells)
+			}
+			return false
+		}
+		return true
+	})
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns := 0
+	layout := false
+	for _, row := range rows {
+		width := 0
+		for _, cell := range row {
+			width += colspan(cell)
+			walk(cell, func(c *html.Node) bool {
+				if c != cell && c.Type == html.ElementNode && (c.Data == "pre" || c.Data == "table") {
+					layout = true
+				}
+				return !layout
+			})
+		}
+		columns = max(columns, width)
+	}
+	if layout || columns < 2 {
+		var blocks []string
+		for _, row := range rows {
+			for _, cell := range row {
+				blocks = append(blocks, renderChildren(cell)...)
+			}
+		}
+		return blocks
+	}
+
+	var lines []string
+	if caption != "" {
+		lines = append(lines, caption, "")
+	}
+	for i, row := range rows {
+		cells := make([]string, 0, columns)
+		for _, cell := range row {
+			text := strings.ReplaceAll(cleanInline(renderInlineChildren(cell)), "\n", " ")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+			for j := 1; j < colspan(cell); j++ {
+				cells = append(cells, "")
+			}
+		}
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return []string{strings.Join(lines, "\n")}
+}
+
+// colspan returns how many columns a table cell spans
+func colspan(cell *html.Node) int {
+	span, err := strconv.Atoi(attr(cell, "colspan"))
+	if err != nil || span < 1 {
+		return 1
+	}
+	return min(span, 100)
+}
+
+// renderList renders a list with nested lists indented under their items
+func renderList(n *html.Node, indent int) string {
+	var lines []string
+	number := 1
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+		marker := "- "
+		if n.Data == "ol" {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+
+		var text strings.Builder
+		var nested []string
+		for c := li.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case c.Type == html.ElementNode && (c.Data == "ul" || c.Data == "ol"):
+				if sub := renderList(c, indent+len(marker)); sub != "" {
+					nested = append(nested, sub)
+				}
+			case c.Type == html.ElementNode && (c.Data == "pre" || c.Data == "table"):
+				// Indented under the item so the block stays part of it
+				for _, block := range renderBlock(c) {
+					nested = append(nested, indentLines(block, indent+len(marker)))
+				}
+			case isBlock(c):
+				text.WriteString(" " + strings.Join(renderBlock(c), " ") + " ")
+			default:
+				text.WriteString(renderInline(c))
+			}
+		}
+		item := strings.ReplaceAll(cleanInline(text.String()), "\n", " ")
+		if item == "" && len(nested) == 0 {
+			continue
+		}
+		lines = append(lines, strings.Repeat(" ", indent)+marker+item)
+		lines = append(lines, nested...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentLines indents every line of a block by n spaces
+func indentLines(block string, n int) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(block, "\n", "\n"+pad)
+}
+
+func renderInlineChildren(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(renderInline(c))
+	}
+	return sb.String()
+}
+
+func renderInline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return whitespaceRe.ReplaceAllString(n.Data, " ")
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.Data {
+	case "br":
+		return "\n"
+	case "img", "picture", "video", "audio", "source":
+		return ""
+	case "code", "kbd", "samp", "tt":
+		code := strings.Join(strings.Fields(textContent(n)), " ")
+		if code == "" {
+			return ""
+		}
+		tick := "`"
+		if strings.Contains(code, "`") {
+			tick = "``"
+		}
+		return tick + code + tick
+	case "strong", "b":
+		return wrapInline(renderInlineChildren(n), "**")
+	case "em", "i":
+		return wrapInline(renderInlineChildren(n), "*")
+	}
+	if isBlock(n) {
+		return " " + strings.Join(renderBlock(n), " ") + " "
+	}
+	return renderInlineChildren(n)
+}
+
+// wrapInline wraps text in a Markdown marker, keeping surrounding spaces outside
+func wrapInline(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	lead := ""
+	if strings.HasPrefix(text, " ") {
+		lead = " "
+	}
+	trail := ""
+	if strings.HasSuffix(text, " ") {
+		trail = " "
+	}
+	return lead + marker + trimmed + marker + trail
+}
+
+// cleanInline collapses spaces and trims each line of rendered inline content
+func cleanInline(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// textContent returns the raw text of a subtree, as in the DOM's textContent
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+		}
+		if c.Type == html.ElementNode && c.Data == "br" {
+			sb.WriteString("\n")
+		}
+		return true
+	})
+	return sb.String()
+}
+
+// attr returns the value of an attribute, or ""
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// walk visits n and its descendants depth-first; returning false skips the children
+func walk(n *html.Node, visit func(*html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c, visit)
+	}
+}
@@ -0,0 +1,108 @@
+package scraper
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// convertMath replaces rendered math (MathJax, KaTeX, MathML) with its LaTeX
+// source: "$x$" inline and "$$x$$" for display math. It must run before
+// boilerplate removal, which drops the <script> tags MathJax 2 keeps the source in.
+func convertMath(root *html.Node) {
+	type replacement struct {
+		node    *html.Node
+		tex     string
+		display bool
+	}
+	var replacements []replacement
+	var mathJaxRendered []*html.Node
+	scripts := 0
+
+	walk(root, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		classes := " " + attr(n, "class") + " "
+		switch {
+		case n.Data == "script" && strings.HasPrefix(attr(n, "type"), "math/tex"):
+			// MathJax 2 keeps the source in a script next to the rendered output
+			replacements = append(replacements, replacement{n, textContent(n), strings.Contains(attr(n, "type"), "mode=display")})
+			scripts++
+			return false
+		case n.Data == "mjx-container" || n.Data == "math":
+			// MathJax 3 and plain MathML; fall back to the formula's text
+			tex := annotationTeX(n)
+			if tex == "" {
+				tex = strings.Join(strings.Fields(textContent(n)), " ")
+			}
+			if tex != "" {
+				display := attr(n, "display") == "true" || attr(n, "display") == "block"
+				replacements = append(replacements, replacement{n, tex, display})
+			}
+			return false
+		case strings.Contains(classes, " katex-display ") || strings.Contains(classes, " katex ") ||
+			strings.Contains(classes, " mwe-math-element "):
+			// KaTeX, and Wikipedia, which hides its MathML next to an image fallback
+			if tex := annotationTeX(n); tex != "" {
+				display := strings.Contains(classes, " katex-display ") || strings.Contains(classes, "mwe-math-element-block") ||
+					findElement(n, func(c *html.Node) bool { return strings.Contains(attr(c, "class"), "mwe-math-mathml-display") })
+				replacements = append(replacements, replacement{n, tex, display})
+				return false
+			}
+		case strings.Contains(classes, " MathJax"):
+			// MathJax 2 rendered output ("MathJax", "MathJax_Display", "MathJax_Preview", ...)
+			mathJaxRendered = append(mathJaxRendered, n)
+		}
+		return true
+	})
+
+	for _, r := range replacements {
+		text := "$" + r.tex + "$"
+		if r.display {
+			text = "$$" + r.tex + "$$"
+		}
+		r.node.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: " " + text + " "}, r.node)
+		r.node.Parent.RemoveChild(r.node)
+	}
+	// MathJax 2 output duplicates the script sources replaced above
+	if scripts > 0 {
+		for _, n := range mathJaxRendered {
+			if n.Parent != nil {
+				n.Parent.RemoveChild(n)
+			}
+		}
+	}
+}
+
+// findElement reports whether n or a descendant element matches
+func findElement(n *html.Node, match func(*html.Node) bool) bool {
+	found := false
+	walk(n, func(c *html.Node) bool {
+		if !found && c.Type == html.ElementNode && match(c) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// annotationTeX returns the LaTeX source of a formula from its TeX annotation
+// (KaTeX, MathJax 3) or MathML alttext (Wikipedia), or ""
+func annotationTeX(n *html.Node) string {
+	var tex string
+	findElement(n, func(c *html.Node) bool {
+		if c.Data == "annotation" && attr(c, "encoding") == "application/x-tex" {
+			tex = textContent(c)
+		} else if c.Data == "math" && attr(c, "alttext") != "" {
+			tex = attr(c, "alttext")
+		}
+		return strings.TrimSpace(tex) != ""
+	})
+	tex = strings.TrimSpace(tex)
+	// Wikipedia wraps every formula in {\displaystyle ...}
+	if inner, ok := strings.CutPrefix(tex, `{\displaystyle`); ok && strings.HasSuffix(inner, "}") {
+		tex = strings.TrimSpace(strings.TrimSuffix(inner, "}"))
+	}
+	return tex
+}
@@ -0,0 +1,275 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/amityadav/landr/internal/egress"
+	"golang.org/x/net/html"
+)
+
+// boilerplateSelector matches elements that are never part of an article
+const boilerplateSelector = `script, style, noscript, template, iframe, svg, canvas, form, button, input, select,
+	nav, aside, footer, dialog, [hidden], [aria-hidden='true'], [aria-modal='true'],
+	[role='navigation'], [role='banner'], [role='contentinfo'], [role='complementary'],
+	[role='dialog'], [role='alertdialog'], [style*='display:none'], [style*='display: none']`
+
+var (
+	// unlikelyRe matches class/id names of navigation, ads, cookie banners and other chrome
+	unlikelyRe = regexp.MustCompile(`(?i)cookie|consent|gdpr|banner|advert|\bads?\b|\bad-|-ad\b|sponsor|promo|` +
+		`newsletter|subscribe|signup|social|share|sharing|related|recommend|comment|sidebar|popup|modal|` +
+		`breadcrumb|menu|masthead|footer|disqus|outbrain|taboola|paywall`)
+	// likelyRe matches class/id names of article containers
+	likelyRe = regexp.MustCompile(`(?i)article|body|content|entry|main|page|post|text|blog|story`)
+)
+
+// ReadabilityExtractor fetches a page directly and keeps its main content,
+// scoring blocks the way Mozilla's Readability does
+type ReadabilityExtractor struct {
+	fetcher *egress.Fetcher
+}
+
+func NewReadabilityExtractor(fetcher *egress.Fetcher) *ReadabilityExtractor {
+	return &ReadabilityExtractor{fetcher: fetcher}
+}
+
+func (e *ReadabilityExtractor) Name() string {
+	return "readability"
+}
+
+func (e *ReadabilityExtractor) Extract(ctx context.Context, pageURL string) (string, error) {
+	page, err := e.fetcher.Fetch(ctx, pageURL, browserHeaders())
+	if err != nil {
+		return "", err
+	}
+	log.Printf("[Scraper.Readability] Fetched %d bytes (cached: %v)", len(page.Body), page.FromCache)
+
+	if page.ContentType != "" && !strings.Contains(page.ContentType, "html") {
+		return "", fmt.Errorf("not an html page: %s", page.ContentType)
+	}
+	return ExtractArticle(bytes.NewReader(page.Body))
+}
+
+// ExtractHTML extracts the article from HTML uploaded by the client
+func (e *ReadabilityExtractor) ExtractHTML(r io.Reader) (string, error) {
+	return ExtractArticle(r)
+}
+
+// browserHeaders are browser-like request headers, to avoid 403 blocks
+func browserHeaders() http.Header {
+	h := http.Header{}
+	h.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	h.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
+	h.Set("Accept-Language", "en-US,en;q=0.9")
+	h.Set("Upgrade-Insecure-Requests", "1")
+	h.Set("Sec-Fetch-Dest", "document")
+	h.Set("Sec-Fetch-Mode", "navigate")
+	h.Set("Sec-Fetch-Site", "none")
+	h.Set("Sec-Fetch-User", "?1")
+	h.Set("Sec-Ch-Ua", `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`)
+	h.Set("Sec-Ch-Ua-Mobile", "?0")
+	h.Set("Sec-Ch-Ua-Platform", `"Windows"`)
+	return h
+}
+
+// ExtractArticle returns the main content of an HTML page as Markdown, led by
+// the page title. Navigation, ads, cookie banners and other chrome are dropped;
+// code keeps its language, tables become GFM tables and math becomes LaTeX.
+func ExtractArticle(r io.Reader) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse html: %w", err)
+	}
+
+	title := strings.TrimSpace(doc.Find(`meta[property="og:title"]`).AttrOr("content", ""))
+	if title == "" {
+		title = strings.TrimSpace(doc.Find("title").First().Text())
+	}
+
+	convertMath(doc.Get(0))
+	removeBoilerplate(doc)
+	nodes := mainContent(doc)
+	if len(nodes) == 0 {
+		return "", fmt.Errorf("no content found")
+	}
+
+	content := renderMarkdown(nodes...)
+	if title != "" && !strings.HasPrefix(content, "# ") {
+		content = "# " + title + "\n\n" + content
+	}
+	return content, nil
+}
+
+// removeBoilerplate drops elements that are not article content
+func removeBoilerplate(doc *goquery.Document) {
+	doc.Find(boilerplateSelector).Remove()
+
+	// Site headers go; headers inside an article usually hold its title
+	doc.Find("header").Each(func(_ int, s *goquery.Selection) {
+		if s.Closest("article").Length() == 0 {
+			s.Remove()
+		}
+	})
+
+	doc.Find("body *").Each(func(_ int, s *goquery.Selection) {
+		switch goquery.NodeName(s) {
+		case "article", "main", "pre", "code", "table", "tbody", "tr", "td", "th":
+			return
+		}
+		names := s.AttrOr("class", "") + " " + s.AttrOr("id", "")
+		if unlikelyRe.MatchString(names) && !likelyRe.MatchString(names) {
+			s.Remove()
+		}
+	})
+}
+
+// mainContent picks the highest-scoring container and its sibling blocks that
+// look like part of the same article
+func mainContent(doc *goquery.Document) []*html.Node {
+	scores := map[*html.Node]float64{}
+	var candidates []*html.Node
+	addScore := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = initialScore(n)
+			candidates = append(candidates, n)
+		}
+		scores[n] += score
+	}
+
+	doc.Find("p, pre, td, blockquote, div").Each(func(_ int, s *goquery.Selection) {
+		n := s.Get(0)
+		// Divs only count when they hold text directly, like a paragraph
+		if n.Data == "div" && s.Children().Filter("p, div, pre, table, ul, ol, blockquote, section, article").Length() > 0 {
+			return
+		}
+		text := strings.TrimSpace(s.Text())
+		if len([]rune(text)) < 25 {
+			return
+		}
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len([]rune(text)))/100, 3)
+		addScore(n.Parent, score)
+		if n.Parent != nil {
+			addScore(n.Parent.Parent, score/2)
+		}
+	})
+
+	var top *html.Node
+	for _, n := range candidates {
+		scores[n] *= 1 - linkDensity(n)
+		if top == nil || scores[n] > scores[top] {
+			top = n
+		}
+	}
+	if top == nil {
+		body := doc.Find("body")
+		if body.Length() == 0 {
+			return nil
+		}
+		return []*html.Node{body.Get(0)}
+	}
+
+	cleanLinkLists(top)
+	if top.Parent == nil {
+		return []*html.Node{top}
+	}
+
+	threshold := math.Max(10, scores[top]*0.2)
+	var nodes []*html.Node
+	for sib := top.Parent.FirstChild; sib != nil; sib = sib.NextSibling {
+		if sib == top {
+			nodes = append(nodes, sib)
+			continue
+		}
+		if sib.Type != html.ElementNode {
+			continue
+		}
+		if score, ok := scores[sib]; ok && score >= threshold {
+			cleanLinkLists(sib)
+			nodes = append(nodes, sib)
+			continue
+		}
+		if sib.Data == "p" || sib.Data == "pre" {
+			text := strings.TrimSpace(textContent(sib))
+			density := linkDensity(sib)
+			if (len(text) > 80 && density < 0.25) || (len(text) > 0 && density == 0 && strings.HasSuffix(text, ".")) {
+				nodes = append(nodes, sib)
+			}
+		}
+	}
+	return nodes
+}
+
+// initialScore weighs a candidate by its tag and its class/id names
+func initialScore(n *html.Node) float64 {
+	var score float64
+	switch n.Data {
+	case "article":
+		score = 10
+	case "div", "main", "section":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "ol", "ul", "dl", "form", "address":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+	names := attr(n, "class") + " " + attr(n, "id")
+	if likelyRe.MatchString(names) {
+		score += 25
+	}
+	if unlikelyRe.MatchString(names) {
+		score -= 25
+	}
+	return score
+}
+
+// linkDensity is the share of a node's text that sits inside links
+func linkDensity(n *html.Node) float64 {
+	total := len(strings.TrimSpace(textContent(n)))
+	if total == 0 {
+		return 0
+	}
+	var linked int
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.ElementNode && c.Data == "a" {
+			linked += len(strings.TrimSpace(textContent(c)))
+			return false
+		}
+		return true
+	})
+	return float64(linked) / float64(total)
+}
+
+// cleanLinkLists removes link-heavy lists and blocks (tables of contents,
+// "read next" boxes) from inside the chosen content
+func cleanLinkLists(n *html.Node) {
+	var remove []*html.Node
+	walk(n, func(c *html.Node) bool {
+		if c == n || c.Type != html.ElementNode {
+			return true
+		}
+		switch c.Data {
+		case "ul", "ol", "div", "section", "p":
+			if linkDensity(c) > 0.5 && len(strings.TrimSpace(textContent(c))) < 500 {
+				remove = append(remove, c)
+				return false
+			}
+		}
+		return true
+	})
+	for _, c := range remove {
+		c.Parent.RemoveChild(c)
+	}
+}
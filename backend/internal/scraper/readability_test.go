@@ -0,0 +1,105 @@
+package scraper
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.md golden files")
+
+const articlePage = `<!DOCTYPE html>
+<html><head><title>Site | Understanding B-Trees</title>
+<meta property="og:title" content="Understanding B-Trees"></head>
+<body>
+<header class="site-header"><a href="/">Home</a> <a href="/blog">Blog</a></header>
+<nav><ul><li><a href="/a">Archive</a></li><li><a href="/b">About</a></li></ul></nav>
+<div id="cookie-consent">We use cookies to improve your experience. Accept all cookies?</div>
+<div class="layout">
+  <aside class="sidebar"><p>Subscribe to our newsletter for weekly updates on databases, storage and more.</p></aside>
+  <article class="post">
+    <h1>Understanding B-Trees</h1>
+    <p>A B-tree is a self-balancing tree that keeps data sorted, and allows searches, insertions and deletions in logarithmic time.</p>
+    <h2>Why databases use them</h2>
+    <p>Each node holds many keys, so the tree stays shallow, which means fewer disk reads per lookup, even for huge tables.</p>
+    <ul>
+      <li>Nodes are <strong>pages</strong> on disk</li>
+      <li>Keys are kept sorted
+        <ol><li>Binary search inside a node</li></ol>
+      </li>
+    </ul>
+    <pre><code>func search(n *node, key int) bool {
+    i := sort.SearchInts(n.keys, key)
+    return i &lt; len(n.keys) &amp;&amp; n.keys[i] == key
+}</code></pre>
+    <p>Use <code>EXPLAIN</code> to check that a query hits the index.</p>
+    <div class="share-buttons"><a href="/t">Tweet</a> <a href="/f">Share</a></div>
+  </article>
+  <div class="advert">Buy our premium plan today, with a discount for the first year of use.</div>
+</div>
+<footer><p>Copyright 2024, Example Inc. All rights reserved, everywhere.</p></footer>
+</body></html>`
+
+func TestExtractArticle(t *testing.T) {
+	got, err := ExtractArticle(strings.NewReader(articlePage))
+	if err != nil {
+		t.Fatalf("ExtractArticle: %v", err)
+	}
+
+	for _, want := range []string{
+		"# Understanding B-Trees\n\nA B-tree is a self-balancing tree",
+		"## Why databases use them",
+		"- Nodes are **pages** on disk\n- Keys are kept sorted\n  1. Binary search inside a node",
+		"```\nfunc search(n *node, key int) bool {\n    i := sort.SearchInts(n.keys, key)\n    return i < len(n.keys) && n.keys[i] == key\n}\n```",
+		"Use `EXPLAIN` to check",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"Archive", "cookies", "newsletter", "Tweet", "premium", "Copyright", "Home"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("boilerplate %q kept in:\n%s", unwanted, got)
+		}
+	}
+}
+
+// TestExtractArticleGolden renders the saved pages in testdata and compares them
+// with their .md golden files. Run with -update after an intended change.
+func TestExtractArticleGolden(t *testing.T) {
+	pages, err := filepath.Glob("testdata/*.html")
+	if err != nil || len(pages) == 0 {
+		t.Fatalf("no fixtures in testdata: %v", err)
+	}
+	for _, page := range pages {
+		name := strings.TrimSuffix(filepath.Base(page), ".html")
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Open(page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			got, err := ExtractArticle(f)
+			if err != nil {
+				t.Fatalf("ExtractArticle: %v", err)
+			}
+
+			golden := strings.TrimSuffix(page, ".html") + ".md"
+			if *update {
+				if err := os.WriteFile(golden, []byte(got+"\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("missing golden file (run with -update): %v", err)
+			}
+			if got+"\n" != string(want) {
+				t.Errorf("output differs from %s:\n%s", golden, got)
+			}
+		})
+	}
+}
@@ -1,233 +1,151 @@
 package scraper
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"net/url"
-	"os"
 	"strings"
-	"time"
+	"unicode/utf8"
 
-	"github.com/PuerkitoBio/goquery"
+	"github.com/amityadav/landr/internal/egress"
 )
 
-type Scraper struct {
-	client *http.Client
-}
-
-func NewScraper() *Scraper {
-	return &Scraper{
-		client: &http.Client{
-			Timeout: 60 * time.Second,
-		},
-	}
-}
-
-// Scrape fetches the URL and extracts text content.
-func (s *Scraper) Scrape(url string) (string, error) {
-	log.Printf("[Scraper] Fetching URL: %s", url)
-
-	// First try direct scraping
-	content, err := s.directScrape(url)
-	if err == nil && len(content) > 100 {
-		return content, nil
-	}
-	log.Printf("[Scraper] Direct scrape failed or insufficient content, trying Jina Reader...")
+const (
+	// minContentWords is the least an extraction must hold to count as an article
+	minContentWords = 50
+	// maxContentLen caps extracted content (in bytes), cut at a paragraph boundary
+	maxContentLen = 200000
+	// maxHTMLLen caps HTML uploaded by clients, like egress caps fetched pages
+	maxHTMLLen = 10 << 20
+)
 
-	// Fallback 1: Use Jina AI Reader for JS-rendered sites
-	content, err = s.jinaReaderScrape(url)
-	if err == nil && len(content) > 100 {
-		return content, nil
-	}
-	log.Printf("[Scraper] Jina Reader failed, trying Supadata...")
+// ErrHTMLTooLarge is returned for uploaded HTML over maxHTMLLen
+var ErrHTMLTooLarge = errors.New("uploaded html too large")
 
-	// Fallback 2: Use Supadata web scraper
-	content, err = s.supadataScrape(url)
-	if err == nil && len(content) > 100 {
-		return content, nil
-	}
-
-	return "", fmt.Errorf("all scraping methods failed")
+// blockPageMarkers appear on bot-check and error pages served instead of the article
+var blockPageMarkers = []string{
+	"enable javascript", "javascript is disabled", "access denied", "checking your browser",
+	"verify you are human", "are you a robot", "captcha", "403 forbidden", "page not found",
 }
 
-// directScrape uses goquery to extract content from static HTML
-func (s *Scraper) directScrape(url string) (string, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set comprehensive browser-like headers to avoid 403 blocks
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Accept-Encoding", "gzip, deflate")
-	req.Header.Set("Cache-Control", "no-cache")
-	req.Header.Set("Pragma", "no-cache")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("Upgrade-Insecure-Requests", "1")
-	req.Header.Set("Sec-Fetch-Dest", "document")
-	req.Header.Set("Sec-Fetch-Mode", "navigate")
-	req.Header.Set("Sec-Fetch-Site", "none")
-	req.Header.Set("Sec-Fetch-User", "?1")
-	req.Header.Set("Sec-Ch-Ua", `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`)
-	req.Header.Set("Sec-Ch-Ua-Mobile", "?0")
-	req.Header.Set("Sec-Ch-Ua-Platform", `"Windows"`)
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch url: %w", err)
-	}
-	defer resp.Body.Close()
+type Scraper struct {
+	registry *Registry
+	robots   *egress.Robots
+}
 
-	log.Printf("[Scraper.Direct] Response status: %d", resp.StatusCode)
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("status code error: %d", resp.StatusCode)
-	}
+func NewScraper(registry *Registry, robots *egress.Robots) *Scraper {
+	return &Scraper{registry: registry, robots: robots}
+}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse html: %w", err)
-	}
+// Scrape fetches the URL and extracts its main content as Markdown, trying the
+// extractors configured for its domain in order. URLs of internal addresses and
+// pages disallowed by robots.txt are refused before any extractor runs, since
+// the hosted extractors would fetch them on our behalf.
+func (s *Scraper) Scrape(ctx context.Context, pageURL string) (string, error) {
+	log.Printf("[Scraper] Fetching URL: %s", pageURL)
 
-	// Remove unwanted elements
-	doc.Find("script, style, nav, footer, header, aside, .sidebar, .advertisement, .ads").Remove()
-
-	var sb strings.Builder
-
-	// Try content selectors
-	selectors := []string{"article", "[role='main']", "main", ".post-content", ".article-content", ".entry-content", ".content"}
-	for _, selector := range selectors {
-		selection := doc.Find(selector)
-		if selection.Length() > 0 {
-			log.Printf("[Scraper.Direct] Found content with selector: %s", selector)
-			selection.Find("p, h1, h2, h3, li").Each(func(i int, s *goquery.Selection) {
-				text := strings.TrimSpace(s.Text())
-				if len(text) > 20 {
-					sb.WriteString(text)
-					sb.WriteString("\n\n")
-				}
-			})
-			break
-		}
+	if err := egress.CheckURL(ctx, pageURL); err != nil {
+		return "", err
 	}
-
-	// Fallback: all paragraphs
-	if sb.Len() == 0 {
-		doc.Find("body p").Each(func(i int, s *goquery.Selection) {
-			text := strings.TrimSpace(s.Text())
-			if len(text) > 30 {
-				sb.WriteString(text)
-				sb.WriteString("\n\n")
-			}
-		})
+	if !s.robots.Allowed(ctx, pageURL) {
+		return "", &egress.DisallowedError{URL: pageURL}
 	}
+	u, _ := url.Parse(pageURL)
 
-	return strings.TrimSpace(sb.String()), nil
+	chain := s.registry.Chain(u.Hostname())
+	return firstUsable(len(chain), func(i int) (string, string, error) {
+		content, err := chain[i].Extract(ctx, pageURL)
+		return chain[i].Name(), content, err
+	})
 }
 
-// jinaReaderScrape uses Jina AI Reader to render JS and extract content
-func (s *Scraper) jinaReaderScrape(url string) (string, error) {
-	jinaURL := "https://r.jina.ai/" + url
-	log.Printf("[Scraper.Jina] Fetching via Jina Reader: %s", jinaURL)
+// ScrapeHTML extracts the main content of page HTML the client uploaded for
+// pageURL (e.g. a page behind a login the server can't fetch). Nothing is
+// fetched: the URL only picks the domain's chain, of which the extractors
+// that accept HTML are used.
+func (s *Scraper) ScrapeHTML(pageURL, pageHTML string) (string, error) {
+	log.Printf("[Scraper] Extracting uploaded HTML for URL: %s (%d bytes)", pageURL, len(pageHTML))
 
-	req, err := http.NewRequest("GET", jinaURL, nil)
+	u, err := url.Parse(pageURL)
 	if err != nil {
-		return "", fmt.Errorf("failed to create jina request: %w", err)
+		return "", fmt.Errorf("%w: %v", egress.ErrInvalidURL, err)
 	}
-
-	req.Header.Set("Accept", "text/plain")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		log.Printf("[Scraper.Jina] Request failed: %v", err)
-		return "", fmt.Errorf("jina request failed: %w", err)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", egress.ErrUnsupportedScheme
 	}
-	defer resp.Body.Close()
-
-	log.Printf("[Scraper.Jina] Response status: %d", resp.StatusCode)
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("jina status code error: %d", resp.StatusCode)
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("%w: missing host", egress.ErrInvalidURL)
 	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read jina response: %w", err)
-	}
-
-	content := string(body)
-
-	// Truncate if too long
-	maxLen := 50000
-	if len(content) > maxLen {
-		log.Printf("[Scraper.Jina] Truncating from %d to %d chars", len(content), maxLen)
-		content = content[:maxLen]
+	if len(pageHTML) > maxHTMLLen {
+		return "", fmt.Errorf("%w: %d bytes", ErrHTMLTooLarge, len(pageHTML))
 	}
 
-	log.Printf("[Scraper.Jina] Successfully extracted %d characters", len(content))
-	return content, nil
+	chain := s.registry.HTMLChain(u.Hostname())
+	return firstUsable(len(chain), func(i int) (string, string, error) {
+		content, err := chain[i].ExtractHTML(strings.NewReader(pageHTML))
+		return chain[i].Name(), content, err
+	})
 }
 
-// supadataScrape uses Supadata's web scraping API
-func (s *Scraper) supadataScrape(targetUrl string) (string, error) {
-	apiKey := os.Getenv("SUPADATA_API_KEY")
-	if apiKey == "" {
-		return "", fmt.Errorf("SUPADATA_API_KEY not set")
-	}
-
-	encodedURL := url.QueryEscape(targetUrl)
-	apiURL := fmt.Sprintf("https://api.supadata.ai/v1/web/scrape?url=%s", encodedURL)
-	log.Printf("[Scraper.Supadata] Fetching: %s", apiURL)
-
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create supadata request: %w", err)
-	}
-
-	req.Header.Set("x-api-key", apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		log.Printf("[Scraper.Supadata] Request failed: %v", err)
-		return "", fmt.Errorf("supadata request failed: %w", err)
+// firstUsable runs the n extractors of a chain in order and returns the first
+// usable content, truncated
+func firstUsable(n int, extract func(i int) (name, content string, err error)) (string, error) {
+	var failures []string
+	for i := 0; i < n; i++ {
+		name, content, err := extract(i)
+		if err == nil {
+			if reason := unusable(content); reason != "" {
+				err = fmt.Errorf("%s", reason)
+			}
+		}
+		if err != nil {
+			log.Printf("[Scraper] Extractor %s failed: %v", name, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		log.Printf("[Scraper] Extracted %d characters with %s", len(content), name)
+		return truncateContent(strings.TrimSpace(content), maxContentLen), nil
 	}
-	defer resp.Body.Close()
 
-	log.Printf("[Scraper.Supadata] Response status: %d", resp.StatusCode)
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("supadata error: %d - %s", resp.StatusCode, string(body))
+	if len(failures) == 0 {
+		return "", fmt.Errorf("no extractors registered")
 	}
+	return "", fmt.Errorf("all extractors failed: %s", strings.Join(failures, "; "))
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read supadata response: %w", err)
+// unusable returns why extracted content can't be used as an article, or ""
+func unusable(content string) string {
+	words := len(strings.Fields(content))
+	if words < minContentWords {
+		return fmt.Sprintf("too little content (%d words)", words)
+	}
+	// Block pages are short; long articles may mention these phrases in passing
+	if words < 300 {
+		lower := strings.ToLower(content)
+		for _, marker := range blockPageMarkers {
+			if strings.Contains(lower, marker) {
+				return fmt.Sprintf("looks like a block page (%q)", marker)
+			}
+		}
 	}
+	return ""
+}
 
-	// Supadata returns JSON with content field
-	var result struct {
-		Name    string `json:"name"`
-		Content string `json:"content"`
-	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return "", fmt.Errorf("failed to parse supadata response: %w", err)
+// truncateContent cuts content to at most maxLen bytes, preferring the last
+// paragraph break and never splitting a UTF-8 character
+func truncateContent(content string, maxLen int) string {
+	if len(content) <= maxLen {
+		return content
 	}
-
-	if result.Content == "" {
-		return "", fmt.Errorf("no content in supadata response")
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(content[cut]) {
+		cut--
 	}
-
-	// Truncate if too long
-	maxLen := 50000
-	if len(result.Content) > maxLen {
-		log.Printf("[Scraper.Supadata] Truncating from %d to %d chars", len(result.Content), maxLen)
-		result.Content = result.Content[:maxLen]
+	if i := strings.LastIndex(content[:cut], "\n\n"); i > maxLen/2 {
+		cut = i
 	}
-
-	log.Printf("[Scraper.Supadata] Successfully extracted %d characters from '%s'", len(result.Content), result.Name)
-	return result.Content, nil
+	log.Printf("[Scraper] Truncating from %d to %d chars", len(content), cut)
+	return strings.TrimSpace(content[:cut])
 }
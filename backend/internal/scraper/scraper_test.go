@@ -0,0 +1,83 @@
+package scraper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amityadav/landr/internal/egress"
+)
+
+func TestUnusable(t *testing.T) {
+	article := strings.Repeat("B-trees keep keys sorted in wide nodes. ", 10)
+	tests := []struct {
+		name    string
+		content string
+		ok      bool
+	}{
+		{"article", article, true},
+		{"too short", "Just a few words here.", false},
+		{"block page", "Checking your browser before accessing the site. " + article, false},
+		{"long article mentioning a marker", "Some sites show captcha pages. " + strings.Repeat(article, 8), true},
+	}
+	for _, tt := range tests {
+		if got := unusable(tt.content) == ""; got != tt.ok {
+			t.Errorf("%s: usable = %v, want %v", tt.name, got, tt.ok)
+		}
+	}
+}
+
+func TestTruncateContent(t *testing.T) {
+	content := strings.Repeat("a", 60) + "\n\n" + strings.Repeat("é", 30)
+	got := truncateContent(content, 100)
+	if got != strings.Repeat("a", 60) {
+		t.Errorf("truncateContent cut at %d bytes, want the paragraph break", len(got))
+	}
+	got = truncateContent(strings.Repeat("é", 30), 11)
+	if got != strings.Repeat("é", 5) {
+		t.Errorf("truncateContent split a character: %q", got)
+	}
+}
+
+func TestScrapeHTML(t *testing.T) {
+	r := NewRegistry()
+	r.Register(NewReadabilityExtractor(nil))
+	r.Register(stubExtractor("jina"))
+	if err := r.SetDomainChain("x.com", "jina"); err != nil {
+		t.Fatalf("SetDomainChain: %v", err)
+	}
+	s := NewScraper(r, nil)
+
+	paragraph := "<p>" + strings.Repeat("Members-only notes on consistent hashing and virtual nodes. ", 10) + "</p>"
+	page := "<html><head><title>Ring Notes</title></head><body><nav>Home</nav><article>" + paragraph + "</article></body></html>"
+
+	// x.com's chain has no HTML extractor, so readability is used anyway
+	for _, pageURL := range []string{"https://example.com/private/notes", "https://x.com/someone/status/1"} {
+		got, err := s.ScrapeHTML(pageURL, page)
+		if err != nil {
+			t.Fatalf("ScrapeHTML(%s): %v", pageURL, err)
+		}
+		if !strings.HasPrefix(got, "# Ring Notes") || strings.Contains(got, "Home") {
+			t.Errorf("ScrapeHTML(%s) = %q", pageURL, got)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pageURL string
+		html    string
+		want    error
+	}{
+		{"unsupported scheme", "file:///etc/passwd", page, egress.ErrUnsupportedScheme},
+		{"missing host", "https:///notes", page, egress.ErrInvalidURL},
+		{"too large", "https://example.com/notes", strings.Repeat(" ", maxHTMLLen+1), ErrHTMLTooLarge},
+	}
+	for _, tt := range tests {
+		if _, err := s.ScrapeHTML(tt.pageURL, tt.html); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if _, err := s.ScrapeHTML("https://example.com/empty", "<html><body><p>Sign in</p></body></html>"); err == nil {
+		t.Error("ScrapeHTML of a page without content should fail")
+	}
+}
@@ -0,0 +1,70 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// SupadataExtractor uses Supadata's web scraping API
+type SupadataExtractor struct {
+	client *http.Client
+	apiKey string
+}
+
+func NewSupadataExtractor(client *http.Client, apiKey string) *SupadataExtractor {
+	return &SupadataExtractor{client: client, apiKey: apiKey}
+}
+
+func (e *SupadataExtractor) Name() string {
+	return "supadata"
+}
+
+func (e *SupadataExtractor) Extract(ctx context.Context, pageURL string) (string, error) {
+	apiURL := fmt.Sprintf("https://api.supadata.ai/v1/web/scrape?url=%s", url.QueryEscape(pageURL))
+	log.Printf("[Scraper.Supadata] Fetching: %s", apiURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create supadata request: %w", err)
+	}
+	req.Header.Set("x-api-key", e.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		log.Printf("[Scraper.Supadata] Request failed: %v", err)
+		return "", fmt.Errorf("supadata request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	log.Printf("[Scraper.Supadata] Response status: %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("supadata error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read supadata response: %w", err)
+	}
+
+	// Supadata returns JSON with content field
+	var result struct {
+		Name    string `json:"name"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse supadata response: %w", err)
+	}
+	if result.Content == "" {
+		return "", fmt.Errorf("no content in supadata response")
+	}
+
+	log.Printf("[Scraper.Supadata] Successfully extracted %d characters from '%s'", len(result.Content), result.Name)
+	return result.Content, nil
+}
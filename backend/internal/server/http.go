@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"runtime/debug"
+	"strings"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"google.golang.org/grpc"
@@ -58,7 +59,12 @@ func CreateCombinedHandler(httpHandler, restHandler http.HandlerFunc) http.Handl
 			r.URL.Path == "/api/admin/set-pro" ||
 			r.URL.Path == "/api/admin/set-block" ||
 			r.URL.Path == "/api/admin/settings" ||
-			r.URL.Path == "/api/payment/webhook" {
+			r.URL.Path == "/api/admin/prompts" ||
+			r.URL.Path == "/api/admin/prompts/weight" ||
+			r.URL.Path == "/api/admin/prompts/override" ||
+			r.URL.Path == "/api/payment/webhook" ||
+			strings.HasPrefix(r.URL.Path, "/api/decks/") ||
+			strings.HasPrefix(r.URL.Path, "/api/reports/decks/") {
 			restHandler.ServeHTTP(w, r)
 			return
 		}
@@ -3,20 +3,24 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/amityadav/landr/internal/config"
 	"github.com/amityadav/landr/internal/core"
 	"github.com/amityadav/landr/internal/notifications"
+	"github.com/amityadav/landr/internal/promptregistry"
 	"github.com/amityadav/landr/internal/service"
 	"github.com/amityadav/landr/internal/settings"
 	"github.com/amityadav/landr/internal/store"
 	"github.com/amityadav/landr/internal/token"
+	"github.com/amityadav/landr/prompts"
 )
 
 // Services groups all service dependencies for REST handlers
@@ -24,12 +28,14 @@ type Services struct {
 	Store           *store.PostgresStore
 	AuthService     *service.AuthService
 	LearningService *service.LearningService
+	LearningCore    *core.LearningCore
 	FeedService     *service.FeedService
 	PaymentService  *service.PaymentService
 	FeedCore        *core.FeedCore
 	NotifWorker     *notifications.Worker
 	TokenManager    *token.Manager
 	SettingsService *settings.Service
+	PromptRegistry  *promptregistry.Registry
 }
 
 // CreateRESTHandler creates REST API endpoints
@@ -63,9 +69,23 @@ func CreateRESTHandler(services Services, cfg config.Config) http.HandlerFunc {
 			handleSetUserBlockStatus(w, r, services.Store, services.TokenManager, cfg.FeedAPIKey)
 		case "/api/admin/settings":
 			handleAdminSettings(w, r, services.Store, services.TokenManager, services.SettingsService)
+		case "/api/admin/prompts":
+			handleAdminPrompts(w, r, services.Store, services.TokenManager, cfg.FeedAPIKey)
+		case "/api/admin/prompts/weight":
+			handleAdminPromptWeight(w, r, services.Store, services.TokenManager, cfg.FeedAPIKey, services.PromptRegistry)
+		case "/api/admin/prompts/override":
+			handleAdminPromptOverride(w, r, services.Store, services.TokenManager, cfg.FeedAPIKey)
 		case "/api/payment/webhook":
 			handlePaymentWebhook(w, r, services.PaymentService, cfg.RazorpayWebhookSecret)
 		default:
+			if slug, ok := strings.CutPrefix(r.URL.Path, "/api/decks/"); ok {
+				handlePublicDeck(w, r, services.LearningCore, slug)
+				return
+			}
+			if collectionID, ok := strings.CutPrefix(r.URL.Path, "/api/reports/decks/"); ok {
+				handleDeckProgressCSV(w, r, services.LearningCore, services.TokenManager, collectionID)
+				return
+			}
 			http.NotFound(w, r)
 		}
 	}
@@ -555,19 +575,15 @@ func handlePaymentWebhook(w http.ResponseWriter, r *http.Request, paymentService
 		Payload struct {
 			Subscription struct {
 				Entity struct {
-					ID     string `json:"id"`
-					Status string `json:"status"`
-					Notes  struct {
-						UserID string `json:"user_id"`
-					} `json:"notes"`
+					ID     string       `json:"id"`
+					Status string       `json:"status"`
+					Notes  webhookNotes `json:"notes"`
 				} `json:"entity"`
 			} `json:"subscription"`
 			Payment struct {
 				Entity struct {
-					ID    string `json:"id"`
-					Notes struct {
-						UserID string `json:"user_id"`
-					} `json:"notes"`
+					ID    string       `json:"id"`
+					Notes webhookNotes `json:"notes"`
 				} `json:"entity"`
 			} `json:"payment"`
 		} `json:"payload"`
@@ -585,7 +601,13 @@ func handlePaymentWebhook(w http.ResponseWriter, r *http.Request, paymentService
 	if payload.Event == "subscription.activated" || payload.Event == "subscription.charged" {
 		sub := payload.Payload.Subscription.Entity
 		userID := sub.Notes.UserID
-		if userID != "" {
+		if sub.Notes.OrganizationID != "" {
+			if err := paymentService.HandleOrganizationSubscriptionActivated(r.Context(), sub.Notes.OrganizationID, sub.Notes.seats(), sub.ID); err != nil {
+				log.Printf("[REST] Failed to handle organization subscription activation: %v", err)
+				http.Error(w, `{"error": "internal error"}`, http.StatusInternalServerError)
+				return
+			}
+		} else if userID != "" {
 			if err := paymentService.HandleSubscriptionActivated(r.Context(), userID, "PRO", "active", sub.ID); err != nil {
 				log.Printf("[REST] Failed to handle subscription activation: %v", err)
 				http.Error(w, `{"error": "internal error"}`, http.StatusInternalServerError)
@@ -602,7 +624,14 @@ func handlePaymentWebhook(w http.ResponseWriter, r *http.Request, paymentService
 		userID := pay.Notes.UserID
 		// For one-time payment, we treat it as "active" subscription for MVP.
 		// We use payment ID as reference if no subscription ID.
-		if userID != "" {
+		if pay.Notes.OrganizationID != "" {
+			log.Printf("[REST] Payment captured for organization: %s (PaymentID: %s)", pay.Notes.OrganizationID, pay.ID)
+			if err := paymentService.HandleOrganizationSubscriptionActivated(r.Context(), pay.Notes.OrganizationID, pay.Notes.seats(), "pay_"+pay.ID); err != nil {
+				log.Printf("[REST] Failed to activate organization pro from payment: %v", err)
+				http.Error(w, `{"error": "internal error"}`, http.StatusInternalServerError)
+				return
+			}
+		} else if userID != "" {
 			log.Printf("[REST] Payment captured for user: %s (PaymentID: %s)", userID, pay.ID)
 			// Logic: Set status to ACTIVE.
 			// Ideally we should track "Pro until..." logic. For now, just set to PRO.
@@ -622,6 +651,22 @@ func handlePaymentWebhook(w http.ResponseWriter, r *http.Request, paymentService
 	w.WriteHeader(http.StatusOK)
 }
 
+// webhookNotes are the notes attached to a Razorpay order by CreateSubscriptionOrder
+type webhookNotes struct {
+	UserID         string `json:"user_id"`
+	OrganizationID string `json:"organization_id"`
+	Seats          string `json:"seats"`
+}
+
+// seats returns the number of organization seats paid for (at least 1)
+func (n webhookNotes) seats() int32 {
+	seats, err := strconv.Atoi(n.Seats)
+	if err != nil || seats < 1 {
+		return 1
+	}
+	return int32(seats)
+}
+
 // handleAdminSettings handles GET and POST for admin settings
 // GET: Returns all settings from database
 // POST: Updates a specific setting by key (admin only)
@@ -737,3 +782,315 @@ func handleAdminSettings(w http.ResponseWriter, r *http.Request, st *store.Postg
 		http.Error(w, `{"error": "method not allowed"}`, http.StatusMethodNotAllowed)
 	}
 }
+
+// promptTemplateResponse is the JSON form of a prompt template version
+type promptTemplateResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Version   int       `json:"version"`
+	Variant   string    `json:"variant"`
+	Body      string    `json:"body"`
+	Weight    int       `json:"weight"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toPromptTemplateResponse(t *store.PromptTemplate) promptTemplateResponse {
+	return promptTemplateResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Version:   t.Version,
+		Variant:   t.Variant,
+		Body:      t.Body,
+		Weight:    t.Weight,
+		Notes:     t.Notes,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// handleAdminPrompts lists prompt versions (GET ?name=) or creates a new inactive version (POST)
+func handleAdminPrompts(w http.ResponseWriter, r *http.Request, st *store.PostgresStore, tm *token.Manager, feedAPIKey string) {
+	if err := verifyAdminOrAPIKey(r, st, tm, feedAPIKey); err != nil {
+		http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		templates, err := st.ListPromptTemplates(r.Context(), r.URL.Query().Get("name"))
+		if err != nil {
+			log.Printf("[REST] handleAdminPrompts - failed to list: %v", err)
+			http.Error(w, `{"error": "failed to list prompts"}`, http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]promptTemplateResponse, 0, len(templates))
+		for _, t := range templates {
+			response = append(response, toPromptTemplateResponse(t))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+
+	case "POST":
+		var req struct {
+			Name    string `json:"name"`
+			Variant string `json:"variant"`
+			Body    string `json:"body"`
+			Notes   string `json:"notes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, `{"error": "invalid JSON body"}`, http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" || req.Body == "" {
+			http.Error(w, `{"error": "name and body are required"}`, http.StatusBadRequest)
+			return
+		}
+		if req.Variant == "" {
+			req.Variant = "control"
+		}
+
+		// Reject templates that would fail at generation time
+		if err := prompts.Validate(req.Name, req.Body); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		t, err := st.CreatePromptTemplate(r.Context(), req.Name, req.Variant, req.Body, req.Notes)
+		if err != nil {
+			log.Printf("[REST] handleAdminPrompts - failed to create: %v", err)
+			http.Error(w, `{"error": "failed to create prompt"}`, http.StatusInternalServerError)
+			return
+		}
+
+		log.Printf("[REST] handleAdminPrompts - created %s v%d (%s)", t.Name, t.Version, t.Variant)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(toPromptTemplateResponse(t))
+
+	default:
+		http.Error(w, `{"error": "method not allowed"}`, http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAdminPromptWeight sets a version's share of traffic; weight 0 deactivates it
+func handleAdminPromptWeight(w http.ResponseWriter, r *http.Request, st *store.PostgresStore, tm *token.Manager, feedAPIKey string, registry *promptregistry.Registry) {
+	if r.Method != "POST" {
+		http.Error(w, `{"error": "method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := verifyAdminOrAPIKey(r, st, tm, feedAPIKey); err != nil {
+		http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		ID     string `json:"id"`
+		Weight int    `json:"weight"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error": "invalid JSON body"}`, http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" || req.Weight < 0 {
+		http.Error(w, `{"error": "id and a non-negative weight are required"}`, http.StatusBadRequest)
+		return
+	}
+
+	if err := st.SetPromptTemplateWeight(r.Context(), req.ID, req.Weight); err != nil {
+		log.Printf("[REST] handleAdminPromptWeight - failed: %v", err)
+		http.Error(w, `{"error": "failed to set weight"}`, http.StatusInternalServerError)
+		return
+	}
+
+	if registry != nil {
+		if err := registry.Refresh(r.Context()); err != nil {
+			log.Printf("[REST] handleAdminPromptWeight - warning: failed to refresh cache: %v", err)
+		}
+	}
+
+	log.Printf("[REST] handleAdminPromptWeight - template %s weight set to %d", req.ID, req.Weight)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status": "success", "message": "Prompt weight updated"}`))
+}
+
+// handleAdminPromptOverride pins a user to a prompt version; empty template_id removes the pin
+func handleAdminPromptOverride(w http.ResponseWriter, r *http.Request, st *store.PostgresStore, tm *token.Manager, feedAPIKey string) {
+	if r.Method != "POST" {
+		http.Error(w, `{"error": "method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := verifyAdminOrAPIKey(r, st, tm, feedAPIKey); err != nil {
+		http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Email      string `json:"email"`
+		Name       string `json:"name"`
+		TemplateID string `json:"template_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error": "invalid JSON body"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Email == "" || req.Name == "" {
+		http.Error(w, `{"error": "email and name are required"}`, http.StatusBadRequest)
+		return
+	}
+
+	userID, err := st.GetUserByEmail(r.Context(), req.Email)
+	if err != nil {
+		http.Error(w, `{"error": "user not found"}`, http.StatusNotFound)
+		return
+	}
+
+	if req.TemplateID != "" {
+		t, err := st.GetPromptTemplate(r.Context(), req.TemplateID)
+		if err != nil || t.Name != req.Name {
+			http.Error(w, `{"error": "template not found for this prompt name"}`, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := st.SetUserPromptOverride(r.Context(), userID, req.Name, req.TemplateID); err != nil {
+		log.Printf("[REST] handleAdminPromptOverride - failed: %v", err)
+		http.Error(w, `{"error": "failed to set override"}`, http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("[REST] handleAdminPromptOverride - %s pinned to '%s' for %s", req.Email, req.TemplateID, req.Name)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status": "success", "message": "Prompt override updated"}`))
+}
+
+type publicDeckCardResponse struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+type publicDeckMaterialResponse struct {
+	Title     string                   `json:"title"`
+	SourceURL string                   `json:"source_url,omitempty"`
+	Summary   string                   `json:"summary,omitempty"`
+	Cards     []publicDeckCardResponse `json:"cards"`
+}
+
+type publicDeckResponse struct {
+	Slug        string                       `json:"slug"`
+	Title       string                       `json:"title"`
+	Description string                       `json:"description"`
+	Author      string                       `json:"author"`
+	CardCount   int32                        `json:"card_count"`
+	CloneCount  int32                        `json:"clone_count"`
+	PublishedAt time.Time                    `json:"published_at"`
+	Materials   []publicDeckMaterialResponse `json:"materials"`
+}
+
+// handlePublicDeck serves a published deck read-only, without authentication
+func handlePublicDeck(w http.ResponseWriter, r *http.Request, learningCore *core.LearningCore, slug string) {
+	if r.Method != "GET" {
+		http.Error(w, `{"error": "method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	deck, materials, err := learningCore.GetPublicDeck(r.Context(), slug)
+	if errors.Is(err, store.ErrDeckNotFound) {
+		http.Error(w, `{"error": "deck not found"}`, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("[REST] handlePublicDeck - failed: %v", err)
+		http.Error(w, `{"error": "failed to load deck"}`, http.StatusInternalServerError)
+		return
+	}
+
+	response := publicDeckResponse{
+		Slug:        deck.Slug,
+		Title:       deck.Title,
+		Description: deck.Description,
+		Author:      deck.Author,
+		CardCount:   deck.CardCount,
+		CloneCount:  deck.CloneCount,
+		PublishedAt: deck.PublishedAt,
+		Materials:   make([]publicDeckMaterialResponse, 0, len(materials)),
+	}
+	for _, m := range materials {
+		mr := publicDeckMaterialResponse{Title: m.Title, SourceURL: m.SourceURL, Summary: m.Summary}
+		for _, c := range m.Cards {
+			mr.Cards = append(mr.Cards, publicDeckCardResponse{Question: c.Question, Answer: c.Answer})
+		}
+		response.Materials = append(response.Materials, mr)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleDeckProgressCSV exports the progress report of a shared collection as CSV
+// GET /api/reports/decks/{collection_id}?days=30 (Authorization: Bearer <token>)
+func handleDeckProgressCSV(w http.ResponseWriter, r *http.Request, learningCore *core.LearningCore, tm *token.Manager, collectionID string) {
+	if r.Method != "GET" {
+		http.Error(w, `{"error": "method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		http.Error(w, `{"error": "unauthorized - missing Authorization header"}`, http.StatusUnauthorized)
+		return
+	}
+	tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
+	userID, err := tm.Verify(tokenStr)
+	if err != nil {
+		http.Error(w, `{"error": "unauthorized - invalid token"}`, http.StatusUnauthorized)
+		return
+	}
+
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	report, err := learningCore.GetDeckProgressReport(r.Context(), userID, collectionID, days)
+	switch {
+	case errors.Is(err, store.ErrCollectionNotFound), errors.Is(err, store.ErrOrganizationNotFound):
+		http.Error(w, `{"error": "collection not found"}`, http.StatusNotFound)
+		return
+	case errors.Is(err, core.ErrRoleNotAllowed):
+		http.Error(w, `{"error": "forbidden"}`, http.StatusForbidden)
+		return
+	case err != nil:
+		log.Printf("[REST] handleDeckProgressCSV - failed: %v", err)
+		http.Error(w, `{"error": "failed to build report"}`, http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("progress-%s-%s.csv", slugifyFilename(report.Collection.Name), time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Cache-Control", "no-store")
+	if err := core.WriteDeckProgressCSV(w, report); err != nil {
+		log.Printf("[REST] handleDeckProgressCSV - failed to write CSV: %v", err)
+	}
+}
+
+// slugifyFilename keeps letters, digits and dashes for use in a download name
+func slugifyFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, name)
+	if name = strings.Trim(name, "-"); name == "" {
+		return "deck"
+	}
+	return name
+}
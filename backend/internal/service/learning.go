@@ -2,27 +2,39 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log"
+	"strings"
 
 	"github.com/amityadav/landr/internal/core"
+	"github.com/amityadav/landr/internal/egress"
+	"github.com/amityadav/landr/internal/imaging"
 	"github.com/amityadav/landr/internal/middleware"
+	"github.com/amityadav/landr/internal/scraper"
 	"github.com/amityadav/landr/internal/store"
+	"github.com/amityadav/landr/internal/youtube"
 	"github.com/amityadav/landr/pkg/pb/learning"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type LearningService struct {
 	learning.UnimplementedLearningServiceServer
-	core  *core.LearningCore
-	store *store.PostgresStore
+	core    *core.LearningCore
+	sources *core.SourceCore
+	imports *core.ImportCore
+	store   *store.PostgresStore
 }
 
-func NewLearningService(c *core.LearningCore, s *store.PostgresStore) *LearningService {
+func NewLearningService(c *core.LearningCore, sources *core.SourceCore, imports *core.ImportCore, s *store.PostgresStore) *LearningService {
 	return &LearningService{
-		core:  c,
-		store: s,
+		core:    c,
+		sources: sources,
+		imports: imports,
+		store:   s,
 	}
 }
 
@@ -37,19 +49,68 @@ func (s *LearningService) AddMaterial(ctx context.Context, req *learning.AddMate
 	}
 	log.Printf("[AddMaterial] Using userID: %s", userID)
 
-	materialID, count, title, tags, err := s.core.AddMaterial(ctx, userID, req.Type, req.Content, req.ImageData, req.ExistingTags)
+	result, err := s.core.AddMaterial(ctx, userID, req.Type, req.Content, requestImages(req.Images, req.ImageData), req.Html, req.Language, req.ExistingTags, req.AllowDuplicate)
 	if err != nil {
 		log.Printf("[AddMaterial] ERROR: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to add material: %v", err)
+		return nil, materialError("failed to add material", err)
 	}
 
-	log.Printf("[AddMaterial] SUCCESS - MaterialID: %s, Flashcards created: %d", materialID, count)
-	return &learning.AddMaterialResponse{
-		MaterialId:        materialID,
-		FlashcardsCreated: count,
-		Title:             title,
-		Tags:              tags,
-	}, nil
+	if req.CollectionId != "" && result.DuplicateOf == nil {
+		if err := s.core.SetMaterialCollection(ctx, userID, result.MaterialID, req.CollectionId); err != nil {
+			// Non-critical: the material is saved, it can be moved later
+			log.Printf("[AddMaterial] Failed to add material to collection %s: %v", req.CollectionId, err)
+		}
+	}
+
+	log.Printf("[AddMaterial] SUCCESS - MaterialID: %s, Flashcards created: %d", result.MaterialID, result.FlashcardsCreated)
+	return toAddMaterialResponse(result), nil
+}
+
+// requestImages returns the images of an IMAGE request, in reading order;
+// imageData is the older single-image field
+func requestImages(images []string, imageData string) []string {
+	if len(images) == 0 && imageData != "" {
+		return []string{imageData}
+	}
+	return images
+}
+
+func (s *LearningService) MergeIntoMaterial(ctx context.Context, req *learning.MergeIntoMaterialRequest) (*learning.AddMaterialResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[MergeIntoMaterial] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+	log.Printf("[MergeIntoMaterial] Merging %s content into material: %s for user: %s", req.Type, req.MaterialId, userID)
+
+	result, err := s.core.MergeIntoMaterial(ctx, userID, req.MaterialId, req.Type, req.Content, requestImages(req.Images, req.ImageData), req.Html, req.Language)
+	if err != nil {
+		log.Printf("[MergeIntoMaterial] ERROR: %v", err)
+		return nil, materialError("failed to merge into material", err)
+	}
+
+	log.Printf("[MergeIntoMaterial] SUCCESS - MaterialID: %s, Flashcards added: %d", result.MaterialID, result.FlashcardsCreated)
+	return toAddMaterialResponse(result), nil
+}
+
+func toAddMaterialResponse(result *core.AddMaterialResult) *learning.AddMaterialResponse {
+	resp := &learning.AddMaterialResponse{
+		MaterialId:            result.MaterialID,
+		FlashcardsCreated:     result.FlashcardsCreated,
+		Title:                 result.Title,
+		Tags:                  result.Tags,
+		DuplicateCardsSkipped: result.DuplicateCardsSkipped,
+		UnverifiedCards:       result.UnverifiedCards,
+	}
+	if d := result.DuplicateOf; d != nil {
+		resp.DuplicateOf = &learning.DuplicateMaterial{
+			MaterialId: d.MaterialID,
+			Title:      d.Title,
+			Reason:     d.Reason,
+			Similarity: d.Similarity,
+		}
+	}
+	return resp
 }
 
 func (s *LearningService) DeleteMaterial(ctx context.Context, req *learning.DeleteMaterialRequest) (*emptypb.Empty, error) {
@@ -69,6 +130,36 @@ func (s *LearningService) DeleteMaterial(ctx context.Context, req *learning.Dele
 	return &emptypb.Empty{}, nil
 }
 
+func (s *LearningService) RefreshMaterial(ctx context.Context, req *learning.RefreshMaterialRequest) (*learning.RefreshMaterialResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[RefreshMaterial] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+	log.Printf("[RefreshMaterial] Refreshing material: %s for user: %s", req.MaterialId, userID)
+
+	result, err := s.core.RefreshMaterial(ctx, userID, req.MaterialId)
+	switch {
+	case errors.Is(err, store.ErrMaterialNotFound):
+		return nil, status.Errorf(codes.NotFound, "material not found")
+	case errors.Is(err, core.ErrNotRefreshable), errors.Is(err, core.ErrPageReplaced):
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case err != nil:
+		log.Printf("[RefreshMaterial] ERROR: %v", err)
+		return nil, materialError("failed to refresh material", err)
+	}
+
+	log.Printf("[RefreshMaterial] SUCCESS - MaterialID: %s, Changed: %v, Cards removed: %d, added: %d",
+		req.MaterialId, result.Changed, result.CardsRemoved, result.CardsAdded)
+	return &learning.RefreshMaterialResponse{
+		Changed:      result.Changed,
+		ChangeRatio:  float32(result.ChangeRatio),
+		CardsKept:    result.CardsKept,
+		CardsRemoved: result.CardsRemoved,
+		CardsAdded:   result.CardsAdded,
+	}, nil
+}
+
 func (s *LearningService) GetDueFlashcards(ctx context.Context, req *learning.GetDueFlashcardsRequest) (*learning.FlashcardList, error) {
 	// Extract user ID from context (set by auth interceptor)
 	userID, err := middleware.GetUserID(ctx)
@@ -76,9 +167,19 @@ func (s *LearningService) GetDueFlashcards(ctx context.Context, req *learning.Ge
 		log.Printf("[GetDueFlashcards] ERROR: Failed to get user ID: %v", err)
 		return nil, err
 	}
-	log.Printf("[GetDueFlashcards] Fetching flashcards for userID: %s, materialID: %s", userID, req.MaterialId)
+	log.Printf("[GetDueFlashcards] Fetching flashcards for userID: %s, materialID: %s, collectionID: %s", userID, req.MaterialId, req.CollectionId)
 
-	cards, err := s.core.GetDueFlashcards(ctx, userID, req.MaterialId)
+	var cards []*learning.Flashcard
+	if req.MaterialId == "" && req.CollectionId != "" {
+		cards, err = s.core.GetCollectionDueFlashcards(ctx, userID, req.CollectionId)
+	} else if req.MaterialId == "" && req.ConceptId != "" {
+		cards, err = s.core.GetConceptFlashcards(ctx, userID, req.ConceptId)
+	} else {
+		cards, err = s.core.GetDueFlashcards(ctx, userID, req.MaterialId)
+	}
+	if errors.Is(err, store.ErrCollectionNotFound) || errors.Is(err, store.ErrConceptNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
 	if err != nil {
 		log.Printf("[GetDueFlashcards] ERROR: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to get due flashcards: %v", err)
@@ -108,9 +209,9 @@ func (s *LearningService) GetDueMaterials(ctx context.Context, req *learning.Get
 		pageSize = 10 // Default page size
 	}
 
-	log.Printf("[GetDueMaterials] Fetching materials for userID: %s, page: %d, pageSize: %d, search: %s, tags: %v, onlyDue: %v", userID, page, pageSize, req.SearchQuery, req.Tags, req.OnlyDue)
+	log.Printf("[GetDueMaterials] Fetching materials for userID: %s, page: %d, pageSize: %d, search: %s, tags: %v, collection: %s, onlyDue: %v", userID, page, pageSize, req.SearchQuery, req.Tags, req.CollectionId, req.OnlyDue)
 
-	materials, totalCount, err := s.core.GetDueMaterials(ctx, userID, page, pageSize, req.SearchQuery, req.Tags, req.OnlyDue)
+	materials, totalCount, err := s.core.GetDueMaterials(ctx, userID, page, pageSize, req.SearchQuery, req.Tags, req.CollectionId, req.OnlyDue)
 	if err != nil {
 		log.Printf("[GetDueMaterials] ERROR: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to get due materials: %v", err)
@@ -130,9 +231,14 @@ func (s *LearningService) GetDueMaterials(ctx context.Context, req *learning.Get
 }
 
 func (s *LearningService) CompleteReview(ctx context.Context, req *learning.CompleteReviewRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[CompleteReview] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
 	log.Printf("[CompleteReview] Completing review for flashcardID: %s", req.FlashcardId)
 
-	if err := s.core.CompleteReview(ctx, req.FlashcardId); err != nil {
+	if err := s.core.CompleteReview(ctx, userID, req.FlashcardId); err != nil {
 		log.Printf("[CompleteReview] ERROR: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to complete review: %v", err)
 	}
@@ -142,9 +248,14 @@ func (s *LearningService) CompleteReview(ctx context.Context, req *learning.Comp
 }
 
 func (s *LearningService) FailReview(ctx context.Context, req *learning.FailReviewRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[FailReview] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
 	log.Printf("[FailReview] Failing review for flashcardID: %s", req.FlashcardId)
 
-	if err := s.core.FailReview(ctx, req.FlashcardId); err != nil {
+	if err := s.core.FailReview(ctx, userID, req.FlashcardId); err != nil {
 		log.Printf("[FailReview] ERROR: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to fail review: %v", err)
 	}
@@ -154,9 +265,21 @@ func (s *LearningService) FailReview(ctx context.Context, req *learning.FailRevi
 }
 
 func (s *LearningService) UpdateFlashcard(ctx context.Context, req *learning.UpdateFlashcardRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[UpdateFlashcard] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
 	log.Printf("[UpdateFlashcard] Updating flashcardID: %s", req.FlashcardId)
 
-	if err := s.core.UpdateFlashcard(ctx, req.FlashcardId, req.Question, req.Answer); err != nil {
+	err = s.core.UpdateFlashcard(ctx, userID, req.FlashcardId, req.Question, req.Answer)
+	if errors.Is(err, store.ErrFlashcardNotFound) {
+		return nil, status.Errorf(codes.NotFound, "flashcard not found")
+	}
+	if errors.Is(err, core.ErrRoleNotAllowed) {
+		return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+	if err != nil {
 		log.Printf("[UpdateFlashcard] ERROR: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to update flashcard: %v", err)
 	}
@@ -165,6 +288,52 @@ func (s *LearningService) UpdateFlashcard(ctx context.Context, req *learning.Upd
 	return &emptypb.Empty{}, nil
 }
 
+func (s *LearningService) SetFlashcardDependencies(ctx context.Context, req *learning.SetFlashcardDependenciesRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[SetFlashcardDependencies] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	err = s.core.SetFlashcardDependencies(ctx, userID, req.FlashcardId, req.DependsOnIds)
+	if errors.Is(err, store.ErrFlashcardNotFound) {
+		return nil, status.Errorf(codes.NotFound, "flashcard not found")
+	}
+	if errors.Is(err, store.ErrDependencyCycle) {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err != nil {
+		log.Printf("[SetFlashcardDependencies] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to set flashcard dependencies: %v", err)
+	}
+
+	log.Printf("[SetFlashcardDependencies] SUCCESS - FlashcardID: %s, %d dependencies", req.FlashcardId, len(req.DependsOnIds))
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) SearchLibrary(ctx context.Context, req *learning.SearchLibraryRequest) (*learning.SearchLibraryResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[SearchLibrary] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "query is required")
+	}
+
+	log.Printf("[SearchLibrary] userID: %s, query: %q", userID, req.Query)
+
+	results, err := s.core.SearchLibrary(ctx, userID, req.Query, int(req.Limit), req.Semantic)
+	if err != nil {
+		log.Printf("[SearchLibrary] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to search library: %v", err)
+	}
+
+	log.Printf("[SearchLibrary] SUCCESS - %d results", len(results))
+	return &learning.SearchLibraryResponse{Results: results}, nil
+}
+
 func (s *LearningService) GetAllTags(ctx context.Context, _ *emptypb.Empty) (*learning.GetAllTagsResponse, error) {
 	// Extract user ID from context (set by auth interceptor)
 	userID, err := middleware.GetUserID(ctx)
@@ -187,73 +356,1153 @@ func (s *LearningService) GetAllTags(ctx context.Context, _ *emptypb.Empty) (*le
 	}, nil
 }
 
-func (s *LearningService) GetNotificationStatus(ctx context.Context, _ *emptypb.Empty) (*learning.NotificationStatusResponse, error) {
-	// Extract user ID from context (set by auth interceptor)
+func (s *LearningService) CreateCollection(ctx context.Context, req *learning.Collection) (*learning.Collection, error) {
+	req.Id = ""
+	return s.saveCollection(ctx, "CreateCollection", req)
+}
+
+func (s *LearningService) UpdateCollection(ctx context.Context, req *learning.Collection) (*learning.Collection, error) {
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "id is required")
+	}
+	return s.saveCollection(ctx, "UpdateCollection", req)
+}
+
+// saveCollection handles CreateCollection and UpdateCollection
+func (s *LearningService) saveCollection(ctx context.Context, method string, req *learning.Collection) (*learning.Collection, error) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		log.Printf("[GetNotificationStatus] ERROR: Failed to get user ID: %v", err)
+		log.Printf("[%s] ERROR: Failed to get user ID: %v", method, err)
 		return nil, err
 	}
 
-	log.Printf("[GetNotificationStatus] Fetching notification status for userID: %s", userID)
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	if !core.ValidSchedulerPreset(req.SchedulerPreset) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown scheduler_preset %q", req.SchedulerPreset)
+	}
 
-	// Fetch user to get Pro status
-	user, err := s.store.GetUserByID(ctx, userID)
+	col, err := s.core.SaveCollection(ctx, userID, &store.Collection{
+		ID:              req.Id,
+		Name:            req.Name,
+		Description:     req.Description,
+		CoverURL:        req.CoverUrl,
+		NewCardsPerDay:  req.NewCardsPerDay,
+		SchedulerPreset: req.SchedulerPreset,
+	})
+	if errors.Is(err, store.ErrCollectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "collection not found")
+	}
 	if err != nil {
-		log.Printf("[GetNotificationStatus] WARNING: Failed to get user profile: %v", err)
-		// Continue implies user is nil, so fields will be false
+		log.Printf("[%s] ERROR: %v", method, err)
+		return nil, status.Errorf(codes.Internal, "failed to save collection: %v", err)
 	}
 
-	flashcardCount, hasDue, materialCount, firstTitle, err := s.core.GetNotificationStatus(ctx, userID)
+	log.Printf("[%s] SUCCESS - CollectionID: %s", method, col.ID)
+	return toCollection(col), nil
+}
+
+func (s *LearningService) DeleteCollection(ctx context.Context, req *learning.DeleteCollectionRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		log.Printf("[GetNotificationStatus] ERROR: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to get notification status: %v", err)
+		log.Printf("[DeleteCollection] ERROR: Failed to get user ID: %v", err)
+		return nil, err
 	}
 
-	isPro := false
-	isBlocked := false
-	if user != nil {
-		isPro = user.IsPro
-		isBlocked = user.IsBlocked
+	err = s.core.DeleteCollection(ctx, userID, req.Id)
+	if errors.Is(err, store.ErrCollectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		log.Printf("[DeleteCollection] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to delete collection: %v", err)
 	}
 
-	log.Printf("[GetNotificationStatus] SUCCESS - %d due flashcards, %d materials, Pro: %v", flashcardCount, materialCount, isPro)
-	return &learning.NotificationStatusResponse{
-		DueFlashcardsCount:    flashcardCount,
-		HasDueMaterials:       hasDue,
-		DueMaterialsCount:     materialCount,
-		FirstDueMaterialTitle: firstTitle,
-		IsPro:                 isPro,
-		IsBlocked:             isBlocked,
-	}, nil
+	log.Printf("[DeleteCollection] SUCCESS - CollectionID: %s", req.Id)
+	return &emptypb.Empty{}, nil
 }
 
-func (s *LearningService) GetMaterialSummary(ctx context.Context, req *learning.GetMaterialSummaryRequest) (*learning.GetMaterialSummaryResponse, error) {
-	// Extract user ID from context (set by auth interceptor)
+func (s *LearningService) ListCollections(ctx context.Context, _ *emptypb.Empty) (*learning.ListCollectionsResponse, error) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		log.Printf("[GetMaterialSummary] ERROR: Failed to get user ID: %v", err)
+		log.Printf("[ListCollections] ERROR: Failed to get user ID: %v", err)
 		return nil, err
 	}
 
-	log.Printf("[GetMaterialSummary] Fetching summary for materialID: %s, userID: %s", req.MaterialId, userID)
+	collections, err := s.core.ListCollections(ctx, userID)
+	if err != nil {
+		log.Printf("[ListCollections] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list collections: %v", err)
+	}
 
-	result, err := s.core.GetMaterialSummary(ctx, userID, req.MaterialId)
+	resp := &learning.ListCollectionsResponse{}
+	for _, col := range collections {
+		resp.Collections = append(resp.Collections, toCollection(col))
+	}
+	log.Printf("[ListCollections] SUCCESS - Found %d collections", len(resp.Collections))
+	return resp, nil
+}
+
+func (s *LearningService) ReorderCollections(ctx context.Context, req *learning.ReorderCollectionsRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		log.Printf("[GetMaterialSummary] ERROR: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to get material summary: %v", err)
+		log.Printf("[ReorderCollections] ERROR: Failed to get user ID: %v", err)
+		return nil, err
 	}
 
-	log.Printf("[GetMaterialSummary] SUCCESS - Summary length: %d, Type: %s", len(result.Summary), result.MaterialType)
-	return &learning.GetMaterialSummaryResponse{
-		Summary:      result.Summary,
-		Title:        result.Title,
-		Content:      result.Content,
-		MaterialType: result.MaterialType,
-		SourceUrl:    result.SourceURL,
+	if err := s.core.ReorderCollections(ctx, userID, req.Ids); err != nil {
+		log.Printf("[ReorderCollections] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to reorder collections: %v", err)
+	}
+
+	log.Printf("[ReorderCollections] SUCCESS - %d collections", len(req.Ids))
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) SetMaterialCollection(ctx context.Context, req *learning.SetMaterialCollectionRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[SetMaterialCollection] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if err := s.core.SetMaterialCollection(ctx, userID, req.MaterialId, req.CollectionId); err != nil {
+		log.Printf("[SetMaterialCollection] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to set material collection: %v", err)
+	}
+
+	log.Printf("[SetMaterialCollection] SUCCESS - MaterialID: %s, CollectionID: %s", req.MaterialId, req.CollectionId)
+	return &emptypb.Empty{}, nil
+}
+
+func toCollection(col *store.Collection) *learning.Collection {
+	return &learning.Collection{
+		Id:              col.ID,
+		Name:            col.Name,
+		Description:     col.Description,
+		CoverUrl:        col.CoverURL,
+		Position:        col.Position,
+		NewCardsPerDay:  col.NewCardsPerDay,
+		SchedulerPreset: col.SchedulerPreset,
+		MaterialCount:   col.MaterialCount,
+		DueCount:        col.DueCount,
+		OrganizationId:  col.OrganizationID,
+	}
+}
+
+func (s *LearningService) PublishDeck(ctx context.Context, req *learning.PublishDeckRequest) (*learning.PublicDeck, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[PublishDeck] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if (req.MaterialId == "") == (req.CollectionId == "") {
+		return nil, status.Errorf(codes.InvalidArgument, "exactly one of material_id or collection_id is required")
+	}
+	if req.Slug != "" && !core.ValidDeckSlug(strings.ToLower(strings.TrimSpace(req.Slug))) {
+		return nil, status.Errorf(codes.InvalidArgument, "slug must be 3-80 lowercase letters, digits and dashes")
+	}
+
+	deck, err := s.core.PublishDeck(ctx, userID, req.MaterialId, req.CollectionId, req.Slug, req.Description)
+	if errors.Is(err, store.ErrSlugTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "slug is already taken")
+	}
+	if errors.Is(err, store.ErrCollectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "collection not found")
+	}
+	if errors.Is(err, core.ErrSharedCollectionDeck) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if err != nil {
+		log.Printf("[PublishDeck] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to publish deck: %v", err)
+	}
+
+	log.Printf("[PublishDeck] SUCCESS - Slug: %s, Cards: %d", deck.Slug, deck.CardCount)
+	return toPublicDeck(deck), nil
+}
+
+func (s *LearningService) UnpublishDeck(ctx context.Context, req *learning.UnpublishDeckRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[UnpublishDeck] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	err = s.core.UnpublishDeck(ctx, userID, req.Slug)
+	if errors.Is(err, store.ErrDeckNotFound) {
+		return nil, status.Errorf(codes.NotFound, "deck not found")
+	}
+	if err != nil {
+		log.Printf("[UnpublishDeck] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to unpublish deck: %v", err)
+	}
+
+	log.Printf("[UnpublishDeck] SUCCESS - Slug: %s", req.Slug)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) ListPublicDecks(ctx context.Context, _ *emptypb.Empty) (*learning.ListPublicDecksResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ListPublicDecks] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	decks, err := s.core.ListPublicDecks(ctx, userID)
+	if err != nil {
+		log.Printf("[ListPublicDecks] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list public decks: %v", err)
+	}
+
+	resp := &learning.ListPublicDecksResponse{}
+	for _, d := range decks {
+		resp.Decks = append(resp.Decks, toPublicDeck(d))
+	}
+	log.Printf("[ListPublicDecks] SUCCESS - Found %d decks", len(resp.Decks))
+	return resp, nil
+}
+
+func (s *LearningService) CloneDeck(ctx context.Context, req *learning.CloneDeckRequest) (*learning.CloneDeckResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[CloneDeck] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	result, err := s.core.CloneDeck(ctx, userID, req.Slug, req.CollectionId)
+	if errors.Is(err, store.ErrDeckNotFound) {
+		return nil, status.Errorf(codes.NotFound, "deck not found")
+	}
+	if errors.Is(err, store.ErrCollectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		log.Printf("[CloneDeck] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to clone deck: %v", err)
+	}
+
+	log.Printf("[CloneDeck] SUCCESS - Slug: %s, Materials: %d, Flashcards: %d", req.Slug, len(result.MaterialIDs), result.FlashcardsCreated)
+	return &learning.CloneDeckResponse{
+		CollectionId:      result.CollectionID,
+		MaterialIds:       result.MaterialIDs,
+		FlashcardsCreated: result.FlashcardsCreated,
 	}, nil
 }
 
+func toPublicDeck(d *store.PublicDeck) *learning.PublicDeck {
+	return &learning.PublicDeck{
+		Slug:         d.Slug,
+		Title:        d.Title,
+		Description:  d.Description,
+		Author:       d.Author,
+		CardCount:    d.CardCount,
+		CloneCount:   d.CloneCount,
+		MaterialId:   d.MaterialID,
+		CollectionId: d.CollectionID,
+		PublishedAt:  timestamppb.New(d.PublishedAt),
+	}
+}
+
+func (s *LearningService) ListTags(ctx context.Context, _ *emptypb.Empty) (*learning.ListTagsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ListTags] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	tags, err := s.core.ListTags(ctx, userID)
+	if err != nil {
+		log.Printf("[ListTags] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+
+	resp := &learning.ListTagsResponse{}
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, &learning.TagInfo{Name: t.Name, MaterialCount: int32(t.MaterialCount)})
+	}
+	log.Printf("[ListTags] SUCCESS - Found %d tags", len(resp.Tags))
+	return resp, nil
+}
+
+func (s *LearningService) RenameTag(ctx context.Context, req *learning.RenameTagRequest) (*learning.TagChangeResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[RenameTag] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.NewName) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name and new_name are required")
+	}
+
+	moved, err := s.core.RenameTag(ctx, userID, req.Name, req.NewName)
+	if err != nil {
+		log.Printf("[RenameTag] ERROR: %v", err)
+		return nil, tagError("failed to rename tag", err)
+	}
+
+	log.Printf("[RenameTag] SUCCESS - %d tags moved", moved)
+	return &learning.TagChangeResponse{TagsAffected: int32(moved)}, nil
+}
+
+func (s *LearningService) MergeTags(ctx context.Context, req *learning.MergeTagsRequest) (*learning.TagChangeResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[MergeTags] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if len(req.Sources) == 0 || strings.TrimSpace(req.Target) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "sources and target are required")
+	}
+
+	merged, err := s.core.MergeTags(ctx, userID, req.Sources, req.Target)
+	if err != nil {
+		log.Printf("[MergeTags] ERROR: %v", err)
+		return nil, tagError("failed to merge tags", err)
+	}
+
+	log.Printf("[MergeTags] SUCCESS - %d tags merged", merged)
+	return &learning.TagChangeResponse{TagsAffected: int32(merged)}, nil
+}
+
+func (s *LearningService) DeleteTag(ctx context.Context, req *learning.DeleteTagRequest) (*learning.TagChangeResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[DeleteTag] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	deleted, err := s.core.DeleteTag(ctx, userID, req.Name, req.Recursive)
+	if err != nil {
+		log.Printf("[DeleteTag] ERROR: %v", err)
+		return nil, tagError("failed to delete tag", err)
+	}
+
+	log.Printf("[DeleteTag] SUCCESS - %d tags deleted", deleted)
+	return &learning.TagChangeResponse{TagsAffected: int32(deleted)}, nil
+}
+
+func (s *LearningService) SetMaterialTags(ctx context.Context, req *learning.SetMaterialTagsRequest) (*learning.SetMaterialTagsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[SetMaterialTags] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	tags, err := s.core.SetMaterialTags(ctx, userID, req.MaterialId, req.Tags)
+	if err != nil {
+		log.Printf("[SetMaterialTags] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to set material tags: %v", err)
+	}
+
+	log.Printf("[SetMaterialTags] SUCCESS - MaterialID: %s, Tags: %v", req.MaterialId, tags)
+	return &learning.SetMaterialTagsResponse{Tags: tags}, nil
+}
+
+func (s *LearningService) GetTagMergeSuggestions(ctx context.Context, req *learning.GetTagMergeSuggestionsRequest) (*learning.GetTagMergeSuggestionsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetTagMergeSuggestions] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	suggestions, err := s.core.GetTagMergeSuggestions(ctx, userID, req.Refresh)
+	if err != nil {
+		log.Printf("[GetTagMergeSuggestions] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get tag merge suggestions: %v", err)
+	}
+
+	resp := &learning.GetTagMergeSuggestionsResponse{}
+	for _, sg := range suggestions {
+		resp.Suggestions = append(resp.Suggestions, &learning.TagMergeSuggestion{
+			Id:        sg.ID,
+			SourceTag: sg.SourceTag,
+			TargetTag: sg.TargetTag,
+			Reason:    sg.Reason,
+			Score:     sg.Score,
+		})
+	}
+	log.Printf("[GetTagMergeSuggestions] SUCCESS - %d suggestions", len(resp.Suggestions))
+	return resp, nil
+}
+
+func (s *LearningService) ResolveTagMergeSuggestion(ctx context.Context, req *learning.ResolveTagMergeSuggestionRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ResolveTagMergeSuggestion] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if err := s.core.ResolveTagMergeSuggestion(ctx, userID, req.Id, req.Accept); err != nil {
+		log.Printf("[ResolveTagMergeSuggestion] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to resolve tag merge suggestion: %v", err)
+	}
+
+	log.Printf("[ResolveTagMergeSuggestion] SUCCESS - ID: %s, Accept: %v", req.Id, req.Accept)
+	return &emptypb.Empty{}, nil
+}
+
+// tagError maps unknown tags to NotFound
+func (s *LearningService) CreateOrganization(ctx context.Context, req *learning.CreateOrganizationRequest) (*learning.Organization, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[CreateOrganization] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	org, err := s.core.CreateOrganization(ctx, userID, req.Name)
+	if err != nil {
+		log.Printf("[CreateOrganization] ERROR: %v", err)
+		return nil, orgError("failed to create organization", err)
+	}
+
+	log.Printf("[CreateOrganization] SUCCESS - OrganizationID: %s", org.ID)
+	return toOrganization(org, userID), nil
+}
+
+func (s *LearningService) ListOrganizations(ctx context.Context, _ *emptypb.Empty) (*learning.ListOrganizationsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ListOrganizations] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	orgs, err := s.core.ListOrganizations(ctx, userID)
+	if err != nil {
+		log.Printf("[ListOrganizations] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list organizations: %v", err)
+	}
+
+	resp := &learning.ListOrganizationsResponse{}
+	for _, o := range orgs {
+		resp.Organizations = append(resp.Organizations, toOrganization(o, userID))
+	}
+	log.Printf("[ListOrganizations] SUCCESS - Found %d organizations", len(resp.Organizations))
+	return resp, nil
+}
+
+func (s *LearningService) DeleteOrganization(ctx context.Context, req *learning.DeleteOrganizationRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[DeleteOrganization] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if err := s.core.DeleteOrganization(ctx, userID, req.OrganizationId); err != nil {
+		log.Printf("[DeleteOrganization] ERROR: %v", err)
+		return nil, orgError("failed to delete organization", err)
+	}
+
+	log.Printf("[DeleteOrganization] SUCCESS - OrganizationID: %s", req.OrganizationId)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) GetOrganizationMembers(ctx context.Context, req *learning.GetOrganizationMembersRequest) (*learning.OrganizationMembersResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetOrganizationMembers] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	members, err := s.core.GetOrganizationMembers(ctx, userID, req.OrganizationId)
+	if err != nil {
+		log.Printf("[GetOrganizationMembers] ERROR: %v", err)
+		return nil, orgError("failed to get organization members", err)
+	}
+
+	log.Printf("[GetOrganizationMembers] SUCCESS - Found %d members", len(members))
+	return toOrganizationMembers(members), nil
+}
+
+func (s *LearningService) AddOrganizationMember(ctx context.Context, req *learning.AddOrganizationMemberRequest) (*learning.OrganizationMembersResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[AddOrganizationMember] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	members, err := s.core.AddOrganizationMember(ctx, userID, req.OrganizationId, req.Email, req.Role)
+	if err != nil {
+		log.Printf("[AddOrganizationMember] ERROR: %v", err)
+		return nil, orgError("failed to add organization member", err)
+	}
+
+	log.Printf("[AddOrganizationMember] SUCCESS - OrganizationID: %s", req.OrganizationId)
+	return toOrganizationMembers(members), nil
+}
+
+func (s *LearningService) SetOrganizationMemberRole(ctx context.Context, req *learning.SetOrganizationMemberRoleRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[SetOrganizationMemberRole] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if err := s.core.SetOrganizationMemberRole(ctx, userID, req.OrganizationId, req.UserId, req.Role); err != nil {
+		log.Printf("[SetOrganizationMemberRole] ERROR: %v", err)
+		return nil, orgError("failed to set member role", err)
+	}
+
+	log.Printf("[SetOrganizationMemberRole] SUCCESS - %s is now %s", req.UserId, req.Role)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) RemoveOrganizationMember(ctx context.Context, req *learning.RemoveOrganizationMemberRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[RemoveOrganizationMember] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if err := s.core.RemoveOrganizationMember(ctx, userID, req.OrganizationId, req.UserId); err != nil {
+		log.Printf("[RemoveOrganizationMember] ERROR: %v", err)
+		return nil, orgError("failed to remove organization member", err)
+	}
+
+	log.Printf("[RemoveOrganizationMember] SUCCESS - Removed %s", req.UserId)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) ShareCollection(ctx context.Context, req *learning.ShareCollectionRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ShareCollection] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	err = s.core.ShareCollection(ctx, userID, req.CollectionId, req.OrganizationId)
+	if errors.Is(err, store.ErrCollectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		log.Printf("[ShareCollection] ERROR: %v", err)
+		return nil, orgError("failed to share collection", err)
+	}
+
+	log.Printf("[ShareCollection] SUCCESS - CollectionID: %s", req.CollectionId)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) ListOrganizationCollections(ctx context.Context, req *learning.ListOrganizationCollectionsRequest) (*learning.ListCollectionsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ListOrganizationCollections] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	collections, err := s.core.ListOrganizationCollections(ctx, userID, req.OrganizationId)
+	if err != nil {
+		log.Printf("[ListOrganizationCollections] ERROR: %v", err)
+		return nil, orgError("failed to list organization collections", err)
+	}
+
+	resp := &learning.ListCollectionsResponse{}
+	for _, col := range collections {
+		resp.Collections = append(resp.Collections, toCollection(col))
+	}
+	log.Printf("[ListOrganizationCollections] SUCCESS - Found %d collections", len(resp.Collections))
+	return resp, nil
+}
+
+func (s *LearningService) GetDeckProgressReport(ctx context.Context, req *learning.GetDeckProgressReportRequest) (*learning.DeckProgressReport, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetDeckProgressReport] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	report, err := s.core.GetDeckProgressReport(ctx, userID, req.CollectionId, int(req.Days))
+	if errors.Is(err, store.ErrCollectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		log.Printf("[GetDeckProgressReport] ERROR: %v", err)
+		return nil, orgError("failed to get progress report", err)
+	}
+
+	resp := &learning.DeckProgressReport{
+		CollectionId:   report.Collection.ID,
+		CollectionName: report.Collection.Name,
+		Days:           int32(report.Days),
+		OptedOutCount:  int32(report.OptedOut),
+		Completion:     float32(report.Completion),
+		Retention:      float32(report.Retention),
+		OverdueCards:   report.OverdueCards,
+	}
+	for _, p := range report.Members {
+		m := &learning.MemberProgress{
+			UserId:       p.UserID,
+			Email:        p.Email,
+			Name:         p.Name,
+			TotalCards:   p.TotalCards,
+			StudiedCards: p.StudiedCards,
+			Completion:   float32(p.Completion()),
+			OverdueCards: p.OverdueCards,
+			Reviews:      p.Reviews,
+			Retention:    float32(p.Retention()),
+		}
+		if p.LastReviewedAt != nil {
+			m.LastReviewedAt = timestamppb.New(*p.LastReviewedAt)
+		}
+		resp.Members = append(resp.Members, m)
+	}
+	log.Printf("[GetDeckProgressReport] SUCCESS - %d members (%d opted out)", len(resp.Members), report.OptedOut)
+	return resp, nil
+}
+
+func (s *LearningService) SetReportingOptOut(ctx context.Context, req *learning.SetReportingOptOutRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[SetReportingOptOut] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if err := s.core.SetReportingOptOut(ctx, userID, req.OrganizationId, req.OptOut); err != nil {
+		log.Printf("[SetReportingOptOut] ERROR: %v", err)
+		return nil, orgError("failed to set reporting opt-out", err)
+	}
+
+	log.Printf("[SetReportingOptOut] SUCCESS - OrganizationID: %s, OptOut: %v", req.OrganizationId, req.OptOut)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LearningService) GetConceptGraph(ctx context.Context, req *learning.GetConceptGraphRequest) (*learning.ConceptGraph, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetConceptGraph] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	graph, err := s.core.GetConceptGraph(ctx, userID, req.ConceptId, int(req.Limit))
+	if errors.Is(err, store.ErrConceptNotFound) {
+		return nil, status.Errorf(codes.NotFound, "concept not found")
+	}
+	if err != nil {
+		log.Printf("[GetConceptGraph] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get concept graph: %v", err)
+	}
+
+	resp := &learning.ConceptGraph{Materials: toRelatedMaterials(graph.Materials)}
+	for _, c := range graph.Concepts {
+		resp.Concepts = append(resp.Concepts, &learning.Concept{
+			Id:             c.ID,
+			Name:           c.Name,
+			Description:    c.Description,
+			MaterialCount:  c.MaterialCount,
+			FlashcardCount: c.FlashcardCount,
+		})
+	}
+	for _, r := range graph.Relations {
+		resp.Edges = append(resp.Edges, &learning.ConceptEdge{
+			SourceId: r.SourceID,
+			TargetId: r.TargetID,
+			Relation: r.Relation,
+			Weight:   r.Weight,
+		})
+	}
+
+	log.Printf("[GetConceptGraph] SUCCESS - %d concepts, %d edges", len(resp.Concepts), len(resp.Edges))
+	return resp, nil
+}
+
+func (s *LearningService) GetRelatedMaterials(ctx context.Context, req *learning.GetRelatedMaterialsRequest) (*learning.GetRelatedMaterialsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetRelatedMaterials] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	materials, err := s.core.GetRelatedMaterials(ctx, userID, req.MaterialId, int(req.Limit))
+	if errors.Is(err, store.ErrMaterialNotFound) {
+		return nil, status.Errorf(codes.NotFound, "material not found")
+	}
+	if err != nil {
+		log.Printf("[GetRelatedMaterials] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get related materials: %v", err)
+	}
+
+	log.Printf("[GetRelatedMaterials] SUCCESS - MaterialID: %s, %d related", req.MaterialId, len(materials))
+	return &learning.GetRelatedMaterialsResponse{Materials: toRelatedMaterials(materials)}, nil
+}
+
+func toRelatedMaterials(materials []*store.RelatedMaterial) []*learning.RelatedMaterial {
+	result := make([]*learning.RelatedMaterial, len(materials))
+	for i, m := range materials {
+		result[i] = &learning.RelatedMaterial{
+			MaterialId:     m.ID,
+			Title:          m.Title,
+			SharedConcepts: m.SharedConcepts,
+			Score:          m.Score,
+		}
+	}
+	return result
+}
+
+func toOrganization(o *store.Organization, userID string) *learning.Organization {
+	org := &learning.Organization{
+		Id:              o.ID,
+		Name:            o.Name,
+		Role:            o.Role,
+		Seats:           o.Seats,
+		MemberCount:     o.MemberCount,
+		Plan:            string(o.Plan),
+		IsCreator:       o.OwnerID == userID,
+		ReportingOptOut: o.ReportingOptOut,
+	}
+	if o.CurrentPeriodEnd != nil {
+		org.CurrentPeriodEnd = timestamppb.New(*o.CurrentPeriodEnd)
+	}
+	return org
+}
+
+func toOrganizationMembers(members []*store.OrganizationMember) *learning.OrganizationMembersResponse {
+	resp := &learning.OrganizationMembersResponse{}
+	for _, m := range members {
+		resp.Members = append(resp.Members, &learning.OrganizationMember{
+			UserId:   m.UserID,
+			Email:    m.Email,
+			Name:     m.Name,
+			Picture:  m.Picture,
+			Role:     m.Role,
+			JoinedAt: timestamppb.New(m.JoinedAt),
+		})
+	}
+	return resp
+}
+
+func (s *LearningService) CreateSource(ctx context.Context, req *learning.CreateSourceRequest) (*learning.Source, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[CreateSource] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Url) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "url is required")
+	}
+
+	src, err := s.sources.CreateSource(ctx, userID, strings.TrimSpace(req.Url), req.Tag)
+	if err != nil {
+		log.Printf("[CreateSource] ERROR: %v", err)
+		return nil, sourceError("failed to subscribe", err)
+	}
+
+	log.Printf("[CreateSource] SUCCESS - SourceID: %s, Kind: %s", src.ID, src.Kind)
+	return toSource(src), nil
+}
+
+func (s *LearningService) ListSources(ctx context.Context, _ *emptypb.Empty) (*learning.ListSourcesResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ListSources] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	sources, err := s.sources.ListSources(ctx, userID)
+	if err != nil {
+		log.Printf("[ListSources] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list sources: %v", err)
+	}
+
+	resp := &learning.ListSourcesResponse{}
+	for _, src := range sources {
+		resp.Sources = append(resp.Sources, toSource(src))
+	}
+	log.Printf("[ListSources] SUCCESS - Found %d sources", len(resp.Sources))
+	return resp, nil
+}
+
+func (s *LearningService) DeleteSource(ctx context.Context, req *learning.DeleteSourceRequest) (*emptypb.Empty, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[DeleteSource] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if err := s.sources.DeleteSource(ctx, userID, req.Id); err != nil {
+		log.Printf("[DeleteSource] ERROR: %v", err)
+		return nil, sourceError("failed to delete source", err)
+	}
+
+	log.Printf("[DeleteSource] SUCCESS - SourceID: %s", req.Id)
+	return &emptypb.Empty{}, nil
+}
+
+func toSource(src *store.Source) *learning.Source {
+	resp := &learning.Source{
+		Id:            src.ID,
+		Url:           src.URL,
+		FeedUrl:       src.FeedURL,
+		Kind:          src.Kind,
+		Title:         src.Title,
+		Tag:           src.Tag,
+		ItemsImported: src.ItemsImported,
+		LastError:     src.LastError,
+		CreatedAt:     timestamppb.New(src.CreatedAt),
+	}
+	if src.LastPolledAt != nil {
+		resp.LastPolledAt = timestamppb.New(*src.LastPolledAt)
+	}
+	return resp
+}
+
+func (s *LearningService) StartImport(ctx context.Context, req *learning.StartImportRequest) (*learning.ImportJob, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[StartImport] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Url) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "url is required")
+	}
+
+	job, err := s.imports.StartImport(ctx, userID, strings.TrimSpace(req.Url), req.CollectionId, req.Language)
+	if err != nil {
+		log.Printf("[StartImport] ERROR: %v", err)
+		return nil, importError("failed to start import", err)
+	}
+
+	log.Printf("[StartImport] SUCCESS - JobID: %s, Videos: %d", job.ID, job.Total)
+	return toImportJob(job, nil), nil
+}
+
+func (s *LearningService) ListImports(ctx context.Context, _ *emptypb.Empty) (*learning.ListImportsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[ListImports] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	jobs, err := s.imports.ListImports(ctx, userID)
+	if err != nil {
+		log.Printf("[ListImports] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list imports: %v", err)
+	}
+
+	resp := &learning.ListImportsResponse{}
+	for _, job := range jobs {
+		resp.Imports = append(resp.Imports, toImportJob(job, nil))
+	}
+	log.Printf("[ListImports] SUCCESS - Found %d imports", len(resp.Imports))
+	return resp, nil
+}
+
+func (s *LearningService) GetImport(ctx context.Context, req *learning.GetImportRequest) (*learning.ImportJob, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetImport] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	job, items, err := s.imports.GetImport(ctx, userID, req.Id)
+	if err != nil {
+		log.Printf("[GetImport] ERROR: %v", err)
+		return nil, importError("failed to get import", err)
+	}
+
+	log.Printf("[GetImport] SUCCESS - JobID: %s, Status: %s", job.ID, job.Status)
+	return toImportJob(job, items), nil
+}
+
+func (s *LearningService) CancelImport(ctx context.Context, req *learning.CancelImportRequest) (*learning.ImportJob, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[CancelImport] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	job, err := s.imports.CancelImport(ctx, userID, req.Id)
+	if err != nil {
+		log.Printf("[CancelImport] ERROR: %v", err)
+		return nil, importError("failed to cancel import", err)
+	}
+
+	log.Printf("[CancelImport] SUCCESS - JobID: %s", job.ID)
+	return toImportJob(job, nil), nil
+}
+
+func toImportJob(job *store.ImportJob, items []*store.ImportItem) *learning.ImportJob {
+	resp := &learning.ImportJob{
+		Id:           job.ID,
+		Url:          job.URL,
+		Kind:         job.Kind,
+		Title:        job.Title,
+		CollectionId: job.CollectionID,
+		Status:       job.Status,
+		LastError:    job.LastError,
+		Total:        job.Total,
+		Pending:      job.Pending,
+		Done:         job.Done,
+		Duplicates:   job.Duplicates,
+		Failed:       job.Failed,
+		CreatedAt:    timestamppb.New(job.CreatedAt),
+	}
+	for _, item := range items {
+		resp.Items = append(resp.Items, &learning.ImportItem{
+			VideoId:    item.VideoID,
+			Title:      item.Title,
+			Status:     item.Status,
+			MaterialId: item.MaterialID,
+			Error:      item.Error,
+		})
+	}
+	return resp
+}
+
+// materialError maps errors of importing a material to gRPC codes; URLs we
+// refuse to fetch are the caller's to fix
+func materialError(msg string, err error) error {
+	var disallowed *egress.DisallowedError
+	switch {
+	case errors.Is(err, egress.ErrBlockedAddress), errors.Is(err, egress.ErrUnsupportedScheme),
+		errors.Is(err, egress.ErrInvalidURL), errors.Is(err, scraper.ErrHTMLTooLarge),
+		errors.Is(err, youtube.ErrListURL), errors.Is(err, core.ErrTooManyImages),
+		errors.Is(err, imaging.ErrInvalidImage), errors.Is(err, imaging.ErrUnsupportedImage),
+		errors.Is(err, imaging.ErrImageTooLarge):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	case errors.As(err, &disallowed):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	case errors.Is(err, core.ErrNoNewCards):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", msg, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+// sourceError maps subscription errors to gRPC codes; unreachable or
+// unsupported URLs are reported like material imports
+func sourceError(msg string, err error) error {
+	var statusErr *egress.StatusError
+	switch {
+	case errors.Is(err, store.ErrSourceNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, store.ErrSourceExists):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", msg, err)
+	case errors.Is(err, core.ErrTooManySources):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	case errors.Is(err, core.ErrNoSourceFeed), errors.As(err, &statusErr):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	}
+	return materialError(msg, err)
+}
+
+// importError maps playlist and channel import errors to gRPC codes; lists
+// that can't be fetched are reported like material imports
+func importError(msg string, err error) error {
+	switch {
+	case errors.Is(err, store.ErrImportNotFound), errors.Is(err, store.ErrCollectionNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, core.ErrTooManyImports):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	case errors.Is(err, youtube.ErrNotList), errors.Is(err, core.ErrNoVideos):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	}
+	return materialError(msg, err)
+}
+
+// orgError maps organization errors to gRPC codes
+func orgError(msg string, err error) error {
+	switch {
+	case errors.Is(err, store.ErrOrganizationNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, core.ErrRoleNotAllowed):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", msg, err)
+	case errors.Is(err, store.ErrNoSeatsLeft):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	case errors.Is(err, core.ErrMemberNotAdded):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+func tagError(msg string, err error) error {
+	if errors.Is(err, store.ErrTagNotFound) {
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+func (s *LearningService) GetNotificationStatus(ctx context.Context, _ *emptypb.Empty) (*learning.NotificationStatusResponse, error) {
+	// Extract user ID from context (set by auth interceptor)
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetNotificationStatus] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[GetNotificationStatus] Fetching notification status for userID: %s", userID)
+
+	// Fetch user to get Pro status
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Printf("[GetNotificationStatus] WARNING: Failed to get user profile: %v", err)
+		// Continue implies user is nil, so fields will be false
+	}
+
+	flashcardCount, hasDue, materialCount, firstTitle, err := s.core.GetNotificationStatus(ctx, userID)
+	if err != nil {
+		log.Printf("[GetNotificationStatus] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get notification status: %v", err)
+	}
+
+	isPro := false
+	isBlocked := false
+	if user != nil {
+		isPro = user.IsPro
+		isBlocked = user.IsBlocked
+	}
+
+	log.Printf("[GetNotificationStatus] SUCCESS - %d due flashcards, %d materials, Pro: %v", flashcardCount, materialCount, isPro)
+	return &learning.NotificationStatusResponse{
+		DueFlashcardsCount:    flashcardCount,
+		HasDueMaterials:       hasDue,
+		DueMaterialsCount:     materialCount,
+		FirstDueMaterialTitle: firstTitle,
+		IsPro:                 isPro,
+		IsBlocked:             isBlocked,
+	}, nil
+}
+
+func (s *LearningService) GetMaterialSummary(ctx context.Context, req *learning.GetMaterialSummaryRequest) (*learning.GetMaterialSummaryResponse, error) {
+	// Extract user ID from context (set by auth interceptor)
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[GetMaterialSummary] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[GetMaterialSummary] Fetching summary for materialID: %s, userID: %s", req.MaterialId, userID)
+
+	result, err := s.core.GetMaterialSummary(ctx, userID, req.MaterialId)
+	if err != nil {
+		log.Printf("[GetMaterialSummary] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get material summary: %v", err)
+	}
+
+	log.Printf("[GetMaterialSummary] SUCCESS - Summary length: %d, Type: %s", len(result.Summary), result.MaterialType)
+	return &learning.GetMaterialSummaryResponse{
+		Summary:      result.Summary,
+		Title:        result.Title,
+		Content:      result.Content,
+		MaterialType: result.MaterialType,
+		SourceUrl:    result.SourceURL,
+	}, nil
+}
+
+func (s *LearningService) StreamMaterialSummary(req *learning.GetMaterialSummaryRequest, stream grpc.ServerStreamingServer[learning.MaterialSummaryChunk]) error {
+	ctx := stream.Context()
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[StreamMaterialSummary] ERROR: Failed to get user ID: %v", err)
+		return err
+	}
+
+	log.Printf("[StreamMaterialSummary] Streaming summary for materialID: %s, userID: %s", req.MaterialId, userID)
+
+	onMeta := func(result *core.MaterialSummaryResult, cached bool) error {
+		return stream.Send(&learning.MaterialSummaryChunk{
+			Title:        result.Title,
+			MaterialType: result.MaterialType,
+			SourceUrl:    result.SourceURL,
+			Cached:       cached,
+		})
+	}
+	onDelta := func(delta string) error {
+		return stream.Send(&learning.MaterialSummaryChunk{Delta: delta})
+	}
+
+	summary, err := s.core.StreamMaterialSummary(ctx, userID, req.MaterialId, onMeta, onDelta)
+	if err != nil {
+		log.Printf("[StreamMaterialSummary] ERROR: %v", err)
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "failed to stream material summary: %v", err)
+	}
+
+	log.Printf("[StreamMaterialSummary] SUCCESS - Summary length: %d", len(summary))
+	return stream.Send(&learning.MaterialSummaryChunk{Done: true})
+}
+
+func (s *LearningService) AskLibrary(req *learning.AskLibraryRequest, stream grpc.ServerStreamingServer[learning.AskLibraryChunk]) error {
+	ctx := stream.Context()
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[AskLibrary] ERROR: Failed to get user ID: %v", err)
+		return err
+	}
+
+	if strings.TrimSpace(req.Question) == "" {
+		return status.Errorf(codes.InvalidArgument, "question is required")
+	}
+
+	log.Printf("[AskLibrary] userID: %s, question: %q", userID, req.Question)
+
+	onSources := func(sources []*core.LibrarySource) error {
+		return stream.Send(&learning.AskLibraryChunk{Citations: toCitations(sources)})
+	}
+	onDelta := func(delta string) error {
+		return stream.Send(&learning.AskLibraryChunk{Delta: delta})
+	}
+
+	answer, cited, err := s.core.AskLibrary(ctx, userID, req.Question, onSources, onDelta)
+	if err != nil {
+		log.Printf("[AskLibrary] ERROR: %v", err)
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "failed to answer question: %v", err)
+	}
+
+	log.Printf("[AskLibrary] SUCCESS - Answer length: %d, citations: %d", len(answer), len(cited))
+	return stream.Send(&learning.AskLibraryChunk{Done: true, Citations: toCitations(cited)})
+}
+
+func toCitations(sources []*core.LibrarySource) []*learning.Citation {
+	citations := make([]*learning.Citation, len(sources))
+	for i, src := range sources {
+		citations[i] = &learning.Citation{
+			Index:      int32(src.Index),
+			MaterialId: src.MaterialID,
+			Title:      src.Title,
+			SourceUrl:  src.SourceURL,
+			Snippet:    src.Text,
+		}
+	}
+	return citations
+}
+
+func (s *LearningService) CreateFlashcardsFromAnswer(ctx context.Context, req *learning.CreateFlashcardsFromAnswerRequest) (*learning.AddMaterialResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		log.Printf("[CreateFlashcardsFromAnswer] ERROR: Failed to get user ID: %v", err)
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Question) == "" || strings.TrimSpace(req.Answer) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "question and answer are required")
+	}
+
+	log.Printf("[CreateFlashcardsFromAnswer] userID: %s, answer length: %d", userID, len(req.Answer))
+
+	result, err := s.core.CreateFlashcardsFromAnswer(ctx, userID, req.Question, req.Answer, req.MaterialIds)
+	if err != nil {
+		log.Printf("[CreateFlashcardsFromAnswer] ERROR: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to create flashcards from answer: %v", err)
+	}
+
+	log.Printf("[CreateFlashcardsFromAnswer] SUCCESS - MaterialID: %s, Flashcards created: %d", result.MaterialID, result.FlashcardsCreated)
+	return toAddMaterialResponse(result), nil
+}
+
 func (s *LearningService) RegisterPushToken(ctx context.Context, req *learning.RegisterPushTokenRequest) (*emptypb.Empty, error) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
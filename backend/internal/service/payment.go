@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/amityadav/landr/internal/payment"
@@ -52,6 +53,28 @@ func (s *PaymentService) CreateSubscriptionOrder(ctx context.Context, req *payme
 		"plan":    req.PlanId,
 	}
 
+	// Organization plans are priced per seat and activate Pro for every member
+	if req.OrganizationId != "" {
+		role, err := s.store.GetOrganizationRole(ctx, req.OrganizationId, userID)
+		if err != nil || role != store.RoleOwner {
+			return nil, status.Errorf(codes.PermissionDenied, "only organization owners can buy seats")
+		}
+		if req.Seats < 1 {
+			return nil, status.Errorf(codes.InvalidArgument, "seats must be at least 1")
+		}
+		org, err := s.store.GetOrganization(ctx, userID, req.OrganizationId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get organization: %v", err)
+		}
+		if req.Seats < org.MemberCount {
+			return nil, status.Errorf(codes.FailedPrecondition, "the organization has %d members; remove members or buy at least %d seats", org.MemberCount, org.MemberCount)
+		}
+		amount *= float64(req.Seats)
+		notes["organization_id"] = req.OrganizationId
+		notes["seats"] = strconv.Itoa(int(req.Seats))
+		log.Printf("[PaymentService] Organization order: %s, seats: %d", req.OrganizationId, req.Seats)
+	}
+
 	// Check Payment Flow (popup vs redirect)
 	// Check Payment Flow (popup vs redirect)
 	flow := s.flow
@@ -144,3 +167,22 @@ func (s *PaymentService) HandleSubscriptionActivated(ctx context.Context, userID
 	log.Printf("[PaymentService] Subscription activated for user %s: %s (expires: %s)", userID, subscriptionID, periodEnd.Format(time.RFC3339))
 	return nil
 }
+
+// HandleOrganizationSubscriptionActivated activates Pro for all members of an organization
+func (s *PaymentService) HandleOrganizationSubscriptionActivated(ctx context.Context, orgID string, seats int32, subscriptionID string) error {
+	// Same 30-day period as individual plans
+	periodEnd := time.Now().Add(30 * 24 * time.Hour)
+
+	sub := &store.Subscription{
+		Plan:                   store.PlanPro,
+		Status:                 store.StatusActive,
+		CurrentPeriodEnd:       &periodEnd,
+		RazorpaySubscriptionID: subscriptionID,
+	}
+	if err := s.store.UpsertOrganizationSubscription(ctx, orgID, seats, sub); err != nil {
+		log.Printf("[PaymentService] Failed to update organization subscription: %v", err)
+		return err
+	}
+	log.Printf("[PaymentService] Organization subscription activated for %s: %s, %d seats (expires: %s)", orgID, subscriptionID, seats, periodEnd.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,296 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Collection is a user's deck of materials with its own review settings
+type Collection struct {
+	ID              string
+	Name            string
+	Description     string
+	CoverURL        string
+	Position        int32
+	NewCardsPerDay  int32 // 0 = unlimited
+	SchedulerPreset string
+	OrganizationID  string // Organization the collection is shared with ("" = private)
+	MaterialCount   int32  // Set by ListCollections / GetCollection
+	DueCount        int32  // Set by ListCollections / GetCollection, from the viewer's own review state
+}
+
+// ErrCollectionNotFound is returned when a collection does not exist for the user
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// Review state of a card as seen by the user $1: their own cards keep it on the
+// flashcard, cards of other members of a shared collection in flashcard_progress
+// (a card never reviewed by the user is new and due from its creation)
+const (
+	viewerStage        = `CASE WHEN m.user_id = $1 THEN f.stage ELSE COALESCE(p.stage, 0) END`
+	viewerNextReviewAt = `CASE WHEN m.user_id = $1 THEN f.next_review_at ELSE COALESCE(p.next_review_at, f.created_at) END`
+	viewerFirstReview  = `CASE WHEN m.user_id = $1 THEN f.first_reviewed_at ELSE p.first_reviewed_at END`
+	viewerProgressJoin = `LEFT JOIN flashcard_progress p ON p.flashcard_id = f.id AND p.user_id = $1`
+)
+
+// collectionColumns selects a collection for the viewing user $1
+const collectionColumns = `
+	c.id, c.name, c.description, c.cover_url, c.position, c.new_cards_per_day, c.scheduler_preset,
+	COALESCE(c.organization_id::text, ''),
+	(SELECT COUNT(*) FROM materials m WHERE m.collection_id = c.id AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)),
+	(SELECT COUNT(*) FROM flashcards f JOIN materials m ON f.material_id = m.id ` + viewerProgressJoin + `
+	 WHERE m.collection_id = c.id AND (m.is_deleted = FALSE OR m.is_deleted IS NULL) AND ` + viewerNextReviewAt + ` <= NOW())`
+
+// memberOfCollectionOrg matches collections shared with an organization the user $1 belongs to
+const memberOfCollectionOrg = `EXISTS (SELECT 1 FROM organization_members om WHERE om.organization_id = c.organization_id AND om.user_id = $1)`
+
+// editorOfCollectionOrg matches collections shared with an organization where
+// the user $1 is an owner or editor
+const editorOfCollectionOrg = `EXISTS (SELECT 1 FROM organization_members om
+	WHERE om.organization_id = c.organization_id AND om.user_id = $1 AND om.role IN ('owner', 'editor'))`
+
+func scanCollection(row pgx.Row) (*Collection, error) {
+	var c Collection
+	err := row.Scan(&c.ID, &c.Name, &c.Description, &c.CoverURL, &c.Position, &c.NewCardsPerDay, &c.SchedulerPreset,
+		&c.OrganizationID, &c.MaterialCount, &c.DueCount)
+	return &c, err
+}
+
+// CreateCollection adds a collection at the end of the user's list
+func (s *PostgresStore) CreateCollection(ctx context.Context, userID string, c *Collection) (string, error) {
+	query := `
+		INSERT INTO collections (user_id, name, description, cover_url, new_cards_per_day, scheduler_preset, position)
+		VALUES ($1, $2, $3, $4, $5, $6, (SELECT COALESCE(MAX(position) + 1, 0) FROM collections WHERE user_id = $1))
+		RETURNING id
+	`
+	var id string
+	err := s.db.QueryRow(ctx, query, userID, c.Name, c.Description, c.CoverURL, c.NewCardsPerDay, c.SchedulerPreset).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("failed to create collection: %w", err)
+	}
+	return id, nil
+}
+
+// UpdateCollection saves the name, description, cover and review settings of a collection
+func (s *PostgresStore) UpdateCollection(ctx context.Context, userID string, c *Collection) error {
+	query := `
+		UPDATE collections
+		SET name = $3, description = $4, cover_url = $5, new_cards_per_day = $6, scheduler_preset = $7, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+	`
+	result, err := s.db.Exec(ctx, query, c.ID, userID, c.Name, c.Description, c.CoverURL, c.NewCardsPerDay, c.SchedulerPreset)
+	if err != nil {
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+// DeleteCollection deletes a collection; its materials are kept without a collection
+func (s *PostgresStore) DeleteCollection(ctx context.Context, userID, collectionID string) error {
+	result, err := s.db.Exec(ctx, `DELETE FROM collections WHERE id = $1 AND user_id = $2`, collectionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+// GetCollection returns one collection of a user with its counts
+func (s *PostgresStore) GetCollection(ctx context.Context, userID, collectionID string) (*Collection, error) {
+	return s.getCollection(ctx, userID, collectionID, `c.user_id = $1`)
+}
+
+// GetStudyCollection returns a collection the user owns or that is shared with
+// one of their organizations, with counts from the user's own review state
+func (s *PostgresStore) GetStudyCollection(ctx context.Context, userID, collectionID string) (*Collection, error) {
+	return s.getCollection(ctx, userID, collectionID, `(c.user_id = $1 OR `+memberOfCollectionOrg+`)`)
+}
+
+func (s *PostgresStore) getCollection(ctx context.Context, userID, collectionID, access string) (*Collection, error) {
+	query := `SELECT ` + collectionColumns + ` FROM collections c WHERE c.id = $2 AND ` + access
+	c, err := scanCollection(s.db.QueryRow(ctx, query, userID, collectionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return c, nil
+}
+
+// ListCollections returns the user's collections in their display order
+func (s *PostgresStore) ListCollections(ctx context.Context, userID string) ([]*Collection, error) {
+	return s.listCollections(ctx, `c.user_id = $1 ORDER BY c.position, c.created_at`, userID)
+}
+
+func (s *PostgresStore) listCollections(ctx context.Context, where string, args ...interface{}) ([]*Collection, error) {
+	query := `SELECT ` + collectionColumns + ` FROM collections c WHERE ` + where
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	defer rows.Close()
+
+	var collections []*Collection
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection: %w", err)
+		}
+		collections = append(collections, c)
+	}
+	return collections, rows.Err()
+}
+
+// ReorderCollections sets the display order to the order of the given IDs
+func (s *PostgresStore) ReorderCollections(ctx context.Context, userID string, collectionIDs []string) error {
+	query := `
+		UPDATE collections c SET position = o.idx - 1, updated_at = NOW()
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, idx)
+		WHERE c.id = o.id AND c.user_id = $1
+	`
+	if _, err := s.db.Exec(ctx, query, userID, collectionIDs); err != nil {
+		return fmt.Errorf("failed to reorder collections: %w", err)
+	}
+	return nil
+}
+
+// SetMaterialCollection moves a user's material into a collection ("" = no
+// collection): one of their own, or a shared one where they are owner or editor
+func (s *PostgresStore) SetMaterialCollection(ctx context.Context, userID, materialID, collectionID string) error {
+	query := `
+		UPDATE materials SET collection_id = NULLIF($3, '')::uuid, updated_at = NOW()
+		WHERE id = $2 AND user_id = $1
+		  AND ($3 = '' OR EXISTS (
+			SELECT 1 FROM collections c WHERE c.id = NULLIF($3, '')::uuid
+			  AND (c.user_id = $1 OR ` + editorOfCollectionOrg + `)))
+	`
+	result, err := s.db.Exec(ctx, query, userID, materialID, collectionID)
+	if err != nil {
+		return fmt.Errorf("failed to set material collection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("material or collection not found")
+	}
+	return nil
+}
+
+// CountNewCardsReviewedSince counts cards of a collection the user first reviewed at or after since
+func (s *PostgresStore) CountNewCardsReviewedSince(ctx context.Context, userID, collectionID string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id
+		` + viewerProgressJoin + `
+		WHERE m.collection_id = $2 AND ` + viewerFirstReview + ` >= $3
+	`
+	var count int
+	if err := s.db.QueryRow(ctx, query, userID, collectionID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count new cards: %w", err)
+	}
+	return count, nil
+}
+
+// GetCollectionDueFlashcards returns the cards of a collection due for the user:
+// cards already in review first (most overdue first), then at most newLimit new
+// cards (newLimit < 0 = unlimited). Access is checked with GetStudyCollection.
+func (s *PostgresStore) GetCollectionDueFlashcards(ctx context.Context, userID, collectionID string, newLimit int) ([]*learning.Flashcard, error) {
+	log.Printf("[Store.GetCollectionDueFlashcards] userID: %s, collectionID: %s, newLimit: %d", userID, collectionID, newLimit)
+	query := `
+		WITH cards AS (
+			SELECT f.id, f.question, f.answer, f.created_at, f.difficulty, f.source_url,
+			       CASE WHEN f.source_verified THEN f.source_quote ELSE '' END AS source_quote,
+			       f.source_verified IS FALSE AS source_unverified, m.title, m.id AS material_id,
+			       ` + viewerStage + ` AS stage,
+			       ` + viewerNextReviewAt + ` AS next_review_at,
+			       ` + viewerFirstReview + ` AS first_reviewed_at
+			FROM flashcards f
+			JOIN materials m ON f.material_id = m.id
+			` + viewerProgressJoin + `
+			WHERE m.collection_id = $2 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		)
+		SELECT id, question, answer, stage, difficulty, source_url, source_quote, source_unverified, next_review_at, title, material_id
+		FROM (
+			SELECT *, 0 AS grp, next_review_at AS sort_at
+			FROM cards WHERE next_review_at <= NOW() AND first_reviewed_at IS NOT NULL
+			UNION ALL
+			(SELECT *, 1, created_at
+			 FROM cards WHERE next_review_at <= NOW() AND first_reviewed_at IS NULL
+			 ORDER BY created_at, id
+			 LIMIT CASE WHEN $3::int < 0 THEN NULL ELSE $3::int END)
+		) due
+		ORDER BY grp, sort_at, id
+	`
+	rows, err := s.db.Query(ctx, query, userID, collectionID, newLimit)
+	if err != nil {
+		log.Printf("[Store.GetCollectionDueFlashcards] Query failed: %v", err)
+		return nil, fmt.Errorf("failed to query flashcards: %w", err)
+	}
+
+	type cardRow struct {
+		card       *learning.Flashcard
+		materialID string
+	}
+	var cardRows []cardRow
+	for rows.Next() {
+		var card learning.Flashcard
+		var nextReviewAt time.Time
+		var matID string
+		if err := rows.Scan(&card.Id, &card.Question, &card.Answer, &card.Stage, &card.Difficulty, &card.SourceUrl,
+			&card.SourceQuote, &card.SourceUnverified, &nextReviewAt, &card.MaterialTitle, &matID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan flashcard: %w", err)
+		}
+		card.NextReviewAt = timestamppb.New(nextReviewAt)
+		cardRows = append(cardRows, cardRow{card: &card, materialID: matID})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Tags per material, loaded once each
+	tagsByMaterial := make(map[string][]string)
+	flashcards := make([]*learning.Flashcard, 0, len(cardRows))
+	for _, r := range cardRows {
+		tags, ok := tagsByMaterial[r.materialID]
+		if !ok {
+			if tags, err = s.GetMaterialTags(ctx, r.materialID); err != nil {
+				log.Printf("[Store.GetCollectionDueFlashcards] Failed to get tags: %v", err)
+				tags = []string{}
+			}
+			tagsByMaterial[r.materialID] = tags
+		}
+		r.card.Tags = tags
+		flashcards = append(flashcards, r.card)
+	}
+	return flashcards, nil
+}
+
+// GetFlashcardSchedulerPreset returns the scheduler preset of the collection a
+// card belongs to ("" when its material is in no collection)
+func (s *PostgresStore) GetFlashcardSchedulerPreset(ctx context.Context, flashcardID string) (string, error) {
+	query := `
+		SELECT COALESCE(c.scheduler_preset, '')
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id
+		LEFT JOIN collections c ON m.collection_id = c.id
+		WHERE f.id = $1
+	`
+	var preset string
+	if err := s.db.QueryRow(ctx, query, flashcardID).Scan(&preset); err != nil {
+		return "", fmt.Errorf("failed to get scheduler preset: %w", err)
+	}
+	return preset, nil
+}
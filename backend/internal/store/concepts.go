@@ -0,0 +1,380 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+	"github.com/jackc/pgx/v5"
+)
+
+// Relations between concepts
+const (
+	RelationPrerequisite = "prerequisite" // Source must be understood before target
+	RelationPartOf       = "part_of"      // Source is a part or kind of target
+	RelationRelated      = "related"
+)
+
+var (
+	ErrConceptNotFound  = errors.New("concept not found")
+	ErrMaterialNotFound = errors.New("material not found")
+)
+
+// Concept is a key idea extracted from one or more of a user's materials
+type Concept struct {
+	ID             string
+	Name           string
+	Description    string
+	MaterialCount  int32
+	FlashcardCount int32
+}
+
+// ConceptRelation is a directed edge between two concepts. Weight is the
+// number of materials that stated it.
+type ConceptRelation struct {
+	SourceID string
+	TargetID string
+	Relation string
+	Weight   int32
+}
+
+// RelatedMaterial is a material connected to another material or a concept
+type RelatedMaterial struct {
+	ID             string
+	Title          string
+	SharedConcepts []string
+	Score          float64
+}
+
+// liveMaterial filters out deleted materials aliased as m
+const liveMaterial = `(m.is_deleted = FALSE OR m.is_deleted IS NULL)`
+
+// UpsertConcept returns the user's concept with the normalized name, creating it
+// if needed. An existing concept keeps its name and gains a description if it had none.
+func (s *PostgresStore) UpsertConcept(ctx context.Context, userID, name, normalizedName, description string) (string, error) {
+	query := `
+		INSERT INTO concepts (user_id, name, normalized_name, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, normalized_name)
+		DO UPDATE SET description = CASE WHEN concepts.description = '' THEN EXCLUDED.description ELSE concepts.description END
+		RETURNING id
+	`
+	var id string
+	if err := s.db.QueryRow(ctx, query, userID, name, normalizedName, description).Scan(&id); err != nil {
+		return "", fmt.Errorf("failed to upsert concept: %w", err)
+	}
+	return id, nil
+}
+
+// LinkMaterialConcept records that a material covers a concept and which of its
+// flashcards test it
+func (s *PostgresStore) LinkMaterialConcept(ctx context.Context, materialID, conceptID string, flashcardIDs []string) error {
+	query := `INSERT INTO material_concepts (material_id, concept_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := s.db.Exec(ctx, query, materialID, conceptID); err != nil {
+		return fmt.Errorf("failed to link material concept: %w", err)
+	}
+	if len(flashcardIDs) == 0 {
+		return nil
+	}
+	query = `
+		INSERT INTO flashcard_concepts (flashcard_id, concept_id)
+		SELECT id, $2 FROM unnest($1::uuid[]) AS id
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := s.db.Exec(ctx, query, flashcardIDs, conceptID); err != nil {
+		return fmt.Errorf("failed to link flashcard concepts: %w", err)
+	}
+	return nil
+}
+
+// AddConceptRelation adds an edge between two concepts or increments its weight
+func (s *PostgresStore) AddConceptRelation(ctx context.Context, userID, sourceID, targetID, relation string) error {
+	query := `
+		INSERT INTO concept_relations (user_id, source_concept_id, target_concept_id, relation)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (source_concept_id, target_concept_id, relation)
+		DO UPDATE SET weight = concept_relations.weight + 1
+	`
+	if _, err := s.db.Exec(ctx, query, userID, sourceID, targetID, relation); err != nil {
+		return fmt.Errorf("failed to add concept relation: %w", err)
+	}
+	return nil
+}
+
+// SetMaterialConceptsExtracted marks a material's concepts as extracted
+func (s *PostgresStore) SetMaterialConceptsExtracted(ctx context.Context, materialID string) error {
+	query := `UPDATE materials SET concepts_extracted_at = NOW() WHERE id = $1`
+	if _, err := s.db.Exec(ctx, query, materialID); err != nil {
+		return fmt.Errorf("failed to mark concepts extracted: %w", err)
+	}
+	return nil
+}
+
+// MaterialConceptsExtracted reports whether concepts were extracted from a user's material
+func (s *PostgresStore) MaterialConceptsExtracted(ctx context.Context, userID, materialID string) (bool, error) {
+	query := `SELECT concepts_extracted_at IS NOT NULL FROM materials WHERE id = $1 AND user_id = $2`
+	var extracted bool
+	err := s.db.QueryRow(ctx, query, materialID, userID).Scan(&extracted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrMaterialNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get material: %w", err)
+	}
+	return extracted, nil
+}
+
+// GetMaterialFlashcards returns the cards of a material in creation order
+func (s *PostgresStore) GetMaterialFlashcards(ctx context.Context, materialID string) ([]FlashcardText, error) {
+	query := `SELECT id, question, answer FROM flashcards WHERE material_id = $1 ORDER BY created_at, id`
+	rows, err := s.db.Query(ctx, query, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query flashcards: %w", err)
+	}
+	defer rows.Close()
+
+	var cards []FlashcardText
+	for rows.Next() {
+		var c FlashcardText
+		if err := rows.Scan(&c.ID, &c.Question, &c.Answer); err != nil {
+			return nil, fmt.Errorf("failed to scan flashcard: %w", err)
+		}
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
+
+// ListConceptNames returns the names of the user's most covered concepts
+func (s *PostgresStore) ListConceptNames(ctx context.Context, userID string, limit int) ([]string, error) {
+	query := `
+		SELECT c.name
+		FROM concepts c
+		LEFT JOIN material_concepts mc ON mc.concept_id = c.id
+		WHERE c.user_id = $1
+		GROUP BY c.id, c.name
+		ORDER BY COUNT(mc.material_id) DESC, c.name
+		LIMIT $2
+	`
+	rows, err := s.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query concepts: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan concept: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// conceptColumns selects a concept c with its live material and flashcard counts
+const conceptColumns = `
+	c.id, c.name, c.description,
+	(SELECT COUNT(*) FROM material_concepts mc JOIN materials m ON m.id = mc.material_id
+	 WHERE mc.concept_id = c.id AND ` + liveMaterial + `),
+	(SELECT COUNT(*) FROM flashcard_concepts fc JOIN flashcards f ON f.id = fc.flashcard_id
+	 JOIN materials m ON m.id = f.material_id
+	 WHERE fc.concept_id = c.id AND ` + liveMaterial + `)
+`
+
+// GetConcepts returns the user's concepts covered by at least one material, most
+// covered first. With a focus concept, only it and its direct neighbours are returned.
+func (s *PostgresStore) GetConcepts(ctx context.Context, userID, focusID string, limit int) ([]*Concept, error) {
+	query := `
+		SELECT * FROM (
+			SELECT ` + conceptColumns + `
+			FROM concepts c
+			WHERE c.user_id = $1 AND ($2 = '' OR c.id::text = $2 OR EXISTS (
+				SELECT 1 FROM concept_relations r
+				WHERE (r.source_concept_id::text = $2 AND r.target_concept_id = c.id)
+				   OR (r.target_concept_id::text = $2 AND r.source_concept_id = c.id)
+			))
+		) AS concept (id, name, description, material_count, flashcard_count)
+		WHERE material_count > 0 OR id::text = $2
+		ORDER BY id::text = $2 DESC, material_count DESC, flashcard_count DESC, name
+		LIMIT $3
+	`
+	rows, err := s.db.Query(ctx, query, userID, focusID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query concepts: %w", err)
+	}
+	defer rows.Close()
+
+	var concepts []*Concept
+	for rows.Next() {
+		var c Concept
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.MaterialCount, &c.FlashcardCount); err != nil {
+			return nil, fmt.Errorf("failed to scan concept: %w", err)
+		}
+		concepts = append(concepts, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if focusID != "" && (len(concepts) == 0 || concepts[0].ID != focusID) {
+		return nil, ErrConceptNotFound
+	}
+	return concepts, nil
+}
+
+// GetConceptRelations returns the edges between the given concepts of the user
+func (s *PostgresStore) GetConceptRelations(ctx context.Context, userID string, conceptIDs []string) ([]*ConceptRelation, error) {
+	query := `
+		SELECT source_concept_id, target_concept_id, relation, weight
+		FROM concept_relations
+		WHERE user_id = $1 AND source_concept_id = ANY($2::uuid[]) AND target_concept_id = ANY($2::uuid[])
+		ORDER BY weight DESC
+	`
+	rows, err := s.db.Query(ctx, query, userID, conceptIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query concept relations: %w", err)
+	}
+	defer rows.Close()
+
+	var relations []*ConceptRelation
+	for rows.Next() {
+		var r ConceptRelation
+		if err := rows.Scan(&r.SourceID, &r.TargetID, &r.Relation, &r.Weight); err != nil {
+			return nil, fmt.Errorf("failed to scan concept relation: %w", err)
+		}
+		relations = append(relations, &r)
+	}
+	return relations, rows.Err()
+}
+
+// GetConceptMaterials returns the user's materials covering a concept, newest first
+func (s *PostgresStore) GetConceptMaterials(ctx context.Context, userID, conceptID string) ([]*RelatedMaterial, error) {
+	query := `
+		SELECT m.id, m.title, c.name
+		FROM material_concepts mc
+		JOIN materials m ON m.id = mc.material_id
+		JOIN concepts c ON c.id = mc.concept_id
+		WHERE mc.concept_id = $2 AND m.user_id = $1 AND ` + liveMaterial + `
+		ORDER BY m.created_at DESC
+	`
+	rows, err := s.db.Query(ctx, query, userID, conceptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query concept materials: %w", err)
+	}
+	defer rows.Close()
+
+	var materials []*RelatedMaterial
+	for rows.Next() {
+		var m RelatedMaterial
+		var concept string
+		if err := rows.Scan(&m.ID, &m.Title, &concept); err != nil {
+			return nil, fmt.Errorf("failed to scan concept material: %w", err)
+		}
+		m.SharedConcepts = []string{concept}
+		m.Score = 1
+		materials = append(materials, &m)
+	}
+	return materials, rows.Err()
+}
+
+// GetRelatedMaterials ranks the user's other materials by the concepts they share
+// with a material. Concepts one relation away count half.
+func (s *PostgresStore) GetRelatedMaterials(ctx context.Context, userID, materialID string, limit int) ([]*RelatedMaterial, error) {
+	query := `
+		WITH own AS (
+			SELECT concept_id FROM material_concepts WHERE material_id = $2
+		), near AS (
+			SELECT concept_id, 1.0 AS weight FROM own
+			UNION ALL
+			SELECT CASE WHEN r.source_concept_id = o.concept_id THEN r.target_concept_id ELSE r.source_concept_id END, 0.5
+			FROM concept_relations r
+			JOIN own o ON o.concept_id IN (r.source_concept_id, r.target_concept_id)
+			WHERE r.user_id = $1
+		), scored AS (
+			SELECT concept_id, MAX(weight) AS weight FROM near GROUP BY concept_id
+		)
+		SELECT m.id, m.title,
+		       COALESCE(ARRAY_AGG(c.name ORDER BY c.name) FILTER (WHERE s.weight = 1.0), '{}'),
+		       SUM(s.weight)::float8 AS score
+		FROM scored s
+		JOIN material_concepts mc ON mc.concept_id = s.concept_id
+		JOIN materials m ON m.id = mc.material_id
+		JOIN concepts c ON c.id = s.concept_id
+		WHERE m.user_id = $1 AND m.id <> $2 AND ` + liveMaterial + `
+		GROUP BY m.id, m.title, m.created_at
+		ORDER BY score DESC, m.created_at DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(ctx, query, userID, materialID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related materials: %w", err)
+	}
+	defer rows.Close()
+
+	var materials []*RelatedMaterial
+	for rows.Next() {
+		var m RelatedMaterial
+		if err := rows.Scan(&m.ID, &m.Title, &m.SharedConcepts, &m.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan related material: %w", err)
+		}
+		materials = append(materials, &m)
+	}
+	return materials, rows.Err()
+}
+
+// GetConceptFlashcards returns the cards testing a concept across all of the
+// user's materials, the most overdue first
+func (s *PostgresStore) GetConceptFlashcards(ctx context.Context, userID, conceptID string) ([]*learning.Flashcard, error) {
+	var exists bool
+	err := s.db.QueryRow(ctx, `SELECT TRUE FROM concepts WHERE id = $1 AND user_id = $2`, conceptID, userID).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrConceptNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get concept: %w", err)
+	}
+
+	query := `
+		SELECT f.id, f.question, f.answer, f.stage, f.difficulty, f.source_url, ` + flashcardSource + `, m.title, m.id
+		FROM flashcard_concepts fc
+		JOIN flashcards f ON f.id = fc.flashcard_id
+		JOIN materials m ON m.id = f.material_id
+		WHERE fc.concept_id = $2 AND m.user_id = $1 AND ` + liveMaterial + `
+		ORDER BY f.next_review_at ASC, f.id ASC
+	`
+	rows, err := s.db.Query(ctx, query, userID, conceptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query concept flashcards: %w", err)
+	}
+	defer rows.Close()
+
+	var flashcards []*learning.Flashcard
+	var materialIDs []string
+	for rows.Next() {
+		var card learning.Flashcard
+		var materialID string
+		if err := rows.Scan(&card.Id, &card.Question, &card.Answer, &card.Stage, &card.Difficulty, &card.SourceUrl,
+			&card.SourceQuote, &card.SourceUnverified, &card.MaterialTitle, &materialID); err != nil {
+			return nil, fmt.Errorf("failed to scan flashcard: %w", err)
+		}
+		flashcards = append(flashcards, &card)
+		materialIDs = append(materialIDs, materialID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagsByMaterial := make(map[string][]string)
+	for i, card := range flashcards {
+		tags, ok := tagsByMaterial[materialIDs[i]]
+		if !ok {
+			if tags, err = s.GetMaterialTags(ctx, materialIDs[i]); err != nil {
+				tags = []string{}
+			}
+			tagsByMaterial[materialIDs[i]] = tags
+		}
+		card.Tags = tags
+	}
+	return flashcards, nil
+}
@@ -0,0 +1,246 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PublicDeck is a material or collection published read-only under a slug
+type PublicDeck struct {
+	ID           string
+	UserID       string
+	Slug         string
+	MaterialID   string // Exactly one of MaterialID / CollectionID is set
+	CollectionID string
+	Title        string
+	Description  string
+	Author       string
+	CardCount    int32
+	CloneCount   int32
+	PublishedAt  time.Time
+}
+
+// PublicDeckMaterial is one material of a public deck with its cards
+type PublicDeckMaterial struct {
+	ID        string
+	Title     string
+	SourceURL string
+	Summary   string
+	Cards     []FlashcardText
+}
+
+var (
+	// ErrDeckNotFound is returned for unknown or unpublished slugs
+	ErrDeckNotFound = errors.New("deck not found")
+	// ErrSlugTaken is returned when a slug is already used by another deck
+	ErrSlugTaken = errors.New("slug is already taken")
+)
+
+const publicDeckColumns = `
+	d.id, d.user_id, d.slug, COALESCE(d.material_id::text, ''), COALESCE(d.collection_id::text, ''),
+	d.title, d.description, u.name, d.clone_count, d.published_at,
+	(SELECT COUNT(*) FROM flashcards f JOIN materials m ON f.material_id = m.id
+	 WHERE (m.id = d.material_id OR m.collection_id = d.collection_id) AND m.user_id = d.user_id
+	   AND (m.is_deleted = FALSE OR m.is_deleted IS NULL))`
+
+func scanPublicDeck(row pgx.Row) (*PublicDeck, error) {
+	var d PublicDeck
+	err := row.Scan(&d.ID, &d.UserID, &d.Slug, &d.MaterialID, &d.CollectionID,
+		&d.Title, &d.Description, &d.Author, &d.CloneCount, &d.PublishedAt, &d.CardCount)
+	return &d, err
+}
+
+// PublishDeck publishes a user's material or collection. Publishing an already
+// published source updates its title, description and (if given) slug; a new
+// deck without a slug gets fallbackSlug.
+func (s *PostgresStore) PublishDeck(ctx context.Context, userID string, d *PublicDeck, fallbackSlug string) (*PublicDeck, error) {
+	var owned bool
+	err := s.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM materials WHERE id = NULLIF($2, '')::uuid AND user_id = $1 AND (is_deleted = FALSE OR is_deleted IS NULL))
+		    OR EXISTS (SELECT 1 FROM collections WHERE id = NULLIF($3, '')::uuid AND user_id = $1)
+	`, userID, d.MaterialID, d.CollectionID).Scan(&owned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check deck source: %w", err)
+	}
+	if !owned {
+		return nil, fmt.Errorf("material or collection not found")
+	}
+
+	query := `
+		INSERT INTO public_decks (user_id, slug, material_id, collection_id, title, description)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6)
+		RETURNING id
+	`
+	slug := d.Slug
+	if slug == "" {
+		slug = fallbackSlug
+	}
+	args := []interface{}{userID, slug, d.MaterialID, d.CollectionID, d.Title, d.Description}
+	var existingID string
+	err = s.db.QueryRow(ctx, `
+		SELECT id FROM public_decks WHERE material_id = NULLIF($1, '')::uuid OR collection_id = NULLIF($2, '')::uuid
+	`, d.MaterialID, d.CollectionID).Scan(&existingID)
+	switch {
+	case err == nil:
+		query = `
+			UPDATE public_decks
+			SET slug = COALESCE(NULLIF($3, ''), slug), title = $4, description = $5, updated_at = NOW()
+			WHERE id = $1 AND user_id = $2
+			RETURNING id
+		`
+		args = []interface{}{existingID, userID, d.Slug, d.Title, d.Description}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("failed to look up deck: %w", err)
+	}
+
+	var id string
+	if err := s.db.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrSlugTaken
+		}
+		return nil, fmt.Errorf("failed to publish deck: %w", err)
+	}
+	return s.getPublicDeck(ctx, `d.id = $1`, id)
+}
+
+// UnpublishDeck removes a user's public deck; the material or collection is kept
+func (s *PostgresStore) UnpublishDeck(ctx context.Context, userID, slug string) error {
+	result, err := s.db.Exec(ctx, `DELETE FROM public_decks WHERE slug = $1 AND user_id = $2`, slug, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unpublish deck: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDeckNotFound
+	}
+	return nil
+}
+
+// GetPublicDeck returns a published deck by slug
+func (s *PostgresStore) GetPublicDeck(ctx context.Context, slug string) (*PublicDeck, error) {
+	return s.getPublicDeck(ctx, `d.slug = $1`, slug)
+}
+
+func (s *PostgresStore) getPublicDeck(ctx context.Context, where string, arg string) (*PublicDeck, error) {
+	query := `SELECT ` + publicDeckColumns + ` FROM public_decks d JOIN users u ON u.id = d.user_id WHERE ` + where
+	d, err := scanPublicDeck(s.db.QueryRow(ctx, query, arg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrDeckNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public deck: %w", err)
+	}
+	return d, nil
+}
+
+// ListPublicDecks returns the decks a user has published, newest first
+func (s *PostgresStore) ListPublicDecks(ctx context.Context, userID string) ([]*PublicDeck, error) {
+	query := `SELECT ` + publicDeckColumns + ` FROM public_decks d JOIN users u ON u.id = d.user_id
+		WHERE d.user_id = $1 ORDER BY d.published_at DESC`
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public decks: %w", err)
+	}
+	defer rows.Close()
+
+	var decks []*PublicDeck
+	for rows.Next() {
+		d, err := scanPublicDeck(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan public deck: %w", err)
+		}
+		decks = append(decks, d)
+	}
+	return decks, rows.Err()
+}
+
+// GetPublicDeckMaterials returns the live materials of a deck with their cards.
+// Only the publisher's own materials are included, even if the collection has
+// since been shared with an organization.
+func (s *PostgresStore) GetPublicDeckMaterials(ctx context.Context, d *PublicDeck) ([]*PublicDeckMaterial, error) {
+	query := `
+		SELECT m.id, COALESCE(m.title, ''), COALESCE(m.source_url, ''), COALESCE(m.summary, ''), f.id, f.question, f.answer
+		FROM materials m
+		JOIN flashcards f ON f.material_id = m.id
+		WHERE (m.id = NULLIF($1, '')::uuid OR m.collection_id = NULLIF($2, '')::uuid)
+		  AND m.user_id = $3
+		  AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		ORDER BY m.created_at, m.id, f.created_at, f.id
+	`
+	rows, err := s.db.Query(ctx, query, d.MaterialID, d.CollectionID, d.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deck materials: %w", err)
+	}
+	defer rows.Close()
+
+	var materials []*PublicDeckMaterial
+	for rows.Next() {
+		var m PublicDeckMaterial
+		var card FlashcardText
+		if err := rows.Scan(&m.ID, &m.Title, &m.SourceURL, &m.Summary, &card.ID, &card.Question, &card.Answer); err != nil {
+			return nil, fmt.Errorf("failed to scan deck card: %w", err)
+		}
+		if n := len(materials); n == 0 || materials[n-1].ID != m.ID {
+			materials = append(materials, &m)
+		}
+		last := materials[len(materials)-1]
+		last.Cards = append(last.Cards, card)
+	}
+	return materials, rows.Err()
+}
+
+// CloneMaterial copies a material with its cards, summary, fingerprint and
+// embeddings into another user's account. Cards start with fresh SRS state.
+// Returns the new material ID and the number of cards copied.
+func (s *PostgresStore) CloneMaterial(ctx context.Context, materialID, toUserID, collectionID string) (string, int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var newID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO materials (user_id, type, content, title, source_url, summary, canonical_url, content_simhash, fingerprinted_at, collection_id)
+		SELECT $2, type, content, title, source_url, summary, canonical_url, content_simhash, fingerprinted_at, NULLIF($3, '')::uuid
+		FROM materials WHERE id = $1
+		RETURNING id
+	`, materialID, toUserID, collectionID).Scan(&newID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to copy material: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		INSERT INTO flashcards (material_id, question, answer, embedding, difficulty, source_url, source_quote, source_verified)
+		SELECT $2, question, answer, embedding, difficulty, source_url, source_quote, source_verified FROM flashcards WHERE material_id = $1
+		ORDER BY created_at, id
+	`, materialID, newID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to copy flashcards: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO material_chunks (material_id, chunk_index, content, embedding)
+		SELECT $2, chunk_index, content, embedding FROM material_chunks WHERE material_id = $1
+	`, materialID, newID); err != nil {
+		return "", 0, fmt.Errorf("failed to copy material chunks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", 0, err
+	}
+	return newID, int(result.RowsAffected()), nil
+}
+
+// IncrementDeckCloneCount records that a deck was cloned
+func (s *PostgresStore) IncrementDeckCloneCount(ctx context.Context, deckID string) error {
+	if _, err := s.db.Exec(ctx, `UPDATE public_decks SET clone_count = clone_count + 1 WHERE id = $1`, deckID); err != nil {
+		return fmt.Errorf("failed to update clone count: %w", err)
+	}
+	return nil
+}
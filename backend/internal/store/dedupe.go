@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaterialFingerprint identifies a material for duplicate detection
+type MaterialFingerprint struct {
+	ID            string
+	Title         string
+	SourceURL     string
+	CanonicalURL  string
+	Simhash       uint64
+	HasSimhash    bool   // False for content too short to fingerprint
+	Fingerprinted bool   // False for rows imported before fingerprinting existed
+	Content       string // Loaded only when not yet fingerprinted
+}
+
+// GetMaterialFingerprints returns the fingerprints of all live materials of a user.
+// Content is included only for rows that still need fingerprinting.
+func (s *PostgresStore) GetMaterialFingerprints(ctx context.Context, userID string) ([]*MaterialFingerprint, error) {
+	query := `
+		SELECT id, COALESCE(title, ''), COALESCE(source_url, ''), COALESCE(canonical_url, ''),
+		       content_simhash, fingerprinted_at IS NOT NULL,
+		       CASE WHEN fingerprinted_at IS NULL THEN content ELSE '' END
+		FROM materials
+		WHERE user_id = $1 AND (is_deleted = FALSE OR is_deleted IS NULL)
+		ORDER BY created_at DESC
+	`
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query material fingerprints: %w", err)
+	}
+	defer rows.Close()
+
+	var fps []*MaterialFingerprint
+	for rows.Next() {
+		var fp MaterialFingerprint
+		var simhash *int64
+		if err := rows.Scan(&fp.ID, &fp.Title, &fp.SourceURL, &fp.CanonicalURL, &simhash, &fp.Fingerprinted, &fp.Content); err != nil {
+			return nil, fmt.Errorf("failed to scan material fingerprint: %w", err)
+		}
+		if simhash != nil {
+			fp.Simhash, fp.HasSimhash = uint64(*simhash), true
+		}
+		fps = append(fps, &fp)
+	}
+	return fps, rows.Err()
+}
+
+// SetMaterialFingerprint stores the canonical URL and content simhash of a material
+// (simhash nil = content too short to fingerprint)
+func (s *PostgresStore) SetMaterialFingerprint(ctx context.Context, materialID, canonicalURL string, simhash *uint64) error {
+	var hash *int64
+	if simhash != nil {
+		h := int64(*simhash) // Stored bit-for-bit in a signed BIGINT
+		hash = &h
+	}
+	query := `
+		UPDATE materials
+		SET canonical_url = NULLIF($2, ''), content_simhash = $3, fingerprinted_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := s.db.Exec(ctx, query, materialID, canonicalURL, hash); err != nil {
+		return fmt.Errorf("failed to set material fingerprint: %w", err)
+	}
+	return nil
+}
+
+// GetSimilarFlashcards returns the live flashcards of a user that best match
+// any of the full-text queries (websearch_to_tsquery syntax), at most perQuery
+// per query. It bounds the cards a duplicate check compares against.
+func (s *PostgresStore) GetSimilarFlashcards(ctx context.Context, userID string, queries []string, perQuery int) ([]FlashcardText, error) {
+	query := `
+		SELECT DISTINCT ON (c.id) c.id, c.question, c.answer
+		FROM unnest($2::text[]) AS q(text)
+		CROSS JOIN LATERAL (
+			SELECT f.id, f.question, f.answer
+			FROM flashcards f
+			JOIN materials m ON f.material_id = m.id
+			WHERE m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+			  AND f.search_tsv @@ websearch_to_tsquery('english', q.text)
+			ORDER BY ts_rank_cd(f.search_tsv, websearch_to_tsquery('english', q.text)) DESC
+			LIMIT $3
+		) c
+		ORDER BY c.id
+	`
+	rows, err := s.db.Query(ctx, query, userID, queries, perQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query similar flashcards: %w", err)
+	}
+	defer rows.Close()
+
+	var cards []FlashcardText
+	for rows.Next() {
+		var c FlashcardText
+		if err := rows.Scan(&c.ID, &c.Question, &c.Answer); err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaterialChunk is a passage of material content with its embedding
+type MaterialChunk struct {
+	Index     int
+	Content   string
+	Embedding []float32
+}
+
+// FlashcardText is the text of a flashcard that needs an embedding
+type FlashcardText struct {
+	ID       string
+	Question string
+	Answer   string
+}
+
+// vectorLiteral encodes a vector in pgvector's text format ("[0.1,0.2,...]")
+// so it can be passed as a parameter and cast with ::vector
+func vectorLiteral(v []float32) string {
+	var sb strings.Builder
+	sb.Grow(len(v) * 10)
+	sb.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(f), 'f', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// ReplaceMaterialChunks replaces all chunks of a material in one transaction
+func (s *PostgresStore) ReplaceMaterialChunks(ctx context.Context, materialID string, chunks []MaterialChunk) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM material_chunks WHERE material_id = $1`, materialID); err != nil {
+		return fmt.Errorf("failed to delete material chunks: %w", err)
+	}
+	for _, c := range chunks {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO material_chunks (material_id, chunk_index, content, embedding)
+			VALUES ($1, $2, $3, $4::vector)
+		`, materialID, c.Index, c.Content, vectorLiteral(c.Embedding))
+		if err != nil {
+			return fmt.Errorf("failed to insert material chunk %d: %w", c.Index, err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetMaterialsMissingChunks returns IDs of live materials that have not been
+// chunked yet, except those in skip
+func (s *PostgresStore) GetMaterialsMissingChunks(ctx context.Context, skip []string, limit int) ([]string, error) {
+	query := `
+		SELECT m.id FROM materials m
+		WHERE (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		  AND btrim(m.content) <> ''
+		  AND NOT EXISTS (SELECT 1 FROM material_chunks mc WHERE mc.material_id = m.id)
+		  AND m.id <> ALL(COALESCE($1::uuid[], '{}'))
+		ORDER BY m.created_at DESC
+		LIMIT $2
+	`
+	rows, err := s.db.Query(ctx, query, skip, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query materials missing chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetMaterialText returns the raw content of a material regardless of owner (for background jobs)
+func (s *PostgresStore) GetMaterialText(ctx context.Context, materialID string) (string, error) {
+	var content string
+	err := s.db.QueryRow(ctx, `SELECT content FROM materials WHERE id = $1`, materialID).Scan(&content)
+	if err != nil {
+		return "", fmt.Errorf("failed to get material content: %w", err)
+	}
+	return content, nil
+}
+
+// GetFlashcardsMissingEmbeddings returns flashcards without an embedding,
+// optionally restricted to one material (materialID = "")
+func (s *PostgresStore) GetFlashcardsMissingEmbeddings(ctx context.Context, materialID string, limit int) ([]FlashcardText, error) {
+	query := `
+		SELECT f.id, f.question, f.answer FROM flashcards f
+		JOIN materials m ON m.id = f.material_id
+		WHERE f.embedding IS NULL
+		  AND ($1 = '' OR f.material_id::text = $1)
+		  AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		ORDER BY f.created_at DESC
+		LIMIT $2
+	`
+	rows, err := s.db.Query(ctx, query, materialID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query flashcards missing embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var cards []FlashcardText
+	for rows.Next() {
+		var c FlashcardText
+		if err := rows.Scan(&c.ID, &c.Question, &c.Answer); err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
+
+// UpdateFlashcardEmbedding stores the embedding of a flashcard
+func (s *PostgresStore) UpdateFlashcardEmbedding(ctx context.Context, flashcardID string, embedding []float32) error {
+	_, err := s.db.Exec(ctx, `UPDATE flashcards SET embedding = $1::vector WHERE id = $2`, vectorLiteral(embedding), flashcardID)
+	if err != nil {
+		return fmt.Errorf("failed to update flashcard embedding: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Import job statuses
+const (
+	ImportRunning  = "RUNNING"
+	ImportDone     = "DONE"
+	ImportCanceled = "CANCELED"
+)
+
+// Import item statuses
+const (
+	ImportItemPending   = "PENDING"
+	ImportItemDone      = "DONE"
+	ImportItemDuplicate = "DUPLICATE" // The video was already a material, which is left where it is
+	ImportItemFailed    = "FAILED"
+	ImportItemCanceled  = "CANCELED"
+)
+
+var ErrImportNotFound = errors.New("import not found")
+
+// ImportJob is a batch import of a YouTube playlist or channel
+type ImportJob struct {
+	ID            string
+	UserID        string
+	Kind          string // youtube.ListPlaylist or youtube.ListChannel
+	URL           string
+	Title         string
+	CollectionID  string
+	Language      string // Preferred caption language
+	Status        string
+	LastError     string // Why the job is waiting, "" while it makes progress
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// Item counts per status
+	Total      int32
+	Pending    int32
+	Done       int32
+	Duplicates int32
+	Failed     int32
+}
+
+// ImportItem is one video of an import job
+type ImportItem struct {
+	JobID      string
+	Position   int32
+	VideoID    string
+	Title      string
+	Status     string
+	MaterialID string
+	Error      string
+	UpdatedAt  time.Time
+}
+
+const importJobQuery = `
+	SELECT j.id, j.user_id, j.kind, j.url, j.title, COALESCE(j.collection_id::text, ''), j.language, j.status,
+		j.last_error, j.next_attempt_at, j.created_at, j.updated_at,
+		COUNT(i.job_id),
+		COUNT(*) FILTER (WHERE i.status = 'PENDING'),
+		COUNT(*) FILTER (WHERE i.status = 'DONE'),
+		COUNT(*) FILTER (WHERE i.status = 'DUPLICATE'),
+		COUNT(*) FILTER (WHERE i.status = 'FAILED')
+	FROM import_jobs j
+	LEFT JOIN import_job_items i ON i.job_id = j.id`
+
+func scanImportJob(row pgx.Row) (*ImportJob, error) {
+	var job ImportJob
+	err := row.Scan(&job.ID, &job.UserID, &job.Kind, &job.URL, &job.Title, &job.CollectionID, &job.Language, &job.Status,
+		&job.LastError, &job.NextAttemptAt, &job.CreatedAt, &job.UpdatedAt,
+		&job.Total, &job.Pending, &job.Done, &job.Duplicates, &job.Failed)
+	return &job, err
+}
+
+// CreateImportJob saves a running import with its videos, processed in the given order
+func (s *PostgresStore) CreateImportJob(ctx context.Context, job *ImportJob, items []*ImportItem) (*ImportJob, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var jobID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO import_jobs (user_id, kind, url, title, collection_id, language)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, $6)
+		RETURNING id
+	`, job.UserID, job.Kind, job.URL, job.Title, job.CollectionID, job.Language).Scan(&jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import: %w", err)
+	}
+
+	videoIDs := make([]string, len(items))
+	titles := make([]string, len(items))
+	for i, item := range items {
+		videoIDs[i] = item.VideoID
+		titles[i] = item.Title
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO import_job_items (job_id, position, video_id, title)
+		SELECT $1, t.ord, t.video_id, t.title
+		FROM unnest($2::text[], $3::text[]) WITH ORDINALITY AS t(video_id, title, ord)
+	`, jobID, videoIDs, titles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save import items: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return s.GetImportJob(ctx, job.UserID, jobID)
+}
+
+// ListImportJobs returns a user's imports, newest first
+func (s *PostgresStore) ListImportJobs(ctx context.Context, userID string) ([]*ImportJob, error) {
+	rows, err := s.db.Query(ctx, importJobQuery+` WHERE j.user_id = $1 GROUP BY j.id ORDER BY j.created_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list imports: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*ImportJob
+	for rows.Next() {
+		job, err := scanImportJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// GetImportJob returns an import of a user
+func (s *PostgresStore) GetImportJob(ctx context.Context, userID, jobID string) (*ImportJob, error) {
+	job, err := scanImportJob(s.db.QueryRow(ctx, importJobQuery+` WHERE j.id = $1 AND j.user_id = $2 GROUP BY j.id`, jobID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrImportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import: %w", err)
+	}
+	return job, nil
+}
+
+// GetImportItems returns the videos of an import in processing order
+func (s *PostgresStore) GetImportItems(ctx context.Context, jobID string) ([]*ImportItem, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT job_id, position, video_id, title, status, COALESCE(material_id::text, ''), error, updated_at
+		FROM import_job_items
+		WHERE job_id = $1
+		ORDER BY position
+	`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*ImportItem
+	for rows.Next() {
+		var item ImportItem
+		if err := rows.Scan(&item.JobID, &item.Position, &item.VideoID, &item.Title, &item.Status,
+			&item.MaterialID, &item.Error, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan import item: %w", err)
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+// CountActiveImportJobs returns the number of running imports of a user
+func (s *PostgresStore) CountActiveImportJobs(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM import_jobs WHERE user_id = $1 AND status = 'RUNNING'`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count imports: %w", err)
+	}
+	return count, nil
+}
+
+// NextImportItem returns the next pending video to import, taking turns
+// between running jobs: the one that progressed least recently goes first.
+// Jobs waiting for a retry are skipped. It returns nil when nothing is due.
+func (s *PostgresStore) NextImportItem(ctx context.Context) (*ImportJob, *ImportItem, error) {
+	var jobID string
+	err := s.db.QueryRow(ctx, `
+		SELECT j.id FROM import_jobs j
+		WHERE j.status = 'RUNNING' AND j.next_attempt_at <= NOW()
+			AND EXISTS (SELECT 1 FROM import_job_items i WHERE i.job_id = j.id AND i.status = 'PENDING')
+		ORDER BY j.updated_at
+		LIMIT 1
+	`).Scan(&jobID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get next import: %w", err)
+	}
+
+	job, err := scanImportJob(s.db.QueryRow(ctx, importJobQuery+` WHERE j.id = $1 GROUP BY j.id`, jobID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get import: %w", err)
+	}
+	var item ImportItem
+	err = s.db.QueryRow(ctx, `
+		SELECT job_id, position, video_id, title, status, updated_at
+		FROM import_job_items
+		WHERE job_id = $1 AND status = 'PENDING'
+		ORDER BY position
+		LIMIT 1
+	`, jobID).Scan(&item.JobID, &item.Position, &item.VideoID, &item.Title, &item.Status, &item.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Canceled in the meantime
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get import item: %w", err)
+	}
+	return job, &item, nil
+}
+
+// SetImportItemStatus records the outcome of a video. The job moves to the
+// back of the queue, and is done once no video is pending.
+func (s *PostgresStore) SetImportItemStatus(ctx context.Context, jobID string, position int32, status, materialID, errMsg string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE import_job_items
+		SET status = $3, material_id = NULLIF($4, '')::uuid, error = $5, updated_at = NOW()
+		WHERE job_id = $1 AND position = $2
+	`, jobID, position, status, materialID, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to update import item: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE import_jobs
+		SET last_error = '', updated_at = NOW(),
+			status = CASE
+				WHEN status = 'RUNNING' AND NOT EXISTS (
+					SELECT 1 FROM import_job_items WHERE job_id = $1 AND status = 'PENDING'
+				) THEN 'DONE'
+				ELSE status
+			END
+		WHERE id = $1
+	`, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to update import: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// DeferImportJob pauses a job until nextAttemptAt, with the reason shown to the user
+func (s *PostgresStore) DeferImportJob(ctx context.Context, jobID, reason string, nextAttemptAt time.Time) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE import_jobs SET last_error = $2, next_attempt_at = $3, updated_at = NOW() WHERE id = $1
+	`, jobID, reason, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("failed to defer import: %w", err)
+	}
+	return nil
+}
+
+// CancelImportJob stops a running import; videos already imported are kept.
+// Canceling a finished import does nothing.
+func (s *PostgresStore) CancelImportJob(ctx context.Context, userID, jobID string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	err = tx.QueryRow(ctx, `SELECT status FROM import_jobs WHERE id = $1 AND user_id = $2 FOR UPDATE`, jobID, userID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrImportNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get import: %w", err)
+	}
+	if status != ImportRunning {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE import_jobs SET status = 'CANCELED', last_error = '', updated_at = NOW() WHERE id = $1`, jobID); err != nil {
+		return fmt.Errorf("failed to cancel import: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE import_job_items SET status = 'CANCELED', updated_at = NOW() WHERE job_id = $1 AND status = 'PENDING'
+	`, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel import items: %w", err)
+	}
+	return tx.Commit(ctx)
+}
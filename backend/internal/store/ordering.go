@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrFlashcardNotFound = errors.New("flashcard not found")
+	ErrDependencyCycle   = errors.New("a card cannot depend on a card that depends on it")
+)
+
+// CardOrdering is what ordering a review session needs to know about a card
+type CardOrdering struct {
+	New bool // Never reviewed by the user
+	// Prerequisites the user hasn't learned yet (stage < 1): explicit
+	// dependencies, and the cards of concepts that are prerequisites of the card's
+	// concepts. Only cards the user can study are listed.
+	Prerequisites []string
+}
+
+// GetCardOrdering returns the ordering info of the given cards for the user.
+// Prerequisite cards must be the user's own or among the given cards, so a card is
+// never held back by one the user can't study. Contradicting concept relations
+// (each a prerequisite of the other) are ignored.
+func (s *PostgresStore) GetCardOrdering(ctx context.Context, userID string, cardIDs []string) (map[string]*CardOrdering, error) {
+	info := make(map[string]*CardOrdering, len(cardIDs))
+	if len(cardIDs) == 0 {
+		return info, nil
+	}
+
+	query := `
+		SELECT f.id, ` + viewerFirstReview + ` IS NULL
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id
+		` + viewerProgressJoin + `
+		WHERE f.id = ANY($2::uuid[])
+	`
+	rows, err := s.db.Query(ctx, query, userID, cardIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query card state: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		var o CardOrdering
+		if err := rows.Scan(&id, &o.New); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan card state: %w", err)
+		}
+		info[id] = &o
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `
+		WITH prereqs AS (
+			SELECT d.flashcard_id AS card_id, d.depends_on_id AS prereq_id
+			FROM flashcard_dependencies d
+			WHERE d.flashcard_id = ANY($2::uuid[])
+			UNION
+			SELECT fc.flashcard_id, pc.flashcard_id
+			FROM flashcard_concepts fc
+			JOIN concept_relations r ON r.target_concept_id = fc.concept_id AND r.relation = 'prerequisite'
+			JOIN flashcard_concepts pc ON pc.concept_id = r.source_concept_id
+			WHERE fc.flashcard_id = ANY($2::uuid[]) AND pc.flashcard_id <> fc.flashcard_id
+			  AND NOT EXISTS (
+				SELECT 1 FROM concept_relations rr
+				WHERE rr.source_concept_id = r.target_concept_id AND rr.target_concept_id = r.source_concept_id
+				  AND rr.relation = 'prerequisite'
+			  )
+		)
+		SELECT pr.card_id, pr.prereq_id
+		FROM prereqs pr
+		JOIN flashcards f ON f.id = pr.prereq_id
+		JOIN materials m ON f.material_id = m.id
+		` + viewerProgressJoin + `
+		WHERE (m.user_id = $1 OR pr.prereq_id = ANY($2::uuid[]))
+		  AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		  AND ` + viewerStage + ` < 1
+	`
+	rows, err = s.db.Query(ctx, query, userID, cardIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query card prerequisites: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var cardID, prereqID string
+		if err := rows.Scan(&cardID, &prereqID); err != nil {
+			return nil, fmt.Errorf("failed to scan card prerequisite: %w", err)
+		}
+		if o := info[cardID]; o != nil {
+			o.Prerequisites = append(o.Prerequisites, prereqID)
+		}
+	}
+	return info, rows.Err()
+}
+
+// SetFlashcardDependencies replaces the cards a card depends on. The card and
+// its dependencies must belong to the user, and no dependency may (transitively)
+// depend on the card.
+func (s *PostgresStore) SetFlashcardDependencies(ctx context.Context, userID, flashcardID string, dependsOnIDs []string) error {
+	ids := append([]string{flashcardID}, dependsOnIDs...)
+	var owned int
+	query := `
+		SELECT COUNT(*)
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id
+		WHERE f.id = ANY($2::uuid[]) AND m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+	`
+	if err := s.db.QueryRow(ctx, query, userID, ids).Scan(&owned); err != nil {
+		return fmt.Errorf("failed to check flashcards: %w", err)
+	}
+	if owned != countUnique(ids) {
+		return ErrFlashcardNotFound
+	}
+
+	if len(dependsOnIDs) > 0 {
+		query = `
+			WITH RECURSIVE reach(id) AS (
+				SELECT unnest($2::uuid[])
+				UNION
+				SELECT d.depends_on_id FROM flashcard_dependencies d JOIN reach ON d.flashcard_id = reach.id
+			)
+			SELECT EXISTS (SELECT 1 FROM reach WHERE id = $1)
+		`
+		var cycle bool
+		if err := s.db.QueryRow(ctx, query, flashcardID, dependsOnIDs).Scan(&cycle); err != nil {
+			return fmt.Errorf("failed to check dependency cycle: %w", err)
+		}
+		if cycle {
+			return ErrDependencyCycle
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM flashcard_dependencies WHERE flashcard_id = $1`, flashcardID); err != nil {
+		return fmt.Errorf("failed to clear dependencies: %w", err)
+	}
+	if len(dependsOnIDs) > 0 {
+		query = `
+			INSERT INTO flashcard_dependencies (flashcard_id, depends_on_id)
+			SELECT $1, id FROM unnest($2::uuid[]) AS id
+			ON CONFLICT DO NOTHING
+		`
+		if _, err := tx.Exec(ctx, query, flashcardID, dependsOnIDs); err != nil {
+			return fmt.Errorf("failed to save dependencies: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func countUnique(ids []string) int {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return len(seen)
+}
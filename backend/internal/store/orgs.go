@@ -0,0 +1,452 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Organization member roles
+const (
+	RoleOwner   = "owner"   // Manages members, billing and shared collections
+	RoleEditor  = "editor"  // Shares collections and adds materials to shared ones
+	RoleLearner = "learner" // Studies shared collections
+)
+
+// Organization is a team workspace; a Pro organization gives all members Pro
+type Organization struct {
+	ID               string
+	Name             string
+	OwnerID          string
+	Seats            int32
+	Plan             SubscriptionPlan
+	Status           SubscriptionStatus
+	CurrentPeriodEnd *time.Time
+	Role             string // Role of the viewing user
+	ReportingOptOut  bool   // Whether the viewing user opted out of individual-level reports
+	MemberCount      int32
+}
+
+// OrganizationMember is a user's membership in an organization
+type OrganizationMember struct {
+	UserID   string
+	Email    string
+	Name     string
+	Picture  string
+	Role     string
+	JoinedAt time.Time
+}
+
+var (
+	// ErrOrganizationNotFound is returned for unknown organizations and for non-members
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrNoSeatsLeft is returned when adding a member to a full organization
+	ErrNoSeatsLeft = errors.New("no seats left in organization")
+)
+
+// FreeOrganizationMembers caps the members of an organization without an active
+// Pro plan; a Pro organization holds as many members as it paid seats for
+const FreeOrganizationMembers = 5
+
+// detachForeignMaterials removes materials of other users from the collections of
+// an organization that are no longer shared, so that private collections only
+// contain their owner's materials. $1 = organization ID.
+const detachForeignMaterials = `
+	UPDATE materials m SET collection_id = NULL, updated_at = NOW()
+	FROM collections c
+	WHERE m.collection_id = c.id AND c.organization_id = $1 AND m.user_id <> c.user_id`
+
+const organizationColumns = `
+	o.id, o.name, o.owner_id, o.seats, o.plan, o.status, o.current_period_end, om.role, om.reporting_opt_out,
+	(SELECT COUNT(*) FROM organization_members x WHERE x.organization_id = o.id)`
+
+func scanOrganization(row pgx.Row) (*Organization, error) {
+	var o Organization
+	var plan, status string
+	err := row.Scan(&o.ID, &o.Name, &o.OwnerID, &o.Seats, &plan, &status, &o.CurrentPeriodEnd, &o.Role, &o.ReportingOptOut, &o.MemberCount)
+	o.Plan = SubscriptionPlan(plan)
+	o.Status = SubscriptionStatus(status)
+	return &o, err
+}
+
+// CreateOrganization creates an organization with the user as its owner
+func (s *PostgresStore) CreateOrganization(ctx context.Context, ownerID, name string) (string, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id string
+	if err := tx.QueryRow(ctx, `INSERT INTO organizations (name, owner_id) VALUES ($1, $2) RETURNING id`, name, ownerID).Scan(&id); err != nil {
+		return "", fmt.Errorf("failed to create organization: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO organization_members (organization_id, user_id, role, reporting_opt_out) VALUES ($1, $2, $3, FALSE)`, id, ownerID, RoleOwner); err != nil {
+		return "", fmt.Errorf("failed to add owner: %w", err)
+	}
+	return id, tx.Commit(ctx)
+}
+
+// GetOrganization returns an organization the user is a member of
+func (s *PostgresStore) GetOrganization(ctx context.Context, userID, orgID string) (*Organization, error) {
+	query := `SELECT ` + organizationColumns + `
+		FROM organizations o JOIN organization_members om ON om.organization_id = o.id AND om.user_id = $1
+		WHERE o.id = $2`
+	o, err := scanOrganization(s.db.QueryRow(ctx, query, userID, orgID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return o, nil
+}
+
+// ListOrganizations returns the organizations the user is a member of
+func (s *PostgresStore) ListOrganizations(ctx context.Context, userID string) ([]*Organization, error) {
+	query := `SELECT ` + organizationColumns + `
+		FROM organizations o JOIN organization_members om ON om.organization_id = o.id AND om.user_id = $1
+		ORDER BY o.name`
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []*Organization
+	for rows.Next() {
+		o, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, o)
+	}
+	return orgs, rows.Err()
+}
+
+// DeleteOrganization deletes an organization owned by the user. Its shared
+// collections become private to their owners again.
+func (s *PostgresStore) DeleteOrganization(ctx context.Context, ownerID, orgID string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, detachForeignMaterials, orgID); err != nil {
+		return fmt.Errorf("failed to detach shared materials: %w", err)
+	}
+	result, err := tx.Exec(ctx, `DELETE FROM organizations WHERE id = $1 AND owner_id = $2`, orgID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrOrganizationNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// GetOrganizationRole returns the user's role in an organization
+func (s *PostgresStore) GetOrganizationRole(ctx context.Context, orgID, userID string) (string, error) {
+	var role string
+	err := s.db.QueryRow(ctx, `SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2`, orgID, userID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrOrganizationNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get organization role: %w", err)
+	}
+	return role, nil
+}
+
+// GetOrganizationMembers returns the members of an organization, owners first
+func (s *PostgresStore) GetOrganizationMembers(ctx context.Context, orgID string) ([]*OrganizationMember, error) {
+	query := `
+		SELECT u.id, u.email, COALESCE(u.name, ''), COALESCE(u.picture, ''), om.role, om.joined_at
+		FROM organization_members om
+		JOIN users u ON u.id = om.user_id
+		WHERE om.organization_id = $1
+		ORDER BY CASE om.role WHEN 'owner' THEN 0 WHEN 'editor' THEN 1 ELSE 2 END, u.name
+	`
+	rows, err := s.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query organization members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*OrganizationMember
+	for rows.Next() {
+		var m OrganizationMember
+		if err := rows.Scan(&m.UserID, &m.Email, &m.Name, &m.Picture, &m.Role, &m.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, &m)
+	}
+	return members, rows.Err()
+}
+
+// AddOrganizationMember adds a user to an organization if a seat is free (paid
+// seats for Pro organizations, FreeOrganizationMembers otherwise). New members
+// are left out of individual-level reports until they opt in.
+// Adding an existing member updates their role.
+func (s *PostgresStore) AddOrganizationMember(ctx context.Context, orgID, userID, role string) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		SELECT $1, $2, $3
+		WHERE (SELECT COUNT(*) FROM organization_members WHERE organization_id = $1)
+		      < (SELECT CASE WHEN plan = $5 AND status = $6 THEN seats ELSE $4 END FROM organizations WHERE id = $1)
+		   OR EXISTS (SELECT 1 FROM organization_members WHERE organization_id = $1 AND user_id = $2)
+		ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+	result, err := s.db.Exec(ctx, query, orgID, userID, role, FreeOrganizationMembers, PlanPro, StatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNoSeatsLeft
+	}
+	return nil
+}
+
+// SetOrganizationMemberRole changes the role of a member
+func (s *PostgresStore) SetOrganizationMemberRole(ctx context.Context, orgID, userID, role string) error {
+	result, err := s.db.Exec(ctx, `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`, orgID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to set member role: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
+
+// RemoveOrganizationMember removes a member. Their materials leave the
+// organization's collections and their own collections stop being shared.
+// Personal review progress is kept in case they rejoin.
+func (s *PostgresStore) RemoveOrganizationMember(ctx context.Context, orgID, userID string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE materials m SET collection_id = NULL, updated_at = NOW()
+		FROM collections c
+		WHERE m.collection_id = c.id AND c.organization_id = $1 AND m.user_id = $2 AND c.user_id <> $2
+	`, orgID, userID); err != nil {
+		return fmt.Errorf("failed to detach member materials: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE materials m SET collection_id = NULL, updated_at = NOW()
+		FROM collections c
+		WHERE m.collection_id = c.id AND c.organization_id = $1 AND c.user_id = $2 AND m.user_id <> $2
+	`, orgID, userID); err != nil {
+		return fmt.Errorf("failed to detach shared materials: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE collections SET organization_id = NULL, updated_at = NOW() WHERE organization_id = $1 AND user_id = $2`, orgID, userID); err != nil {
+		return fmt.Errorf("failed to unshare collections: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return tx.Commit(ctx)
+}
+
+// ShareCollection shares a user's collection with an organization ("" = make it
+// private again, which removes other members' materials from it)
+func (s *PostgresStore) ShareCollection(ctx context.Context, userID, collectionID, orgID string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE materials SET collection_id = NULL, updated_at = NOW()
+		WHERE collection_id = $1 AND user_id <> $2
+		  AND EXISTS (SELECT 1 FROM collections WHERE id = $1 AND user_id = $2
+		              AND organization_id IS DISTINCT FROM NULLIF($3, '')::uuid)
+	`, collectionID, userID, orgID); err != nil {
+		return fmt.Errorf("failed to detach shared materials: %w", err)
+	}
+	result, err := tx.Exec(ctx, `
+		UPDATE collections SET organization_id = NULLIF($3, '')::uuid, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+	`, collectionID, userID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to share collection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCollectionNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// ListOrganizationCollections returns the collections shared with an
+// organization, with counts from the viewing member's own review state
+func (s *PostgresStore) ListOrganizationCollections(ctx context.Context, userID, orgID string) ([]*Collection, error) {
+	return s.listCollections(ctx, `c.organization_id = $2 AND `+memberOfCollectionOrg+` ORDER BY c.name`, userID, orgID)
+}
+
+// GetFlashcardOwnership reports whether a card the user can study is their own
+// and, if not, the organization its collection is shared with
+func (s *PostgresStore) GetFlashcardOwnership(ctx context.Context, userID, flashcardID string) (bool, string, error) {
+	query := `
+		SELECT m.user_id = $1, COALESCE(c.organization_id::text, '')
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id
+		LEFT JOIN collections c ON c.id = m.collection_id
+		WHERE f.id = $2 AND (m.user_id = $1 OR ` + memberOfCollectionOrg + `)
+	`
+	var own bool
+	var orgID string
+	err := s.db.QueryRow(ctx, query, userID, flashcardID).Scan(&own, &orgID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, "", ErrFlashcardNotFound
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get flashcard ownership: %w", err)
+	}
+	return own, orgID, nil
+}
+
+// GetFlashcardReviewStage returns the user's current stage for a card and whether
+// the card is their own. Cards of other members in a shared collection keep
+// per-user progress (see UpdateFlashcardProgress).
+func (s *PostgresStore) GetFlashcardReviewStage(ctx context.Context, userID, flashcardID string) (int32, bool, error) {
+	query := `
+		SELECT ` + viewerStage + `, m.user_id = $1
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id
+		LEFT JOIN collections c ON c.id = m.collection_id
+		` + viewerProgressJoin + `
+		WHERE f.id = $2 AND (m.user_id = $1 OR ` + memberOfCollectionOrg + `)
+	`
+	var stage int32
+	var own bool
+	err := s.db.QueryRow(ctx, query, userID, flashcardID).Scan(&stage, &own)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, fmt.Errorf("flashcard not found")
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get review stage: %w", err)
+	}
+	return stage, own, nil
+}
+
+// UpdateFlashcardProgress saves a member's personal review state for a shared card
+func (s *PostgresStore) UpdateFlashcardProgress(ctx context.Context, userID, flashcardID string, stage int32, nextReviewAt time.Time) error {
+	query := `
+		INSERT INTO flashcard_progress (user_id, flashcard_id, stage, next_review_at, first_reviewed_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id, flashcard_id) DO UPDATE SET
+			stage = EXCLUDED.stage,
+			next_review_at = EXCLUDED.next_review_at,
+			updated_at = NOW()
+	`
+	if _, err := s.db.Exec(ctx, query, userID, flashcardID, stage, nextReviewAt); err != nil {
+		return fmt.Errorf("failed to update flashcard progress: %w", err)
+	}
+	return nil
+}
+
+// UpsertOrganizationSubscription sets the plan and the paid seat count of an organization
+func (s *PostgresStore) UpsertOrganizationSubscription(ctx context.Context, orgID string, seats int32, sub *Subscription) error {
+	query := `
+		UPDATE organizations
+		SET plan = $2, status = $3, seats = $4, current_period_end = $5, razorpay_subscription_id = $6, updated_at = NOW()
+		WHERE id = $1
+	`
+	result, err := s.db.Exec(ctx, query, orgID, sub.Plan, sub.Status, seats, sub.CurrentPeriodEnd, sub.RazorpaySubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
+
+// getOrganizationSubscription returns the Pro plan a user gets through an active
+// Pro organization (the one with the most seats), or nil. Seats go to members in
+// the order they joined, so members beyond the paid seats don't get Pro.
+func (s *PostgresStore) getOrganizationSubscription(ctx context.Context, userID string) (*Subscription, error) {
+	query := `
+		SELECT o.id, o.seats, o.status, o.current_period_end, COALESCE(o.razorpay_subscription_id, ''), o.created_at, o.updated_at
+		FROM organizations o
+		JOIN organization_members om ON om.organization_id = o.id AND om.user_id = $1
+		WHERE o.plan = $2 AND o.status = $3 AND (o.current_period_end IS NULL OR o.current_period_end > NOW())
+		  AND (SELECT COUNT(*) FROM organization_members e
+		       WHERE e.organization_id = o.id AND (e.joined_at, e.user_id) < (om.joined_at, om.user_id)) < o.seats
+		ORDER BY o.seats DESC, o.created_at
+		LIMIT 1
+	`
+	sub := Subscription{UserID: userID, Plan: PlanPro}
+	var status string
+	err := s.db.QueryRow(ctx, query, userID, PlanPro, StatusActive).Scan(
+		&sub.OrganizationID, &sub.Seats, &status, &sub.CurrentPeriodEnd, &sub.RazorpaySubscriptionID, &sub.CreatedAt, &sub.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization subscription: %w", err)
+	}
+	sub.Status = SubscriptionStatus(status)
+	return &sub, nil
+}
+
+// CheckOrganizationQuota checks an organization's pooled daily limit for a resource
+func (s *PostgresStore) CheckOrganizationQuota(ctx context.Context, orgID, resource string, limit int) (bool, error) {
+	query := `
+		INSERT INTO organization_usage_quotas (organization_id, resource, count, last_reset_at)
+		VALUES ($1, $2, 0, CURRENT_DATE)
+		ON CONFLICT (organization_id, resource) DO UPDATE SET
+			count = CASE WHEN organization_usage_quotas.last_reset_at < CURRENT_DATE THEN 0 ELSE organization_usage_quotas.count END,
+			last_reset_at = CURRENT_DATE
+		RETURNING count
+	`
+	var currentCount int
+	if err := s.db.QueryRow(ctx, query, orgID, resource).Scan(&currentCount); err != nil {
+		return false, fmt.Errorf("failed to check/reset organization quota: %w", err)
+	}
+	return currentCount < limit, nil
+}
+
+// IncrementOrganizationQuota increments an organization's pooled usage count
+func (s *PostgresStore) IncrementOrganizationQuota(ctx context.Context, orgID, resource string) error {
+	query := `
+		INSERT INTO organization_usage_quotas (organization_id, resource, count, last_reset_at)
+		VALUES ($1, $2, 1, CURRENT_DATE)
+		ON CONFLICT (organization_id, resource) DO UPDATE SET
+			count = CASE WHEN organization_usage_quotas.last_reset_at < CURRENT_DATE THEN 1 ELSE organization_usage_quotas.count + 1 END,
+			last_reset_at = CURRENT_DATE
+	`
+	if _, err := s.db.Exec(ctx, query, orgID, resource); err != nil {
+		return fmt.Errorf("failed to increment organization quota: %w", err)
+	}
+	return nil
+}
+
+// DowngradeExpiredOrganizations moves organizations whose Pro period ended back to FREE
+func (s *PostgresStore) DowngradeExpiredOrganizations(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE organizations
+		SET plan = $1, status = $2, updated_at = NOW()
+		WHERE plan = $3 AND current_period_end < NOW()
+	`
+	tag, err := s.db.Exec(ctx, query, PlanFree, StatusPastDue, PlanPro)
+	if err != nil {
+		return 0, fmt.Errorf("failed to downgrade expired organizations: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
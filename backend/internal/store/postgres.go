@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/amityadav/landr/pkg/pb/auth"
 	"github.com/amityadav/landr/pkg/pb/learning"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -377,14 +379,32 @@ func (s *PostgresStore) GetMaterialTags(ctx context.Context, materialID string)
 	return tags, nil
 }
 
+// flashcardSource selects a card's quote, only when it was found in the
+// material, and whether the card is flagged because it wasn't (flashcards as f)
+const flashcardSource = `CASE WHEN f.source_verified THEN f.source_quote ELSE '' END, f.source_verified IS FALSE`
+
+// sourceVerified is the source_verified of a new card: NULL when its quote wasn't checked
+func sourceVerified(card *learning.Flashcard) *bool {
+	if card.SourceUnverified {
+		verified := false
+		return &verified
+	}
+	if card.SourceQuote != "" {
+		verified := true
+		return &verified
+	}
+	return nil
+}
+
 func (s *PostgresStore) CreateFlashcards(ctx context.Context, materialID string, cards []*learning.Flashcard) error {
 	log.Printf("[Store.CreateFlashcards] Inserting %d flashcards for material: %s", len(cards), materialID)
 	for i, card := range cards {
 		query := `
-            INSERT INTO flashcards (material_id, question, answer, stage, next_review_at)
-            VALUES ($1, $2, $3, $4, NOW());
+            INSERT INTO flashcards (material_id, question, answer, stage, next_review_at, difficulty, source_url, source_quote, source_verified)
+            VALUES ($1, $2, $3, $4, NOW(), $5, $6, $7, $8);
         `
-		_, err := s.db.Exec(ctx, query, materialID, card.Question, card.Answer, 0)
+		_, err := s.db.Exec(ctx, query, materialID, card.Question, card.Answer, 0, card.Difficulty, card.SourceUrl,
+			card.SourceQuote, sourceVerified(card))
 		if err != nil {
 			log.Printf("[Store.CreateFlashcards] Failed to insert flashcard %d: %v", i, err)
 			return fmt.Errorf("failed to insert flashcard: %w", err)
@@ -430,11 +450,11 @@ func (s *PostgresStore) GetFlashcard(ctx context.Context, id string) (*learning.
 func (s *PostgresStore) GetDueFlashcards(ctx context.Context, userID, materialID string) ([]*learning.Flashcard, error) {
 	log.Printf("[Store.GetDueFlashcards] Querying flashcards for userID: %s, materialID: %s", userID, materialID)
 	query := `
-        SELECT f.id, f.question, f.answer, f.stage, m.title, m.id
+        SELECT f.id, f.question, f.answer, f.stage, f.difficulty, f.source_url, ` + flashcardSource + `, m.title, m.id
         FROM flashcards f
         JOIN materials m ON f.material_id = m.id
         WHERE m.user_id = $1 AND m.id = $2 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
-        ORDER BY f.id ASC;
+        ORDER BY f.first_reviewed_at IS NULL, f.next_review_at, f.created_at, f.id;
     `
 	rows, err := s.db.Query(ctx, query, userID, materialID)
 	if err != nil {
@@ -448,7 +468,8 @@ func (s *PostgresStore) GetDueFlashcards(ctx context.Context, userID, materialID
 		var card learning.Flashcard
 		var title string
 		var matID string
-		if err := rows.Scan(&card.Id, &card.Question, &card.Answer, &card.Stage, &title, &matID); err != nil {
+		if err := rows.Scan(&card.Id, &card.Question, &card.Answer, &card.Stage, &card.Difficulty, &card.SourceUrl,
+			&card.SourceQuote, &card.SourceUnverified, &title, &matID); err != nil {
 			log.Printf("[Store.GetDueFlashcards] Scan failed: %v", err)
 			return nil, fmt.Errorf("failed to scan flashcard: %w", err)
 		}
@@ -467,27 +488,34 @@ func (s *PostgresStore) GetDueFlashcards(ctx context.Context, userID, materialID
 	return flashcards, nil
 }
 
-func (s *PostgresStore) UpdateFlashcardContent(ctx context.Context, id, question, answer string) error {
+// UpdateFlashcardContent edits a card of one of the user's materials, or of a
+// collection shared with an organization where they are an owner or editor.
+// Returns ErrFlashcardNotFound for any other card.
+func (s *PostgresStore) UpdateFlashcardContent(ctx context.Context, userID, id, question, answer string) error {
 	log.Printf("[Store.UpdateFlashcardContent] Updating flashcard: %s", id)
 	query := `
-		UPDATE flashcards
-		SET question = $1, answer = $2, updated_at = NOW()
-		WHERE id = $3;
+		UPDATE flashcards f
+		SET question = $3, answer = $4, embedding = NULL, updated_at = NOW(),
+			source_verified = NULLIF(f.source_verified, FALSE) -- Edited by the user: no longer flagged
+		FROM materials m
+		LEFT JOIN collections c ON c.id = m.collection_id
+		WHERE f.id = $2 AND f.material_id = m.id
+		  AND (m.user_id = $1 OR ` + editorOfCollectionOrg + `);
 	`
-	result, err := s.db.Exec(ctx, query, question, answer, id)
+	result, err := s.db.Exec(ctx, query, userID, id, question, answer)
 	if err != nil {
 		log.Printf("[Store.UpdateFlashcardContent] Update failed: %v", err)
 		return fmt.Errorf("failed to update flashcard content: %w", err)
 	}
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("flashcard not found")
+		return ErrFlashcardNotFound
 	}
 	log.Printf("[Store.UpdateFlashcardContent] Flashcard content updated successfully")
 	return nil
 }
 
-func (s *PostgresStore) GetDueMaterials(ctx context.Context, userID string, page, pageSize int32, searchQuery string, filterTags []string, onlyDue bool) ([]*learning.MaterialSummary, int32, error) {
-	log.Printf("[Store.GetDueMaterials] Querying materials for userID: %s, page: %d, pageSize: %d, search: %s, tags: %v, onlyDue: %v", userID, page, pageSize, searchQuery, filterTags, onlyDue)
+func (s *PostgresStore) GetDueMaterials(ctx context.Context, userID string, page, pageSize int32, searchQuery string, filterTags []string, collectionID string, onlyDue bool) ([]*learning.MaterialSummary, int32, error) {
+	log.Printf("[Store.GetDueMaterials] Querying materials for userID: %s, page: %d, pageSize: %d, search: %s, tags: %v, collection: %s, onlyDue: %v", userID, page, pageSize, searchQuery, filterTags, collectionID, onlyDue)
 
 	// Base conditions
 	whereClause := "m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)"
@@ -501,19 +529,23 @@ func (s *PostgresStore) GetDueMaterials(ctx context.Context, userID string, page
 		args = append(args, "%"+searchQuery+"%")
 	}
 
-	// Add tag filter
-	if len(filterTags) > 0 {
+	// Add tag filter (every tag must match; a parent tag also matches its nested tags)
+	for _, tag := range filterTags {
 		paramCount++
-		whereClause += fmt.Sprintf(` AND m.id IN (
-			SELECT mt.material_id 
-			FROM material_tags mt 
-			JOIN tags t ON mt.tag_id = t.id 
-			WHERE t.name = ANY($%d)
-			GROUP BY mt.material_id 
-			HAVING COUNT(DISTINCT t.name) = $%d
-		)`, paramCount, paramCount+1)
-		args = append(args, filterTags, len(filterTags))
+		whereClause += fmt.Sprintf(` AND EXISTS (
+			SELECT 1
+			FROM material_tags mt
+			JOIN tags t ON mt.tag_id = t.id
+			WHERE mt.material_id = m.id AND (t.name = $%d OR starts_with(t.name, $%d || '/'))
+		)`, paramCount, paramCount)
+		args = append(args, tag)
+	}
+
+	// Add collection filter
+	if collectionID != "" {
 		paramCount++
+		whereClause += fmt.Sprintf(" AND m.collection_id = $%d", paramCount)
+		args = append(args, collectionID)
 	}
 
 	// Subquery for due count
@@ -548,7 +580,8 @@ func (s *PostgresStore) GetDueMaterials(ctx context.Context, userID string, page
 	args = append(args, offset)
 
 	query := fmt.Sprintf(`
-		SELECT m.id, m.title, %s as due_count
+		SELECT m.id, m.title, %s as due_count, COALESCE(m.collection_id::text, ''),
+		       EXISTS (SELECT 1 FROM material_refreshes r WHERE r.material_id = m.id)
 		FROM materials m
 		WHERE %s
 		ORDER BY m.created_at DESC
@@ -564,7 +597,7 @@ func (s *PostgresStore) GetDueMaterials(ctx context.Context, userID string, page
 	var materials []*learning.MaterialSummary
 	for rows.Next() {
 		var m learning.MaterialSummary
-		if err := rows.Scan(&m.Id, &m.Title, &m.DueCount); err != nil {
+		if err := rows.Scan(&m.Id, &m.Title, &m.DueCount, &m.CollectionId, &m.RefreshAvailable); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan material: %w", err)
 		}
 
@@ -642,7 +675,7 @@ func (s *PostgresStore) UpdateFlashcard(ctx context.Context, id string, stage in
 	log.Printf("[Store.UpdateFlashcard] Updating flashcard: %s, Stage: %d, NextReviewAt: %v", id, stage, nextReviewAt)
 	query := `
         UPDATE flashcards
-        SET stage = $1, next_review_at = $2, updated_at = NOW()
+        SET stage = $1, next_review_at = $2, first_reviewed_at = COALESCE(first_reviewed_at, NOW()), updated_at = NOW()
         WHERE id = $3;
     `
 	_, err := s.db.Exec(ctx, query, stage, nextReviewAt, id)
@@ -663,6 +696,9 @@ func (s *PostgresStore) GetMaterialContent(ctx context.Context, userID, material
 	`
 	var content, summary, title, materialType, sourceURL string
 	err := s.db.QueryRow(ctx, query, materialID, userID).Scan(&content, &summary, &title, &materialType, &sourceURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", "", "", "", "", ErrMaterialNotFound
+	}
 	if err != nil {
 		log.Printf("[Store.GetMaterialContent] Query failed: %v", err)
 		return "", "", "", "", "", fmt.Errorf("failed to get material content: %w", err)
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PromptTemplate is a versioned prompt stored in the database
+type PromptTemplate struct {
+	ID        string
+	Name      string
+	Version   int
+	Variant   string
+	Body      string
+	Weight    int // Share of A/B traffic; 0 = inactive
+	Notes     string
+	CreatedAt time.Time
+}
+
+const promptTemplateColumns = `id, name, version, variant, body, weight, COALESCE(notes, ''), created_at`
+
+func scanPromptTemplate(row pgx.Row) (*PromptTemplate, error) {
+	var t PromptTemplate
+	if err := row.Scan(&t.ID, &t.Name, &t.Version, &t.Variant, &t.Body, &t.Weight, &t.Notes, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// CreatePromptTemplate stores a new version of a prompt (version = latest + 1)
+func (s *PostgresStore) CreatePromptTemplate(ctx context.Context, name, variant, body, notes string) (*PromptTemplate, error) {
+	query := `
+		INSERT INTO prompt_templates (name, version, variant, body, notes)
+		VALUES ($1, (SELECT COALESCE(MAX(version), 0) + 1 FROM prompt_templates WHERE name = $1), $2, $3, $4)
+		RETURNING ` + promptTemplateColumns
+	t, err := scanPromptTemplate(s.db.QueryRow(ctx, query, name, variant, body, notes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prompt template '%s': %w", name, err)
+	}
+	return t, nil
+}
+
+// GetPromptTemplate retrieves a prompt template by ID
+func (s *PostgresStore) GetPromptTemplate(ctx context.Context, id string) (*PromptTemplate, error) {
+	query := `SELECT ` + promptTemplateColumns + ` FROM prompt_templates WHERE id = $1`
+	t, err := scanPromptTemplate(s.db.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt template: %w", err)
+	}
+	return t, nil
+}
+
+// ListPromptTemplates returns all versions, optionally filtered by name (newest first)
+func (s *PostgresStore) ListPromptTemplates(ctx context.Context, name string) ([]*PromptTemplate, error) {
+	query := `SELECT ` + promptTemplateColumns + ` FROM prompt_templates
+		WHERE ($1 = '' OR name = $1)
+		ORDER BY name, version DESC`
+	return s.queryPromptTemplates(ctx, query, name)
+}
+
+// GetActivePromptTemplates returns all templates with a positive weight
+func (s *PostgresStore) GetActivePromptTemplates(ctx context.Context) ([]*PromptTemplate, error) {
+	query := `SELECT ` + promptTemplateColumns + ` FROM prompt_templates
+		WHERE weight > 0
+		ORDER BY name, version`
+	return s.queryPromptTemplates(ctx, query)
+}
+
+func (s *PostgresStore) queryPromptTemplates(ctx context.Context, query string, args ...interface{}) ([]*PromptTemplate, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*PromptTemplate
+	for rows.Next() {
+		t, err := scanPromptTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prompt template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// SetPromptTemplateWeight changes a template's share of traffic (0 deactivates it)
+func (s *PostgresStore) SetPromptTemplateWeight(ctx context.Context, id string, weight int) error {
+	query := `UPDATE prompt_templates SET weight = $2, updated_at = NOW() WHERE id = $1`
+	tag, err := s.db.Exec(ctx, query, id, weight)
+	if err != nil {
+		return fmt.Errorf("failed to set prompt weight: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("prompt template not found")
+	}
+	return nil
+}
+
+// GetUserPromptOverride returns the template a user is pinned to for a prompt name, or nil
+func (s *PostgresStore) GetUserPromptOverride(ctx context.Context, userID, name string) (*PromptTemplate, error) {
+	query := `
+		SELECT t.id, t.name, t.version, t.variant, t.body, t.weight, COALESCE(t.notes, ''), t.created_at
+		FROM user_prompt_overrides o
+		JOIN prompt_templates t ON t.id = o.template_id
+		WHERE o.user_id = $1 AND o.name = $2
+	`
+	t, err := scanPromptTemplate(s.db.QueryRow(ctx, query, userID, name))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt override: %w", err)
+	}
+	return t, nil
+}
+
+// SetUserPromptOverride pins a user to a template; an empty templateID removes the override
+func (s *PostgresStore) SetUserPromptOverride(ctx context.Context, userID, name, templateID string) error {
+	if templateID == "" {
+		_, err := s.db.Exec(ctx, `DELETE FROM user_prompt_overrides WHERE user_id = $1 AND name = $2`, userID, name)
+		if err != nil {
+			return fmt.Errorf("failed to remove prompt override: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO user_prompt_overrides (user_id, name, template_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, name) DO UPDATE SET template_id = $3, created_at = NOW()
+	`
+	if _, err := s.db.Exec(ctx, query, userID, name, templateID); err != nil {
+		return fmt.Errorf("failed to set prompt override: %w", err)
+	}
+	return nil
+}
+
+// SetMaterialPromptTemplate records which prompt template generated a material's flashcards
+func (s *PostgresStore) SetMaterialPromptTemplate(ctx context.Context, materialID, templateID string) error {
+	query := `UPDATE materials SET prompt_template_id = $2 WHERE id = $1`
+	if _, err := s.db.Exec(ctx, query, materialID, templateID); err != nil {
+		return fmt.Errorf("failed to record prompt template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// LinkedMaterial is a material imported from a web page, checked for changes to its source
+type LinkedMaterial struct {
+	ID        string
+	UserID    string
+	SourceURL string
+	Content   string
+}
+
+// MaterialRefresh is a newer extraction of a material's source that differs
+// significantly from the stored content
+type MaterialRefresh struct {
+	Content     string
+	ChangeRatio float64
+	DetectedAt  time.Time
+}
+
+// GetMaterialsToRecheck returns up to limit live LINK materials whose source
+// wasn't checked since checkedBefore and has no pending refresh, longest unchecked first
+func (s *PostgresStore) GetMaterialsToRecheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*LinkedMaterial, error) {
+	query := `
+		SELECT m.id, m.user_id, m.source_url, m.content
+		FROM materials m
+		WHERE m.type = 'LINK' AND COALESCE(m.source_url, '') <> ''
+		  AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		  AND COALESCE(m.content_checked_at, m.created_at) < $1
+		  AND NOT EXISTS (SELECT 1 FROM material_refreshes r WHERE r.material_id = m.id)
+		ORDER BY COALESCE(m.content_checked_at, m.created_at)
+		LIMIT $2
+	`
+	rows, err := s.db.Query(ctx, query, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get materials to recheck: %w", err)
+	}
+	defer rows.Close()
+
+	var materials []*LinkedMaterial
+	for rows.Next() {
+		var m LinkedMaterial
+		if err := rows.Scan(&m.ID, &m.UserID, &m.SourceURL, &m.Content); err != nil {
+			return nil, fmt.Errorf("failed to scan material: %w", err)
+		}
+		materials = append(materials, &m)
+	}
+	return materials, rows.Err()
+}
+
+// SetMaterialChecked records that a material's source was checked and showed no significant change
+func (s *PostgresStore) SetMaterialChecked(ctx context.Context, materialID string) error {
+	if _, err := s.db.Exec(ctx, `UPDATE materials SET content_checked_at = NOW() WHERE id = $1`, materialID); err != nil {
+		return fmt.Errorf("failed to update material: %w", err)
+	}
+	return nil
+}
+
+// SaveMaterialRefresh flags a material whose source changed, keeping the new extraction
+func (s *PostgresStore) SaveMaterialRefresh(ctx context.Context, materialID, content string, changeRatio float64) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO material_refreshes (material_id, content, change_ratio)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (material_id) DO UPDATE
+		SET content = EXCLUDED.content, change_ratio = EXCLUDED.change_ratio, detected_at = NOW()
+	`, materialID, content, changeRatio)
+	if err != nil {
+		return fmt.Errorf("failed to save material refresh: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE materials SET content_checked_at = NOW() WHERE id = $1`, materialID); err != nil {
+		return fmt.Errorf("failed to update material: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// GetMaterialRefresh returns the pending refresh of a material, nil when there is none
+func (s *PostgresStore) GetMaterialRefresh(ctx context.Context, materialID string) (*MaterialRefresh, error) {
+	var r MaterialRefresh
+	err := s.db.QueryRow(ctx, `
+		SELECT content, change_ratio, detected_at FROM material_refreshes WHERE material_id = $1
+	`, materialID).Scan(&r.Content, &r.ChangeRatio, &r.DetectedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get material refresh: %w", err)
+	}
+	return &r, nil
+}
+
+// ApplyMaterialRefresh replaces a material's content, deletes the cards that
+// no longer match it and clears the pending refresh. The summary is reset so
+// it is regenerated from the new content.
+func (s *PostgresStore) ApplyMaterialRefresh(ctx context.Context, materialID, content string, staleCardIDs []string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE materials
+		SET summary = CASE WHEN content = $2 THEN summary ELSE NULL END,
+		    content = $2, content_checked_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, materialID, content)
+	if err != nil {
+		return fmt.Errorf("failed to update material content: %w", err)
+	}
+	if len(staleCardIDs) > 0 {
+		_, err = tx.Exec(ctx, `DELETE FROM flashcards WHERE material_id = $1 AND id = ANY($2::uuid[])`, materialID, staleCardIDs)
+		if err != nil {
+			return fmt.Errorf("failed to delete stale flashcards: %w", err)
+		}
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM material_refreshes WHERE material_id = $1`, materialID); err != nil {
+		return fmt.Errorf("failed to clear material refresh: %w", err)
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MemberProgress is one organization member's progress on a shared collection
+type MemberProgress struct {
+	UserID         string
+	Email          string
+	Name           string
+	OptedOut       bool // Opted out of individual-level reporting
+	TotalCards     int32
+	StudiedCards   int32 // Reviewed at least once
+	OverdueCards   int32 // Studied and past their next review
+	Reviews        int32 // Reviews in the report window
+	PassedReviews  int32
+	LastReviewedAt *time.Time
+}
+
+// Completion is the share of the collection's cards the member has studied (0-1)
+func (p *MemberProgress) Completion() float64 {
+	if p.TotalCards == 0 {
+		return 0
+	}
+	return float64(p.StudiedCards) / float64(p.TotalCards)
+}
+
+// Retention is the share of the member's reviews that passed (0-1)
+func (p *MemberProgress) Retention() float64 {
+	if p.Reviews == 0 {
+		return 0
+	}
+	return float64(p.PassedReviews) / float64(p.Reviews)
+}
+
+// RecordReview logs a review for progress reports
+func (s *PostgresStore) RecordReview(ctx context.Context, userID, flashcardID string, passed bool) error {
+	query := `INSERT INTO review_events (user_id, flashcard_id, passed) VALUES ($1, $2, $3)`
+	if _, err := s.db.Exec(ctx, query, userID, flashcardID, passed); err != nil {
+		return fmt.Errorf("failed to record review: %w", err)
+	}
+	return nil
+}
+
+// GetCollectionMemberProgress returns the progress of every member of an
+// organization on one of its shared collections. Review counts cover reviews
+// since the given time; card counts are current.
+func (s *PostgresStore) GetCollectionMemberProgress(ctx context.Context, orgID, collectionID string, since time.Time) ([]*MemberProgress, error) {
+	query := `
+		WITH members AS (
+			SELECT om.user_id, u.email, COALESCE(u.name, '') AS name, om.reporting_opt_out
+			FROM organization_members om
+			JOIN users u ON u.id = om.user_id
+			WHERE om.organization_id = $1
+		), cards AS (
+			SELECT f.id, f.next_review_at, f.first_reviewed_at, m.user_id AS owner_id
+			FROM flashcards f
+			JOIN materials m ON f.material_id = m.id
+			WHERE m.collection_id = $2 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		), state AS (
+			SELECT mb.user_id, c.id AS card_id,
+			       CASE WHEN c.owner_id = mb.user_id THEN c.first_reviewed_at ELSE p.first_reviewed_at END AS first_reviewed_at,
+			       CASE WHEN c.owner_id = mb.user_id THEN c.next_review_at ELSE p.next_review_at END AS next_review_at
+			FROM members mb
+			CROSS JOIN cards c
+			LEFT JOIN flashcard_progress p ON p.flashcard_id = c.id AND p.user_id = mb.user_id
+		), events AS (
+			SELECT e.user_id, COUNT(*) AS reviews, COUNT(*) FILTER (WHERE e.passed) AS passed, MAX(e.reviewed_at) AS last_reviewed_at
+			FROM review_events e
+			JOIN cards c ON c.id = e.flashcard_id
+			WHERE e.user_id IN (SELECT user_id FROM members) AND e.reviewed_at >= $3
+			GROUP BY e.user_id
+		)
+		SELECT mb.user_id, mb.email, mb.name, mb.reporting_opt_out,
+		       (SELECT COUNT(*) FROM cards),
+		       COUNT(st.card_id) FILTER (WHERE st.first_reviewed_at IS NOT NULL),
+		       COUNT(st.card_id) FILTER (WHERE st.first_reviewed_at IS NOT NULL AND st.next_review_at < NOW()),
+		       COALESCE(ev.reviews, 0), COALESCE(ev.passed, 0), ev.last_reviewed_at
+		FROM members mb
+		LEFT JOIN state st ON st.user_id = mb.user_id
+		LEFT JOIN events ev ON ev.user_id = mb.user_id
+		GROUP BY mb.user_id, mb.email, mb.name, mb.reporting_opt_out, ev.reviews, ev.passed, ev.last_reviewed_at
+		ORDER BY mb.name, mb.email
+	`
+	rows, err := s.db.Query(ctx, query, orgID, collectionID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query member progress: %w", err)
+	}
+	defer rows.Close()
+
+	var progress []*MemberProgress
+	for rows.Next() {
+		var p MemberProgress
+		if err := rows.Scan(&p.UserID, &p.Email, &p.Name, &p.OptedOut, &p.TotalCards, &p.StudiedCards, &p.OverdueCards,
+			&p.Reviews, &p.PassedReviews, &p.LastReviewedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan member progress: %w", err)
+		}
+		progress = append(progress, &p)
+	}
+	return progress, rows.Err()
+}
+
+// SetReportingOptOut sets whether a member is left out of individual-level reports
+func (s *PostgresStore) SetReportingOptOut(ctx context.Context, orgID, userID string, optOut bool) error {
+	query := `UPDATE organization_members SET reporting_opt_out = $3 WHERE organization_id = $1 AND user_id = $2`
+	result, err := s.db.Exec(ctx, query, orgID, userID, optOut)
+	if err != nil {
+		return fmt.Errorf("failed to set reporting opt-out: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amityadav/landr/pkg/pb/learning"
+	"github.com/jackc/pgx/v5"
+)
+
+// HighlightStart and HighlightStop wrap matched terms in full-text snippets.
+// They are private-use characters rather than HTML tags because snippets hold
+// raw (scraped) text: the caller escapes it before turning them into markup.
+const (
+	HighlightStart = "\ue000"
+	HighlightStop  = "\ue001"
+)
+
+// headlineOptions configures ts_headline snippets
+const headlineOptions = `StartSel=` + HighlightStart + `, StopSel=` + HighlightStop + `, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+// MaterialHit is a material matching a library search
+type MaterialHit struct {
+	MaterialID string
+	Title      string
+	SourceURL  string
+	Snippet    string
+	MatchedIn  string // "summary" or "content"
+	Rank       float64
+}
+
+// FlashcardHit is a flashcard matching a library search
+type FlashcardHit struct {
+	Card       *learning.Flashcard
+	MaterialID string
+	SourceURL  string
+	Snippet    string
+	Rank       float64 // ts_rank for full-text hits, cosine similarity for vector hits
+}
+
+// SearchMaterials ranks a user's materials by full-text match on title, summary and content.
+// Snippets are taken from the summary when it matches, otherwise from the content.
+// Only the first 500,000 characters of the content are indexed (migration 000034),
+// so snippets come from the same prefix.
+func (s *PostgresStore) SearchMaterials(ctx context.Context, userID, query string, limit int) ([]*MaterialHit, error) {
+	sqlQuery := `
+		WITH q AS (SELECT websearch_to_tsquery('english', $2) AS query),
+		hits AS (
+			SELECT m.id, COALESCE(m.title, '') AS title, COALESCE(m.source_url, '') AS source_url,
+			       left(m.content, 500000) AS content, COALESCE(m.summary, '') AS summary,
+			       ts_rank_cd(m.search_tsv, q.query)::float8 AS rank, q.query
+			FROM materials m, q
+			WHERE m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+			  AND m.search_tsv @@ q.query
+			ORDER BY rank DESC
+			LIMIT $3
+		)
+		SELECT id, title, source_url,
+		       CASE WHEN to_tsvector('english', summary) @@ query
+		            THEN ts_headline('english', summary, query, '` + headlineOptions + `')
+		            ELSE ts_headline('english', content, query, '` + headlineOptions + `') END,
+		       CASE WHEN to_tsvector('english', summary) @@ query THEN 'summary' ELSE 'content' END,
+		       rank
+		FROM hits
+		ORDER BY rank DESC
+	`
+	rows, err := s.db.Query(ctx, sqlQuery, userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search materials: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*MaterialHit
+	for rows.Next() {
+		var h MaterialHit
+		if err := rows.Scan(&h.MaterialID, &h.Title, &h.SourceURL, &h.Snippet, &h.MatchedIn, &h.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan material hit: %w", err)
+		}
+		hits = append(hits, &h)
+	}
+	return hits, rows.Err()
+}
+
+// SearchFlashcards ranks a user's flashcards by full-text match on question and answer
+func (s *PostgresStore) SearchFlashcards(ctx context.Context, userID, query string, limit int) ([]*FlashcardHit, error) {
+	sqlQuery := `
+		WITH q AS (SELECT websearch_to_tsquery('english', $2) AS query)
+		SELECT f.id, f.question, f.answer, f.stage, COALESCE(m.title, ''), m.id, COALESCE(m.source_url, ''),
+		       ts_headline('english', f.question || ' — ' || f.answer, q.query, 'StartSel=` + HighlightStart + `, StopSel=` + HighlightStop + `, HighlightAll=true'),
+		       ts_rank_cd(f.search_tsv, q.query)::float8 AS rank
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id, q
+		WHERE m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		  AND f.search_tsv @@ q.query
+		ORDER BY rank DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(ctx, sqlQuery, userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search flashcards: %w", err)
+	}
+	defer rows.Close()
+	return scanFlashcardHits(rows)
+}
+
+// SearchFlashcardsByVector ranks a user's flashcards by cosine similarity to an embedding
+func (s *PostgresStore) SearchFlashcardsByVector(ctx context.Context, userID string, embedding []float32, limit int) ([]*FlashcardHit, error) {
+	sqlQuery := `
+		SELECT f.id, f.question, f.answer, f.stage, COALESCE(m.title, ''), m.id, COALESCE(m.source_url, ''),
+		       f.question || ' — ' || f.answer,
+		       1 - (f.embedding <=> $2::vector) AS similarity
+		FROM flashcards f
+		JOIN materials m ON f.material_id = m.id
+		WHERE m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		  AND f.embedding IS NOT NULL
+		ORDER BY f.embedding <=> $2::vector
+		LIMIT $3
+	`
+	rows, err := s.db.Query(ctx, sqlQuery, userID, vectorLiteral(embedding), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search flashcards by vector: %w", err)
+	}
+	defer rows.Close()
+	return scanFlashcardHits(rows)
+}
+
+func scanFlashcardHits(rows pgx.Rows) ([]*FlashcardHit, error) {
+	var hits []*FlashcardHit
+	for rows.Next() {
+		h := FlashcardHit{Card: &learning.Flashcard{}}
+		if err := rows.Scan(&h.Card.Id, &h.Card.Question, &h.Card.Answer, &h.Card.Stage, &h.Card.MaterialTitle,
+			&h.MaterialID, &h.SourceURL, &h.Snippet, &h.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan flashcard hit: %w", err)
+		}
+		hits = append(hits, &h)
+	}
+	return hits, rows.Err()
+}
+
+// SearchChunksByVector ranks a user's material chunks by cosine similarity to an embedding.
+// Snippet holds the chunk text; at most one chunk (the closest) is returned per material.
+func (s *PostgresStore) SearchChunksByVector(ctx context.Context, userID string, embedding []float32, limit int) ([]*MaterialHit, error) {
+	sqlQuery := `
+		SELECT material_id, title, source_url, content, similarity FROM (
+			SELECT DISTINCT ON (m.id) m.id AS material_id, COALESCE(m.title, '') AS title,
+			       COALESCE(m.source_url, '') AS source_url, c.content,
+			       1 - (c.embedding <=> $2::vector) AS similarity
+			FROM material_chunks c
+			JOIN materials m ON c.material_id = m.id
+			WHERE m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+			  AND c.embedding IS NOT NULL
+			ORDER BY m.id, c.embedding <=> $2::vector
+		) best
+		ORDER BY similarity DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(ctx, sqlQuery, userID, vectorLiteral(embedding), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search chunks by vector: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*MaterialHit
+	for rows.Next() {
+		h := MaterialHit{MatchedIn: "content"}
+		if err := rows.Scan(&h.MaterialID, &h.Title, &h.SourceURL, &h.Snippet, &h.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan chunk hit: %w", err)
+		}
+		hits = append(hits, &h)
+	}
+	return hits, rows.Err()
+}
+
+// SearchPassagesByVector returns the user's chunks closest to an embedding.
+// Unlike SearchChunksByVector, a material may contribute several chunks.
+func (s *PostgresStore) SearchPassagesByVector(ctx context.Context, userID string, embedding []float32, limit int) ([]*MaterialHit, error) {
+	sqlQuery := `
+		SELECT m.id, COALESCE(m.title, ''), COALESCE(m.source_url, ''), c.content,
+		       1 - (c.embedding <=> $2::vector) AS similarity
+		FROM material_chunks c
+		JOIN materials m ON c.material_id = m.id
+		WHERE m.user_id = $1 AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		  AND c.embedding IS NOT NULL
+		ORDER BY c.embedding <=> $2::vector
+		LIMIT $3
+	`
+	rows, err := s.db.Query(ctx, sqlQuery, userID, vectorLiteral(embedding), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search passages by vector: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*MaterialHit
+	for rows.Next() {
+		h := MaterialHit{MatchedIn: "content"}
+		if err := rows.Scan(&h.MaterialID, &h.Title, &h.SourceURL, &h.Snippet, &h.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan passage hit: %w", err)
+		}
+		hits = append(hits, &h)
+	}
+	return hits, rows.Err()
+}
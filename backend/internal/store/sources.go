@@ -0,0 +1,219 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Source kinds
+const (
+	SourceFeed = "FEED" // RSS or Atom feed, including YouTube channel and playlist feeds
+	SourcePage = "PAGE" // HTML index page (e.g. a blog without a feed) whose new article links are imported
+)
+
+var (
+	ErrSourceNotFound = errors.New("source not found")
+	ErrSourceExists   = errors.New("already subscribed to this source")
+)
+
+// Source is a feed or page a user subscribed to
+type Source struct {
+	ID            string
+	UserID        string
+	Kind          string
+	URL           string // As entered by the user
+	FeedURL       string // What is polled
+	Title         string
+	Tag           string // Added to every material imported from the source
+	ETag          string
+	LastModified  string
+	LastError     string // Of the last poll, "" when it succeeded
+	ItemsImported int32
+	LastPolledAt  *time.Time
+	CreatedAt     time.Time
+}
+
+const sourceColumns = `id, user_id, kind, url, feed_url, title, tag, etag, last_modified, last_error,
+	items_imported, last_polled_at, created_at`
+
+func scanSource(row pgx.Row) (*Source, error) {
+	var src Source
+	err := row.Scan(&src.ID, &src.UserID, &src.Kind, &src.URL, &src.FeedURL, &src.Title, &src.Tag,
+		&src.ETag, &src.LastModified, &src.LastError, &src.ItemsImported, &src.LastPolledAt, &src.CreatedAt)
+	return &src, err
+}
+
+// CreateSource saves a subscription with the keys of the entries it already
+// has, which are never imported
+func (s *PostgresStore) CreateSource(ctx context.Context, src *Source, seenKeys []string, nextPollAt time.Time) (*Source, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO sources (user_id, kind, url, feed_url, title, tag, etag, last_modified, last_polled_at, next_poll_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), $9)
+		RETURNING ` + sourceColumns
+	created, err := scanSource(tx.QueryRow(ctx, query, src.UserID, src.Kind, src.URL, src.FeedURL, src.Title, src.Tag,
+		src.ETag, src.LastModified, nextPollAt))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrSourceExists
+		}
+		return nil, fmt.Errorf("failed to create source: %w", err)
+	}
+
+	if len(seenKeys) > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO source_items (source_id, item_key)
+			SELECT $1, k FROM unnest($2::text[]) AS k
+			ON CONFLICT DO NOTHING
+		`, created.ID, seenKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save source items: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListSources returns a user's subscriptions, newest first
+func (s *PostgresStore) ListSources(ctx context.Context, userID string) ([]*Source, error) {
+	rows, err := s.db.Query(ctx, `SELECT `+sourceColumns+` FROM sources WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*Source
+	for rows.Next() {
+		src, err := scanSource(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan source: %w", err)
+		}
+		sources = append(sources, src)
+	}
+	return sources, rows.Err()
+}
+
+// CountSources returns the number of subscriptions of a user
+func (s *PostgresStore) CountSources(ctx context.Context, userID string) (int, error) {
+	var count int
+	if err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM sources WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count sources: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteSource unsubscribes; materials already imported are kept
+func (s *PostgresStore) DeleteSource(ctx context.Context, userID, sourceID string) error {
+	result, err := s.db.Exec(ctx, `DELETE FROM sources WHERE id = $1 AND user_id = $2`, sourceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete source: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSourceNotFound
+	}
+	return nil
+}
+
+// GetDueSources returns up to limit subscriptions due for polling, longest waiting first
+func (s *PostgresStore) GetDueSources(ctx context.Context, limit int) ([]*Source, error) {
+	query := `SELECT ` + sourceColumns + ` FROM sources WHERE next_poll_at <= NOW() ORDER BY next_poll_at LIMIT $1`
+	rows, err := s.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*Source
+	for rows.Next() {
+		src, err := scanSource(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan source: %w", err)
+		}
+		sources = append(sources, src)
+	}
+	return sources, rows.Err()
+}
+
+// GetSeenSourceItems returns which of the keys the source has already seen:
+// entries that were imported or skipped, and entries that failed maxAttempts times
+func (s *PostgresStore) GetSeenSourceItems(ctx context.Context, sourceID string, keys []string, maxAttempts int) (map[string]bool, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT item_key FROM source_items
+		WHERE source_id = $1 AND item_key = ANY($2) AND (failed_attempts = 0 OR failed_attempts >= $3)
+	`, sourceID, keys, maxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source items: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan source item: %w", err)
+		}
+		seen[key] = true
+	}
+	return seen, rows.Err()
+}
+
+// MarkSourceItem records an entry as seen, with the material it was imported
+// as ("" when it was already in the library)
+func (s *PostgresStore) MarkSourceItem(ctx context.Context, sourceID, key, materialID string) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO source_items (source_id, item_key, material_id)
+		VALUES ($1, $2, NULLIF($3, '')::uuid)
+		ON CONFLICT (source_id, item_key) DO UPDATE SET
+			material_id = EXCLUDED.material_id, failed_attempts = 0, seen_at = NOW()
+	`, sourceID, key, materialID)
+	if err != nil {
+		return fmt.Errorf("failed to mark source item: %w", err)
+	}
+	if materialID != "" {
+		if _, err := s.db.Exec(ctx, `UPDATE sources SET items_imported = items_imported + 1 WHERE id = $1`, sourceID); err != nil {
+			return fmt.Errorf("failed to count source item: %w", err)
+		}
+	}
+	return nil
+}
+
+// MarkSourceItemFailed counts a failed import of an entry
+func (s *PostgresStore) MarkSourceItemFailed(ctx context.Context, sourceID, key string) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO source_items (source_id, item_key, failed_attempts)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (source_id, item_key) DO UPDATE SET
+			failed_attempts = source_items.failed_attempts + 1, seen_at = NOW()
+	`, sourceID, key)
+	if err != nil {
+		return fmt.Errorf("failed to mark source item failed: %w", err)
+	}
+	return nil
+}
+
+// SetSourcePolled records the outcome of a poll and schedules the next one
+func (s *PostgresStore) SetSourcePolled(ctx context.Context, sourceID, etag, lastModified, lastError string, nextPollAt time.Time) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE sources
+		SET etag = $2, last_modified = $3, last_error = $4, last_polled_at = NOW(), next_poll_at = $5
+		WHERE id = $1
+	`, sourceID, etag, lastModified, lastError, nextPollAt)
+	if err != nil {
+		return fmt.Errorf("failed to update source: %w", err)
+	}
+	return nil
+}
@@ -20,22 +20,137 @@ type Store interface {
 	CreateMaterial(ctx context.Context, userID, matType, content, title, sourceURL string) (string, error)
 	GetMaterialBySourceURL(ctx context.Context, userID, sourceURL string) (string, error)
 	SoftDeleteMaterial(ctx context.Context, userID, materialID string) error
+	SetMaterialPromptTemplate(ctx context.Context, materialID, templateID string) error
 
 	// Tags
 	CreateTag(ctx context.Context, userID, name string) (string, error)
+	ResolveTag(ctx context.Context, userID, name string) (id, canonical string, err error)
 	GetTags(ctx context.Context, userID string) ([]string, error)
 	AddMaterialTags(ctx context.Context, materialID string, tagIDs []string) error
 	GetMaterialTags(ctx context.Context, materialID string) ([]string, error)
+	ListTags(ctx context.Context, userID string) ([]*TagInfo, error)
+	MoveTag(ctx context.Context, userID, from, to string) (int, error)
+	DeleteTag(ctx context.Context, userID, name string, recursive bool) (int, error)
+	SetMaterialTags(ctx context.Context, userID, materialID string, tagIDs []string) error
+	SaveTagMergeSuggestion(ctx context.Context, userID, sourceTagID, targetTagID, reason string, score float64) error
+	GetTagMergeSuggestions(ctx context.Context, userID string) ([]*TagMergeSuggestion, error)
+	GetTagMergeSuggestion(ctx context.Context, userID, id string) (*TagMergeSuggestion, error)
+	SetTagMergeSuggestionStatus(ctx context.Context, userID, id, status string) error
+	GetUsersWithTags(ctx context.Context, minTags int) ([]string, error)
 
 	// Flashcard
 	CreateFlashcards(ctx context.Context, materialID string, cards []*learning.Flashcard) error
 	GetFlashcard(ctx context.Context, id string) (*learning.Flashcard, error)
 	GetDueFlashcards(ctx context.Context, userID, materialID string) ([]*learning.Flashcard, error)
-	GetDueMaterials(ctx context.Context, userID string, page, pageSize int32, searchQuery string, tags []string, onlyDue bool) ([]*learning.MaterialSummary, int32, error)
+	GetDueMaterials(ctx context.Context, userID string, page, pageSize int32, searchQuery string, tags []string, collectionID string, onlyDue bool) ([]*learning.MaterialSummary, int32, error)
 	GetDueFlashcardsCount(ctx context.Context, userID string) (int32, error)
 	GetNotificationData(ctx context.Context, userID string) (flashcardsCount int32, materialsCount int32, firstTitle string, err error)
 	UpdateFlashcard(ctx context.Context, id string, stage int32, nextReviewAt time.Time) error
-	UpdateFlashcardContent(ctx context.Context, id, question, answer string) error
+	UpdateFlashcardContent(ctx context.Context, userID, id, question, answer string) error
+
+	// Collections
+	CreateCollection(ctx context.Context, userID string, c *Collection) (string, error)
+	UpdateCollection(ctx context.Context, userID string, c *Collection) error
+	DeleteCollection(ctx context.Context, userID, collectionID string) error
+	GetCollection(ctx context.Context, userID, collectionID string) (*Collection, error)
+	GetStudyCollection(ctx context.Context, userID, collectionID string) (*Collection, error)
+	ListCollections(ctx context.Context, userID string) ([]*Collection, error)
+	ReorderCollections(ctx context.Context, userID string, collectionIDs []string) error
+	SetMaterialCollection(ctx context.Context, userID, materialID, collectionID string) error
+	CountNewCardsReviewedSince(ctx context.Context, userID, collectionID string, since time.Time) (int, error)
+	GetCollectionDueFlashcards(ctx context.Context, userID, collectionID string, newLimit int) ([]*learning.Flashcard, error)
+	GetFlashcardSchedulerPreset(ctx context.Context, flashcardID string) (string, error)
+
+	// Organizations
+	CreateOrganization(ctx context.Context, ownerID, name string) (string, error)
+	GetOrganization(ctx context.Context, userID, orgID string) (*Organization, error)
+	ListOrganizations(ctx context.Context, userID string) ([]*Organization, error)
+	DeleteOrganization(ctx context.Context, ownerID, orgID string) error
+	GetOrganizationRole(ctx context.Context, orgID, userID string) (string, error)
+	GetOrganizationMembers(ctx context.Context, orgID string) ([]*OrganizationMember, error)
+	AddOrganizationMember(ctx context.Context, orgID, userID, role string) error
+	SetOrganizationMemberRole(ctx context.Context, orgID, userID, role string) error
+	RemoveOrganizationMember(ctx context.Context, orgID, userID string) error
+	DowngradeExpiredOrganizations(ctx context.Context) (int64, error)
+	ShareCollection(ctx context.Context, userID, collectionID, orgID string) error
+	ListOrganizationCollections(ctx context.Context, userID, orgID string) ([]*Collection, error)
+	GetFlashcardReviewStage(ctx context.Context, userID, flashcardID string) (int32, bool, error)
+	GetFlashcardOwnership(ctx context.Context, userID, flashcardID string) (bool, string, error)
+	UpdateFlashcardProgress(ctx context.Context, userID, flashcardID string, stage int32, nextReviewAt time.Time) error
+	GetUserByEmail(ctx context.Context, email string) (string, error)
+
+	// Progress reports
+	RecordReview(ctx context.Context, userID, flashcardID string, passed bool) error
+	GetCollectionMemberProgress(ctx context.Context, orgID, collectionID string, since time.Time) ([]*MemberProgress, error)
+	SetReportingOptOut(ctx context.Context, orgID, userID string, optOut bool) error
+
+	// Concepts
+	UpsertConcept(ctx context.Context, userID, name, normalizedName, description string) (string, error)
+	LinkMaterialConcept(ctx context.Context, materialID, conceptID string, flashcardIDs []string) error
+	AddConceptRelation(ctx context.Context, userID, sourceID, targetID, relation string) error
+	SetMaterialConceptsExtracted(ctx context.Context, materialID string) error
+	MaterialConceptsExtracted(ctx context.Context, userID, materialID string) (bool, error)
+	GetMaterialFlashcards(ctx context.Context, materialID string) ([]FlashcardText, error)
+	ListConceptNames(ctx context.Context, userID string, limit int) ([]string, error)
+	GetConcepts(ctx context.Context, userID, focusID string, limit int) ([]*Concept, error)
+	GetConceptRelations(ctx context.Context, userID string, conceptIDs []string) ([]*ConceptRelation, error)
+	GetConceptMaterials(ctx context.Context, userID, conceptID string) ([]*RelatedMaterial, error)
+	GetRelatedMaterials(ctx context.Context, userID, materialID string, limit int) ([]*RelatedMaterial, error)
+	GetConceptFlashcards(ctx context.Context, userID, conceptID string) ([]*learning.Flashcard, error)
+
+	// Card ordering
+	GetCardOrdering(ctx context.Context, userID string, cardIDs []string) (map[string]*CardOrdering, error)
+	SetFlashcardDependencies(ctx context.Context, userID, flashcardID string, dependsOnIDs []string) error
+
+	// Public decks
+	PublishDeck(ctx context.Context, userID string, d *PublicDeck, fallbackSlug string) (*PublicDeck, error)
+	UnpublishDeck(ctx context.Context, userID, slug string) error
+	GetPublicDeck(ctx context.Context, slug string) (*PublicDeck, error)
+	ListPublicDecks(ctx context.Context, userID string) ([]*PublicDeck, error)
+	GetPublicDeckMaterials(ctx context.Context, d *PublicDeck) ([]*PublicDeckMaterial, error)
+	CloneMaterial(ctx context.Context, materialID, toUserID, collectionID string) (string, int, error)
+	IncrementDeckCloneCount(ctx context.Context, deckID string) error
+
+	// Search
+	SearchMaterials(ctx context.Context, userID, query string, limit int) ([]*MaterialHit, error)
+	SearchFlashcards(ctx context.Context, userID, query string, limit int) ([]*FlashcardHit, error)
+	SearchChunksByVector(ctx context.Context, userID string, embedding []float32, limit int) ([]*MaterialHit, error)
+	SearchFlashcardsByVector(ctx context.Context, userID string, embedding []float32, limit int) ([]*FlashcardHit, error)
+	SearchPassagesByVector(ctx context.Context, userID string, embedding []float32, limit int) ([]*MaterialHit, error)
+
+	// Sources
+	CreateSource(ctx context.Context, src *Source, seenKeys []string, nextPollAt time.Time) (*Source, error)
+	ListSources(ctx context.Context, userID string) ([]*Source, error)
+	CountSources(ctx context.Context, userID string) (int, error)
+	DeleteSource(ctx context.Context, userID, sourceID string) error
+	GetDueSources(ctx context.Context, limit int) ([]*Source, error)
+	GetSeenSourceItems(ctx context.Context, sourceID string, keys []string, maxAttempts int) (map[string]bool, error)
+	MarkSourceItem(ctx context.Context, sourceID, key, materialID string) error
+	MarkSourceItemFailed(ctx context.Context, sourceID, key string) error
+	SetSourcePolled(ctx context.Context, sourceID, etag, lastModified, lastError string, nextPollAt time.Time) error
+
+	// YouTube playlist and channel imports
+	CreateImportJob(ctx context.Context, job *ImportJob, items []*ImportItem) (*ImportJob, error)
+	ListImportJobs(ctx context.Context, userID string) ([]*ImportJob, error)
+	GetImportJob(ctx context.Context, userID, jobID string) (*ImportJob, error)
+	GetImportItems(ctx context.Context, jobID string) ([]*ImportItem, error)
+	CountActiveImportJobs(ctx context.Context, userID string) (int, error)
+	NextImportItem(ctx context.Context) (*ImportJob, *ImportItem, error)
+	SetImportItemStatus(ctx context.Context, jobID string, position int32, status, materialID, errMsg string) error
+	DeferImportJob(ctx context.Context, jobID, reason string, nextAttemptAt time.Time) error
+	CancelImportJob(ctx context.Context, userID, jobID string) error
+
+	// Material refresh
+	GetMaterialsToRecheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*LinkedMaterial, error)
+	SetMaterialChecked(ctx context.Context, materialID string) error
+	SaveMaterialRefresh(ctx context.Context, materialID, content string, changeRatio float64) error
+	GetMaterialRefresh(ctx context.Context, materialID string) (*MaterialRefresh, error)
+	ApplyMaterialRefresh(ctx context.Context, materialID, content string, staleCardIDs []string) error
+
+	// Duplicate detection
+	GetMaterialFingerprints(ctx context.Context, userID string) ([]*MaterialFingerprint, error)
+	SetMaterialFingerprint(ctx context.Context, materialID, canonicalURL string, simhash *uint64) error
+	GetSimilarFlashcards(ctx context.Context, userID string, queries []string, perQuery int) ([]FlashcardText, error)
 
 	// Material Summary
 	GetMaterialContent(ctx context.Context, userID, materialID string) (content string, summary string, title string, materialType string, sourceURL string, err error)
@@ -55,6 +170,8 @@ type Store interface {
 	CheckQuota(ctx context.Context, userID, resource string, limit int) (bool, error)
 	IncrementQuota(ctx context.Context, userID, resource string) error
 	GetUsage(ctx context.Context, userID, resource string) (int, error)
+	CheckOrganizationQuota(ctx context.Context, orgID, resource string, limit int) (bool, error)
+	IncrementOrganizationQuota(ctx context.Context, orgID, resource string) error
 
 	// Settings
 	GetSetting(ctx context.Context, key string) ([]byte, error)
@@ -27,12 +27,34 @@ type Subscription struct {
 	Status                 SubscriptionStatus
 	CurrentPeriodEnd       *time.Time
 	RazorpaySubscriptionID string
+	OrganizationID         string // Set when Pro comes from an organization seat
+	Seats                  int32  // Seats of that organization
 	CreatedAt              time.Time
 	UpdatedAt              time.Time
 }
 
-// GetSubscription retrieves a user's subscription
+// GetSubscription retrieves a user's subscription. Users without a personal Pro
+// plan get Pro through an active Pro organization they are a member of.
 func (s *PostgresStore) GetSubscription(ctx context.Context, userID string) (*Subscription, error) {
+	sub, err := s.getUserSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Plan == PlanPro && sub.Status == StatusActive && (sub.CurrentPeriodEnd == nil || sub.CurrentPeriodEnd.After(time.Now())) {
+		return sub, nil
+	}
+	orgSub, err := s.getOrganizationSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if orgSub != nil {
+		return orgSub, nil
+	}
+	return sub, nil
+}
+
+// getUserSubscription retrieves a user's personal subscription
+func (s *PostgresStore) getUserSubscription(ctx context.Context, userID string) (*Subscription, error) {
 	query := `
 		SELECT plan, status, current_period_end, razorpay_subscription_id, created_at, updated_at
 		FROM subscriptions
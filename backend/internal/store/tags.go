@@ -0,0 +1,294 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TagInfo is a tag with the number of live materials it is attached to
+type TagInfo struct {
+	ID            string
+	Name          string
+	MaterialCount int
+}
+
+// TagMergeSuggestion proposes merging SourceTag into TargetTag
+type TagMergeSuggestion struct {
+	ID        string
+	SourceTag string
+	TargetTag string
+	Reason    string
+	Score     float64
+}
+
+// ErrTagNotFound is returned when a tag (path) does not exist for the user
+var ErrTagNotFound = errors.New("tag not found")
+
+// ResolveTag returns the user's canonical tag for name, matching case-insensitively
+// and through aliases left by renamed/merged tags. Creates the tag if unknown.
+func (s *PostgresStore) ResolveTag(ctx context.Context, userID, name string) (id, canonical string, err error) {
+	err = s.db.QueryRow(ctx, `
+		SELECT id, name FROM tags WHERE user_id = $1 AND lower(name) = lower($2)
+		ORDER BY (name = $2) DESC LIMIT 1
+	`, userID, name).Scan(&id, &canonical)
+	if err == nil {
+		return id, canonical, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", "", fmt.Errorf("failed to look up tag: %w", err)
+	}
+
+	err = s.db.QueryRow(ctx, `
+		SELECT t.id, t.name FROM tag_aliases a JOIN tags t ON t.id = a.tag_id
+		WHERE a.user_id = $1 AND a.alias = lower($2)
+	`, userID, name).Scan(&id, &canonical)
+	if err == nil {
+		return id, canonical, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", "", fmt.Errorf("failed to look up tag alias: %w", err)
+	}
+
+	id, err = s.CreateTag(ctx, userID, name)
+	return id, name, err
+}
+
+// ListTags returns all tags of a user with their material counts, ordered by path
+func (s *PostgresStore) ListTags(ctx context.Context, userID string) ([]*TagInfo, error) {
+	query := `
+		SELECT t.id, t.name, COUNT(m.id)
+		FROM tags t
+		LEFT JOIN material_tags mt ON mt.tag_id = t.id
+		LEFT JOIN materials m ON m.id = mt.material_id AND (m.is_deleted = FALSE OR m.is_deleted IS NULL)
+		WHERE t.user_id = $1
+		GROUP BY t.id, t.name
+		ORDER BY t.name
+	`
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*TagInfo
+	for rows.Next() {
+		var t TagInfo
+		if err := rows.Scan(&t.ID, &t.Name, &t.MaterialCount); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, &t)
+	}
+	return tags, rows.Err()
+}
+
+// MoveTag renames a tag and its descendants from one path to another
+// ("go" -> "lang/go" also moves "go/generics" -> "lang/go/generics").
+// Where a destination tag already exists the two are merged. Old names are
+// kept as aliases so future AI-generated tags resolve to the new ones.
+// Returns the number of tags moved.
+func (s *PostgresStore) MoveTag(ctx context.Context, userID, from, to string) (int, error) {
+	if to == from {
+		return 0, nil
+	}
+	if strings.HasPrefix(to, from+"/") {
+		return 0, fmt.Errorf("cannot move tag '%s' under itself", from)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, name FROM tags
+		WHERE user_id = $1 AND (name = $2 OR starts_with(name, $2 || '/'))
+		ORDER BY length(name)
+		FOR UPDATE
+	`, userID, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query tags: %w", err)
+	}
+	type tagRow struct{ id, name string }
+	var subtree []tagRow
+	for rows.Next() {
+		var t tagRow
+		if err := rows.Scan(&t.id, &t.name); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		subtree = append(subtree, t)
+	}
+	rows.Close()
+	if len(subtree) == 0 {
+		return 0, ErrTagNotFound
+	}
+
+	for _, t := range subtree {
+		dest := to + strings.TrimPrefix(t.name, from)
+		target := t.id
+
+		var existingID string
+		err := tx.QueryRow(ctx, `SELECT id FROM tags WHERE user_id = $1 AND name = $2`, userID, dest).Scan(&existingID)
+		switch {
+		case err == nil && existingID != t.id:
+			// Merge into the existing tag
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO material_tags (material_id, tag_id)
+				SELECT material_id, $2 FROM material_tags WHERE tag_id = $1
+				ON CONFLICT DO NOTHING
+			`, t.id, existingID); err != nil {
+				return 0, fmt.Errorf("failed to relink materials: %w", err)
+			}
+			if _, err := tx.Exec(ctx, `UPDATE tag_aliases SET tag_id = $2 WHERE tag_id = $1`, t.id, existingID); err != nil {
+				return 0, fmt.Errorf("failed to move aliases: %w", err)
+			}
+			if _, err := tx.Exec(ctx, `DELETE FROM tags WHERE id = $1`, t.id); err != nil {
+				return 0, fmt.Errorf("failed to delete merged tag: %w", err)
+			}
+			target = existingID
+		case err == nil || errors.Is(err, pgx.ErrNoRows):
+			if _, err := tx.Exec(ctx, `UPDATE tags SET name = $2 WHERE id = $1`, t.id, dest); err != nil {
+				return 0, fmt.Errorf("failed to rename tag: %w", err)
+			}
+		default:
+			return 0, fmt.Errorf("failed to look up tag: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO tag_aliases (user_id, alias, tag_id) VALUES ($1, lower($2), $3)
+			ON CONFLICT (user_id, alias) DO UPDATE SET tag_id = EXCLUDED.tag_id
+		`, userID, t.name, target); err != nil {
+			return 0, fmt.Errorf("failed to record tag alias: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(subtree), nil
+}
+
+// DeleteTag removes a tag from all materials and deletes it (and its descendants
+// if recursive). Materials themselves are kept. Returns the number of tags deleted.
+func (s *PostgresStore) DeleteTag(ctx context.Context, userID, name string, recursive bool) (int, error) {
+	query := `DELETE FROM tags WHERE user_id = $1 AND (name = $2 OR ($3 AND starts_with(name, $2 || '/')))`
+	result, err := s.db.Exec(ctx, query, userID, name, recursive)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return 0, ErrTagNotFound
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// SetMaterialTags replaces the tags of a user's material
+func (s *PostgresStore) SetMaterialTags(ctx context.Context, userID, materialID string, tagIDs []string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var owned bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM materials WHERE id = $1 AND user_id = $2)`, materialID, userID).Scan(&owned); err != nil {
+		return fmt.Errorf("failed to check material: %w", err)
+	}
+	if !owned {
+		return fmt.Errorf("material not found")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM material_tags WHERE material_id = $1`, materialID); err != nil {
+		return fmt.Errorf("failed to clear material tags: %w", err)
+	}
+	for _, tagID := range tagIDs {
+		if _, err := tx.Exec(ctx, `INSERT INTO material_tags (material_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, materialID, tagID); err != nil {
+			return fmt.Errorf("failed to link tag: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// SaveTagMergeSuggestion records a pending suggestion; pairs already suggested
+// (including dismissed ones) are left untouched
+func (s *PostgresStore) SaveTagMergeSuggestion(ctx context.Context, userID, sourceTagID, targetTagID, reason string, score float64) error {
+	query := `
+		INSERT INTO tag_merge_suggestions (user_id, source_tag_id, target_tag_id, reason, score)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, source_tag_id, target_tag_id) DO NOTHING
+	`
+	if _, err := s.db.Exec(ctx, query, userID, sourceTagID, targetTagID, reason, score); err != nil {
+		return fmt.Errorf("failed to save tag merge suggestion: %w", err)
+	}
+	return nil
+}
+
+const tagMergeSuggestionQuery = `
+	SELECT sg.id, src.name, dst.name, sg.reason, sg.score
+	FROM tag_merge_suggestions sg
+	JOIN tags src ON src.id = sg.source_tag_id
+	JOIN tags dst ON dst.id = sg.target_tag_id
+	WHERE sg.user_id = $1 AND sg.status = 'pending'`
+
+// GetTagMergeSuggestions returns the pending merge suggestions of a user, best first
+func (s *PostgresStore) GetTagMergeSuggestions(ctx context.Context, userID string) ([]*TagMergeSuggestion, error) {
+	rows, err := s.db.Query(ctx, tagMergeSuggestionQuery+` ORDER BY sg.score DESC, src.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tag merge suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []*TagMergeSuggestion
+	for rows.Next() {
+		var sg TagMergeSuggestion
+		if err := rows.Scan(&sg.ID, &sg.SourceTag, &sg.TargetTag, &sg.Reason, &sg.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan tag merge suggestion: %w", err)
+		}
+		suggestions = append(suggestions, &sg)
+	}
+	return suggestions, rows.Err()
+}
+
+// GetTagMergeSuggestion returns one pending suggestion of a user
+func (s *PostgresStore) GetTagMergeSuggestion(ctx context.Context, userID, id string) (*TagMergeSuggestion, error) {
+	var sg TagMergeSuggestion
+	err := s.db.QueryRow(ctx, tagMergeSuggestionQuery+` AND sg.id = $2`, userID, id).
+		Scan(&sg.ID, &sg.SourceTag, &sg.TargetTag, &sg.Reason, &sg.Score)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag merge suggestion: %w", err)
+	}
+	return &sg, nil
+}
+
+// SetTagMergeSuggestionStatus marks a suggestion as 'accepted' or 'dismissed'
+func (s *PostgresStore) SetTagMergeSuggestionStatus(ctx context.Context, userID, id, status string) error {
+	query := `UPDATE tag_merge_suggestions SET status = $3 WHERE id = $1 AND user_id = $2`
+	if _, err := s.db.Exec(ctx, query, id, userID, status); err != nil {
+		return fmt.Errorf("failed to update tag merge suggestion: %w", err)
+	}
+	return nil
+}
+
+// GetUsersWithTags returns users that have at least minTags tags
+func (s *PostgresStore) GetUsersWithTags(ctx context.Context, minTags int) ([]string, error) {
+	rows, err := s.db.Query(ctx, `SELECT user_id FROM tags GROUP BY user_id HAVING COUNT(*) >= $1`, minTags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users with tags: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
@@ -0,0 +1,386 @@
+package youtube
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Segment is one caption cue
+type Segment struct {
+	Start    time.Duration
+	Duration time.Duration
+	Text     string
+}
+
+// Chapter is a section of a video, from the timestamps in its description
+type Chapter struct {
+	Start time.Duration
+	Title string
+}
+
+// Transcript is the captions of a video with its chapters
+type Transcript struct {
+	VideoID       string
+	Title         string
+	Language      string // Code of the caption track, e.g. "en" or "pt-BR"; "" when unknown
+	AutoGenerated bool   // Speech recognition rather than uploaded captions
+	Segments      []Segment
+	Chapters      []Chapter // Empty when the description has none
+}
+
+// textLength is the number of bytes of caption text
+func (t *Transcript) textLength() int {
+	n := 0
+	for _, seg := range t.Segments {
+		n += len(seg.Text)
+	}
+	return n
+}
+
+// ErrNoCaptions is returned when a caption document holds no text
+var ErrNoCaptions = errors.New("no captions in response")
+
+// ParseTimedText parses a caption track in YouTube's JSON3 format or one of its
+// XML formats (srv1 <text start dur>, srv3 <p t d> in milliseconds)
+func ParseTimedText(body []byte) ([]Segment, error) {
+	body = bytes.TrimSpace(body)
+	var segments []Segment
+	var err error
+	switch {
+	case bytes.HasPrefix(body, []byte("{")):
+		segments, err = parseJSON3(body)
+	case bytes.HasPrefix(body, []byte("<")):
+		segments, err = parseTimedTextXML(body)
+	default:
+		return nil, fmt.Errorf("unknown caption format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, ErrNoCaptions
+	}
+	return segments, nil
+}
+
+func parseJSON3(body []byte) ([]Segment, error) {
+	var doc struct {
+		Events []struct {
+			StartMs    int64 `json:"tStartMs"`
+			DurationMs int64 `json:"dDurationMs"`
+			Segs       []struct {
+				UTF8 string `json:"utf8"`
+			} `json:"segs"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("invalid json3 captions: %w", err)
+	}
+
+	var segments []Segment
+	for _, event := range doc.Events {
+		var sb strings.Builder
+		for _, seg := range event.Segs {
+			sb.WriteString(seg.UTF8)
+		}
+		segments = appendSegment(segments, time.Duration(event.StartMs)*time.Millisecond,
+			time.Duration(event.DurationMs)*time.Millisecond, sb.String())
+	}
+	return segments, nil
+}
+
+func parseTimedTextXML(body []byte) ([]Segment, error) {
+	var doc struct {
+		XMLName xml.Name
+		Texts   []struct {
+			Start string `xml:"start,attr"`
+			Dur   string `xml:"dur,attr"`
+			Text  string `xml:",chardata"`
+		} `xml:"text"`
+		Paragraphs []struct {
+			T     int64  `xml:"t,attr"`
+			D     int64  `xml:"d,attr"`
+			Inner string `xml:",innerxml"` // Words are wrapped in <s> elements
+		} `xml:"body>p"`
+	}
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("invalid xml captions: %w", err)
+	}
+
+	var segments []Segment
+	switch doc.XMLName.Local {
+	case "transcript": // srv1, text is HTML-escaped once more
+		for _, t := range doc.Texts {
+			segments = appendSegment(segments, parseSeconds(t.Start), parseSeconds(t.Dur), html.UnescapeString(t.Text))
+		}
+	case "timedtext": // srv3
+		for _, p := range doc.Paragraphs {
+			text := html.UnescapeString(xmlTagRe.ReplaceAllString(p.Inner, ""))
+			segments = appendSegment(segments, time.Duration(p.T)*time.Millisecond, time.Duration(p.D)*time.Millisecond, text)
+		}
+	default:
+		return nil, fmt.Errorf("unknown caption document <%s>", doc.XMLName.Local)
+	}
+	return segments, nil
+}
+
+var xmlTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// appendSegment adds a cue with normalized whitespace, skipping empty ones
+// (JSON3 has line-break and window events without text)
+func appendSegment(segments []Segment, start, duration time.Duration, text string) []Segment {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return segments
+	}
+	return append(segments, Segment{Start: start, Duration: duration, Text: text})
+}
+
+// parseSeconds parses a decimal number of seconds, such as "12.34"
+func parseSeconds(s string) time.Duration {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(f * float64(time.Second))
+}
+
+// playerResponse holds the parts of a watch page's ytInitialPlayerResponse we use
+type playerResponse struct {
+	VideoDetails struct {
+		VideoID          string `json:"videoId"`
+		Title            string `json:"title"`
+		ShortDescription string `json:"shortDescription"`
+	} `json:"videoDetails"`
+	Captions struct {
+		Renderer struct {
+			Tracks []captionTrack `json:"captionTracks"`
+		} `json:"playerCaptionsTracklistRenderer"`
+	} `json:"captions"`
+}
+
+// captionTrack is one caption track offered for a video
+type captionTrack struct {
+	BaseURL      string `json:"baseUrl"`
+	LanguageCode string `json:"languageCode"`
+	Kind         string `json:"kind"` // "asr" for auto-generated
+}
+
+func (t captionTrack) autoGenerated() bool {
+	return t.Kind == "asr"
+}
+
+var playerResponseRe = regexp.MustCompile(`ytInitialPlayerResponse\s*=\s*`)
+
+// parsePlayerResponse reads the ytInitialPlayerResponse object embedded in a watch page
+func parsePlayerResponse(page []byte) (*playerResponse, error) {
+	loc := playerResponseRe.FindIndex(page)
+	if loc == nil {
+		return nil, fmt.Errorf("no player response in page")
+	}
+	var pr playerResponse
+	// The decoder stops at the end of the object, ignoring the script after it
+	if err := json.NewDecoder(bytes.NewReader(page[loc[1]:])).Decode(&pr); err != nil {
+		return nil, fmt.Errorf("invalid player response: %w", err)
+	}
+	return &pr, nil
+}
+
+// selectTrack picks the caption track for the preferred language, then English,
+// then any language. Within a language, uploaded captions win over auto-generated ones.
+func selectTrack(tracks []captionTrack, language string) (captionTrack, bool) {
+	var prefs []string
+	if language != "" {
+		prefs = append(prefs, language)
+	}
+	prefs = append(prefs, "en")
+
+	for _, lang := range prefs {
+		if t, ok := bestTrack(tracks, func(t captionTrack) bool { return sameLanguage(t.LanguageCode, lang) }); ok {
+			return t, true
+		}
+	}
+	return bestTrack(tracks, func(captionTrack) bool { return true })
+}
+
+// bestTrack returns the first uploaded track matching, or else the first auto-generated one
+func bestTrack(tracks []captionTrack, match func(captionTrack) bool) (captionTrack, bool) {
+	var auto *captionTrack
+	for i, t := range tracks {
+		if !match(t) || t.BaseURL == "" {
+			continue
+		}
+		if !t.autoGenerated() {
+			return t, true
+		}
+		if auto == nil {
+			auto = &tracks[i]
+		}
+	}
+	if auto != nil {
+		return *auto, true
+	}
+	return captionTrack{}, false
+}
+
+// sameLanguage compares language codes, treating a bare code as matching its
+// regional variants ("en" matches "en-GB", "pt-BR" only "pt-BR" and "pt")
+func sameLanguage(code, want string) bool {
+	code, want = strings.ToLower(code), strings.ToLower(want)
+	if code == want {
+		return true
+	}
+	base, _, _ := strings.Cut(code, "-")
+	wantBase, region, _ := strings.Cut(want, "-")
+	return base == wantBase && (region == "" || code == wantBase)
+}
+
+// timestampRe matches m:ss and h:mm:ss timestamps
+var timestampRe = regexp.MustCompile(`\b(?:(\d{1,2}):)?(\d{1,2}):(\d{2})\b`)
+
+// ParseTimestamp parses an m:ss or h:mm:ss timestamp
+func ParseTimestamp(s string) (time.Duration, bool) {
+	m := timestampRe.FindStringSubmatch(s)
+	if m == nil || m[0] != strings.TrimSpace(s) {
+		return 0, false
+	}
+	d := timestampDuration(m)
+	return d, d >= 0
+}
+
+func timestampDuration(m []string) time.Duration {
+	h, _ := strconv.Atoi(m[1])
+	mins, _ := strconv.Atoi(m[2])
+	sec, _ := strconv.Atoi(m[3])
+	if sec >= 60 {
+		return -1
+	}
+	return time.Duration(h)*time.Hour + time.Duration(mins)*time.Minute + time.Duration(sec)*time.Second
+}
+
+// FormatTimestamp formats a position in a video as m:ss, or h:mm:ss past an hour
+func FormatTimestamp(d time.Duration) string {
+	s := int(d / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// TimestampURL links to a position in a video
+func TimestampURL(videoID string, at time.Duration) string {
+	return fmt.Sprintf("https://youtu.be/%s?t=%d", videoID, int(at/time.Second))
+}
+
+// chapterSeparators trims the punctuation between a chapter timestamp and its title
+const chapterSeparators = " \t-–—:|.•*()[]"
+
+// ParseChapters reads the chapter list from a video description: lines with a
+// timestamp and a title, the first at 0:00, in ascending order. Like YouTube,
+// it requires at least three chapters. Timestamps after the list (e.g. in
+// comments about the video) are ignored.
+func ParseChapters(description string) []Chapter {
+	var chapters []Chapter
+	for _, line := range strings.Split(description, "\n") {
+		loc := timestampRe.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		start := timestampDuration(timestampRe.FindStringSubmatch(line))
+		// The title follows the timestamp, or precedes it ("Intro 0:00")
+		title := strings.Trim(line[loc[1]:], chapterSeparators)
+		if title == "" {
+			title = strings.Trim(line[:loc[0]], chapterSeparators)
+		}
+		if start < 0 || title == "" {
+			continue
+		}
+
+		if len(chapters) == 0 {
+			if start == 0 {
+				chapters = append(chapters, Chapter{Start: start, Title: title})
+			}
+			continue
+		}
+		if start <= chapters[len(chapters)-1].Start {
+			break
+		}
+		chapters = append(chapters, Chapter{Start: start, Title: title})
+	}
+	if len(chapters) < 3 {
+		return nil
+	}
+	return chapters
+}
+
+const (
+	// Transcript paragraphs end at a sentence end after paragraphMinLength,
+	// or anywhere after paragraphMaxLength
+	paragraphMinLength = 30 * time.Second
+	paragraphMaxLength = 60 * time.Second
+)
+
+// Text renders the transcript as Markdown: the video title, a heading per
+// chapter, and paragraphs of about half a minute, each starting with its
+// [m:ss] timestamp (see ParagraphTimestamp)
+func (t *Transcript) Text() string {
+	var sb strings.Builder
+	if t.Title != "" {
+		sb.WriteString("# " + t.Title + "\n\n")
+	}
+
+	chapter := 0
+	var para []string
+	var paraStart time.Duration
+	flush := func() {
+		if len(para) > 0 {
+			sb.WriteString("[" + FormatTimestamp(paraStart) + "] " + strings.Join(para, " ") + "\n\n")
+			para = nil
+		}
+	}
+	for _, seg := range t.Segments {
+		if chapter < len(t.Chapters) && seg.Start >= t.Chapters[chapter].Start {
+			flush()
+			for chapter < len(t.Chapters) && seg.Start >= t.Chapters[chapter].Start {
+				chapter++
+			}
+			sb.WriteString("## " + t.Chapters[chapter-1].Title + "\n\n")
+		}
+		if len(para) == 0 {
+			paraStart = seg.Start
+		}
+		para = append(para, seg.Text)
+
+		elapsed := seg.Start + seg.Duration - paraStart
+		if elapsed >= paragraphMaxLength || (elapsed >= paragraphMinLength && endsSentence(seg.Text)) {
+			flush()
+		}
+	}
+	flush()
+	return strings.TrimSpace(sb.String())
+}
+
+func endsSentence(text string) bool {
+	return strings.HasSuffix(text, ".") || strings.HasSuffix(text, "?") || strings.HasSuffix(text, "!")
+}
+
+// paragraphTimestampRe matches the timestamp Text puts before each paragraph
+var paragraphTimestampRe = regexp.MustCompile(`^\[((?:\d+:)?\d+:\d{2})\] `)
+
+// ParagraphTimestamp returns the position of a paragraph of a rendered
+// transcript, from its [m:ss] prefix
+func ParagraphTimestamp(paragraph string) (time.Duration, bool) {
+	m := paragraphTimestampRe.FindStringSubmatch(paragraph)
+	if m == nil {
+		return 0, false
+	}
+	return ParseTimestamp(m[1])
+}
@@ -0,0 +1,144 @@
+package youtube
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func ms(n int) time.Duration { return time.Duration(n) * time.Millisecond }
+
+// fixtureSegments is the caption track saved in testdata in every format
+var fixtureSegments = []Segment{
+	{Start: ms(320), Duration: ms(4080), Text: "welcome back to the channel"},
+	{Start: ms(2480), Duration: ms(5120), Text: "today we look at Raft's leader election"},
+	{Start: ms(65010), Duration: ms(3500), Text: "timeouts are randomized"},
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestParseTimedText(t *testing.T) {
+	for _, name := range []string{"timedtext.json3", "timedtext_srv1.xml", "timedtext_srv3.xml"} {
+		got, err := ParseTimedText(readFixture(t, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, fixtureSegments) {
+			t.Errorf("%s:\n got %+v\nwant %+v", name, got, fixtureSegments)
+		}
+	}
+
+	if _, err := ParseTimedText([]byte(`{"events":[{"tStartMs":0,"segs":[{"utf8":"\n"}]}]}`)); err != ErrNoCaptions {
+		t.Errorf("empty track: err = %v, want ErrNoCaptions", err)
+	}
+	if _, err := ParseTimedText([]byte("<html><body>Sign in</body></html>")); err == nil {
+		t.Error("HTML page should fail")
+	}
+}
+
+func TestParseSupadataTranscript(t *testing.T) {
+	got, lang, err := parseSupadataTranscript(readFixture(t, "supadata.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lang != "en" || !reflect.DeepEqual(got, fixtureSegments) {
+		t.Errorf("got %q %+v", lang, got)
+	}
+}
+
+func TestParsePlayerResponse(t *testing.T) {
+	pr, err := parsePlayerResponse(readFixture(t, "watch_page.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.VideoDetails.Title != "Raft explained" || len(pr.Captions.Renderer.Tracks) != 3 {
+		t.Fatalf("player response = %+v", pr)
+	}
+
+	wantChapters := []Chapter{
+		{Start: 0, Title: "Intro"},
+		{Start: 65 * time.Second, Title: "Leader election"},
+		{Start: 750 * time.Second, Title: "Log replication"},
+		{Start: 1270 * time.Second, Title: "Snapshots"},
+	}
+	if got := ParseChapters(pr.VideoDetails.ShortDescription); !reflect.DeepEqual(got, wantChapters) {
+		t.Errorf("chapters = %+v, want %+v", got, wantChapters)
+	}
+
+	tracks := pr.Captions.Renderer.Tracks
+	tests := []struct {
+		language string
+		wantLang string
+	}{
+		{"", "en-GB"},   // Uploaded English beats auto-generated English
+		{"de", "de"},    // Requested language
+		{"de-AT", "de"}, // No Austrian track: the generic German one
+		{"fr", "en-GB"}, // Unavailable: English
+	}
+	for _, tt := range tests {
+		track, ok := selectTrack(tracks, tt.language)
+		if !ok || track.LanguageCode != tt.wantLang {
+			t.Errorf("selectTrack(%q) = %q, want %q", tt.language, track.LanguageCode, tt.wantLang)
+		}
+	}
+
+	auto := []captionTrack{{BaseURL: "u1", LanguageCode: "es", Kind: "asr"}}
+	if track, ok := selectTrack(auto, "de"); !ok || track.BaseURL != "u1" {
+		t.Errorf("only track should be used, got %+v", track)
+	}
+}
+
+func TestParseChapters(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        int
+	}{
+		{"too few", "0:00 Intro\n5:00 Outro", 0},
+		{"not from zero", "1:00 A\n2:00 B\n3:00 C", 0},
+		{"hours", "00:00 Intro\n59:59 Middle\n1:02:03 Late part\n1:02:04 End", 4},
+		{"invalid seconds", "0:00 Intro\n1:75 Broken\n2:00 Fine\n3:00 Also fine", 3},
+	}
+	for _, tt := range tests {
+		if got := ParseChapters(tt.description); len(got) != tt.want {
+			t.Errorf("%s: %d chapters %+v, want %d", tt.name, len(got), got, tt.want)
+		}
+	}
+}
+
+func TestTranscriptText(t *testing.T) {
+	tr := &Transcript{
+		Title: "Raft explained",
+		Segments: []Segment{
+			{Start: 0, Duration: ms(4000), Text: "welcome back."},
+			{Start: ms(4000), Duration: ms(30000), Text: "today: leader election."},
+			{Start: ms(34000), Duration: ms(5000), Text: "a leader sends heartbeats"},
+			{Start: ms(65000), Duration: ms(5000), Text: "timeouts are randomized."},
+			{Start: ms(3725000), Duration: ms(5000), Text: "that's all."},
+		},
+		Chapters: []Chapter{{Start: 0, Title: "Intro"}, {Start: 60 * time.Second, Title: "Elections"}, {Start: time.Hour, Title: "Outro"}},
+	}
+	want := "# Raft explained\n\n" +
+		"## Intro\n\n[0:00] welcome back. today: leader election.\n\n[0:34] a leader sends heartbeats\n\n" +
+		"## Elections\n\n[1:05] timeouts are randomized.\n\n" +
+		"## Outro\n\n[1:02:05] that's all."
+	if got := tr.Text(); got != want {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, want)
+	}
+
+	if at, ok := ParagraphTimestamp("[1:02:05] that's all."); !ok || at != 3725*time.Second {
+		t.Errorf("ParagraphTimestamp = %v, %v", at, ok)
+	}
+	if got := TimestampURL("abcdefghijk", ms(65900)); got != "https://youtu.be/abcdefghijk?t=65" {
+		t.Errorf("TimestampURL = %s", got)
+	}
+}
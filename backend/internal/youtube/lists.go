@@ -0,0 +1,385 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// List kinds
+const (
+	ListPlaylist = "PLAYLIST"
+	ListChannel  = "CHANNEL"
+)
+
+var (
+	// ErrNotList is returned by ParseListURL for URLs that aren't a playlist or channel
+	ErrNotList = errors.New("not a youtube playlist or channel url")
+	// ErrListURL is returned by ExtractVideoID for playlist and channel URLs
+	ErrListURL = errors.New("this is a youtube playlist or channel, import it as a batch")
+)
+
+// ListRef identifies a playlist or a channel
+type ListRef struct {
+	Kind string
+	// Playlist ID, or the channel's path: "channel/UC…", "@handle", "c/name" or "user/name"
+	ID string
+}
+
+var (
+	playlistIDRe   = regexp.MustCompile(`^[A-Za-z0-9_-]{10,64}$`)
+	channelPathRes = []*regexp.Regexp{
+		regexp.MustCompile(`^/(channel/UC[A-Za-z0-9_-]{22})(?:/|$)`),
+		regexp.MustCompile(`^/(@[^/]+)(?:/|$)`),
+		regexp.MustCompile(`^/((?:c|user)/[^/]+)(?:/|$)`),
+	}
+)
+
+// ParseListURL recognizes playlist URLs (including a video opened from a
+// playlist) and channel URLs
+func ParseListURL(raw string) (ListRef, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ListRef{}, ErrNotList
+	}
+	switch strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.") {
+	case "youtube.com", "m.youtube.com", "music.youtube.com":
+	default:
+		return ListRef{}, ErrNotList
+	}
+
+	if list := u.Query().Get("list"); list != "" && (u.Path == "/playlist" || u.Path == "/watch") {
+		if !playlistIDRe.MatchString(list) {
+			return ListRef{}, ErrNotList
+		}
+		return ListRef{Kind: ListPlaylist, ID: list}, nil
+	}
+	for _, re := range channelPathRes {
+		if m := re.FindStringSubmatch(u.Path); m != nil {
+			return ListRef{Kind: ListChannel, ID: m[1]}, nil
+		}
+	}
+	return ListRef{}, ErrNotList
+}
+
+// pageURL is the page listing the videos
+func (r ListRef) pageURL() string {
+	if r.Kind == ListPlaylist {
+		return "https://www.youtube.com/playlist?list=" + url.QueryEscape(r.ID)
+	}
+	return "https://www.youtube.com/" + r.ID + "/videos"
+}
+
+// WatchURL is the page of a video
+func WatchURL(videoID string) string {
+	return "https://www.youtube.com/watch?v=" + videoID
+}
+
+// Video is one video of a playlist or channel
+type Video struct {
+	ID    string
+	Title string
+}
+
+// VideoList is the videos of a playlist (in playlist order) or a channel (newest first)
+type VideoList struct {
+	Title     string
+	ChannelID string // UC… ID, when the page gives it
+	Videos    []Video
+}
+
+// defaultClientVersion is sent with continuation requests when the page doesn't say
+const defaultClientVersion = "2.20250101.00.00"
+
+// ListExtractor enumerates the videos of playlists and channels
+type ListExtractor struct {
+	client *http.Client
+}
+
+func NewListExtractor() *ListExtractor {
+	return &ListExtractor{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// ListVideos returns up to max videos of a playlist or channel. The list page
+// is read first, following its continuations; the RSS feed (latest 15 videos)
+// is the fallback when the page can't be parsed.
+func (l *ListExtractor) ListVideos(ctx context.Context, ref ListRef, max int) (*VideoList, error) {
+	log.Printf("[YouTube.ListVideos] Listing %s %s", ref.Kind, ref.ID)
+	list, err := l.listFromPage(ctx, ref, max)
+	if err == nil && len(list.Videos) > 0 {
+		log.Printf("[YouTube.ListVideos] Found %d videos on the page", len(list.Videos))
+		return list, nil
+	}
+	log.Printf("[YouTube.ListVideos] Page failed: %v, trying the feed...", err)
+
+	feedURL := ""
+	switch {
+	case ref.Kind == ListPlaylist:
+		feedURL = "https://www.youtube.com/feeds/videos.xml?playlist_id=" + url.QueryEscape(ref.ID)
+	case strings.HasPrefix(ref.ID, "channel/"):
+		feedURL = "https://www.youtube.com/feeds/videos.xml?channel_id=" + strings.TrimPrefix(ref.ID, "channel/")
+	case list != nil && list.ChannelID != "":
+		feedURL = "https://www.youtube.com/feeds/videos.xml?channel_id=" + list.ChannelID
+	default:
+		return nil, fmt.Errorf("failed to list videos: %w", err)
+	}
+	body, err := l.get(ctx, feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list videos: %w", err)
+	}
+	feedList, err := parseVideoFeed(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(feedList.Videos) > max {
+		feedList.Videos = feedList.Videos[:max]
+	}
+	log.Printf("[YouTube.ListVideos] Found %d videos in the feed", len(feedList.Videos))
+	return feedList, nil
+}
+
+func (l *ListExtractor) listFromPage(ctx context.Context, ref ListRef, max int) (*VideoList, error) {
+	page, err := l.get(ctx, ref.pageURL())
+	if err != nil {
+		return nil, err
+	}
+	list, token, err := parseInitialData(page)
+	if err != nil {
+		return nil, err
+	}
+
+	clientVersion := defaultClientVersion
+	if m := clientVersionRe.FindSubmatch(page); m != nil {
+		clientVersion = string(m[1])
+	}
+	seen := map[string]bool{}
+	for _, v := range list.Videos {
+		seen[v.ID] = true
+	}
+	for token != "" && len(list.Videos) < max {
+		body, err := l.browse(ctx, token, clientVersion)
+		if err != nil {
+			// Keep what was listed so far
+			log.Printf("[YouTube.ListVideos] Continuation failed: %v", err)
+			break
+		}
+		var more *VideoList
+		more, token, err = parseInitialData(body)
+		if err != nil {
+			log.Printf("[YouTube.ListVideos] Continuation failed: %v", err)
+			break
+		}
+		added := 0
+		for _, v := range more.Videos {
+			if !seen[v.ID] {
+				seen[v.ID] = true
+				list.Videos = append(list.Videos, v)
+				added++
+			}
+		}
+		if added == 0 {
+			break
+		}
+	}
+	if len(list.Videos) > max {
+		list.Videos = list.Videos[:max]
+	}
+	return list, nil
+}
+
+func (l *ListExtractor) get(ctx context.Context, pageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	return l.do(req)
+}
+
+// browse loads the next part of a list through YouTube's internal API
+func (l *ListExtractor) browse(ctx context.Context, token, clientVersion string) ([]byte, error) {
+	payload, err := json.Marshal(map[string]any{
+		"context":      map[string]any{"client": map[string]string{"clientName": "WEB", "clientVersion": clientVersion, "hl": "en"}},
+		"continuation": token,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://www.youtube.com/youtubei/v1/browse?prettyPrint=false", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	return l.do(req)
+}
+
+func (l *ListExtractor) do(req *http.Request) ([]byte, error) {
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("youtube returned %d for %s", resp.StatusCode, req.URL.Path)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+}
+
+var (
+	initialDataRe   = regexp.MustCompile(`(?:var ytInitialData|window\["ytInitialData"\])\s*=\s*`)
+	clientVersionRe = regexp.MustCompile(`"INNERTUBE_CLIENT_VERSION"\s*:\s*"([^"]+)"`)
+)
+
+// ytText is a text field of YouTube's page data
+type ytText struct {
+	SimpleText string `json:"simpleText"`
+	Content    string `json:"content"`
+	Runs       []struct {
+		Text string `json:"text"`
+	} `json:"runs"`
+}
+
+func (t ytText) String() string {
+	if t.SimpleText != "" {
+		return t.SimpleText
+	}
+	if t.Content != "" {
+		return t.Content
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.Text)
+	}
+	return sb.String()
+}
+
+// videoRenderer is a video entry of a playlist or channel page
+type videoRenderer struct {
+	VideoID    string `json:"videoId"`
+	Title      ytText `json:"title"`
+	IsPlayable *bool  `json:"isPlayable"` // False for private and deleted playlist entries
+}
+
+// lockupViewModel is the newer form of a video entry on channel pages
+type lockupViewModel struct {
+	ContentID   string `json:"contentId"`
+	ContentType string `json:"contentType"`
+	Metadata    struct {
+		Lockup struct {
+			Title ytText `json:"title"`
+		} `json:"lockupMetadataViewModel"`
+	} `json:"metadata"`
+}
+
+var videoIDRe = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// parseInitialData reads the videos, title and continuation token from a
+// page's ytInitialData, or from a browse API response. Entries are found by
+// their key anywhere in the document, in document order, so layout changes
+// around them don't matter.
+func parseInitialData(data []byte) (*VideoList, string, error) {
+	if loc := initialDataRe.FindIndex(data); loc != nil {
+		data = data[loc[1]:]
+	} else if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, "", fmt.Errorf("no initial data in page")
+	}
+
+	list := &VideoList{}
+	token := ""
+	seen := map[string]bool{}
+	add := func(id, title string) {
+		if videoIDRe.MatchString(id) && !seen[id] {
+			seen[id] = true
+			list.Videos = append(list.Videos, Video{ID: id, Title: strings.Join(strings.Fields(title), " ")})
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid initial data: %w", err)
+		}
+		switch t := tok.(type) {
+		case json.Delim:
+			if t == '{' || t == '[' {
+				depth++
+			} else if depth--; depth == 0 {
+				return list, token, nil
+			}
+		case string:
+			// Decoding the value keeps the tokenizer in step: it consumes the whole object
+			var err error
+			switch t {
+			case "playlistVideoRenderer", "videoRenderer", "gridVideoRenderer":
+				var r videoRenderer
+				if err = dec.Decode(&r); err == nil && (r.IsPlayable == nil || *r.IsPlayable) {
+					add(r.VideoID, r.Title.String())
+				}
+			case "lockupViewModel":
+				var r lockupViewModel
+				if err = dec.Decode(&r); err == nil && r.ContentType == "LOCKUP_CONTENT_TYPE_VIDEO" {
+					add(r.ContentID, r.Metadata.Lockup.Title.String())
+				}
+			case "playlistMetadataRenderer", "channelMetadataRenderer":
+				var m struct {
+					Title      string `json:"title"`
+					ExternalID string `json:"externalId"`
+				}
+				if err = dec.Decode(&m); err == nil {
+					list.Title = strings.TrimSpace(m.Title)
+					list.ChannelID = m.ExternalID
+				}
+			case "continuationCommand":
+				var c struct {
+					Token string `json:"token"`
+				}
+				if err = dec.Decode(&c); err == nil && token == "" {
+					token = c.Token
+				}
+			}
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid initial data: %w", err)
+			}
+		}
+	}
+}
+
+// parseVideoFeed reads a channel or playlist Atom feed
+func parseVideoFeed(body []byte) (*VideoList, error) {
+	var feed struct {
+		Title   string `xml:"title"`
+		Entries []struct {
+			VideoID string `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+			Title   string `xml:"title"`
+		} `xml:"entry"`
+		ChannelID string `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+	}
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("invalid video feed: %w", err)
+	}
+	list := &VideoList{Title: strings.TrimSpace(feed.Title), ChannelID: feed.ChannelID}
+	for _, e := range feed.Entries {
+		if videoIDRe.MatchString(e.VideoID) {
+			list.Videos = append(list.Videos, Video{ID: e.VideoID, Title: strings.TrimSpace(e.Title)})
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,94 @@
+package youtube
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseListURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want ListRef
+		ok   bool
+	}{
+		{"https://www.youtube.com/playlist?list=PLgoindepth0001", ListRef{ListPlaylist, "PLgoindepth0001"}, true},
+		{"https://youtube.com/watch?v=aaaaaaaaaa1&list=PLgoindepth0001&index=2", ListRef{ListPlaylist, "PLgoindepth0001"}, true},
+		{"https://m.youtube.com/channel/UCabcdefghijklmnopqrstuv/videos", ListRef{ListChannel, "channel/UCabcdefghijklmnopqrstuv"}, true},
+		{"https://www.youtube.com/@GophersConf", ListRef{ListChannel, "@GophersConf"}, true},
+		{"https://www.youtube.com/c/GophersConf/featured", ListRef{ListChannel, "c/GophersConf"}, true},
+		{"https://www.youtube.com/user/gophers", ListRef{ListChannel, "user/gophers"}, true},
+		{"https://www.youtube.com/watch?v=aaaaaaaaaa1", ListRef{}, false},
+		{"https://www.youtube.com/playlist?list=x'%3Cscript", ListRef{}, false},
+		{"https://example.com/@GophersConf", ListRef{}, false},
+		{"youtube.com/@GophersConf", ListRef{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseListURL(tt.url)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseListURL(%q) = %+v, %v", tt.url, got, err)
+		}
+	}
+
+	if _, err := ExtractVideoID("https://www.youtube.com/@GophersConf"); err != ErrListURL {
+		t.Errorf("ExtractVideoID(channel) err = %v, want ErrListURL", err)
+	}
+	if id, err := ExtractVideoID("https://youtube.com/watch?v=aaaaaaaaaa1&list=PLgoindepth0001"); err != nil || id != "aaaaaaaaaa1" {
+		t.Errorf("ExtractVideoID(video in playlist) = %q, %v", id, err)
+	}
+}
+
+func TestParseInitialData(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		title     string
+		channelID string
+		videos    []Video
+		token     string
+	}{
+		{
+			fixture: "playlist_page.html",
+			title:   "Go in depth",
+			videos:  []Video{{"aaaaaaaaaa1", "1. Setup and tooling"}, {"aaaaaaaaaa3", "2. Types & interfaces"}},
+			token:   "4qmFsgJhEiRWTFBMLi4u",
+		},
+		{
+			fixture: "browse_continuation.json",
+			videos:  []Video{{"aaaaaaaaaa3", "2. Types & interfaces"}, {"aaaaaaaaaa4", "3. Concurrency"}},
+		},
+		{
+			fixture:   "channel_videos.html",
+			title:     "Gophers Conf",
+			channelID: "UCabcdefghijklmnopqrstuv",
+			videos:    []Video{{"bbbbbbbbbb1", "Newest talk"}, {"bbbbbbbbbb2", "Older talk"}},
+		},
+	}
+	for _, tt := range tests {
+		list, token, err := parseInitialData(readFixture(t, tt.fixture))
+		if err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+		if list.Title != tt.title || list.ChannelID != tt.channelID || token != tt.token || !reflect.DeepEqual(list.Videos, tt.videos) {
+			t.Errorf("%s: got %+v, token %q", tt.fixture, list, token)
+		}
+	}
+
+	if _, _, err := parseInitialData([]byte("<html><body>Before you continue</body></html>")); err == nil {
+		t.Error("consent page should fail")
+	}
+}
+
+func TestParseVideoFeed(t *testing.T) {
+	list, err := parseVideoFeed(readFixture(t, "video_feed.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &VideoList{
+		Title:     "Gophers Conf",
+		ChannelID: "UCabcdefghijklmnopqrstuv",
+		Videos:    []Video{{"bbbbbbbbbb1", "Newest talk"}, {"bbbbbbbbbb2", "Older talk"}},
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("got %+v, want %+v", list, want)
+	}
+}
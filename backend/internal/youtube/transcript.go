@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"io"
 	"log"
 	"net/http"
@@ -46,91 +47,93 @@ func ExtractVideoID(urlStr string) (string, error) {
 		return urlStr, nil
 	}
 
+	if _, err := ParseListURL(urlStr); err == nil {
+		return "", ErrListURL
+	}
 	return "", fmt.Errorf("could not extract video ID from: %s", urlStr)
 }
 
-// GetTranscript fetches the transcript for a YouTube video
-func (t *TranscriptExtractor) GetTranscript(ctx context.Context, videoURL string) (string, error) {
+// GetTranscript fetches the captions of a YouTube video with its chapters.
+// language is the preferred caption language (e.g. "de"); English, then any
+// language, is used when it's empty or the video has no such track.
+func (t *TranscriptExtractor) GetTranscript(ctx context.Context, videoURL, language string) (*Transcript, error) {
 	videoID, err := ExtractVideoID(videoURL)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	log.Printf("[YouTube] Extracting transcript for video: %s", videoID)
+	log.Printf("[YouTube] Extracting transcript for video: %s (language: %q)", videoID, language)
+
+	// Title, description (for chapters) and caption tracks come from the watch page
+	player, err := t.fetchPlayerResponse(ctx, videoID)
+	if err != nil {
+		log.Printf("[YouTube] Watch page failed: %v, continuing without chapters", err)
+		player = &playerResponse{}
+	}
+	transcript := &Transcript{
+		VideoID:  videoID,
+		Title:    player.VideoDetails.Title,
+		Chapters: ParseChapters(player.VideoDetails.ShortDescription),
+	}
 
-	// Method 1: Use Supadata API (free, no key required)
-	transcript, err := t.fetchViaSupadata(ctx, videoID)
-	if err == nil && len(transcript) > 100 {
-		log.Printf("[YouTube] Got transcript via Supadata, length: %d", len(transcript))
+	// Method 1: Use Supadata API
+	transcript.Segments, transcript.Language, err = t.fetchViaSupadata(ctx, videoID, language)
+	if err == nil && transcript.textLength() > 100 {
+		log.Printf("[YouTube] Got transcript via Supadata, %d segments", len(transcript.Segments))
 		return transcript, nil
 	}
 	log.Printf("[YouTube] Supadata failed: %v, trying InnerTube...", err)
 
 	// Method 2: Try InnerTube (direct YouTube)
-	transcript, err = t.fetchViaInnerTube(ctx, videoID)
-	if err == nil && len(transcript) > 100 {
-		log.Printf("[YouTube] Got transcript via InnerTube, length: %d", len(transcript))
+	if track, ok := selectTrack(player.Captions.Renderer.Tracks, language); ok {
+		transcript.Segments, err = t.fetchCaptions(ctx, track.BaseURL)
+		transcript.Language, transcript.AutoGenerated = track.LanguageCode, track.autoGenerated()
+	} else {
+		err = fmt.Errorf("no captions found for video")
+	}
+	if err == nil && transcript.textLength() > 100 {
+		log.Printf("[YouTube] Got transcript via InnerTube (%s, auto-generated: %v), %d segments",
+			transcript.Language, transcript.AutoGenerated, len(transcript.Segments))
 		return transcript, nil
 	}
 	log.Printf("[YouTube] InnerTube failed: %v, trying youtubetranscript.com...", err)
 
 	// Method 3: Third-party service
-	transcript, err = t.fetchViaFallback(ctx, videoID)
-	if err == nil && len(transcript) > 100 {
-		log.Printf("[YouTube] Got transcript via fallback, length: %d", len(transcript))
+	transcript.Segments, err = t.fetchViaFallback(ctx, videoID)
+	transcript.Language, transcript.AutoGenerated = "", false
+	if err == nil && transcript.textLength() > 100 {
+		log.Printf("[YouTube] Got transcript via fallback, %d segments", len(transcript.Segments))
 		return transcript, nil
 	}
 
-	return "", fmt.Errorf("failed to get transcript: video may not have captions enabled")
+	return nil, fmt.Errorf("failed to get transcript: video may not have captions enabled")
 }
 
-// fetchViaInnerTube uses YouTube's internal API
-func (t *TranscriptExtractor) fetchViaInnerTube(ctx context.Context, videoID string) (string, error) {
-	// First, get the video page to extract caption track info
+// fetchPlayerResponse loads the watch page and reads its player response
+func (t *TranscriptExtractor) fetchPlayerResponse(ctx context.Context, videoID string) (*playerResponse, error) {
 	videoURL := "https://www.youtube.com/watch?v=" + videoID
 	req, err := http.NewRequestWithContext(ctx, "GET", videoURL, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
 
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
-	}
-
-	// Extract captions URL from page
-	captionURL := t.extractCaptionURL(string(body))
-	if captionURL == "" {
-		return "", fmt.Errorf("no captions found for video")
+		return nil, err
 	}
-
-	// Fetch the captions
-	return t.fetchCaptions(ctx, captionURL)
+	return parsePlayerResponse(body)
 }
 
-// extractCaptionURL finds the caption track URL in the video page HTML
-func (t *TranscriptExtractor) extractCaptionURL(html string) string {
-	// Look for captionTracks in the page
-	re := regexp.MustCompile(`"captionTracks":\[.*?"baseUrl":"([^"]+)"`)
-	matches := re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		// Unescape the URL
-		captionURL := strings.ReplaceAll(matches[1], `\u0026`, "&")
-		return captionURL
-	}
-	return ""
-}
-
-// fetchCaptions downloads and parses the caption track
-func (t *TranscriptExtractor) fetchCaptions(ctx context.Context, captionURL string) (string, error) {
+// fetchCaptions downloads and parses a caption track
+func (t *TranscriptExtractor) fetchCaptions(ctx context.Context, captionURL string) ([]Segment, error) {
 	// Request JSON3 format for easier parsing
 	if !strings.Contains(captionURL, "fmt=") {
 		if strings.Contains(captionURL, "?") {
@@ -142,102 +145,67 @@ func (t *TranscriptExtractor) fetchCaptions(ctx context.Context, captionURL stri
 
 	req, err := http.NewRequestWithContext(ctx, "GET", captionURL, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
-	}
-
-	// Parse JSON3 format
-	var captionData struct {
-		Events []struct {
-			Segs []struct {
-				Utf8 string `json:"utf8"`
-			} `json:"segs"`
-		} `json:"events"`
+		return nil, err
 	}
-
-	if err := json.Unmarshal(body, &captionData); err != nil {
-		// Try parsing as plain text (older format)
-		return t.parsePlainCaptions(string(body)), nil
-	}
-
-	var sb strings.Builder
-	for _, event := range captionData.Events {
-		for _, seg := range event.Segs {
-			if seg.Utf8 != "" && seg.Utf8 != "\n" {
-				sb.WriteString(seg.Utf8)
-			}
-		}
-	}
-
-	return strings.TrimSpace(sb.String()), nil
-}
-
-// parsePlainCaptions handles XML/SRT format captions
-func (t *TranscriptExtractor) parsePlainCaptions(content string) string {
-	// Remove XML tags
-	re := regexp.MustCompile(`<[^>]+>`)
-	text := re.ReplaceAllString(content, " ")
-	// Clean up whitespace
-	text = regexp.MustCompile(`\s+`).ReplaceAllString(text, " ")
-	return strings.TrimSpace(text)
+	return ParseTimedText(body)
 }
 
 // fetchViaFallback uses a third-party transcript service
-func (t *TranscriptExtractor) fetchViaFallback(ctx context.Context, videoID string) (string, error) {
+func (t *TranscriptExtractor) fetchViaFallback(ctx context.Context, videoID string) ([]Segment, error) {
 	// Use youtubetranscript.com API
 	apiURL := fmt.Sprintf("https://youtubetranscript.com/?server_vid2=%s", url.QueryEscape(videoID))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0")
 
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
-	}
-
-	// Parse the XML response
-	text := t.parsePlainCaptions(string(body))
-	if len(text) < 50 {
-		return "", fmt.Errorf("transcript too short or empty")
+		return nil, err
 	}
 
-	return text, nil
+	// The response is a srv1 <transcript> document
+	return ParseTimedText(body)
 }
 
-// fetchViaSupadata uses the Supadata transcript API with authentication
-func (t *TranscriptExtractor) fetchViaSupadata(ctx context.Context, videoID string) (string, error) {
+// fetchViaSupadata uses the Supadata transcript API with authentication.
+// Returns the segments and their language.
+func (t *TranscriptExtractor) fetchViaSupadata(ctx context.Context, videoID, language string) ([]Segment, string, error) {
 	apiKey := os.Getenv("SUPADATA_API_KEY")
 	if apiKey == "" {
-		return "", fmt.Errorf("SUPADATA_API_KEY not set")
+		return nil, "", fmt.Errorf("SUPADATA_API_KEY not set")
 	}
 
-	// Use the universal transcript endpoint
-	videoURL := url.QueryEscape(fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID))
-	apiURL := fmt.Sprintf("https://api.supadata.ai/v1/transcript?url=%s&text=true&mode=native", videoURL)
+	// Use the universal transcript endpoint; without text=true it returns timed segments
+	params := url.Values{"url": {"https://www.youtube.com/watch?v=" + videoID}, "mode": {"native"}}
+	if language != "" {
+		params.Set("lang", language)
+	}
+	apiURL := "https://api.supadata.ai/v1/transcript?" + params.Encode()
 	log.Printf("[YouTube.Supadata] Fetching: %s", apiURL)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	// Add required authentication header
@@ -247,35 +215,51 @@ func (t *TranscriptExtractor) fetchViaSupadata(ctx context.Context, videoID stri
 	resp, err := t.client.Do(req)
 	if err != nil {
 		log.Printf("[YouTube.Supadata] Request failed: %v", err)
-		return "", err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
 	log.Printf("[YouTube.Supadata] Response status: %d", resp.StatusCode)
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("supadata error: %d - %s", resp.StatusCode, string(body))
+		return nil, "", fmt.Errorf("supadata error: %d - %s", resp.StatusCode, string(body))
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
-	// Supadata returns JSON with content field when text=true
+	segments, lang, err := parseSupadataTranscript(body)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Printf("[YouTube.Supadata] Got transcript in %s, %d segments", lang, len(segments))
+	return segments, lang, nil
+}
+
+// parseSupadataTranscript parses a Supadata transcript response with timed
+// segments (offset and duration in milliseconds)
+func parseSupadataTranscript(body []byte) ([]Segment, string, error) {
 	var result struct {
-		Content string `json:"content"`
-		Lang    string `json:"lang"`
+		Content []struct {
+			Text     string  `json:"text"`
+			Offset   float64 `json:"offset"`
+			Duration float64 `json:"duration"`
+		} `json:"content"`
+		Lang string `json:"lang"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
-		// Try treating response as plain text
-		return strings.TrimSpace(string(body)), nil
+		return nil, "", fmt.Errorf("invalid supadata response: %w", err)
 	}
 
-	if result.Content == "" {
-		return "", fmt.Errorf("no transcript in response")
+	var segments []Segment
+	for _, c := range result.Content {
+		segments = appendSegment(segments, time.Duration(c.Offset*float64(time.Millisecond)),
+			time.Duration(c.Duration*float64(time.Millisecond)), html.UnescapeString(c.Text))
 	}
-
-	log.Printf("[YouTube.Supadata] Got transcript in %s, length: %d", result.Lang, len(result.Content))
-	return result.Content, nil
+	if len(segments) == 0 {
+		return nil, "", ErrNoCaptions
+	}
+	return segments, result.Lang, nil
 }
@@ -24,11 +24,18 @@ const (
 )
 
 type AddMaterialRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "TEXT", "LINK", "IMAGE", or "YOUTUBE"
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	ExistingTags  []string               `protobuf:"bytes,3,rep,name=existing_tags,json=existingTags,proto3" json:"existing_tags,omitempty"`
-	ImageData     string                 `protobuf:"bytes,4,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"` // Base64 encoded image for IMAGE type
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "TEXT", "LINK", "IMAGE", or "YOUTUBE"
+	Content        string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ExistingTags   []string               `protobuf:"bytes,3,rep,name=existing_tags,json=existingTags,proto3" json:"existing_tags,omitempty"`
+	ImageData      string                 `protobuf:"bytes,4,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`                 // Base64 encoded image for IMAGE type; a single-image shorthand for images
+	AllowDuplicate bool                   `protobuf:"varint,5,opt,name=allow_duplicate,json=allowDuplicate,proto3" json:"allow_duplicate,omitempty"` // Import even if an existing material already covers it
+	CollectionId   string                 `protobuf:"bytes,6,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`        // Optional collection to add the new material to
+	Html           string                 `protobuf:"bytes,7,opt,name=html,proto3" json:"html,omitempty"`                                            // LINK only: the page as rendered by the client, extracted instead of fetching content (kept for dedup and citation)
+	Language       string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`                                    // YOUTUBE only: preferred caption language (e.g. "de"); English, then any language, when empty or unavailable
+	// IMAGE only: base64 (or data URL) JPEG, PNG, GIF or WebP images in reading order, at most 20 of
+	// at most 15 MB each, merged into one material. Takes precedence over image_data.
+	Images        []string `protobuf:"bytes,9,rep,name=images,proto3" json:"images,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,14 +98,52 @@ func (x *AddMaterialRequest) GetImageData() string {
 	return ""
 }
 
+func (x *AddMaterialRequest) GetAllowDuplicate() bool {
+	if x != nil {
+		return x.AllowDuplicate
+	}
+	return false
+}
+
+func (x *AddMaterialRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *AddMaterialRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *AddMaterialRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *AddMaterialRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
 type AddMaterialResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	MaterialId        string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
-	FlashcardsCreated int32                  `protobuf:"varint,2,opt,name=flashcards_created,json=flashcardsCreated,proto3" json:"flashcards_created,omitempty"`
-	Title             string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Tags              []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId            string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	FlashcardsCreated     int32                  `protobuf:"varint,2,opt,name=flashcards_created,json=flashcardsCreated,proto3" json:"flashcards_created,omitempty"`
+	Title                 string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Tags                  []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	DuplicateOf           *DuplicateMaterial     `protobuf:"bytes,5,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`                                  // Set when nothing was created: material_id is the existing material
+	DuplicateCardsSkipped int32                  `protobuf:"varint,6,opt,name=duplicate_cards_skipped,json=duplicateCardsSkipped,proto3" json:"duplicate_cards_skipped,omitempty"` // Generated cards not saved because they repeat existing cards
+	UnverifiedCards       int32                  `protobuf:"varint,7,opt,name=unverified_cards,json=unverifiedCards,proto3" json:"unverified_cards,omitempty"`                     // Saved cards flagged source_unverified
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *AddMaterialResponse) Reset() {
@@ -159,27 +204,52 @@ func (x *AddMaterialResponse) GetTags() []string {
 	return nil
 }
 
-type DeleteMaterialRequest struct {
+func (x *AddMaterialResponse) GetDuplicateOf() *DuplicateMaterial {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return nil
+}
+
+func (x *AddMaterialResponse) GetDuplicateCardsSkipped() int32 {
+	if x != nil {
+		return x.DuplicateCardsSkipped
+	}
+	return 0
+}
+
+func (x *AddMaterialResponse) GetUnverifiedCards() int32 {
+	if x != nil {
+		return x.UnverifiedCards
+	}
+	return 0
+}
+
+// An existing material that already covers an import
+type DuplicateMaterial struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`           // "same_url" or "similar_content"
+	Similarity    float64                `protobuf:"fixed64,4,opt,name=similarity,proto3" json:"similarity,omitempty"` // 0..1
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMaterialRequest) Reset() {
-	*x = DeleteMaterialRequest{}
+func (x *DuplicateMaterial) Reset() {
+	*x = DuplicateMaterial{}
 	mi := &file_backend_proto_learning_learning_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMaterialRequest) String() string {
+func (x *DuplicateMaterial) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMaterialRequest) ProtoMessage() {}
+func (*DuplicateMaterial) ProtoMessage() {}
 
-func (x *DeleteMaterialRequest) ProtoReflect() protoreflect.Message {
+func (x *DuplicateMaterial) ProtoReflect() protoreflect.Message {
 	mi := &file_backend_proto_learning_learning_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -191,42 +261,67 @@ func (x *DeleteMaterialRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMaterialRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMaterialRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DuplicateMaterial.ProtoReflect.Descriptor instead.
+func (*DuplicateMaterial) Descriptor() ([]byte, []int) {
 	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *DeleteMaterialRequest) GetMaterialId() string {
+func (x *DuplicateMaterial) GetMaterialId() string {
 	if x != nil {
 		return x.MaterialId
 	}
 	return ""
 }
 
-type MaterialSummary struct {
+func (x *DuplicateMaterial) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DuplicateMaterial) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DuplicateMaterial) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+// Adds cards generated from new content to an existing material (see AddMaterialResponse.duplicate_of)
+type MergeIntoMaterialRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	DueCount      int32                  `protobuf:"varint,3,opt,name=due_count,json=dueCount,proto3" json:"due_count,omitempty"`
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // "TEXT", "LINK", "IMAGE", or "YOUTUBE"
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	ImageData     string                 `protobuf:"bytes,4,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	Html          string                 `protobuf:"bytes,5,opt,name=html,proto3" json:"html,omitempty"`         // LINK only, as in AddMaterialRequest
+	Language      string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"` // YOUTUBE only, as in AddMaterialRequest
+	Images        []string               `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`     // IMAGE only, as in AddMaterialRequest
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MaterialSummary) Reset() {
-	*x = MaterialSummary{}
+func (x *MergeIntoMaterialRequest) Reset() {
+	*x = MergeIntoMaterialRequest{}
 	mi := &file_backend_proto_learning_learning_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MaterialSummary) String() string {
+func (x *MergeIntoMaterialRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MaterialSummary) ProtoMessage() {}
+func (*MergeIntoMaterialRequest) ProtoMessage() {}
 
-func (x *MaterialSummary) ProtoReflect() protoreflect.Message {
+func (x *MergeIntoMaterialRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_backend_proto_learning_learning_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -238,64 +333,81 @@ func (x *MaterialSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MaterialSummary.ProtoReflect.Descriptor instead.
-func (*MaterialSummary) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeIntoMaterialRequest.ProtoReflect.Descriptor instead.
+func (*MergeIntoMaterialRequest) Descriptor() ([]byte, []int) {
 	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *MaterialSummary) GetId() string {
+func (x *MergeIntoMaterialRequest) GetMaterialId() string {
 	if x != nil {
-		return x.Id
+		return x.MaterialId
 	}
 	return ""
 }
 
-func (x *MaterialSummary) GetTitle() string {
+func (x *MergeIntoMaterialRequest) GetType() string {
 	if x != nil {
-		return x.Title
+		return x.Type
 	}
 	return ""
 }
 
-func (x *MaterialSummary) GetDueCount() int32 {
+func (x *MergeIntoMaterialRequest) GetContent() string {
 	if x != nil {
-		return x.DueCount
+		return x.Content
 	}
-	return 0
+	return ""
 }
 
-func (x *MaterialSummary) GetTags() []string {
+func (x *MergeIntoMaterialRequest) GetImageData() string {
 	if x != nil {
-		return x.Tags
+		return x.ImageData
+	}
+	return ""
+}
+
+func (x *MergeIntoMaterialRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *MergeIntoMaterialRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *MergeIntoMaterialRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
 	}
 	return nil
 }
 
-type GetDueMaterialsRequest struct {
+type DeleteMaterialRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	SearchQuery   string                 `protobuf:"bytes,3,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
-	OnlyDue       bool                   `protobuf:"varint,5,opt,name=only_due,json=onlyDue,proto3" json:"only_due,omitempty"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDueMaterialsRequest) Reset() {
-	*x = GetDueMaterialsRequest{}
+func (x *DeleteMaterialRequest) Reset() {
+	*x = DeleteMaterialRequest{}
 	mi := &file_backend_proto_learning_learning_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDueMaterialsRequest) String() string {
+func (x *DeleteMaterialRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDueMaterialsRequest) ProtoMessage() {}
+func (*DeleteMaterialRequest) ProtoMessage() {}
 
-func (x *GetDueMaterialsRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteMaterialRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_backend_proto_learning_learning_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -307,72 +419,90 @@ func (x *GetDueMaterialsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDueMaterialsRequest.ProtoReflect.Descriptor instead.
-func (*GetDueMaterialsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteMaterialRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMaterialRequest) Descriptor() ([]byte, []int) {
 	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetDueMaterialsRequest) GetPage() int32 {
+func (x *DeleteMaterialRequest) GetMaterialId() string {
 	if x != nil {
-		return x.Page
+		return x.MaterialId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetDueMaterialsRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
+// Updates a LINK material to the current version of its page. Only cards whose
+// supporting text changed are replaced; the others keep their review state.
+type RefreshMaterialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDueMaterialsRequest) GetSearchQuery() string {
-	if x != nil {
-		return x.SearchQuery
-	}
-	return ""
+func (x *RefreshMaterialRequest) Reset() {
+	*x = RefreshMaterialRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDueMaterialsRequest) GetTags() []string {
+func (x *RefreshMaterialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshMaterialRequest) ProtoMessage() {}
+
+func (x *RefreshMaterialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[5]
 	if x != nil {
-		return x.Tags
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetDueMaterialsRequest) GetOnlyDue() bool {
+// Deprecated: Use RefreshMaterialRequest.ProtoReflect.Descriptor instead.
+func (*RefreshMaterialRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefreshMaterialRequest) GetMaterialId() string {
 	if x != nil {
-		return x.OnlyDue
+		return x.MaterialId
 	}
-	return false
+	return ""
 }
 
-type GetDueMaterialsResponse struct {
+type RefreshMaterialResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Materials     []*MaterialSummary     `protobuf:"bytes,1,rep,name=materials,proto3" json:"materials,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`                             // False when the page matches the stored content
+	ChangeRatio   float32                `protobuf:"fixed32,2,opt,name=change_ratio,json=changeRatio,proto3" json:"change_ratio,omitempty"` // Share of words added or removed, 0..1
+	CardsKept     int32                  `protobuf:"varint,3,opt,name=cards_kept,json=cardsKept,proto3" json:"cards_kept,omitempty"`
+	CardsRemoved  int32                  `protobuf:"varint,4,opt,name=cards_removed,json=cardsRemoved,proto3" json:"cards_removed,omitempty"` // Cards whose supporting text changed or was removed
+	CardsAdded    int32                  `protobuf:"varint,5,opt,name=cards_added,json=cardsAdded,proto3" json:"cards_added,omitempty"`       // Generated from the new and changed text
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDueMaterialsResponse) Reset() {
-	*x = GetDueMaterialsResponse{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[5]
+func (x *RefreshMaterialResponse) Reset() {
+	*x = RefreshMaterialResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDueMaterialsResponse) String() string {
+func (x *RefreshMaterialResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDueMaterialsResponse) ProtoMessage() {}
+func (*RefreshMaterialResponse) ProtoMessage() {}
 
-func (x *GetDueMaterialsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[5]
+func (x *RefreshMaterialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -383,68 +513,73 @@ func (x *GetDueMaterialsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDueMaterialsResponse.ProtoReflect.Descriptor instead.
-func (*GetDueMaterialsResponse) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use RefreshMaterialResponse.ProtoReflect.Descriptor instead.
+func (*RefreshMaterialResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetDueMaterialsResponse) GetMaterials() []*MaterialSummary {
+func (x *RefreshMaterialResponse) GetChanged() bool {
 	if x != nil {
-		return x.Materials
+		return x.Changed
 	}
-	return nil
+	return false
 }
 
-func (x *GetDueMaterialsResponse) GetTotalCount() int32 {
+func (x *RefreshMaterialResponse) GetChangeRatio() float32 {
 	if x != nil {
-		return x.TotalCount
+		return x.ChangeRatio
 	}
 	return 0
 }
 
-func (x *GetDueMaterialsResponse) GetPage() int32 {
+func (x *RefreshMaterialResponse) GetCardsKept() int32 {
 	if x != nil {
-		return x.Page
+		return x.CardsKept
 	}
 	return 0
 }
 
-func (x *GetDueMaterialsResponse) GetPageSize() int32 {
+func (x *RefreshMaterialResponse) GetCardsRemoved() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.CardsRemoved
 	}
 	return 0
 }
 
-func (x *GetDueMaterialsResponse) GetTotalPages() int32 {
+func (x *RefreshMaterialResponse) GetCardsAdded() int32 {
 	if x != nil {
-		return x.TotalPages
+		return x.CardsAdded
 	}
 	return 0
 }
 
-type GetDueFlashcardsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type MaterialSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	DueCount         int32                  `protobuf:"varint,3,opt,name=due_count,json=dueCount,proto3" json:"due_count,omitempty"`
+	Tags             []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	CollectionId     string                 `protobuf:"bytes,5,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	RefreshAvailable bool                   `protobuf:"varint,6,opt,name=refresh_available,json=refreshAvailable,proto3" json:"refresh_available,omitempty"` // The linked page changed significantly since import, see RefreshMaterial
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *GetDueFlashcardsRequest) Reset() {
-	*x = GetDueFlashcardsRequest{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[6]
+func (x *MaterialSummary) Reset() {
+	*x = MaterialSummary{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDueFlashcardsRequest) String() string {
+func (x *MaterialSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDueFlashcardsRequest) ProtoMessage() {}
+func (*MaterialSummary) ProtoMessage() {}
 
-func (x *GetDueFlashcardsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[6]
+func (x *MaterialSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,46 +590,4044 @@ func (x *GetDueFlashcardsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDueFlashcardsRequest.ProtoReflect.Descriptor instead.
-func (*GetDueFlashcardsRequest) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use MaterialSummary.ProtoReflect.Descriptor instead.
+func (*MaterialSummary) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetDueFlashcardsRequest) GetMaterialId() string {
+func (x *MaterialSummary) GetId() string {
 	if x != nil {
-		return x.MaterialId
+		return x.Id
 	}
 	return ""
 }
 
-type Flashcard struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Question      string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
-	Answer        string                 `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
-	Stage         int32                  `protobuf:"varint,4,opt,name=stage,proto3" json:"stage,omitempty"`
-	NextReviewAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_review_at,json=nextReviewAt,proto3" json:"next_review_at,omitempty"`
-	MaterialTitle string                 `protobuf:"bytes,6,opt,name=material_title,json=materialTitle,proto3" json:"material_title,omitempty"`
-	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MaterialSummary) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
 }
 
-func (x *Flashcard) Reset() {
+func (x *MaterialSummary) GetDueCount() int32 {
+	if x != nil {
+		return x.DueCount
+	}
+	return 0
+}
+
+func (x *MaterialSummary) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MaterialSummary) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *MaterialSummary) GetRefreshAvailable() bool {
+	if x != nil {
+		return x.RefreshAvailable
+	}
+	return false
+}
+
+type GetDueMaterialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SearchQuery   string                 `protobuf:"bytes,3,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	OnlyDue       bool                   `protobuf:"varint,5,opt,name=only_due,json=onlyDue,proto3" json:"only_due,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,6,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"` // Only materials in this collection
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDueMaterialsRequest) Reset() {
+	*x = GetDueMaterialsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDueMaterialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDueMaterialsRequest) ProtoMessage() {}
+
+func (x *GetDueMaterialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDueMaterialsRequest.ProtoReflect.Descriptor instead.
+func (*GetDueMaterialsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetDueMaterialsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetDueMaterialsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetDueMaterialsRequest) GetSearchQuery() string {
+	if x != nil {
+		return x.SearchQuery
+	}
+	return ""
+}
+
+func (x *GetDueMaterialsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetDueMaterialsRequest) GetOnlyDue() bool {
+	if x != nil {
+		return x.OnlyDue
+	}
+	return false
+}
+
+func (x *GetDueMaterialsRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+type GetDueMaterialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Materials     []*MaterialSummary     `protobuf:"bytes,1,rep,name=materials,proto3" json:"materials,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDueMaterialsResponse) Reset() {
+	*x = GetDueMaterialsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDueMaterialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDueMaterialsResponse) ProtoMessage() {}
+
+func (x *GetDueMaterialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDueMaterialsResponse.ProtoReflect.Descriptor instead.
+func (*GetDueMaterialsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetDueMaterialsResponse) GetMaterials() []*MaterialSummary {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *GetDueMaterialsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetDueMaterialsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetDueMaterialsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetDueMaterialsResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type GetDueFlashcardsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	// Instead of material_id: the due cards of a whole collection, with at most
+	// the collection's daily limit of new cards. In every mode prerequisites come
+	// first and new cards whose prerequisites aren't learned yet are held back.
+	CollectionId string `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Instead of material_id: the cards testing a concept across all materials
+	ConceptId     string `protobuf:"bytes,3,opt,name=concept_id,json=conceptId,proto3" json:"concept_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDueFlashcardsRequest) Reset() {
+	*x = GetDueFlashcardsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDueFlashcardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDueFlashcardsRequest) ProtoMessage() {}
+
+func (x *GetDueFlashcardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDueFlashcardsRequest.ProtoReflect.Descriptor instead.
+func (*GetDueFlashcardsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDueFlashcardsRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *GetDueFlashcardsRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *GetDueFlashcardsRequest) GetConceptId() string {
+	if x != nil {
+		return x.ConceptId
+	}
+	return ""
+}
+
+type Flashcard struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Question         string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Answer           string                 `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
+	Stage            int32                  `protobuf:"varint,4,opt,name=stage,proto3" json:"stage,omitempty"`
+	NextReviewAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_review_at,json=nextReviewAt,proto3" json:"next_review_at,omitempty"`
+	MaterialTitle    string                 `protobuf:"bytes,6,opt,name=material_title,json=materialTitle,proto3" json:"material_title,omitempty"`
+	Tags             []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Difficulty       int32                  `protobuf:"varint,8,opt,name=difficulty,proto3" json:"difficulty,omitempty"`                                      // 1 (easiest) to 5, 0 = unknown
+	SourceUrl        string                 `protobuf:"bytes,9,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`                        // Where in the source the card comes from, e.g. https://youtu.be/ID?t=123; "" = the material as a whole
+	SourceQuote      string                 `protobuf:"bytes,10,opt,name=source_quote,json=sourceQuote,proto3" json:"source_quote,omitempty"`                 // Passage of the material the card is based on, as written there ("show source"); "" = none
+	SourceUnverified bool                   `protobuf:"varint,11,opt,name=source_unverified,json=sourceUnverified,proto3" json:"source_unverified,omitempty"` // The card's supporting quote wasn't found in the material: it may not be backed by it
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Flashcard) Reset() {
 	*x = Flashcard{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[7]
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Flashcard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Flashcard) ProtoMessage() {}
+
+func (x *Flashcard) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Flashcard.ProtoReflect.Descriptor instead.
+func (*Flashcard) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Flashcard) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Flashcard) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Flashcard) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *Flashcard) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *Flashcard) GetNextReviewAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextReviewAt
+	}
+	return nil
+}
+
+func (x *Flashcard) GetMaterialTitle() string {
+	if x != nil {
+		return x.MaterialTitle
+	}
+	return ""
+}
+
+func (x *Flashcard) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Flashcard) GetDifficulty() int32 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *Flashcard) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *Flashcard) GetSourceQuote() string {
+	if x != nil {
+		return x.SourceQuote
+	}
+	return ""
+}
+
+func (x *Flashcard) GetSourceUnverified() bool {
+	if x != nil {
+		return x.SourceUnverified
+	}
+	return false
+}
+
+type FlashcardList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flashcards    []*Flashcard           `protobuf:"bytes,1,rep,name=flashcards,proto3" json:"flashcards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlashcardList) Reset() {
+	*x = FlashcardList{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlashcardList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlashcardList) ProtoMessage() {}
+
+func (x *FlashcardList) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlashcardList.ProtoReflect.Descriptor instead.
+func (*FlashcardList) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FlashcardList) GetFlashcards() []*Flashcard {
+	if x != nil {
+		return x.Flashcards
+	}
+	return nil
+}
+
+type CompleteReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlashcardId   string                 `protobuf:"bytes,1,opt,name=flashcard_id,json=flashcardId,proto3" json:"flashcard_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteReviewRequest) Reset() {
+	*x = CompleteReviewRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteReviewRequest) ProtoMessage() {}
+
+func (x *CompleteReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteReviewRequest.ProtoReflect.Descriptor instead.
+func (*CompleteReviewRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CompleteReviewRequest) GetFlashcardId() string {
+	if x != nil {
+		return x.FlashcardId
+	}
+	return ""
+}
+
+type FailReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlashcardId   string                 `protobuf:"bytes,1,opt,name=flashcard_id,json=flashcardId,proto3" json:"flashcard_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailReviewRequest) Reset() {
+	*x = FailReviewRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailReviewRequest) ProtoMessage() {}
+
+func (x *FailReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailReviewRequest.ProtoReflect.Descriptor instead.
+func (*FailReviewRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FailReviewRequest) GetFlashcardId() string {
+	if x != nil {
+		return x.FlashcardId
+	}
+	return ""
+}
+
+type GetAllTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllTagsResponse) Reset() {
+	*x = GetAllTagsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllTagsResponse) ProtoMessage() {}
+
+func (x *GetAllTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllTagsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllTagsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetAllTagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// A deck of materials with its own review settings
+type Collection struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CoverUrl        string                 `protobuf:"bytes,4,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	Position        int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`                                       // Display order (read-only, see ReorderCollections)
+	NewCardsPerDay  int32                  `protobuf:"varint,6,opt,name=new_cards_per_day,json=newCardsPerDay,proto3" json:"new_cards_per_day,omitempty"` // 0 = unlimited
+	SchedulerPreset string                 `protobuf:"bytes,7,opt,name=scheduler_preset,json=schedulerPreset,proto3" json:"scheduler_preset,omitempty"`   // "standard" (default), "intensive" or "relaxed"
+	MaterialCount   int32                  `protobuf:"varint,8,opt,name=material_count,json=materialCount,proto3" json:"material_count,omitempty"`        // Read-only
+	DueCount        int32                  `protobuf:"varint,9,opt,name=due_count,json=dueCount,proto3" json:"due_count,omitempty"`                       // Read-only, from the caller's own review state
+	OrganizationId  string                 `protobuf:"bytes,10,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`     // Read-only, see ShareCollection
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Collection) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Collection) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *Collection) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Collection) GetNewCardsPerDay() int32 {
+	if x != nil {
+		return x.NewCardsPerDay
+	}
+	return 0
+}
+
+func (x *Collection) GetSchedulerPreset() string {
+	if x != nil {
+		return x.SchedulerPreset
+	}
+	return ""
+}
+
+func (x *Collection) GetMaterialCount() int32 {
+	if x != nil {
+		return x.MaterialCount
+	}
+	return 0
+}
+
+func (x *Collection) GetDueCount() int32 {
+	if x != nil {
+		return x.DueCount
+	}
+	return 0
+}
+
+func (x *Collection) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type DeleteCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Materials are kept, without a collection
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionRequest) Reset() {
+	*x = DeleteCollectionRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionRequest) ProtoMessage() {}
+
+func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteCollectionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCollectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*Collection          `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsResponse) Reset() {
+	*x = ListCollectionsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsResponse) ProtoMessage() {}
+
+func (x *ListCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListCollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type ReorderCollectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderCollectionsRequest) Reset() {
+	*x = ReorderCollectionsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderCollectionsRequest) ProtoMessage() {}
+
+func (x *ReorderCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ReorderCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReorderCollectionsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type SetMaterialCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"` // Empty = remove from its collection
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaterialCollectionRequest) Reset() {
+	*x = SetMaterialCollectionRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaterialCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaterialCollectionRequest) ProtoMessage() {}
+
+func (x *SetMaterialCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaterialCollectionRequest.ProtoReflect.Descriptor instead.
+func (*SetMaterialCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetMaterialCollectionRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *SetMaterialCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+// Publishes a material or a collection (exactly one) as a read-only deck,
+// readable without login at GET /api/decks/{slug}
+type PublishDeckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"` // Optional; generated from the title when empty
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishDeckRequest) Reset() {
+	*x = PublishDeckRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishDeckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishDeckRequest) ProtoMessage() {}
+
+func (x *PublishDeckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishDeckRequest.ProtoReflect.Descriptor instead.
+func (*PublishDeckRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PublishDeckRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *PublishDeckRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *PublishDeckRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *PublishDeckRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type PublicDeck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Author        string                 `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	CardCount     int32                  `protobuf:"varint,5,opt,name=card_count,json=cardCount,proto3" json:"card_count,omitempty"`
+	CloneCount    int32                  `protobuf:"varint,6,opt,name=clone_count,json=cloneCount,proto3" json:"clone_count,omitempty"`
+	MaterialId    string                 `protobuf:"bytes,7,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,8,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicDeck) Reset() {
+	*x = PublicDeck{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicDeck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicDeck) ProtoMessage() {}
+
+func (x *PublicDeck) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicDeck.ProtoReflect.Descriptor instead.
+func (*PublicDeck) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PublicDeck) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *PublicDeck) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PublicDeck) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PublicDeck) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *PublicDeck) GetCardCount() int32 {
+	if x != nil {
+		return x.CardCount
+	}
+	return 0
+}
+
+func (x *PublicDeck) GetCloneCount() int32 {
+	if x != nil {
+		return x.CloneCount
+	}
+	return 0
+}
+
+func (x *PublicDeck) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *PublicDeck) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *PublicDeck) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+type UnpublishDeckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishDeckRequest) Reset() {
+	*x = UnpublishDeckRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishDeckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishDeckRequest) ProtoMessage() {}
+
+func (x *UnpublishDeckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishDeckRequest.ProtoReflect.Descriptor instead.
+func (*UnpublishDeckRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UnpublishDeckRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type ListPublicDecksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decks         []*PublicDeck          `protobuf:"bytes,1,rep,name=decks,proto3" json:"decks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicDecksResponse) Reset() {
+	*x = ListPublicDecksResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicDecksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicDecksResponse) ProtoMessage() {}
+
+func (x *ListPublicDecksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicDecksResponse.ProtoReflect.Descriptor instead.
+func (*ListPublicDecksResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListPublicDecksResponse) GetDecks() []*PublicDeck {
+	if x != nil {
+		return x.Decks
+	}
+	return nil
+}
+
+// Copies a public deck's cards into the caller's account with fresh review state
+type CloneDeckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"` // Optional target collection; collection decks otherwise get a new one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneDeckRequest) Reset() {
+	*x = CloneDeckRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneDeckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneDeckRequest) ProtoMessage() {}
+
+func (x *CloneDeckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneDeckRequest.ProtoReflect.Descriptor instead.
+func (*CloneDeckRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CloneDeckRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CloneDeckRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+type CloneDeckResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId      string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	MaterialIds       []string               `protobuf:"bytes,2,rep,name=material_ids,json=materialIds,proto3" json:"material_ids,omitempty"`
+	FlashcardsCreated int32                  `protobuf:"varint,3,opt,name=flashcards_created,json=flashcardsCreated,proto3" json:"flashcards_created,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CloneDeckResponse) Reset() {
+	*x = CloneDeckResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneDeckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneDeckResponse) ProtoMessage() {}
+
+func (x *CloneDeckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneDeckResponse.ProtoReflect.Descriptor instead.
+func (*CloneDeckResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CloneDeckResponse) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *CloneDeckResponse) GetMaterialIds() []string {
+	if x != nil {
+		return x.MaterialIds
+	}
+	return nil
+}
+
+func (x *CloneDeckResponse) GetFlashcardsCreated() int32 {
+	if x != nil {
+		return x.FlashcardsCreated
+	}
+	return 0
+}
+
+// A team workspace. Members study its shared collections with their own review
+// state; a PRO organization gives every member Pro with a pooled daily quota.
+type Organization struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role             string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // Caller's role: "owner", "editor" or "learner"
+	Seats            int32                  `protobuf:"varint,4,opt,name=seats,proto3" json:"seats,omitempty"`
+	MemberCount      int32                  `protobuf:"varint,5,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	Plan             string                 `protobuf:"bytes,6,opt,name=plan,proto3" json:"plan,omitempty"` // "FREE" or "PRO"
+	CurrentPeriodEnd *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
+	IsCreator        bool                   `protobuf:"varint,8,opt,name=is_creator,json=isCreator,proto3" json:"is_creator,omitempty"`                     // Only the creator can delete the organization
+	ReportingOptOut  bool                   `protobuf:"varint,9,opt,name=reporting_opt_out,json=reportingOptOut,proto3" json:"reporting_opt_out,omitempty"` // Caller is left out of individual-level progress reports
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Organization) GetSeats() int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
+func (x *Organization) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *Organization) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+func (x *Organization) GetCurrentPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CurrentPeriodEnd
+	}
+	return nil
+}
+
+func (x *Organization) GetIsCreator() bool {
+	if x != nil {
+		return x.IsCreator
+	}
+	return false
+}
+
+func (x *Organization) GetReportingOptOut() bool {
+	if x != nil {
+		return x.ReportingOptOut
+	}
+	return false
+}
+
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type DeleteOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteOrganizationRequest) Reset() {
+	*x = DeleteOrganizationRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrganizationRequest) ProtoMessage() {}
+
+func (x *DeleteOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteOrganizationRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type OrganizationMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Picture       string                 `protobuf:"bytes,4,opt,name=picture,proto3" json:"picture,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationMember) Reset() {
+	*x = OrganizationMember{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationMember) ProtoMessage() {}
+
+func (x *OrganizationMember) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationMember.ProtoReflect.Descriptor instead.
+func (*OrganizationMember) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *OrganizationMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetPicture() string {
+	if x != nil {
+		return x.Picture
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+type GetOrganizationMembersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOrganizationMembersRequest) Reset() {
+	*x = GetOrganizationMembersRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationMembersRequest) ProtoMessage() {}
+
+func (x *GetOrganizationMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationMembersRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationMembersRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetOrganizationMembersRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type OrganizationMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrganizationMember  `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationMembersResponse) Reset() {
+	*x = OrganizationMembersResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationMembersResponse) ProtoMessage() {}
+
+func (x *OrganizationMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationMembersResponse.ProtoReflect.Descriptor instead.
+func (*OrganizationMembersResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *OrganizationMembersResponse) GetMembers() []*OrganizationMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Adds a user with an existing account (owners only)
+type AddOrganizationMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Email          string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role           string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // Default "learner"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddOrganizationMemberRequest) Reset() {
+	*x = AddOrganizationMemberRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *AddOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AddOrganizationMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type SetOrganizationMemberRoleRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role           string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetOrganizationMemberRoleRequest) Reset() {
+	*x = SetOrganizationMemberRoleRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrganizationMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrganizationMemberRoleRequest) ProtoMessage() {}
+
+func (x *SetOrganizationMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrganizationMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetOrganizationMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetOrganizationMemberRoleRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *SetOrganizationMemberRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetOrganizationMemberRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// Owners remove members; any member may remove themselves to leave
+type RemoveOrganizationMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveOrganizationMemberRequest) Reset() {
+	*x = RemoveOrganizationMemberRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *RemoveOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RemoveOrganizationMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *RemoveOrganizationMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Shares one of the caller's collections with an organization where they are an
+// owner or editor. Owners and editors can then add their materials to it.
+type ShareCollectionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId   string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"` // Empty = make the collection private again
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShareCollectionRequest) Reset() {
+	*x = ShareCollectionRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareCollectionRequest) ProtoMessage() {}
+
+func (x *ShareCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareCollectionRequest.ProtoReflect.Descriptor instead.
+func (*ShareCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ShareCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *ShareCollectionRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type ListOrganizationCollectionsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListOrganizationCollectionsRequest) Reset() {
+	*x = ListOrganizationCollectionsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationCollectionsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListOrganizationCollectionsRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+// Progress of the members of an organization on one of its shared collections,
+// for the collection's owner and organization owners. CSV export:
+// GET /api/reports/decks/{collection_id}?days=N (Authorization: Bearer)
+type GetDeckProgressReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"` // Window for review counts and retention (default 30)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeckProgressReportRequest) Reset() {
+	*x = GetDeckProgressReportRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeckProgressReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeckProgressReportRequest) ProtoMessage() {}
+
+func (x *GetDeckProgressReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeckProgressReportRequest.ProtoReflect.Descriptor instead.
+func (*GetDeckProgressReportRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetDeckProgressReportRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *GetDeckProgressReportRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type MemberProgress struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email          string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	TotalCards     int32                  `protobuf:"varint,4,opt,name=total_cards,json=totalCards,proto3" json:"total_cards,omitempty"`
+	StudiedCards   int32                  `protobuf:"varint,5,opt,name=studied_cards,json=studiedCards,proto3" json:"studied_cards,omitempty"` // Reviewed at least once
+	Completion     float32                `protobuf:"fixed32,6,opt,name=completion,proto3" json:"completion,omitempty"`                        // studied_cards / total_cards (0-1)
+	OverdueCards   int32                  `protobuf:"varint,7,opt,name=overdue_cards,json=overdueCards,proto3" json:"overdue_cards,omitempty"` // Studied and past their next review
+	Reviews        int32                  `protobuf:"varint,8,opt,name=reviews,proto3" json:"reviews,omitempty"`                               // Reviews in the window
+	Retention      float32                `protobuf:"fixed32,9,opt,name=retention,proto3" json:"retention,omitempty"`                          // Share of passed reviews in the window (0-1)
+	LastReviewedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_reviewed_at,json=lastReviewedAt,proto3" json:"last_reviewed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MemberProgress) Reset() {
+	*x = MemberProgress{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberProgress) ProtoMessage() {}
+
+func (x *MemberProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberProgress.ProtoReflect.Descriptor instead.
+func (*MemberProgress) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *MemberProgress) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MemberProgress) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *MemberProgress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MemberProgress) GetTotalCards() int32 {
+	if x != nil {
+		return x.TotalCards
+	}
+	return 0
+}
+
+func (x *MemberProgress) GetStudiedCards() int32 {
+	if x != nil {
+		return x.StudiedCards
+	}
+	return 0
+}
+
+func (x *MemberProgress) GetCompletion() float32 {
+	if x != nil {
+		return x.Completion
+	}
+	return 0
+}
+
+func (x *MemberProgress) GetOverdueCards() int32 {
+	if x != nil {
+		return x.OverdueCards
+	}
+	return 0
+}
+
+func (x *MemberProgress) GetReviews() int32 {
+	if x != nil {
+		return x.Reviews
+	}
+	return 0
+}
+
+func (x *MemberProgress) GetRetention() float32 {
+	if x != nil {
+		return x.Retention
+	}
+	return 0
+}
+
+func (x *MemberProgress) GetLastReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReviewedAt
+	}
+	return nil
+}
+
+type DeckProgressReport struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId   string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	CollectionName string                 `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Days           int32                  `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	Members        []*MemberProgress      `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`                                     // Members who did not opt out
+	OptedOutCount  int32                  `protobuf:"varint,5,opt,name=opted_out_count,json=optedOutCount,proto3" json:"opted_out_count,omitempty"` // Members left out of the report
+	Completion     float32                `protobuf:"fixed32,6,opt,name=completion,proto3" json:"completion,omitempty"`                             // Average over reported members
+	Retention      float32                `protobuf:"fixed32,7,opt,name=retention,proto3" json:"retention,omitempty"`
+	OverdueCards   int32                  `protobuf:"varint,8,opt,name=overdue_cards,json=overdueCards,proto3" json:"overdue_cards,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeckProgressReport) Reset() {
+	*x = DeckProgressReport{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeckProgressReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeckProgressReport) ProtoMessage() {}
+
+func (x *DeckProgressReport) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeckProgressReport.ProtoReflect.Descriptor instead.
+func (*DeckProgressReport) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeckProgressReport) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *DeckProgressReport) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *DeckProgressReport) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *DeckProgressReport) GetMembers() []*MemberProgress {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *DeckProgressReport) GetOptedOutCount() int32 {
+	if x != nil {
+		return x.OptedOutCount
+	}
+	return 0
+}
+
+func (x *DeckProgressReport) GetCompletion() float32 {
+	if x != nil {
+		return x.Completion
+	}
+	return 0
+}
+
+func (x *DeckProgressReport) GetRetention() float32 {
+	if x != nil {
+		return x.Retention
+	}
+	return 0
+}
+
+func (x *DeckProgressReport) GetOverdueCards() int32 {
+	if x != nil {
+		return x.OverdueCards
+	}
+	return 0
+}
+
+type SetReportingOptOutRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	OptOut         bool                   `protobuf:"varint,2,opt,name=opt_out,json=optOut,proto3" json:"opt_out,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetReportingOptOutRequest) Reset() {
+	*x = SetReportingOptOutRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReportingOptOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReportingOptOutRequest) ProtoMessage() {}
+
+func (x *SetReportingOptOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReportingOptOutRequest.ProtoReflect.Descriptor instead.
+func (*SetReportingOptOutRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetReportingOptOutRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *SetReportingOptOutRequest) GetOptOut() bool {
+	if x != nil {
+		return x.OptOut
+	}
+	return false
+}
+
+type GetConceptGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConceptId     string                 `protobuf:"bytes,1,opt,name=concept_id,json=conceptId,proto3" json:"concept_id,omitempty"` // Optional: only this concept and its direct neighbours
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                         // Max concepts (default 50, max 200)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConceptGraphRequest) Reset() {
+	*x = GetConceptGraphRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConceptGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConceptGraphRequest) ProtoMessage() {}
+
+func (x *GetConceptGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConceptGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetConceptGraphRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetConceptGraphRequest) GetConceptId() string {
+	if x != nil {
+		return x.ConceptId
+	}
+	return ""
+}
+
+func (x *GetConceptGraphRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A key idea extracted from one or more materials
+type Concept struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	MaterialCount  int32                  `protobuf:"varint,4,opt,name=material_count,json=materialCount,proto3" json:"material_count,omitempty"`
+	FlashcardCount int32                  `protobuf:"varint,5,opt,name=flashcard_count,json=flashcardCount,proto3" json:"flashcard_count,omitempty"` // Review them with GetDueFlashcards(concept_id)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Concept) Reset() {
+	*x = Concept{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Concept) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Concept) ProtoMessage() {}
+
+func (x *Concept) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Concept.ProtoReflect.Descriptor instead.
+func (*Concept) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Concept) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Concept) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Concept) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Concept) GetMaterialCount() int32 {
+	if x != nil {
+		return x.MaterialCount
+	}
+	return 0
+}
+
+func (x *Concept) GetFlashcardCount() int32 {
+	if x != nil {
+		return x.FlashcardCount
+	}
+	return 0
+}
+
+type ConceptEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceId      string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"` // "prerequisite" (source before target), "part_of" or "related"
+	Weight        int32                  `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`    // Number of materials stating the relation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConceptEdge) Reset() {
+	*x = ConceptEdge{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConceptEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConceptEdge) ProtoMessage() {}
+
+func (x *ConceptEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConceptEdge.ProtoReflect.Descriptor instead.
+func (*ConceptEdge) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ConceptEdge) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *ConceptEdge) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ConceptEdge) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ConceptEdge) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type ConceptGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Concepts      []*Concept             `protobuf:"bytes,1,rep,name=concepts,proto3" json:"concepts,omitempty"`
+	Edges         []*ConceptEdge         `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	Materials     []*RelatedMaterial     `protobuf:"bytes,3,rep,name=materials,proto3" json:"materials,omitempty"` // With concept_id: the materials covering it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConceptGraph) Reset() {
+	*x = ConceptGraph{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConceptGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConceptGraph) ProtoMessage() {}
+
+func (x *ConceptGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConceptGraph.ProtoReflect.Descriptor instead.
+func (*ConceptGraph) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ConceptGraph) GetConcepts() []*Concept {
+	if x != nil {
+		return x.Concepts
+	}
+	return nil
+}
+
+func (x *ConceptGraph) GetEdges() []*ConceptEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *ConceptGraph) GetMaterials() []*RelatedMaterial {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+type GetRelatedMaterialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Default 10, max 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedMaterialsRequest) Reset() {
+	*x = GetRelatedMaterialsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedMaterialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedMaterialsRequest) ProtoMessage() {}
+
+func (x *GetRelatedMaterialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedMaterialsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedMaterialsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetRelatedMaterialsRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *GetRelatedMaterialsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RelatedMaterial struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId     string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	SharedConcepts []string               `protobuf:"bytes,3,rep,name=shared_concepts,json=sharedConcepts,proto3" json:"shared_concepts,omitempty"`
+	Score          float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"` // Shared concepts count 1, concepts one relation away 0.5
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RelatedMaterial) Reset() {
+	*x = RelatedMaterial{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedMaterial) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedMaterial) ProtoMessage() {}
+
+func (x *RelatedMaterial) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedMaterial.ProtoReflect.Descriptor instead.
+func (*RelatedMaterial) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RelatedMaterial) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *RelatedMaterial) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RelatedMaterial) GetSharedConcepts() []string {
+	if x != nil {
+		return x.SharedConcepts
+	}
+	return nil
+}
+
+func (x *RelatedMaterial) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetRelatedMaterialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Materials     []*RelatedMaterial     `protobuf:"bytes,1,rep,name=materials,proto3" json:"materials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedMaterialsResponse) Reset() {
+	*x = GetRelatedMaterialsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedMaterialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedMaterialsResponse) ProtoMessage() {}
+
+func (x *GetRelatedMaterialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedMaterialsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedMaterialsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetRelatedMaterialsResponse) GetMaterials() []*RelatedMaterial {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+// Tags are paths nested with "/", e.g. "cs/distributed/raft"
+type TagInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaterialCount int32                  `protobuf:"varint,2,opt,name=material_count,json=materialCount,proto3" json:"material_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagInfo) Reset() {
+	*x = TagInfo{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagInfo) ProtoMessage() {}
+
+func (x *TagInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagInfo.ProtoReflect.Descriptor instead.
+func (*TagInfo) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TagInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TagInfo) GetMaterialCount() int32 {
+	if x != nil {
+		return x.MaterialCount
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*TagInfo             `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagInfo {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Renaming onto an existing tag merges the two; nested tags move along
+type RenameTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagRequest) ProtoMessage() {}
+
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RenameTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenameTagRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type MergeTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sources       []string               `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *MergeTagsRequest) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type DeleteTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Recursive     bool                   `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"` // Also delete nested tags
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTagRequest) Reset() {
+	*x = DeleteTagRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagRequest) ProtoMessage() {}
+
+func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteTagRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+type TagChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TagsAffected  int32                  `protobuf:"varint,1,opt,name=tags_affected,json=tagsAffected,proto3" json:"tags_affected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagChangeResponse) Reset() {
+	*x = TagChangeResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagChangeResponse) ProtoMessage() {}
+
+func (x *TagChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagChangeResponse.ProtoReflect.Descriptor instead.
+func (*TagChangeResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *TagChangeResponse) GetTagsAffected() int32 {
+	if x != nil {
+		return x.TagsAffected
+	}
+	return 0
+}
+
+type SetMaterialTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaterialTagsRequest) Reset() {
+	*x = SetMaterialTagsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaterialTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaterialTagsRequest) ProtoMessage() {}
+
+func (x *SetMaterialTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaterialTagsRequest.ProtoReflect.Descriptor instead.
+func (*SetMaterialTagsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetMaterialTagsRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *SetMaterialTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type SetMaterialTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"` // Canonical tag names after resolving aliases
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaterialTagsResponse) Reset() {
+	*x = SetMaterialTagsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaterialTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaterialTagsResponse) ProtoMessage() {}
+
+func (x *SetMaterialTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaterialTagsResponse.ProtoReflect.Descriptor instead.
+func (*SetMaterialTagsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetMaterialTagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GetTagMergeSuggestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refresh       bool                   `protobuf:"varint,1,opt,name=refresh,proto3" json:"refresh,omitempty"` // Recompute suggestions before returning them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagMergeSuggestionsRequest) Reset() {
+	*x = GetTagMergeSuggestionsRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagMergeSuggestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagMergeSuggestionsRequest) ProtoMessage() {}
+
+func (x *GetTagMergeSuggestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagMergeSuggestionsRequest.ProtoReflect.Descriptor instead.
+func (*GetTagMergeSuggestionsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetTagMergeSuggestionsRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+type TagMergeSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceTag     string                 `protobuf:"bytes,2,opt,name=source_tag,json=sourceTag,proto3" json:"source_tag,omitempty"`
+	TargetTag     string                 `protobuf:"bytes,3,opt,name=target_tag,json=targetTag,proto3" json:"target_tag,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // "spelling", "embedding" or "llm"
+	Score         float64                `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagMergeSuggestion) Reset() {
+	*x = TagMergeSuggestion{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagMergeSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagMergeSuggestion) ProtoMessage() {}
+
+func (x *TagMergeSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagMergeSuggestion.ProtoReflect.Descriptor instead.
+func (*TagMergeSuggestion) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *TagMergeSuggestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TagMergeSuggestion) GetSourceTag() string {
+	if x != nil {
+		return x.SourceTag
+	}
+	return ""
+}
+
+func (x *TagMergeSuggestion) GetTargetTag() string {
+	if x != nil {
+		return x.TargetTag
+	}
+	return ""
+}
+
+func (x *TagMergeSuggestion) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TagMergeSuggestion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetTagMergeSuggestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*TagMergeSuggestion  `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagMergeSuggestionsResponse) Reset() {
+	*x = GetTagMergeSuggestionsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagMergeSuggestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagMergeSuggestionsResponse) ProtoMessage() {}
+
+func (x *GetTagMergeSuggestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagMergeSuggestionsResponse.ProtoReflect.Descriptor instead.
+func (*GetTagMergeSuggestionsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetTagMergeSuggestionsResponse) GetSuggestions() []*TagMergeSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type ResolveTagMergeSuggestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Accept        bool                   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"` // false = dismiss
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveTagMergeSuggestionRequest) Reset() {
+	*x = ResolveTagMergeSuggestionRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveTagMergeSuggestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveTagMergeSuggestionRequest) ProtoMessage() {}
+
+func (x *ResolveTagMergeSuggestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveTagMergeSuggestionRequest.ProtoReflect.Descriptor instead.
+func (*ResolveTagMergeSuggestionRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ResolveTagMergeSuggestionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResolveTagMergeSuggestionRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type NotificationStatusResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DueFlashcardsCount    int32                  `protobuf:"varint,1,opt,name=due_flashcards_count,json=dueFlashcardsCount,proto3" json:"due_flashcards_count,omitempty"`
+	HasDueMaterials       bool                   `protobuf:"varint,2,opt,name=has_due_materials,json=hasDueMaterials,proto3" json:"has_due_materials,omitempty"`
+	DueMaterialsCount     int32                  `protobuf:"varint,3,opt,name=due_materials_count,json=dueMaterialsCount,proto3" json:"due_materials_count,omitempty"`
+	FirstDueMaterialTitle string                 `protobuf:"bytes,4,opt,name=first_due_material_title,json=firstDueMaterialTitle,proto3" json:"first_due_material_title,omitempty"`
+	IsPro                 bool                   `protobuf:"varint,5,opt,name=is_pro,json=isPro,proto3" json:"is_pro,omitempty"`
+	IsBlocked             bool                   `protobuf:"varint,6,opt,name=is_blocked,json=isBlocked,proto3" json:"is_blocked,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *NotificationStatusResponse) Reset() {
+	*x = NotificationStatusResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationStatusResponse) ProtoMessage() {}
+
+func (x *NotificationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationStatusResponse.ProtoReflect.Descriptor instead.
+func (*NotificationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *NotificationStatusResponse) GetDueFlashcardsCount() int32 {
+	if x != nil {
+		return x.DueFlashcardsCount
+	}
+	return 0
+}
+
+func (x *NotificationStatusResponse) GetHasDueMaterials() bool {
+	if x != nil {
+		return x.HasDueMaterials
+	}
+	return false
+}
+
+func (x *NotificationStatusResponse) GetDueMaterialsCount() int32 {
+	if x != nil {
+		return x.DueMaterialsCount
+	}
+	return 0
+}
+
+func (x *NotificationStatusResponse) GetFirstDueMaterialTitle() string {
+	if x != nil {
+		return x.FirstDueMaterialTitle
+	}
+	return ""
+}
+
+func (x *NotificationStatusResponse) GetIsPro() bool {
+	if x != nil {
+		return x.IsPro
+	}
+	return false
+}
+
+func (x *NotificationStatusResponse) GetIsBlocked() bool {
+	if x != nil {
+		return x.IsBlocked
+	}
+	return false
+}
+
+type GetMaterialSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaterialSummaryRequest) Reset() {
+	*x = GetMaterialSummaryRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaterialSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaterialSummaryRequest) ProtoMessage() {}
+
+func (x *GetMaterialSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaterialSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetMaterialSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetMaterialSummaryRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+type GetMaterialSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	MaterialType  string                 `protobuf:"bytes,4,opt,name=material_type,json=materialType,proto3" json:"material_type,omitempty"` // "TEXT", "LINK", "IMAGE", or "YOUTUBE"
+	SourceUrl     string                 `protobuf:"bytes,5,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`          // Original URL for LINK/YOUTUBE types
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaterialSummaryResponse) Reset() {
+	*x = GetMaterialSummaryResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaterialSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaterialSummaryResponse) ProtoMessage() {}
+
+func (x *GetMaterialSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaterialSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetMaterialSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetMaterialSummaryResponse) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *GetMaterialSummaryResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GetMaterialSummaryResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *GetMaterialSummaryResponse) GetMaterialType() string {
+	if x != nil {
+		return x.MaterialType
+	}
+	return ""
+}
+
+func (x *GetMaterialSummaryResponse) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+// Streamed summary: the first chunk carries material metadata, later chunks carry text deltas
+type MaterialSummaryChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delta         string                 `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"` // Next piece of summary text (may be empty on the first/last chunk)
+	Done          bool                   `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`  // True on the final chunk
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	MaterialType  string                 `protobuf:"bytes,4,opt,name=material_type,json=materialType,proto3" json:"material_type,omitempty"`
+	SourceUrl     string                 `protobuf:"bytes,5,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	Cached        bool                   `protobuf:"varint,6,opt,name=cached,proto3" json:"cached,omitempty"` // Summary was already stored and is sent in one piece
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaterialSummaryChunk) Reset() {
+	*x = MaterialSummaryChunk{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaterialSummaryChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaterialSummaryChunk) ProtoMessage() {}
+
+func (x *MaterialSummaryChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaterialSummaryChunk.ProtoReflect.Descriptor instead.
+func (*MaterialSummaryChunk) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *MaterialSummaryChunk) GetDelta() string {
+	if x != nil {
+		return x.Delta
+	}
+	return ""
+}
+
+func (x *MaterialSummaryChunk) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *MaterialSummaryChunk) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MaterialSummaryChunk) GetMaterialType() string {
+	if x != nil {
+		return x.MaterialType
+	}
+	return ""
+}
+
+func (x *MaterialSummaryChunk) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *MaterialSummaryChunk) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type UpdateFlashcardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlashcardId   string                 `protobuf:"bytes,1,opt,name=flashcard_id,json=flashcardId,proto3" json:"flashcard_id,omitempty"`
+	Question      string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Answer        string                 `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFlashcardRequest) Reset() {
+	*x = UpdateFlashcardRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFlashcardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFlashcardRequest) ProtoMessage() {}
+
+func (x *UpdateFlashcardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFlashcardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFlashcardRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *UpdateFlashcardRequest) GetFlashcardId() string {
+	if x != nil {
+		return x.FlashcardId
+	}
+	return ""
+}
+
+func (x *UpdateFlashcardRequest) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *UpdateFlashcardRequest) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+// Replaces the cards a card depends on: while it is new, it is held back from
+// review sessions until they are learned
+type SetFlashcardDependenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlashcardId   string                 `protobuf:"bytes,1,opt,name=flashcard_id,json=flashcardId,proto3" json:"flashcard_id,omitempty"`
+	DependsOnIds  []string               `protobuf:"bytes,2,rep,name=depends_on_ids,json=dependsOnIds,proto3" json:"depends_on_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFlashcardDependenciesRequest) Reset() {
+	*x = SetFlashcardDependenciesRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFlashcardDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFlashcardDependenciesRequest) ProtoMessage() {}
+
+func (x *SetFlashcardDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFlashcardDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*SetFlashcardDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SetFlashcardDependenciesRequest) GetFlashcardId() string {
+	if x != nil {
+		return x.FlashcardId
+	}
+	return ""
+}
+
+func (x *SetFlashcardDependenciesRequest) GetDependsOnIds() []string {
+	if x != nil {
+		return x.DependsOnIds
+	}
+	return nil
+}
+
+type SearchLibraryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`       // Max results (default 20, max 50)
+	Semantic      bool                   `protobuf:"varint,3,opt,name=semantic,proto3" json:"semantic,omitempty"` // Also rank by embedding similarity when embeddings are enabled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchLibraryRequest) Reset() {
+	*x = SearchLibraryRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchLibraryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLibraryRequest) ProtoMessage() {}
+
+func (x *SearchLibraryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLibraryRequest.ProtoReflect.Descriptor instead.
+func (*SearchLibraryRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SearchLibraryRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchLibraryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchLibraryRequest) GetSemantic() bool {
+	if x != nil {
+		return x.Semantic
+	}
+	return false
+}
+
+// One material matching a library search
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	SourceUrl     string                 `protobuf:"bytes,3,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	Snippet       string                 `protobuf:"bytes,4,opt,name=snippet,proto3" json:"snippet,omitempty"`                      // Matching passage as safe HTML: text is escaped, matched terms wrapped in <mark></mark>
+	MatchedIn     string                 `protobuf:"bytes,5,opt,name=matched_in,json=matchedIn,proto3" json:"matched_in,omitempty"` // "content", "summary" or "card"
+	Card          *Flashcard             `protobuf:"bytes,6,opt,name=card,proto3" json:"card,omitempty"`                            // Best matching card of the material, if any
+	Score         float64                `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"`                        // Fused relevance score (higher is better)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SearchResult) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchResult) GetMatchedIn() string {
+	if x != nil {
+		return x.MatchedIn
+	}
+	return ""
+}
+
+func (x *SearchResult) GetCard() *Flashcard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SearchLibraryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchLibraryResponse) Reset() {
+	*x = SearchLibraryResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchLibraryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLibraryResponse) ProtoMessage() {}
+
+func (x *SearchLibraryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLibraryResponse.ProtoReflect.Descriptor instead.
+func (*SearchLibraryResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SearchLibraryResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type AskLibraryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Question      string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AskLibraryRequest) Reset() {
+	*x = AskLibraryRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AskLibraryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AskLibraryRequest) ProtoMessage() {}
+
+func (x *AskLibraryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AskLibraryRequest.ProtoReflect.Descriptor instead.
+func (*AskLibraryRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *AskLibraryRequest) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+// A library passage used to answer a question; answers cite it as [index]
+type Citation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	MaterialId    string                 `protobuf:"bytes,2,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	SourceUrl     string                 `protobuf:"bytes,4,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	Snippet       string                 `protobuf:"bytes,5,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Citation) Reset() {
+	*x = Citation{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Citation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Citation) ProtoMessage() {}
+
+func (x *Citation) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Citation.ProtoReflect.Descriptor instead.
+func (*Citation) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *Citation) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Citation) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *Citation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Citation) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *Citation) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+// Streamed answer: the first chunk carries the retrieved sources, later chunks carry text deltas,
+// and the final chunk (done=true) carries only the sources the answer actually cites
+type AskLibraryChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delta         string                 `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	Done          bool                   `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	Citations     []*Citation            `protobuf:"bytes,3,rep,name=citations,proto3" json:"citations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AskLibraryChunk) Reset() {
+	*x = AskLibraryChunk{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Flashcard) String() string {
+func (x *AskLibraryChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Flashcard) ProtoMessage() {}
+func (*AskLibraryChunk) ProtoMessage() {}
 
-func (x *Flashcard) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[7]
+func (x *AskLibraryChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,82 +4638,178 @@ func (x *Flashcard) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Flashcard.ProtoReflect.Descriptor instead.
-func (*Flashcard) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AskLibraryChunk.ProtoReflect.Descriptor instead.
+func (*AskLibraryChunk) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *Flashcard) GetId() string {
+func (x *AskLibraryChunk) GetDelta() string {
 	if x != nil {
-		return x.Id
+		return x.Delta
 	}
 	return ""
 }
 
-func (x *Flashcard) GetQuestion() string {
+func (x *AskLibraryChunk) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *AskLibraryChunk) GetCitations() []*Citation {
+	if x != nil {
+		return x.Citations
+	}
+	return nil
+}
+
+// Saves a question/answer pair as a new TEXT material and generates flashcards from it
+type CreateFlashcardsFromAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Question      string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Answer        string                 `protobuf:"bytes,2,opt,name=answer,proto3" json:"answer,omitempty"`
+	MaterialIds   []string               `protobuf:"bytes,3,rep,name=material_ids,json=materialIds,proto3" json:"material_ids,omitempty"` // Cited materials, listed as sources in the new material
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFlashcardsFromAnswerRequest) Reset() {
+	*x = CreateFlashcardsFromAnswerRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFlashcardsFromAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFlashcardsFromAnswerRequest) ProtoMessage() {}
+
+func (x *CreateFlashcardsFromAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFlashcardsFromAnswerRequest.ProtoReflect.Descriptor instead.
+func (*CreateFlashcardsFromAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CreateFlashcardsFromAnswerRequest) GetQuestion() string {
 	if x != nil {
 		return x.Question
 	}
 	return ""
 }
 
-func (x *Flashcard) GetAnswer() string {
+func (x *CreateFlashcardsFromAnswerRequest) GetAnswer() string {
 	if x != nil {
 		return x.Answer
 	}
 	return ""
 }
 
-func (x *Flashcard) GetStage() int32 {
+func (x *CreateFlashcardsFromAnswerRequest) GetMaterialIds() []string {
 	if x != nil {
-		return x.Stage
+		return x.MaterialIds
 	}
-	return 0
+	return nil
 }
 
-func (x *Flashcard) GetNextReviewAt() *timestamppb.Timestamp {
+type RegisterPushTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"` // "android" or "ios"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushTokenRequest) Reset() {
+	*x = RegisterPushTokenRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushTokenRequest) ProtoMessage() {}
+
+func (x *RegisterPushTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[75]
 	if x != nil {
-		return x.NextReviewAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Flashcard) GetMaterialTitle() string {
+// Deprecated: Use RegisterPushTokenRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPushTokenRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RegisterPushTokenRequest) GetToken() string {
 	if x != nil {
-		return x.MaterialTitle
+		return x.Token
 	}
 	return ""
 }
 
-func (x *Flashcard) GetTags() []string {
+func (x *RegisterPushTokenRequest) GetPlatform() string {
 	if x != nil {
-		return x.Tags
+		return x.Platform
 	}
-	return nil
+	return ""
 }
 
-type FlashcardList struct {
+// A feed or page the user subscribed to. New entries are imported as materials
+// every hour, counting towards the daily import quotas.
+type Source struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Flashcards    []*Flashcard           `protobuf:"bytes,1,rep,name=flashcards,proto3" json:"flashcards,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                        // As entered
+	FeedUrl       string                 `protobuf:"bytes,3,opt,name=feed_url,json=feedUrl,proto3" json:"feed_url,omitempty"` // What is polled: the discovered RSS/Atom feed, or the page itself
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`                      // "FEED" or "PAGE" (article links of an index page)
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Tag           string                 `protobuf:"bytes,6,opt,name=tag,proto3" json:"tag,omitempty"` // Added to every material imported from the source
+	ItemsImported int32                  `protobuf:"varint,7,opt,name=items_imported,json=itemsImported,proto3" json:"items_imported,omitempty"`
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // Of the last poll, empty when it succeeded
+	LastPolledAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_polled_at,json=lastPolledAt,proto3" json:"last_polled_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FlashcardList) Reset() {
-	*x = FlashcardList{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[8]
+func (x *Source) Reset() {
+	*x = Source{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FlashcardList) String() string {
+func (x *Source) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FlashcardList) ProtoMessage() {}
+func (*Source) ProtoMessage() {}
 
-func (x *FlashcardList) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[8]
+func (x *Source) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,40 +4820,106 @@ func (x *FlashcardList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FlashcardList.ProtoReflect.Descriptor instead.
-func (*FlashcardList) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Source.ProtoReflect.Descriptor instead.
+func (*Source) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *FlashcardList) GetFlashcards() []*Flashcard {
+func (x *Source) GetId() string {
 	if x != nil {
-		return x.Flashcards
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Source) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Source) GetFeedUrl() string {
+	if x != nil {
+		return x.FeedUrl
+	}
+	return ""
+}
+
+func (x *Source) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Source) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Source) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Source) GetItemsImported() int32 {
+	if x != nil {
+		return x.ItemsImported
+	}
+	return 0
+}
+
+func (x *Source) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Source) GetLastPolledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPolledAt
 	}
 	return nil
 }
 
-type CompleteReviewRequest struct {
+func (x *Source) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Subscribes to a feed, a page advertising one (blog, YouTube channel) or an index page.
+// Entries already published are not imported.
+type CreateSourceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FlashcardId   string                 `protobuf:"bytes,1,opt,name=flashcard_id,json=flashcardId,proto3" json:"flashcard_id,omitempty"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"` // Optional, defaults to "Sources/<title>"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteReviewRequest) Reset() {
-	*x = CompleteReviewRequest{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[9]
+func (x *CreateSourceRequest) Reset() {
+	*x = CreateSourceRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteReviewRequest) String() string {
+func (x *CreateSourceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteReviewRequest) ProtoMessage() {}
+func (*CreateSourceRequest) ProtoMessage() {}
 
-func (x *CompleteReviewRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[9]
+func (x *CreateSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,40 +4930,47 @@ func (x *CompleteReviewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteReviewRequest.ProtoReflect.Descriptor instead.
-func (*CompleteReviewRequest) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CreateSourceRequest.ProtoReflect.Descriptor instead.
+func (*CreateSourceRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *CompleteReviewRequest) GetFlashcardId() string {
+func (x *CreateSourceRequest) GetUrl() string {
 	if x != nil {
-		return x.FlashcardId
+		return x.Url
 	}
 	return ""
 }
 
-type FailReviewRequest struct {
+func (x *CreateSourceRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type ListSourcesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FlashcardId   string                 `protobuf:"bytes,1,opt,name=flashcard_id,json=flashcardId,proto3" json:"flashcard_id,omitempty"`
+	Sources       []*Source              `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FailReviewRequest) Reset() {
-	*x = FailReviewRequest{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[10]
+func (x *ListSourcesResponse) Reset() {
+	*x = ListSourcesResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FailReviewRequest) String() string {
+func (x *ListSourcesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FailReviewRequest) ProtoMessage() {}
+func (*ListSourcesResponse) ProtoMessage() {}
 
-func (x *FailReviewRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[10]
+func (x *ListSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,40 +4981,90 @@ func (x *FailReviewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FailReviewRequest.ProtoReflect.Descriptor instead.
-func (*FailReviewRequest) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListSourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *FailReviewRequest) GetFlashcardId() string {
+func (x *ListSourcesResponse) GetSources() []*Source {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type DeleteSourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Materials already imported are kept
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSourceRequest) Reset() {
+	*x = DeleteSourceRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSourceRequest) ProtoMessage() {}
+
+func (x *DeleteSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSourceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSourceRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DeleteSourceRequest) GetId() string {
 	if x != nil {
-		return x.FlashcardId
+		return x.Id
 	}
 	return ""
 }
 
-type GetAllTagsResponse struct {
+// Imports every video of a YouTube playlist (in playlist order) or channel
+// (oldest first, at most 200 videos) as its own material, in the background.
+// Each video counts towards the daily YouTube import quota; a job out of quota
+// waits and resumes by itself.
+type StartImportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                                       // youtube.com/playlist?list=…, /channel/UC…, /@handle, /c/… or /user/…
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"` // Optional, defaults to a new collection named after the playlist or channel
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`                             // Optional preferred caption language, see AddMaterialRequest.language
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllTagsResponse) Reset() {
-	*x = GetAllTagsResponse{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[11]
+func (x *StartImportRequest) Reset() {
+	*x = StartImportRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllTagsResponse) String() string {
+func (x *StartImportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllTagsResponse) ProtoMessage() {}
+func (*StartImportRequest) ProtoMessage() {}
 
-func (x *GetAllTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[11]
+func (x *StartImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -723,45 +5075,67 @@ func (x *GetAllTagsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllTagsResponse.ProtoReflect.Descriptor instead.
-func (*GetAllTagsResponse) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use StartImportRequest.ProtoReflect.Descriptor instead.
+func (*StartImportRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *GetAllTagsResponse) GetTags() []string {
+func (x *StartImportRequest) GetUrl() string {
 	if x != nil {
-		return x.Tags
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
-type NotificationStatusResponse struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	DueFlashcardsCount    int32                  `protobuf:"varint,1,opt,name=due_flashcards_count,json=dueFlashcardsCount,proto3" json:"due_flashcards_count,omitempty"`
-	HasDueMaterials       bool                   `protobuf:"varint,2,opt,name=has_due_materials,json=hasDueMaterials,proto3" json:"has_due_materials,omitempty"`
-	DueMaterialsCount     int32                  `protobuf:"varint,3,opt,name=due_materials_count,json=dueMaterialsCount,proto3" json:"due_materials_count,omitempty"`
-	FirstDueMaterialTitle string                 `protobuf:"bytes,4,opt,name=first_due_material_title,json=firstDueMaterialTitle,proto3" json:"first_due_material_title,omitempty"`
-	IsPro                 bool                   `protobuf:"varint,5,opt,name=is_pro,json=isPro,proto3" json:"is_pro,omitempty"`
-	IsBlocked             bool                   `protobuf:"varint,6,opt,name=is_blocked,json=isBlocked,proto3" json:"is_blocked,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+func (x *StartImportRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
 }
 
-func (x *NotificationStatusResponse) Reset() {
-	*x = NotificationStatusResponse{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[12]
+func (x *StartImportRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type ImportJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"` // "PLAYLIST" or "CHANNEL"
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,5,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                        // "RUNNING", "DONE" or "CANCELED"
+	LastError     string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // Why a running job waits (e.g. quota used up), empty while it makes progress
+	Total         int32                  `protobuf:"varint,8,opt,name=total,proto3" json:"total,omitempty"`
+	Pending       int32                  `protobuf:"varint,9,opt,name=pending,proto3" json:"pending,omitempty"`
+	Done          int32                  `protobuf:"varint,10,opt,name=done,proto3" json:"done,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,11,opt,name=duplicates,proto3" json:"duplicates,omitempty"` // Videos already in the library
+	Failed        int32                  `protobuf:"varint,12,opt,name=failed,proto3" json:"failed,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Items         []*ImportItem          `protobuf:"bytes,14,rep,name=items,proto3" json:"items,omitempty"` // Only set by GetImport
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotificationStatusResponse) String() string {
+func (x *ImportJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotificationStatusResponse) ProtoMessage() {}
+func (*ImportJob) ProtoMessage() {}
 
-func (x *NotificationStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[12]
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,123 +5146,135 @@ func (x *NotificationStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotificationStatusResponse.ProtoReflect.Descriptor instead.
-func (*NotificationStatusResponse) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *NotificationStatusResponse) GetDueFlashcardsCount() int32 {
+func (x *ImportJob) GetId() string {
 	if x != nil {
-		return x.DueFlashcardsCount
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *NotificationStatusResponse) GetHasDueMaterials() bool {
+func (x *ImportJob) GetUrl() string {
 	if x != nil {
-		return x.HasDueMaterials
+		return x.Url
 	}
-	return false
+	return ""
 }
 
-func (x *NotificationStatusResponse) GetDueMaterialsCount() int32 {
+func (x *ImportJob) GetKind() string {
 	if x != nil {
-		return x.DueMaterialsCount
+		return x.Kind
 	}
-	return 0
+	return ""
 }
 
-func (x *NotificationStatusResponse) GetFirstDueMaterialTitle() string {
+func (x *ImportJob) GetTitle() string {
 	if x != nil {
-		return x.FirstDueMaterialTitle
+		return x.Title
 	}
 	return ""
 }
 
-func (x *NotificationStatusResponse) GetIsPro() bool {
+func (x *ImportJob) GetCollectionId() string {
 	if x != nil {
-		return x.IsPro
+		return x.CollectionId
 	}
-	return false
+	return ""
 }
 
-func (x *NotificationStatusResponse) GetIsBlocked() bool {
+func (x *ImportJob) GetStatus() string {
 	if x != nil {
-		return x.IsBlocked
+		return x.Status
 	}
-	return false
+	return ""
 }
 
-type GetMaterialSummaryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaterialId    string                 `protobuf:"bytes,1,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ImportJob) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
 }
 
-func (x *GetMaterialSummaryRequest) Reset() {
-	*x = GetMaterialSummaryRequest{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ImportJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
 }
 
-func (x *GetMaterialSummaryRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ImportJob) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
 }
 
-func (*GetMaterialSummaryRequest) ProtoMessage() {}
+func (x *ImportJob) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
 
-func (x *GetMaterialSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[13]
+func (x *ImportJob) GetDuplicates() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Duplicates
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetMaterialSummaryRequest.ProtoReflect.Descriptor instead.
-func (*GetMaterialSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{13}
+func (x *ImportJob) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
 }
 
-func (x *GetMaterialSummaryRequest) GetMaterialId() string {
+func (x *ImportJob) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.MaterialId
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-type GetMaterialSummaryResponse struct {
+func (x *ImportJob) GetItems() []*ImportItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ImportItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Summary       string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
 	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	MaterialType  string                 `protobuf:"bytes,4,opt,name=material_type,json=materialType,proto3" json:"material_type,omitempty"` // "TEXT", "LINK", "IMAGE", or "YOUTUBE"
-	SourceUrl     string                 `protobuf:"bytes,5,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`          // Original URL for LINK/YOUTUBE types
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                           // "PENDING", "DONE", "DUPLICATE", "FAILED" or "CANCELED"
+	MaterialId    string                 `protobuf:"bytes,4,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"` // The material created, or the existing one for duplicates
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMaterialSummaryResponse) Reset() {
-	*x = GetMaterialSummaryResponse{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[14]
+func (x *ImportItem) Reset() {
+	*x = ImportItem{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMaterialSummaryResponse) String() string {
+func (x *ImportItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMaterialSummaryResponse) ProtoMessage() {}
+func (*ImportItem) ProtoMessage() {}
 
-func (x *GetMaterialSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[14]
+func (x *ImportItem) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -899,70 +5285,68 @@ func (x *GetMaterialSummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMaterialSummaryResponse.ProtoReflect.Descriptor instead.
-func (*GetMaterialSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ImportItem.ProtoReflect.Descriptor instead.
+func (*ImportItem) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *GetMaterialSummaryResponse) GetSummary() string {
+func (x *ImportItem) GetVideoId() string {
 	if x != nil {
-		return x.Summary
+		return x.VideoId
 	}
 	return ""
 }
 
-func (x *GetMaterialSummaryResponse) GetTitle() string {
+func (x *ImportItem) GetTitle() string {
 	if x != nil {
 		return x.Title
 	}
 	return ""
 }
 
-func (x *GetMaterialSummaryResponse) GetContent() string {
+func (x *ImportItem) GetStatus() string {
 	if x != nil {
-		return x.Content
+		return x.Status
 	}
 	return ""
 }
 
-func (x *GetMaterialSummaryResponse) GetMaterialType() string {
+func (x *ImportItem) GetMaterialId() string {
 	if x != nil {
-		return x.MaterialType
+		return x.MaterialId
 	}
 	return ""
 }
 
-func (x *GetMaterialSummaryResponse) GetSourceUrl() string {
+func (x *ImportItem) GetError() string {
 	if x != nil {
-		return x.SourceUrl
+		return x.Error
 	}
 	return ""
 }
 
-type UpdateFlashcardRequest struct {
+type ListImportsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FlashcardId   string                 `protobuf:"bytes,1,opt,name=flashcard_id,json=flashcardId,proto3" json:"flashcard_id,omitempty"`
-	Question      string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
-	Answer        string                 `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
+	Imports       []*ImportJob           `protobuf:"bytes,1,rep,name=imports,proto3" json:"imports,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateFlashcardRequest) Reset() {
-	*x = UpdateFlashcardRequest{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[15]
+func (x *ListImportsResponse) Reset() {
+	*x = ListImportsResponse{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFlashcardRequest) String() string {
+func (x *ListImportsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateFlashcardRequest) ProtoMessage() {}
+func (*ListImportsResponse) ProtoMessage() {}
 
-func (x *UpdateFlashcardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[15]
+func (x *ListImportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,55 +5357,84 @@ func (x *UpdateFlashcardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateFlashcardRequest.ProtoReflect.Descriptor instead.
-func (*UpdateFlashcardRequest) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListImportsResponse.ProtoReflect.Descriptor instead.
+func (*ListImportsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *UpdateFlashcardRequest) GetFlashcardId() string {
+func (x *ListImportsResponse) GetImports() []*ImportJob {
 	if x != nil {
-		return x.FlashcardId
+		return x.Imports
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateFlashcardRequest) GetQuestion() string {
+type GetImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportRequest) Reset() {
+	*x = GetImportRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportRequest) ProtoMessage() {}
+
+func (x *GetImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[84]
 	if x != nil {
-		return x.Question
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateFlashcardRequest) GetAnswer() string {
+// Deprecated: Use GetImportRequest.ProtoReflect.Descriptor instead.
+func (*GetImportRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetImportRequest) GetId() string {
 	if x != nil {
-		return x.Answer
+		return x.Id
 	}
 	return ""
 }
 
-type RegisterPushTokenRequest struct {
+type CancelImportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"` // "android" or "ios"
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Videos already imported are kept
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterPushTokenRequest) Reset() {
-	*x = RegisterPushTokenRequest{}
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[16]
+func (x *CancelImportRequest) Reset() {
+	*x = CancelImportRequest{}
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterPushTokenRequest) String() string {
+func (x *CancelImportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterPushTokenRequest) ProtoMessage() {}
+func (*CancelImportRequest) ProtoMessage() {}
 
-func (x *RegisterPushTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_learning_learning_proto_msgTypes[16]
+func (x *CancelImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_learning_learning_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1032,21 +5445,14 @@ func (x *RegisterPushTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterPushTokenRequest.ProtoReflect.Descriptor instead.
-func (*RegisterPushTokenRequest) Descriptor() ([]byte, []int) {
-	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *RegisterPushTokenRequest) GetToken() string {
-	if x != nil {
-		return x.Token
-	}
-	return ""
+// Deprecated: Use CancelImportRequest.ProtoReflect.Descriptor instead.
+func (*CancelImportRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_learning_learning_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *RegisterPushTokenRequest) GetPlatform() string {
+func (x *CancelImportRequest) GetId() string {
 	if x != nil {
-		return x.Platform
+		return x.Id
 	}
 	return ""
 }
@@ -1055,33 +5461,73 @@ var File_backend_proto_learning_learning_proto protoreflect.FileDescriptor
 
 const file_backend_proto_learning_learning_proto_rawDesc = "" +
 	"\n" +
-	"%backend/proto/learning/learning.proto\x12\blearning\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x86\x01\n" +
+	"%backend/proto/learning/learning.proto\x12\blearning\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x9c\x02\n" +
 	"\x12AddMaterialRequest\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12#\n" +
 	"\rexisting_tags\x18\x03 \x03(\tR\fexistingTags\x12\x1d\n" +
 	"\n" +
-	"image_data\x18\x04 \x01(\tR\timageData\"\x8f\x01\n" +
+	"image_data\x18\x04 \x01(\tR\timageData\x12'\n" +
+	"\x0fallow_duplicate\x18\x05 \x01(\bR\x0eallowDuplicate\x12#\n" +
+	"\rcollection_id\x18\x06 \x01(\tR\fcollectionId\x12\x12\n" +
+	"\x04html\x18\a \x01(\tR\x04html\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x12\x16\n" +
+	"\x06images\x18\t \x03(\tR\x06images\"\xb2\x02\n" +
 	"\x13AddMaterialResponse\x12\x1f\n" +
 	"\vmaterial_id\x18\x01 \x01(\tR\n" +
 	"materialId\x12-\n" +
 	"\x12flashcards_created\x18\x02 \x01(\x05R\x11flashcardsCreated\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"8\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12>\n" +
+	"\fduplicate_of\x18\x05 \x01(\v2\x1b.learning.DuplicateMaterialR\vduplicateOf\x126\n" +
+	"\x17duplicate_cards_skipped\x18\x06 \x01(\x05R\x15duplicateCardsSkipped\x12)\n" +
+	"\x10unverified_cards\x18\a \x01(\x05R\x0funverifiedCards\"\x82\x01\n" +
+	"\x11DuplicateMaterial\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x04 \x01(\x01R\n" +
+	"similarity\"\xd0\x01\n" +
+	"\x18MergeIntoMaterialRequest\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1d\n" +
+	"\n" +
+	"image_data\x18\x04 \x01(\tR\timageData\x12\x12\n" +
+	"\x04html\x18\x05 \x01(\tR\x04html\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12\x16\n" +
+	"\x06images\x18\a \x03(\tR\x06images\"8\n" +
 	"\x15DeleteMaterialRequest\x12\x1f\n" +
 	"\vmaterial_id\x18\x01 \x01(\tR\n" +
-	"materialId\"h\n" +
+	"materialId\"9\n" +
+	"\x16RefreshMaterialRequest\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\"\xbb\x01\n" +
+	"\x17RefreshMaterialResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\x12!\n" +
+	"\fchange_ratio\x18\x02 \x01(\x02R\vchangeRatio\x12\x1d\n" +
+	"\n" +
+	"cards_kept\x18\x03 \x01(\x05R\tcardsKept\x12#\n" +
+	"\rcards_removed\x18\x04 \x01(\x05R\fcardsRemoved\x12\x1f\n" +
+	"\vcards_added\x18\x05 \x01(\x05R\n" +
+	"cardsAdded\"\xba\x01\n" +
 	"\x0fMaterialSummary\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1b\n" +
 	"\tdue_count\x18\x03 \x01(\x05R\bdueCount\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"\x9b\x01\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12#\n" +
+	"\rcollection_id\x18\x05 \x01(\tR\fcollectionId\x12+\n" +
+	"\x11refresh_available\x18\x06 \x01(\bR\x10refreshAvailable\"\xc0\x01\n" +
 	"\x16GetDueMaterialsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12!\n" +
 	"\fsearch_query\x18\x03 \x01(\tR\vsearchQuery\x12\x12\n" +
 	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x19\n" +
-	"\bonly_due\x18\x05 \x01(\bR\aonlyDue\"\xc5\x01\n" +
+	"\bonly_due\x18\x05 \x01(\bR\aonlyDue\x12#\n" +
+	"\rcollection_id\x18\x06 \x01(\tR\fcollectionId\"\xc5\x01\n" +
 	"\x17GetDueMaterialsResponse\x127\n" +
 	"\tmaterials\x18\x01 \x03(\v2\x19.learning.MaterialSummaryR\tmaterials\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
@@ -1089,10 +5535,13 @@ const file_backend_proto_learning_learning_proto_rawDesc = "" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
 	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
-	"totalPages\":\n" +
+	"totalPages\"~\n" +
 	"\x17GetDueFlashcardsRequest\x12\x1f\n" +
 	"\vmaterial_id\x18\x01 \x01(\tR\n" +
-	"materialId\"\xe2\x01\n" +
+	"materialId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x1d\n" +
+	"\n" +
+	"concept_id\x18\x03 \x01(\tR\tconceptId\"\xf1\x02\n" +
 	"\tFlashcard\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\bquestion\x18\x02 \x01(\tR\bquestion\x12\x16\n" +
@@ -1100,7 +5549,15 @@ const file_backend_proto_learning_learning_proto_rawDesc = "" +
 	"\x05stage\x18\x04 \x01(\x05R\x05stage\x12@\n" +
 	"\x0enext_review_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fnextReviewAt\x12%\n" +
 	"\x0ematerial_title\x18\x06 \x01(\tR\rmaterialTitle\x12\x12\n" +
-	"\x04tags\x18\a \x03(\tR\x04tags\"D\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\b \x01(\x05R\n" +
+	"difficulty\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\t \x01(\tR\tsourceUrl\x12!\n" +
+	"\fsource_quote\x18\n" +
+	" \x01(\tR\vsourceQuote\x12+\n" +
+	"\x11source_unverified\x18\v \x01(\bR\x10sourceUnverified\"D\n" +
 	"\rFlashcardList\x123\n" +
 	"\n" +
 	"flashcards\x18\x01 \x03(\v2\x13.learning.FlashcardR\n" +
@@ -1110,7 +5567,205 @@ const file_backend_proto_learning_learning_proto_rawDesc = "" +
 	"\x11FailReviewRequest\x12!\n" +
 	"\fflashcard_id\x18\x01 \x01(\tR\vflashcardId\"(\n" +
 	"\x12GetAllTagsResponse\x12\x12\n" +
-	"\x04tags\x18\x01 \x03(\tR\x04tags\"\x99\x02\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"\xce\x02\n" +
+	"\n" +
+	"Collection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tcover_url\x18\x04 \x01(\tR\bcoverUrl\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12)\n" +
+	"\x11new_cards_per_day\x18\x06 \x01(\x05R\x0enewCardsPerDay\x12)\n" +
+	"\x10scheduler_preset\x18\a \x01(\tR\x0fschedulerPreset\x12%\n" +
+	"\x0ematerial_count\x18\b \x01(\x05R\rmaterialCount\x12\x1b\n" +
+	"\tdue_count\x18\t \x01(\x05R\bdueCount\x12'\n" +
+	"\x0forganization_id\x18\n" +
+	" \x01(\tR\x0eorganizationId\")\n" +
+	"\x17DeleteCollectionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x17ListCollectionsResponse\x126\n" +
+	"\vcollections\x18\x01 \x03(\v2\x14.learning.CollectionR\vcollections\"-\n" +
+	"\x19ReorderCollectionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"d\n" +
+	"\x1cSetMaterialCollectionRequest\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\"\x90\x01\n" +
+	"\x12PublishDeckRequest\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xb5\x02\n" +
+	"\n" +
+	"PublicDeck\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12\x1d\n" +
+	"\n" +
+	"card_count\x18\x05 \x01(\x05R\tcardCount\x12\x1f\n" +
+	"\vclone_count\x18\x06 \x01(\x05R\n" +
+	"cloneCount\x12\x1f\n" +
+	"\vmaterial_id\x18\a \x01(\tR\n" +
+	"materialId\x12#\n" +
+	"\rcollection_id\x18\b \x01(\tR\fcollectionId\x12=\n" +
+	"\fpublished_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\"*\n" +
+	"\x14UnpublishDeckRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"E\n" +
+	"\x17ListPublicDecksResponse\x12*\n" +
+	"\x05decks\x18\x01 \x03(\v2\x14.learning.PublicDeckR\x05decks\"K\n" +
+	"\x10CloneDeckRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\"\x8a\x01\n" +
+	"\x11CloneDeckResponse\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12!\n" +
+	"\fmaterial_ids\x18\x02 \x03(\tR\vmaterialIds\x12-\n" +
+	"\x12flashcards_created\x18\x03 \x01(\x05R\x11flashcardsCreated\"\xa8\x02\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x14\n" +
+	"\x05seats\x18\x04 \x01(\x05R\x05seats\x12!\n" +
+	"\fmember_count\x18\x05 \x01(\x05R\vmemberCount\x12\x12\n" +
+	"\x04plan\x18\x06 \x01(\tR\x04plan\x12H\n" +
+	"\x12current_period_end\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x10currentPeriodEnd\x12\x1d\n" +
+	"\n" +
+	"is_creator\x18\b \x01(\bR\tisCreator\x12*\n" +
+	"\x11reporting_opt_out\x18\t \x01(\bR\x0freportingOptOut\"/\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Y\n" +
+	"\x19ListOrganizationsResponse\x12<\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x16.learning.OrganizationR\rorganizations\"D\n" +
+	"\x19DeleteOrganizationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"\xbe\x01\n" +
+	"\x12OrganizationMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\apicture\x18\x04 \x01(\tR\apicture\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x127\n" +
+	"\tjoined_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"H\n" +
+	"\x1dGetOrganizationMembersRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"U\n" +
+	"\x1bOrganizationMembersResponse\x126\n" +
+	"\amembers\x18\x01 \x03(\v2\x1c.learning.OrganizationMemberR\amembers\"q\n" +
+	"\x1cAddOrganizationMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"x\n" +
+	" SetOrganizationMemberRoleRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"c\n" +
+	"\x1fRemoveOrganizationMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"f\n" +
+	"\x16ShareCollectionRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\"M\n" +
+	"\"ListOrganizationCollectionsRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"W\n" +
+	"\x1cGetDeckProgressReportRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\xdc\x02\n" +
+	"\x0eMemberProgress\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vtotal_cards\x18\x04 \x01(\x05R\n" +
+	"totalCards\x12#\n" +
+	"\rstudied_cards\x18\x05 \x01(\x05R\fstudiedCards\x12\x1e\n" +
+	"\n" +
+	"completion\x18\x06 \x01(\x02R\n" +
+	"completion\x12#\n" +
+	"\roverdue_cards\x18\a \x01(\x05R\foverdueCards\x12\x18\n" +
+	"\areviews\x18\b \x01(\x05R\areviews\x12\x1c\n" +
+	"\tretention\x18\t \x01(\x02R\tretention\x12D\n" +
+	"\x10last_reviewed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0elastReviewedAt\"\xb5\x02\n" +
+	"\x12DeckProgressReport\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12'\n" +
+	"\x0fcollection_name\x18\x02 \x01(\tR\x0ecollectionName\x12\x12\n" +
+	"\x04days\x18\x03 \x01(\x05R\x04days\x122\n" +
+	"\amembers\x18\x04 \x03(\v2\x18.learning.MemberProgressR\amembers\x12&\n" +
+	"\x0fopted_out_count\x18\x05 \x01(\x05R\roptedOutCount\x12\x1e\n" +
+	"\n" +
+	"completion\x18\x06 \x01(\x02R\n" +
+	"completion\x12\x1c\n" +
+	"\tretention\x18\a \x01(\x02R\tretention\x12#\n" +
+	"\roverdue_cards\x18\b \x01(\x05R\foverdueCards\"]\n" +
+	"\x19SetReportingOptOutRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\aopt_out\x18\x02 \x01(\bR\x06optOut\"M\n" +
+	"\x16GetConceptGraphRequest\x12\x1d\n" +
+	"\n" +
+	"concept_id\x18\x01 \x01(\tR\tconceptId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x9f\x01\n" +
+	"\aConcept\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
+	"\x0ematerial_count\x18\x04 \x01(\x05R\rmaterialCount\x12'\n" +
+	"\x0fflashcard_count\x18\x05 \x01(\x05R\x0eflashcardCount\"{\n" +
+	"\vConceptEdge\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x05R\x06weight\"\xa3\x01\n" +
+	"\fConceptGraph\x12-\n" +
+	"\bconcepts\x18\x01 \x03(\v2\x11.learning.ConceptR\bconcepts\x12+\n" +
+	"\x05edges\x18\x02 \x03(\v2\x15.learning.ConceptEdgeR\x05edges\x127\n" +
+	"\tmaterials\x18\x03 \x03(\v2\x19.learning.RelatedMaterialR\tmaterials\"S\n" +
+	"\x1aGetRelatedMaterialsRequest\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x87\x01\n" +
+	"\x0fRelatedMaterial\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12'\n" +
+	"\x0fshared_concepts\x18\x03 \x03(\tR\x0esharedConcepts\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\"V\n" +
+	"\x1bGetRelatedMaterialsResponse\x127\n" +
+	"\tmaterials\x18\x01 \x03(\v2\x19.learning.RelatedMaterialR\tmaterials\"D\n" +
+	"\aTagInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x0ematerial_count\x18\x02 \x01(\x05R\rmaterialCount\"9\n" +
+	"\x10ListTagsResponse\x12%\n" +
+	"\x04tags\x18\x01 \x03(\v2\x11.learning.TagInfoR\x04tags\"A\n" +
+	"\x10RenameTagRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\"D\n" +
+	"\x10MergeTagsRequest\x12\x18\n" +
+	"\asources\x18\x01 \x03(\tR\asources\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\"D\n" +
+	"\x10DeleteTagRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\"8\n" +
+	"\x11TagChangeResponse\x12#\n" +
+	"\rtags_affected\x18\x01 \x01(\x05R\ftagsAffected\"M\n" +
+	"\x16SetMaterialTagsRequest\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"-\n" +
+	"\x17SetMaterialTagsResponse\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"9\n" +
+	"\x1dGetTagMergeSuggestionsRequest\x12\x18\n" +
+	"\arefresh\x18\x01 \x01(\bR\arefresh\"\x90\x01\n" +
+	"\x12TagMergeSuggestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"source_tag\x18\x02 \x01(\tR\tsourceTag\x12\x1d\n" +
+	"\n" +
+	"target_tag\x18\x03 \x01(\tR\ttargetTag\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\"`\n" +
+	"\x1eGetTagMergeSuggestionsResponse\x12>\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1c.learning.TagMergeSuggestionR\vsuggestions\"J\n" +
+	" ResolveTagMergeSuggestionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06accept\x18\x02 \x01(\bR\x06accept\"\x99\x02\n" +
 	"\x1aNotificationStatusResponse\x120\n" +
 	"\x14due_flashcards_count\x18\x01 \x01(\x05R\x12dueFlashcardsCount\x12*\n" +
 	"\x11has_due_materials\x18\x02 \x01(\bR\x0fhasDueMaterials\x12.\n" +
@@ -1128,28 +5783,178 @@ const file_backend_proto_learning_learning_proto_rawDesc = "" +
 	"\acontent\x18\x03 \x01(\tR\acontent\x12#\n" +
 	"\rmaterial_type\x18\x04 \x01(\tR\fmaterialType\x12\x1d\n" +
 	"\n" +
-	"source_url\x18\x05 \x01(\tR\tsourceUrl\"o\n" +
+	"source_url\x18\x05 \x01(\tR\tsourceUrl\"\xb2\x01\n" +
+	"\x14MaterialSummaryChunk\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\tR\x05delta\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\bR\x04done\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12#\n" +
+	"\rmaterial_type\x18\x04 \x01(\tR\fmaterialType\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x05 \x01(\tR\tsourceUrl\x12\x16\n" +
+	"\x06cached\x18\x06 \x01(\bR\x06cached\"o\n" +
 	"\x16UpdateFlashcardRequest\x12!\n" +
 	"\fflashcard_id\x18\x01 \x01(\tR\vflashcardId\x12\x1a\n" +
 	"\bquestion\x18\x02 \x01(\tR\bquestion\x12\x16\n" +
-	"\x06answer\x18\x03 \x01(\tR\x06answer\"L\n" +
+	"\x06answer\x18\x03 \x01(\tR\x06answer\"j\n" +
+	"\x1fSetFlashcardDependenciesRequest\x12!\n" +
+	"\fflashcard_id\x18\x01 \x01(\tR\vflashcardId\x12$\n" +
+	"\x0edepends_on_ids\x18\x02 \x03(\tR\fdependsOnIds\"^\n" +
+	"\x14SearchLibraryRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\bsemantic\x18\x03 \x01(\bR\bsemantic\"\xdc\x01\n" +
+	"\fSearchResult\x12\x1f\n" +
+	"\vmaterial_id\x18\x01 \x01(\tR\n" +
+	"materialId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x03 \x01(\tR\tsourceUrl\x12\x18\n" +
+	"\asnippet\x18\x04 \x01(\tR\asnippet\x12\x1d\n" +
+	"\n" +
+	"matched_in\x18\x05 \x01(\tR\tmatchedIn\x12'\n" +
+	"\x04card\x18\x06 \x01(\v2\x13.learning.FlashcardR\x04card\x12\x14\n" +
+	"\x05score\x18\a \x01(\x01R\x05score\"I\n" +
+	"\x15SearchLibraryResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.learning.SearchResultR\aresults\"/\n" +
+	"\x11AskLibraryRequest\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\"\x90\x01\n" +
+	"\bCitation\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1f\n" +
+	"\vmaterial_id\x18\x02 \x01(\tR\n" +
+	"materialId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x04 \x01(\tR\tsourceUrl\x12\x18\n" +
+	"\asnippet\x18\x05 \x01(\tR\asnippet\"m\n" +
+	"\x0fAskLibraryChunk\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\tR\x05delta\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\bR\x04done\x120\n" +
+	"\tcitations\x18\x03 \x03(\v2\x12.learning.CitationR\tcitations\"z\n" +
+	"!CreateFlashcardsFromAnswerRequest\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\x12\x16\n" +
+	"\x06answer\x18\x02 \x01(\tR\x06answer\x12!\n" +
+	"\fmaterial_ids\x18\x03 \x03(\tR\vmaterialIds\"L\n" +
 	"\x18RegisterPushTokenRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
-	"\bplatform\x18\x02 \x01(\tR\bplatform2\xf8\x06\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\"\xc4\x02\n" +
+	"\x06Source\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x19\n" +
+	"\bfeed_url\x18\x03 \x01(\tR\afeedUrl\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x10\n" +
+	"\x03tag\x18\x06 \x01(\tR\x03tag\x12%\n" +
+	"\x0eitems_imported\x18\a \x01(\x05R\ritemsImported\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12@\n" +
+	"\x0elast_polled_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\flastPolledAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"9\n" +
+	"\x13CreateSourceRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\"A\n" +
+	"\x13ListSourcesResponse\x12*\n" +
+	"\asources\x18\x01 \x03(\v2\x10.learning.SourceR\asources\"%\n" +
+	"\x13DeleteSourceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"g\n" +
+	"\x12StartImportRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\"\x96\x03\n" +
+	"\tImportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12#\n" +
+	"\rcollection_id\x18\x05 \x01(\tR\fcollectionId\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x12\x14\n" +
+	"\x05total\x18\b \x01(\x05R\x05total\x12\x18\n" +
+	"\apending\x18\t \x01(\x05R\apending\x12\x12\n" +
+	"\x04done\x18\n" +
+	" \x01(\x05R\x04done\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\v \x01(\x05R\n" +
+	"duplicates\x12\x16\n" +
+	"\x06failed\x18\f \x01(\x05R\x06failed\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12*\n" +
+	"\x05items\x18\x0e \x03(\v2\x14.learning.ImportItemR\x05items\"\x8c\x01\n" +
+	"\n" +
+	"ImportItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vmaterial_id\x18\x04 \x01(\tR\n" +
+	"materialId\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"D\n" +
+	"\x13ListImportsResponse\x12-\n" +
+	"\aimports\x18\x01 \x03(\v2\x13.learning.ImportJobR\aimports\"\"\n" +
+	"\x10GetImportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x13CancelImportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x8e#\n" +
 	"\x0fLearningService\x12J\n" +
-	"\vAddMaterial\x12\x1c.learning.AddMaterialRequest\x1a\x1d.learning.AddMaterialResponse\x12I\n" +
+	"\vAddMaterial\x12\x1c.learning.AddMaterialRequest\x1a\x1d.learning.AddMaterialResponse\x12V\n" +
+	"\x11MergeIntoMaterial\x12\".learning.MergeIntoMaterialRequest\x1a\x1d.learning.AddMaterialResponse\x12I\n" +
 	"\x0eDeleteMaterial\x12\x1f.learning.DeleteMaterialRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x0fRefreshMaterial\x12 .learning.RefreshMaterialRequest\x1a!.learning.RefreshMaterialResponse\x12V\n" +
 	"\x0fGetDueMaterials\x12 .learning.GetDueMaterialsRequest\x1a!.learning.GetDueMaterialsResponse\x12N\n" +
 	"\x10GetDueFlashcards\x12!.learning.GetDueFlashcardsRequest\x1a\x17.learning.FlashcardList\x12I\n" +
 	"\x0eCompleteReview\x12\x1f.learning.CompleteReviewRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
 	"\n" +
 	"FailReview\x12\x1b.learning.FailReviewRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
 	"\n" +
-	"GetAllTags\x12\x16.google.protobuf.Empty\x1a\x1c.learning.GetAllTagsResponse\x12U\n" +
+	"GetAllTags\x12\x16.google.protobuf.Empty\x1a\x1c.learning.GetAllTagsResponse\x12>\n" +
+	"\x10CreateCollection\x12\x14.learning.Collection\x1a\x14.learning.Collection\x12>\n" +
+	"\x10UpdateCollection\x12\x14.learning.Collection\x1a\x14.learning.Collection\x12M\n" +
+	"\x10DeleteCollection\x12!.learning.DeleteCollectionRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x0fListCollections\x12\x16.google.protobuf.Empty\x1a!.learning.ListCollectionsResponse\x12Q\n" +
+	"\x12ReorderCollections\x12#.learning.ReorderCollectionsRequest\x1a\x16.google.protobuf.Empty\x12W\n" +
+	"\x15SetMaterialCollection\x12&.learning.SetMaterialCollectionRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\vPublishDeck\x12\x1c.learning.PublishDeckRequest\x1a\x14.learning.PublicDeck\x12G\n" +
+	"\rUnpublishDeck\x12\x1e.learning.UnpublishDeckRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x0fListPublicDecks\x12\x16.google.protobuf.Empty\x1a!.learning.ListPublicDecksResponse\x12D\n" +
+	"\tCloneDeck\x12\x1a.learning.CloneDeckRequest\x1a\x1b.learning.CloneDeckResponse\x12Q\n" +
+	"\x12CreateOrganization\x12#.learning.CreateOrganizationRequest\x1a\x16.learning.Organization\x12P\n" +
+	"\x11ListOrganizations\x12\x16.google.protobuf.Empty\x1a#.learning.ListOrganizationsResponse\x12Q\n" +
+	"\x12DeleteOrganization\x12#.learning.DeleteOrganizationRequest\x1a\x16.google.protobuf.Empty\x12h\n" +
+	"\x16GetOrganizationMembers\x12'.learning.GetOrganizationMembersRequest\x1a%.learning.OrganizationMembersResponse\x12f\n" +
+	"\x15AddOrganizationMember\x12&.learning.AddOrganizationMemberRequest\x1a%.learning.OrganizationMembersResponse\x12_\n" +
+	"\x19SetOrganizationMemberRole\x12*.learning.SetOrganizationMemberRoleRequest\x1a\x16.google.protobuf.Empty\x12]\n" +
+	"\x18RemoveOrganizationMember\x12).learning.RemoveOrganizationMemberRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\x0fShareCollection\x12 .learning.ShareCollectionRequest\x1a\x16.google.protobuf.Empty\x12n\n" +
+	"\x1bListOrganizationCollections\x12,.learning.ListOrganizationCollectionsRequest\x1a!.learning.ListCollectionsResponse\x12]\n" +
+	"\x15GetDeckProgressReport\x12&.learning.GetDeckProgressReportRequest\x1a\x1c.learning.DeckProgressReport\x12Q\n" +
+	"\x12SetReportingOptOut\x12#.learning.SetReportingOptOutRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\x0fGetConceptGraph\x12 .learning.GetConceptGraphRequest\x1a\x16.learning.ConceptGraph\x12b\n" +
+	"\x13GetRelatedMaterials\x12$.learning.GetRelatedMaterialsRequest\x1a%.learning.GetRelatedMaterialsResponse\x12>\n" +
+	"\bListTags\x12\x16.google.protobuf.Empty\x1a\x1a.learning.ListTagsResponse\x12D\n" +
+	"\tRenameTag\x12\x1a.learning.RenameTagRequest\x1a\x1b.learning.TagChangeResponse\x12D\n" +
+	"\tMergeTags\x12\x1a.learning.MergeTagsRequest\x1a\x1b.learning.TagChangeResponse\x12D\n" +
+	"\tDeleteTag\x12\x1a.learning.DeleteTagRequest\x1a\x1b.learning.TagChangeResponse\x12V\n" +
+	"\x0fSetMaterialTags\x12 .learning.SetMaterialTagsRequest\x1a!.learning.SetMaterialTagsResponse\x12k\n" +
+	"\x16GetTagMergeSuggestions\x12'.learning.GetTagMergeSuggestionsRequest\x1a(.learning.GetTagMergeSuggestionsResponse\x12_\n" +
+	"\x19ResolveTagMergeSuggestion\x12*.learning.ResolveTagMergeSuggestionRequest\x1a\x16.google.protobuf.Empty\x12U\n" +
 	"\x15GetNotificationStatus\x12\x16.google.protobuf.Empty\x1a$.learning.NotificationStatusResponse\x12_\n" +
-	"\x12GetMaterialSummary\x12#.learning.GetMaterialSummaryRequest\x1a$.learning.GetMaterialSummaryResponse\x12K\n" +
-	"\x0fUpdateFlashcard\x12 .learning.UpdateFlashcardRequest\x1a\x16.google.protobuf.Empty\x12O\n" +
-	"\x11RegisterPushToken\x12\".learning.RegisterPushTokenRequest\x1a\x16.google.protobuf.EmptyB,Z*github.com/amityadav/landr/pkg/pb/learningb\x06proto3"
+	"\x12GetMaterialSummary\x12#.learning.GetMaterialSummaryRequest\x1a$.learning.GetMaterialSummaryResponse\x12^\n" +
+	"\x15StreamMaterialSummary\x12#.learning.GetMaterialSummaryRequest\x1a\x1e.learning.MaterialSummaryChunk0\x01\x12K\n" +
+	"\x0fUpdateFlashcard\x12 .learning.UpdateFlashcardRequest\x1a\x16.google.protobuf.Empty\x12]\n" +
+	"\x18SetFlashcardDependencies\x12).learning.SetFlashcardDependenciesRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\rSearchLibrary\x12\x1e.learning.SearchLibraryRequest\x1a\x1f.learning.SearchLibraryResponse\x12F\n" +
+	"\n" +
+	"AskLibrary\x12\x1b.learning.AskLibraryRequest\x1a\x19.learning.AskLibraryChunk0\x01\x12h\n" +
+	"\x1aCreateFlashcardsFromAnswer\x12+.learning.CreateFlashcardsFromAnswerRequest\x1a\x1d.learning.AddMaterialResponse\x12O\n" +
+	"\x11RegisterPushToken\x12\".learning.RegisterPushTokenRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\fCreateSource\x12\x1d.learning.CreateSourceRequest\x1a\x10.learning.Source\x12D\n" +
+	"\vListSources\x12\x16.google.protobuf.Empty\x1a\x1d.learning.ListSourcesResponse\x12E\n" +
+	"\fDeleteSource\x12\x1d.learning.DeleteSourceRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\vStartImport\x12\x1c.learning.StartImportRequest\x1a\x13.learning.ImportJob\x12D\n" +
+	"\vListImports\x12\x16.google.protobuf.Empty\x1a\x1d.learning.ListImportsResponse\x12<\n" +
+	"\tGetImport\x12\x1a.learning.GetImportRequest\x1a\x13.learning.ImportJob\x12B\n" +
+	"\fCancelImport\x12\x1d.learning.CancelImportRequest\x1a\x13.learning.ImportJobB,Z*github.com/amityadav/landr/pkg/pb/learningb\x06proto3"
 
 var (
 	file_backend_proto_learning_learning_proto_rawDescOnce sync.Once
@@ -1163,59 +5968,241 @@ func file_backend_proto_learning_learning_proto_rawDescGZIP() []byte {
 	return file_backend_proto_learning_learning_proto_rawDescData
 }
 
-var file_backend_proto_learning_learning_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_backend_proto_learning_learning_proto_msgTypes = make([]protoimpl.MessageInfo, 86)
 var file_backend_proto_learning_learning_proto_goTypes = []any{
-	(*AddMaterialRequest)(nil),         // 0: learning.AddMaterialRequest
-	(*AddMaterialResponse)(nil),        // 1: learning.AddMaterialResponse
-	(*DeleteMaterialRequest)(nil),      // 2: learning.DeleteMaterialRequest
-	(*MaterialSummary)(nil),            // 3: learning.MaterialSummary
-	(*GetDueMaterialsRequest)(nil),     // 4: learning.GetDueMaterialsRequest
-	(*GetDueMaterialsResponse)(nil),    // 5: learning.GetDueMaterialsResponse
-	(*GetDueFlashcardsRequest)(nil),    // 6: learning.GetDueFlashcardsRequest
-	(*Flashcard)(nil),                  // 7: learning.Flashcard
-	(*FlashcardList)(nil),              // 8: learning.FlashcardList
-	(*CompleteReviewRequest)(nil),      // 9: learning.CompleteReviewRequest
-	(*FailReviewRequest)(nil),          // 10: learning.FailReviewRequest
-	(*GetAllTagsResponse)(nil),         // 11: learning.GetAllTagsResponse
-	(*NotificationStatusResponse)(nil), // 12: learning.NotificationStatusResponse
-	(*GetMaterialSummaryRequest)(nil),  // 13: learning.GetMaterialSummaryRequest
-	(*GetMaterialSummaryResponse)(nil), // 14: learning.GetMaterialSummaryResponse
-	(*UpdateFlashcardRequest)(nil),     // 15: learning.UpdateFlashcardRequest
-	(*RegisterPushTokenRequest)(nil),   // 16: learning.RegisterPushTokenRequest
-	(*timestamppb.Timestamp)(nil),      // 17: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),              // 18: google.protobuf.Empty
+	(*AddMaterialRequest)(nil),                 // 0: learning.AddMaterialRequest
+	(*AddMaterialResponse)(nil),                // 1: learning.AddMaterialResponse
+	(*DuplicateMaterial)(nil),                  // 2: learning.DuplicateMaterial
+	(*MergeIntoMaterialRequest)(nil),           // 3: learning.MergeIntoMaterialRequest
+	(*DeleteMaterialRequest)(nil),              // 4: learning.DeleteMaterialRequest
+	(*RefreshMaterialRequest)(nil),             // 5: learning.RefreshMaterialRequest
+	(*RefreshMaterialResponse)(nil),            // 6: learning.RefreshMaterialResponse
+	(*MaterialSummary)(nil),                    // 7: learning.MaterialSummary
+	(*GetDueMaterialsRequest)(nil),             // 8: learning.GetDueMaterialsRequest
+	(*GetDueMaterialsResponse)(nil),            // 9: learning.GetDueMaterialsResponse
+	(*GetDueFlashcardsRequest)(nil),            // 10: learning.GetDueFlashcardsRequest
+	(*Flashcard)(nil),                          // 11: learning.Flashcard
+	(*FlashcardList)(nil),                      // 12: learning.FlashcardList
+	(*CompleteReviewRequest)(nil),              // 13: learning.CompleteReviewRequest
+	(*FailReviewRequest)(nil),                  // 14: learning.FailReviewRequest
+	(*GetAllTagsResponse)(nil),                 // 15: learning.GetAllTagsResponse
+	(*Collection)(nil),                         // 16: learning.Collection
+	(*DeleteCollectionRequest)(nil),            // 17: learning.DeleteCollectionRequest
+	(*ListCollectionsResponse)(nil),            // 18: learning.ListCollectionsResponse
+	(*ReorderCollectionsRequest)(nil),          // 19: learning.ReorderCollectionsRequest
+	(*SetMaterialCollectionRequest)(nil),       // 20: learning.SetMaterialCollectionRequest
+	(*PublishDeckRequest)(nil),                 // 21: learning.PublishDeckRequest
+	(*PublicDeck)(nil),                         // 22: learning.PublicDeck
+	(*UnpublishDeckRequest)(nil),               // 23: learning.UnpublishDeckRequest
+	(*ListPublicDecksResponse)(nil),            // 24: learning.ListPublicDecksResponse
+	(*CloneDeckRequest)(nil),                   // 25: learning.CloneDeckRequest
+	(*CloneDeckResponse)(nil),                  // 26: learning.CloneDeckResponse
+	(*Organization)(nil),                       // 27: learning.Organization
+	(*CreateOrganizationRequest)(nil),          // 28: learning.CreateOrganizationRequest
+	(*ListOrganizationsResponse)(nil),          // 29: learning.ListOrganizationsResponse
+	(*DeleteOrganizationRequest)(nil),          // 30: learning.DeleteOrganizationRequest
+	(*OrganizationMember)(nil),                 // 31: learning.OrganizationMember
+	(*GetOrganizationMembersRequest)(nil),      // 32: learning.GetOrganizationMembersRequest
+	(*OrganizationMembersResponse)(nil),        // 33: learning.OrganizationMembersResponse
+	(*AddOrganizationMemberRequest)(nil),       // 34: learning.AddOrganizationMemberRequest
+	(*SetOrganizationMemberRoleRequest)(nil),   // 35: learning.SetOrganizationMemberRoleRequest
+	(*RemoveOrganizationMemberRequest)(nil),    // 36: learning.RemoveOrganizationMemberRequest
+	(*ShareCollectionRequest)(nil),             // 37: learning.ShareCollectionRequest
+	(*ListOrganizationCollectionsRequest)(nil), // 38: learning.ListOrganizationCollectionsRequest
+	(*GetDeckProgressReportRequest)(nil),       // 39: learning.GetDeckProgressReportRequest
+	(*MemberProgress)(nil),                     // 40: learning.MemberProgress
+	(*DeckProgressReport)(nil),                 // 41: learning.DeckProgressReport
+	(*SetReportingOptOutRequest)(nil),          // 42: learning.SetReportingOptOutRequest
+	(*GetConceptGraphRequest)(nil),             // 43: learning.GetConceptGraphRequest
+	(*Concept)(nil),                            // 44: learning.Concept
+	(*ConceptEdge)(nil),                        // 45: learning.ConceptEdge
+	(*ConceptGraph)(nil),                       // 46: learning.ConceptGraph
+	(*GetRelatedMaterialsRequest)(nil),         // 47: learning.GetRelatedMaterialsRequest
+	(*RelatedMaterial)(nil),                    // 48: learning.RelatedMaterial
+	(*GetRelatedMaterialsResponse)(nil),        // 49: learning.GetRelatedMaterialsResponse
+	(*TagInfo)(nil),                            // 50: learning.TagInfo
+	(*ListTagsResponse)(nil),                   // 51: learning.ListTagsResponse
+	(*RenameTagRequest)(nil),                   // 52: learning.RenameTagRequest
+	(*MergeTagsRequest)(nil),                   // 53: learning.MergeTagsRequest
+	(*DeleteTagRequest)(nil),                   // 54: learning.DeleteTagRequest
+	(*TagChangeResponse)(nil),                  // 55: learning.TagChangeResponse
+	(*SetMaterialTagsRequest)(nil),             // 56: learning.SetMaterialTagsRequest
+	(*SetMaterialTagsResponse)(nil),            // 57: learning.SetMaterialTagsResponse
+	(*GetTagMergeSuggestionsRequest)(nil),      // 58: learning.GetTagMergeSuggestionsRequest
+	(*TagMergeSuggestion)(nil),                 // 59: learning.TagMergeSuggestion
+	(*GetTagMergeSuggestionsResponse)(nil),     // 60: learning.GetTagMergeSuggestionsResponse
+	(*ResolveTagMergeSuggestionRequest)(nil),   // 61: learning.ResolveTagMergeSuggestionRequest
+	(*NotificationStatusResponse)(nil),         // 62: learning.NotificationStatusResponse
+	(*GetMaterialSummaryRequest)(nil),          // 63: learning.GetMaterialSummaryRequest
+	(*GetMaterialSummaryResponse)(nil),         // 64: learning.GetMaterialSummaryResponse
+	(*MaterialSummaryChunk)(nil),               // 65: learning.MaterialSummaryChunk
+	(*UpdateFlashcardRequest)(nil),             // 66: learning.UpdateFlashcardRequest
+	(*SetFlashcardDependenciesRequest)(nil),    // 67: learning.SetFlashcardDependenciesRequest
+	(*SearchLibraryRequest)(nil),               // 68: learning.SearchLibraryRequest
+	(*SearchResult)(nil),                       // 69: learning.SearchResult
+	(*SearchLibraryResponse)(nil),              // 70: learning.SearchLibraryResponse
+	(*AskLibraryRequest)(nil),                  // 71: learning.AskLibraryRequest
+	(*Citation)(nil),                           // 72: learning.Citation
+	(*AskLibraryChunk)(nil),                    // 73: learning.AskLibraryChunk
+	(*CreateFlashcardsFromAnswerRequest)(nil),  // 74: learning.CreateFlashcardsFromAnswerRequest
+	(*RegisterPushTokenRequest)(nil),           // 75: learning.RegisterPushTokenRequest
+	(*Source)(nil),                             // 76: learning.Source
+	(*CreateSourceRequest)(nil),                // 77: learning.CreateSourceRequest
+	(*ListSourcesResponse)(nil),                // 78: learning.ListSourcesResponse
+	(*DeleteSourceRequest)(nil),                // 79: learning.DeleteSourceRequest
+	(*StartImportRequest)(nil),                 // 80: learning.StartImportRequest
+	(*ImportJob)(nil),                          // 81: learning.ImportJob
+	(*ImportItem)(nil),                         // 82: learning.ImportItem
+	(*ListImportsResponse)(nil),                // 83: learning.ListImportsResponse
+	(*GetImportRequest)(nil),                   // 84: learning.GetImportRequest
+	(*CancelImportRequest)(nil),                // 85: learning.CancelImportRequest
+	(*timestamppb.Timestamp)(nil),              // 86: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                      // 87: google.protobuf.Empty
 }
 var file_backend_proto_learning_learning_proto_depIdxs = []int32{
-	3,  // 0: learning.GetDueMaterialsResponse.materials:type_name -> learning.MaterialSummary
-	17, // 1: learning.Flashcard.next_review_at:type_name -> google.protobuf.Timestamp
-	7,  // 2: learning.FlashcardList.flashcards:type_name -> learning.Flashcard
-	0,  // 3: learning.LearningService.AddMaterial:input_type -> learning.AddMaterialRequest
-	2,  // 4: learning.LearningService.DeleteMaterial:input_type -> learning.DeleteMaterialRequest
-	4,  // 5: learning.LearningService.GetDueMaterials:input_type -> learning.GetDueMaterialsRequest
-	6,  // 6: learning.LearningService.GetDueFlashcards:input_type -> learning.GetDueFlashcardsRequest
-	9,  // 7: learning.LearningService.CompleteReview:input_type -> learning.CompleteReviewRequest
-	10, // 8: learning.LearningService.FailReview:input_type -> learning.FailReviewRequest
-	18, // 9: learning.LearningService.GetAllTags:input_type -> google.protobuf.Empty
-	18, // 10: learning.LearningService.GetNotificationStatus:input_type -> google.protobuf.Empty
-	13, // 11: learning.LearningService.GetMaterialSummary:input_type -> learning.GetMaterialSummaryRequest
-	15, // 12: learning.LearningService.UpdateFlashcard:input_type -> learning.UpdateFlashcardRequest
-	16, // 13: learning.LearningService.RegisterPushToken:input_type -> learning.RegisterPushTokenRequest
-	1,  // 14: learning.LearningService.AddMaterial:output_type -> learning.AddMaterialResponse
-	18, // 15: learning.LearningService.DeleteMaterial:output_type -> google.protobuf.Empty
-	5,  // 16: learning.LearningService.GetDueMaterials:output_type -> learning.GetDueMaterialsResponse
-	8,  // 17: learning.LearningService.GetDueFlashcards:output_type -> learning.FlashcardList
-	18, // 18: learning.LearningService.CompleteReview:output_type -> google.protobuf.Empty
-	18, // 19: learning.LearningService.FailReview:output_type -> google.protobuf.Empty
-	11, // 20: learning.LearningService.GetAllTags:output_type -> learning.GetAllTagsResponse
-	12, // 21: learning.LearningService.GetNotificationStatus:output_type -> learning.NotificationStatusResponse
-	14, // 22: learning.LearningService.GetMaterialSummary:output_type -> learning.GetMaterialSummaryResponse
-	18, // 23: learning.LearningService.UpdateFlashcard:output_type -> google.protobuf.Empty
-	18, // 24: learning.LearningService.RegisterPushToken:output_type -> google.protobuf.Empty
-	14, // [14:25] is the sub-list for method output_type
-	3,  // [3:14] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	2,  // 0: learning.AddMaterialResponse.duplicate_of:type_name -> learning.DuplicateMaterial
+	7,  // 1: learning.GetDueMaterialsResponse.materials:type_name -> learning.MaterialSummary
+	86, // 2: learning.Flashcard.next_review_at:type_name -> google.protobuf.Timestamp
+	11, // 3: learning.FlashcardList.flashcards:type_name -> learning.Flashcard
+	16, // 4: learning.ListCollectionsResponse.collections:type_name -> learning.Collection
+	86, // 5: learning.PublicDeck.published_at:type_name -> google.protobuf.Timestamp
+	22, // 6: learning.ListPublicDecksResponse.decks:type_name -> learning.PublicDeck
+	86, // 7: learning.Organization.current_period_end:type_name -> google.protobuf.Timestamp
+	27, // 8: learning.ListOrganizationsResponse.organizations:type_name -> learning.Organization
+	86, // 9: learning.OrganizationMember.joined_at:type_name -> google.protobuf.Timestamp
+	31, // 10: learning.OrganizationMembersResponse.members:type_name -> learning.OrganizationMember
+	86, // 11: learning.MemberProgress.last_reviewed_at:type_name -> google.protobuf.Timestamp
+	40, // 12: learning.DeckProgressReport.members:type_name -> learning.MemberProgress
+	44, // 13: learning.ConceptGraph.concepts:type_name -> learning.Concept
+	45, // 14: learning.ConceptGraph.edges:type_name -> learning.ConceptEdge
+	48, // 15: learning.ConceptGraph.materials:type_name -> learning.RelatedMaterial
+	48, // 16: learning.GetRelatedMaterialsResponse.materials:type_name -> learning.RelatedMaterial
+	50, // 17: learning.ListTagsResponse.tags:type_name -> learning.TagInfo
+	59, // 18: learning.GetTagMergeSuggestionsResponse.suggestions:type_name -> learning.TagMergeSuggestion
+	11, // 19: learning.SearchResult.card:type_name -> learning.Flashcard
+	69, // 20: learning.SearchLibraryResponse.results:type_name -> learning.SearchResult
+	72, // 21: learning.AskLibraryChunk.citations:type_name -> learning.Citation
+	86, // 22: learning.Source.last_polled_at:type_name -> google.protobuf.Timestamp
+	86, // 23: learning.Source.created_at:type_name -> google.protobuf.Timestamp
+	76, // 24: learning.ListSourcesResponse.sources:type_name -> learning.Source
+	86, // 25: learning.ImportJob.created_at:type_name -> google.protobuf.Timestamp
+	82, // 26: learning.ImportJob.items:type_name -> learning.ImportItem
+	81, // 27: learning.ListImportsResponse.imports:type_name -> learning.ImportJob
+	0,  // 28: learning.LearningService.AddMaterial:input_type -> learning.AddMaterialRequest
+	3,  // 29: learning.LearningService.MergeIntoMaterial:input_type -> learning.MergeIntoMaterialRequest
+	4,  // 30: learning.LearningService.DeleteMaterial:input_type -> learning.DeleteMaterialRequest
+	5,  // 31: learning.LearningService.RefreshMaterial:input_type -> learning.RefreshMaterialRequest
+	8,  // 32: learning.LearningService.GetDueMaterials:input_type -> learning.GetDueMaterialsRequest
+	10, // 33: learning.LearningService.GetDueFlashcards:input_type -> learning.GetDueFlashcardsRequest
+	13, // 34: learning.LearningService.CompleteReview:input_type -> learning.CompleteReviewRequest
+	14, // 35: learning.LearningService.FailReview:input_type -> learning.FailReviewRequest
+	87, // 36: learning.LearningService.GetAllTags:input_type -> google.protobuf.Empty
+	16, // 37: learning.LearningService.CreateCollection:input_type -> learning.Collection
+	16, // 38: learning.LearningService.UpdateCollection:input_type -> learning.Collection
+	17, // 39: learning.LearningService.DeleteCollection:input_type -> learning.DeleteCollectionRequest
+	87, // 40: learning.LearningService.ListCollections:input_type -> google.protobuf.Empty
+	19, // 41: learning.LearningService.ReorderCollections:input_type -> learning.ReorderCollectionsRequest
+	20, // 42: learning.LearningService.SetMaterialCollection:input_type -> learning.SetMaterialCollectionRequest
+	21, // 43: learning.LearningService.PublishDeck:input_type -> learning.PublishDeckRequest
+	23, // 44: learning.LearningService.UnpublishDeck:input_type -> learning.UnpublishDeckRequest
+	87, // 45: learning.LearningService.ListPublicDecks:input_type -> google.protobuf.Empty
+	25, // 46: learning.LearningService.CloneDeck:input_type -> learning.CloneDeckRequest
+	28, // 47: learning.LearningService.CreateOrganization:input_type -> learning.CreateOrganizationRequest
+	87, // 48: learning.LearningService.ListOrganizations:input_type -> google.protobuf.Empty
+	30, // 49: learning.LearningService.DeleteOrganization:input_type -> learning.DeleteOrganizationRequest
+	32, // 50: learning.LearningService.GetOrganizationMembers:input_type -> learning.GetOrganizationMembersRequest
+	34, // 51: learning.LearningService.AddOrganizationMember:input_type -> learning.AddOrganizationMemberRequest
+	35, // 52: learning.LearningService.SetOrganizationMemberRole:input_type -> learning.SetOrganizationMemberRoleRequest
+	36, // 53: learning.LearningService.RemoveOrganizationMember:input_type -> learning.RemoveOrganizationMemberRequest
+	37, // 54: learning.LearningService.ShareCollection:input_type -> learning.ShareCollectionRequest
+	38, // 55: learning.LearningService.ListOrganizationCollections:input_type -> learning.ListOrganizationCollectionsRequest
+	39, // 56: learning.LearningService.GetDeckProgressReport:input_type -> learning.GetDeckProgressReportRequest
+	42, // 57: learning.LearningService.SetReportingOptOut:input_type -> learning.SetReportingOptOutRequest
+	43, // 58: learning.LearningService.GetConceptGraph:input_type -> learning.GetConceptGraphRequest
+	47, // 59: learning.LearningService.GetRelatedMaterials:input_type -> learning.GetRelatedMaterialsRequest
+	87, // 60: learning.LearningService.ListTags:input_type -> google.protobuf.Empty
+	52, // 61: learning.LearningService.RenameTag:input_type -> learning.RenameTagRequest
+	53, // 62: learning.LearningService.MergeTags:input_type -> learning.MergeTagsRequest
+	54, // 63: learning.LearningService.DeleteTag:input_type -> learning.DeleteTagRequest
+	56, // 64: learning.LearningService.SetMaterialTags:input_type -> learning.SetMaterialTagsRequest
+	58, // 65: learning.LearningService.GetTagMergeSuggestions:input_type -> learning.GetTagMergeSuggestionsRequest
+	61, // 66: learning.LearningService.ResolveTagMergeSuggestion:input_type -> learning.ResolveTagMergeSuggestionRequest
+	87, // 67: learning.LearningService.GetNotificationStatus:input_type -> google.protobuf.Empty
+	63, // 68: learning.LearningService.GetMaterialSummary:input_type -> learning.GetMaterialSummaryRequest
+	63, // 69: learning.LearningService.StreamMaterialSummary:input_type -> learning.GetMaterialSummaryRequest
+	66, // 70: learning.LearningService.UpdateFlashcard:input_type -> learning.UpdateFlashcardRequest
+	67, // 71: learning.LearningService.SetFlashcardDependencies:input_type -> learning.SetFlashcardDependenciesRequest
+	68, // 72: learning.LearningService.SearchLibrary:input_type -> learning.SearchLibraryRequest
+	71, // 73: learning.LearningService.AskLibrary:input_type -> learning.AskLibraryRequest
+	74, // 74: learning.LearningService.CreateFlashcardsFromAnswer:input_type -> learning.CreateFlashcardsFromAnswerRequest
+	75, // 75: learning.LearningService.RegisterPushToken:input_type -> learning.RegisterPushTokenRequest
+	77, // 76: learning.LearningService.CreateSource:input_type -> learning.CreateSourceRequest
+	87, // 77: learning.LearningService.ListSources:input_type -> google.protobuf.Empty
+	79, // 78: learning.LearningService.DeleteSource:input_type -> learning.DeleteSourceRequest
+	80, // 79: learning.LearningService.StartImport:input_type -> learning.StartImportRequest
+	87, // 80: learning.LearningService.ListImports:input_type -> google.protobuf.Empty
+	84, // 81: learning.LearningService.GetImport:input_type -> learning.GetImportRequest
+	85, // 82: learning.LearningService.CancelImport:input_type -> learning.CancelImportRequest
+	1,  // 83: learning.LearningService.AddMaterial:output_type -> learning.AddMaterialResponse
+	1,  // 84: learning.LearningService.MergeIntoMaterial:output_type -> learning.AddMaterialResponse
+	87, // 85: learning.LearningService.DeleteMaterial:output_type -> google.protobuf.Empty
+	6,  // 86: learning.LearningService.RefreshMaterial:output_type -> learning.RefreshMaterialResponse
+	9,  // 87: learning.LearningService.GetDueMaterials:output_type -> learning.GetDueMaterialsResponse
+	12, // 88: learning.LearningService.GetDueFlashcards:output_type -> learning.FlashcardList
+	87, // 89: learning.LearningService.CompleteReview:output_type -> google.protobuf.Empty
+	87, // 90: learning.LearningService.FailReview:output_type -> google.protobuf.Empty
+	15, // 91: learning.LearningService.GetAllTags:output_type -> learning.GetAllTagsResponse
+	16, // 92: learning.LearningService.CreateCollection:output_type -> learning.Collection
+	16, // 93: learning.LearningService.UpdateCollection:output_type -> learning.Collection
+	87, // 94: learning.LearningService.DeleteCollection:output_type -> google.protobuf.Empty
+	18, // 95: learning.LearningService.ListCollections:output_type -> learning.ListCollectionsResponse
+	87, // 96: learning.LearningService.ReorderCollections:output_type -> google.protobuf.Empty
+	87, // 97: learning.LearningService.SetMaterialCollection:output_type -> google.protobuf.Empty
+	22, // 98: learning.LearningService.PublishDeck:output_type -> learning.PublicDeck
+	87, // 99: learning.LearningService.UnpublishDeck:output_type -> google.protobuf.Empty
+	24, // 100: learning.LearningService.ListPublicDecks:output_type -> learning.ListPublicDecksResponse
+	26, // 101: learning.LearningService.CloneDeck:output_type -> learning.CloneDeckResponse
+	27, // 102: learning.LearningService.CreateOrganization:output_type -> learning.Organization
+	29, // 103: learning.LearningService.ListOrganizations:output_type -> learning.ListOrganizationsResponse
+	87, // 104: learning.LearningService.DeleteOrganization:output_type -> google.protobuf.Empty
+	33, // 105: learning.LearningService.GetOrganizationMembers:output_type -> learning.OrganizationMembersResponse
+	33, // 106: learning.LearningService.AddOrganizationMember:output_type -> learning.OrganizationMembersResponse
+	87, // 107: learning.LearningService.SetOrganizationMemberRole:output_type -> google.protobuf.Empty
+	87, // 108: learning.LearningService.RemoveOrganizationMember:output_type -> google.protobuf.Empty
+	87, // 109: learning.LearningService.ShareCollection:output_type -> google.protobuf.Empty
+	18, // 110: learning.LearningService.ListOrganizationCollections:output_type -> learning.ListCollectionsResponse
+	41, // 111: learning.LearningService.GetDeckProgressReport:output_type -> learning.DeckProgressReport
+	87, // 112: learning.LearningService.SetReportingOptOut:output_type -> google.protobuf.Empty
+	46, // 113: learning.LearningService.GetConceptGraph:output_type -> learning.ConceptGraph
+	49, // 114: learning.LearningService.GetRelatedMaterials:output_type -> learning.GetRelatedMaterialsResponse
+	51, // 115: learning.LearningService.ListTags:output_type -> learning.ListTagsResponse
+	55, // 116: learning.LearningService.RenameTag:output_type -> learning.TagChangeResponse
+	55, // 117: learning.LearningService.MergeTags:output_type -> learning.TagChangeResponse
+	55, // 118: learning.LearningService.DeleteTag:output_type -> learning.TagChangeResponse
+	57, // 119: learning.LearningService.SetMaterialTags:output_type -> learning.SetMaterialTagsResponse
+	60, // 120: learning.LearningService.GetTagMergeSuggestions:output_type -> learning.GetTagMergeSuggestionsResponse
+	87, // 121: learning.LearningService.ResolveTagMergeSuggestion:output_type -> google.protobuf.Empty
+	62, // 122: learning.LearningService.GetNotificationStatus:output_type -> learning.NotificationStatusResponse
+	64, // 123: learning.LearningService.GetMaterialSummary:output_type -> learning.GetMaterialSummaryResponse
+	65, // 124: learning.LearningService.StreamMaterialSummary:output_type -> learning.MaterialSummaryChunk
+	87, // 125: learning.LearningService.UpdateFlashcard:output_type -> google.protobuf.Empty
+	87, // 126: learning.LearningService.SetFlashcardDependencies:output_type -> google.protobuf.Empty
+	70, // 127: learning.LearningService.SearchLibrary:output_type -> learning.SearchLibraryResponse
+	73, // 128: learning.LearningService.AskLibrary:output_type -> learning.AskLibraryChunk
+	1,  // 129: learning.LearningService.CreateFlashcardsFromAnswer:output_type -> learning.AddMaterialResponse
+	87, // 130: learning.LearningService.RegisterPushToken:output_type -> google.protobuf.Empty
+	76, // 131: learning.LearningService.CreateSource:output_type -> learning.Source
+	78, // 132: learning.LearningService.ListSources:output_type -> learning.ListSourcesResponse
+	87, // 133: learning.LearningService.DeleteSource:output_type -> google.protobuf.Empty
+	81, // 134: learning.LearningService.StartImport:output_type -> learning.ImportJob
+	83, // 135: learning.LearningService.ListImports:output_type -> learning.ListImportsResponse
+	81, // 136: learning.LearningService.GetImport:output_type -> learning.ImportJob
+	81, // 137: learning.LearningService.CancelImport:output_type -> learning.ImportJob
+	83, // [83:138] is the sub-list for method output_type
+	28, // [28:83] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_backend_proto_learning_learning_proto_init() }
@@ -1229,7 +6216,7 @@ func file_backend_proto_learning_learning_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_backend_proto_learning_learning_proto_rawDesc), len(file_backend_proto_learning_learning_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   86,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
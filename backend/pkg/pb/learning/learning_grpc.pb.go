@@ -20,17 +20,61 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LearningService_AddMaterial_FullMethodName           = "/learning.LearningService/AddMaterial"
-	LearningService_DeleteMaterial_FullMethodName        = "/learning.LearningService/DeleteMaterial"
-	LearningService_GetDueMaterials_FullMethodName       = "/learning.LearningService/GetDueMaterials"
-	LearningService_GetDueFlashcards_FullMethodName      = "/learning.LearningService/GetDueFlashcards"
-	LearningService_CompleteReview_FullMethodName        = "/learning.LearningService/CompleteReview"
-	LearningService_FailReview_FullMethodName            = "/learning.LearningService/FailReview"
-	LearningService_GetAllTags_FullMethodName            = "/learning.LearningService/GetAllTags"
-	LearningService_GetNotificationStatus_FullMethodName = "/learning.LearningService/GetNotificationStatus"
-	LearningService_GetMaterialSummary_FullMethodName    = "/learning.LearningService/GetMaterialSummary"
-	LearningService_UpdateFlashcard_FullMethodName       = "/learning.LearningService/UpdateFlashcard"
-	LearningService_RegisterPushToken_FullMethodName     = "/learning.LearningService/RegisterPushToken"
+	LearningService_AddMaterial_FullMethodName                 = "/learning.LearningService/AddMaterial"
+	LearningService_MergeIntoMaterial_FullMethodName           = "/learning.LearningService/MergeIntoMaterial"
+	LearningService_DeleteMaterial_FullMethodName              = "/learning.LearningService/DeleteMaterial"
+	LearningService_RefreshMaterial_FullMethodName             = "/learning.LearningService/RefreshMaterial"
+	LearningService_GetDueMaterials_FullMethodName             = "/learning.LearningService/GetDueMaterials"
+	LearningService_GetDueFlashcards_FullMethodName            = "/learning.LearningService/GetDueFlashcards"
+	LearningService_CompleteReview_FullMethodName              = "/learning.LearningService/CompleteReview"
+	LearningService_FailReview_FullMethodName                  = "/learning.LearningService/FailReview"
+	LearningService_GetAllTags_FullMethodName                  = "/learning.LearningService/GetAllTags"
+	LearningService_CreateCollection_FullMethodName            = "/learning.LearningService/CreateCollection"
+	LearningService_UpdateCollection_FullMethodName            = "/learning.LearningService/UpdateCollection"
+	LearningService_DeleteCollection_FullMethodName            = "/learning.LearningService/DeleteCollection"
+	LearningService_ListCollections_FullMethodName             = "/learning.LearningService/ListCollections"
+	LearningService_ReorderCollections_FullMethodName          = "/learning.LearningService/ReorderCollections"
+	LearningService_SetMaterialCollection_FullMethodName       = "/learning.LearningService/SetMaterialCollection"
+	LearningService_PublishDeck_FullMethodName                 = "/learning.LearningService/PublishDeck"
+	LearningService_UnpublishDeck_FullMethodName               = "/learning.LearningService/UnpublishDeck"
+	LearningService_ListPublicDecks_FullMethodName             = "/learning.LearningService/ListPublicDecks"
+	LearningService_CloneDeck_FullMethodName                   = "/learning.LearningService/CloneDeck"
+	LearningService_CreateOrganization_FullMethodName          = "/learning.LearningService/CreateOrganization"
+	LearningService_ListOrganizations_FullMethodName           = "/learning.LearningService/ListOrganizations"
+	LearningService_DeleteOrganization_FullMethodName          = "/learning.LearningService/DeleteOrganization"
+	LearningService_GetOrganizationMembers_FullMethodName      = "/learning.LearningService/GetOrganizationMembers"
+	LearningService_AddOrganizationMember_FullMethodName       = "/learning.LearningService/AddOrganizationMember"
+	LearningService_SetOrganizationMemberRole_FullMethodName   = "/learning.LearningService/SetOrganizationMemberRole"
+	LearningService_RemoveOrganizationMember_FullMethodName    = "/learning.LearningService/RemoveOrganizationMember"
+	LearningService_ShareCollection_FullMethodName             = "/learning.LearningService/ShareCollection"
+	LearningService_ListOrganizationCollections_FullMethodName = "/learning.LearningService/ListOrganizationCollections"
+	LearningService_GetDeckProgressReport_FullMethodName       = "/learning.LearningService/GetDeckProgressReport"
+	LearningService_SetReportingOptOut_FullMethodName          = "/learning.LearningService/SetReportingOptOut"
+	LearningService_GetConceptGraph_FullMethodName             = "/learning.LearningService/GetConceptGraph"
+	LearningService_GetRelatedMaterials_FullMethodName         = "/learning.LearningService/GetRelatedMaterials"
+	LearningService_ListTags_FullMethodName                    = "/learning.LearningService/ListTags"
+	LearningService_RenameTag_FullMethodName                   = "/learning.LearningService/RenameTag"
+	LearningService_MergeTags_FullMethodName                   = "/learning.LearningService/MergeTags"
+	LearningService_DeleteTag_FullMethodName                   = "/learning.LearningService/DeleteTag"
+	LearningService_SetMaterialTags_FullMethodName             = "/learning.LearningService/SetMaterialTags"
+	LearningService_GetTagMergeSuggestions_FullMethodName      = "/learning.LearningService/GetTagMergeSuggestions"
+	LearningService_ResolveTagMergeSuggestion_FullMethodName   = "/learning.LearningService/ResolveTagMergeSuggestion"
+	LearningService_GetNotificationStatus_FullMethodName       = "/learning.LearningService/GetNotificationStatus"
+	LearningService_GetMaterialSummary_FullMethodName          = "/learning.LearningService/GetMaterialSummary"
+	LearningService_StreamMaterialSummary_FullMethodName       = "/learning.LearningService/StreamMaterialSummary"
+	LearningService_UpdateFlashcard_FullMethodName             = "/learning.LearningService/UpdateFlashcard"
+	LearningService_SetFlashcardDependencies_FullMethodName    = "/learning.LearningService/SetFlashcardDependencies"
+	LearningService_SearchLibrary_FullMethodName               = "/learning.LearningService/SearchLibrary"
+	LearningService_AskLibrary_FullMethodName                  = "/learning.LearningService/AskLibrary"
+	LearningService_CreateFlashcardsFromAnswer_FullMethodName  = "/learning.LearningService/CreateFlashcardsFromAnswer"
+	LearningService_RegisterPushToken_FullMethodName           = "/learning.LearningService/RegisterPushToken"
+	LearningService_CreateSource_FullMethodName                = "/learning.LearningService/CreateSource"
+	LearningService_ListSources_FullMethodName                 = "/learning.LearningService/ListSources"
+	LearningService_DeleteSource_FullMethodName                = "/learning.LearningService/DeleteSource"
+	LearningService_StartImport_FullMethodName                 = "/learning.LearningService/StartImport"
+	LearningService_ListImports_FullMethodName                 = "/learning.LearningService/ListImports"
+	LearningService_GetImport_FullMethodName                   = "/learning.LearningService/GetImport"
+	LearningService_CancelImport_FullMethodName                = "/learning.LearningService/CancelImport"
 )
 
 // LearningServiceClient is the client API for LearningService service.
@@ -38,16 +82,60 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LearningServiceClient interface {
 	AddMaterial(ctx context.Context, in *AddMaterialRequest, opts ...grpc.CallOption) (*AddMaterialResponse, error)
+	MergeIntoMaterial(ctx context.Context, in *MergeIntoMaterialRequest, opts ...grpc.CallOption) (*AddMaterialResponse, error)
 	DeleteMaterial(ctx context.Context, in *DeleteMaterialRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RefreshMaterial(ctx context.Context, in *RefreshMaterialRequest, opts ...grpc.CallOption) (*RefreshMaterialResponse, error)
 	GetDueMaterials(ctx context.Context, in *GetDueMaterialsRequest, opts ...grpc.CallOption) (*GetDueMaterialsResponse, error)
 	GetDueFlashcards(ctx context.Context, in *GetDueFlashcardsRequest, opts ...grpc.CallOption) (*FlashcardList, error)
 	CompleteReview(ctx context.Context, in *CompleteReviewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	FailReview(ctx context.Context, in *FailReviewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetAllTags(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetAllTagsResponse, error)
+	CreateCollection(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*Collection, error)
+	UpdateCollection(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*Collection, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCollections(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	ReorderCollections(ctx context.Context, in *ReorderCollectionsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetMaterialCollection(ctx context.Context, in *SetMaterialCollectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PublishDeck(ctx context.Context, in *PublishDeckRequest, opts ...grpc.CallOption) (*PublicDeck, error)
+	UnpublishDeck(ctx context.Context, in *UnpublishDeckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListPublicDecks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPublicDecksResponse, error)
+	CloneDeck(ctx context.Context, in *CloneDeckRequest, opts ...grpc.CallOption) (*CloneDeckResponse, error)
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	ListOrganizations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
+	DeleteOrganization(ctx context.Context, in *DeleteOrganizationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetOrganizationMembers(ctx context.Context, in *GetOrganizationMembersRequest, opts ...grpc.CallOption) (*OrganizationMembersResponse, error)
+	AddOrganizationMember(ctx context.Context, in *AddOrganizationMemberRequest, opts ...grpc.CallOption) (*OrganizationMembersResponse, error)
+	SetOrganizationMemberRole(ctx context.Context, in *SetOrganizationMemberRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveOrganizationMember(ctx context.Context, in *RemoveOrganizationMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ShareCollection(ctx context.Context, in *ShareCollectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListOrganizationCollections(ctx context.Context, in *ListOrganizationCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	GetDeckProgressReport(ctx context.Context, in *GetDeckProgressReportRequest, opts ...grpc.CallOption) (*DeckProgressReport, error)
+	SetReportingOptOut(ctx context.Context, in *SetReportingOptOutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetConceptGraph(ctx context.Context, in *GetConceptGraphRequest, opts ...grpc.CallOption) (*ConceptGraph, error)
+	GetRelatedMaterials(ctx context.Context, in *GetRelatedMaterialsRequest, opts ...grpc.CallOption) (*GetRelatedMaterialsResponse, error)
+	ListTags(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error)
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*TagChangeResponse, error)
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error)
+	SetMaterialTags(ctx context.Context, in *SetMaterialTagsRequest, opts ...grpc.CallOption) (*SetMaterialTagsResponse, error)
+	GetTagMergeSuggestions(ctx context.Context, in *GetTagMergeSuggestionsRequest, opts ...grpc.CallOption) (*GetTagMergeSuggestionsResponse, error)
+	ResolveTagMergeSuggestion(ctx context.Context, in *ResolveTagMergeSuggestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetNotificationStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NotificationStatusResponse, error)
 	GetMaterialSummary(ctx context.Context, in *GetMaterialSummaryRequest, opts ...grpc.CallOption) (*GetMaterialSummaryResponse, error)
+	StreamMaterialSummary(ctx context.Context, in *GetMaterialSummaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MaterialSummaryChunk], error)
 	UpdateFlashcard(ctx context.Context, in *UpdateFlashcardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetFlashcardDependencies(ctx context.Context, in *SetFlashcardDependenciesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SearchLibrary(ctx context.Context, in *SearchLibraryRequest, opts ...grpc.CallOption) (*SearchLibraryResponse, error)
+	AskLibrary(ctx context.Context, in *AskLibraryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AskLibraryChunk], error)
+	CreateFlashcardsFromAnswer(ctx context.Context, in *CreateFlashcardsFromAnswerRequest, opts ...grpc.CallOption) (*AddMaterialResponse, error)
 	RegisterPushToken(ctx context.Context, in *RegisterPushTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateSource(ctx context.Context, in *CreateSourceRequest, opts ...grpc.CallOption) (*Source, error)
+	ListSources(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSourcesResponse, error)
+	DeleteSource(ctx context.Context, in *DeleteSourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	StartImport(ctx context.Context, in *StartImportRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	ListImports(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListImportsResponse, error)
+	GetImport(ctx context.Context, in *GetImportRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	CancelImport(ctx context.Context, in *CancelImportRequest, opts ...grpc.CallOption) (*ImportJob, error)
 }
 
 type learningServiceClient struct {
@@ -68,6 +156,16 @@ func (c *learningServiceClient) AddMaterial(ctx context.Context, in *AddMaterial
 	return out, nil
 }
 
+func (c *learningServiceClient) MergeIntoMaterial(ctx context.Context, in *MergeIntoMaterialRequest, opts ...grpc.CallOption) (*AddMaterialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddMaterialResponse)
+	err := c.cc.Invoke(ctx, LearningService_MergeIntoMaterial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *learningServiceClient) DeleteMaterial(ctx context.Context, in *DeleteMaterialRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -78,6 +176,16 @@ func (c *learningServiceClient) DeleteMaterial(ctx context.Context, in *DeleteMa
 	return out, nil
 }
 
+func (c *learningServiceClient) RefreshMaterial(ctx context.Context, in *RefreshMaterialRequest, opts ...grpc.CallOption) (*RefreshMaterialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshMaterialResponse)
+	err := c.cc.Invoke(ctx, LearningService_RefreshMaterial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *learningServiceClient) GetDueMaterials(ctx context.Context, in *GetDueMaterialsRequest, opts ...grpc.CallOption) (*GetDueMaterialsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetDueMaterialsResponse)
@@ -128,319 +236,1711 @@ func (c *learningServiceClient) GetAllTags(ctx context.Context, in *emptypb.Empt
 	return out, nil
 }
 
-func (c *learningServiceClient) GetNotificationStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NotificationStatusResponse, error) {
+func (c *learningServiceClient) CreateCollection(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*Collection, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(NotificationStatusResponse)
-	err := c.cc.Invoke(ctx, LearningService_GetNotificationStatus_FullMethodName, in, out, cOpts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, LearningService_CreateCollection_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *learningServiceClient) GetMaterialSummary(ctx context.Context, in *GetMaterialSummaryRequest, opts ...grpc.CallOption) (*GetMaterialSummaryResponse, error) {
+func (c *learningServiceClient) UpdateCollection(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*Collection, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetMaterialSummaryResponse)
-	err := c.cc.Invoke(ctx, LearningService_GetMaterialSummary_FullMethodName, in, out, cOpts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, LearningService_UpdateCollection_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *learningServiceClient) UpdateFlashcard(ctx context.Context, in *UpdateFlashcardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *learningServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, LearningService_UpdateFlashcard_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, LearningService_DeleteCollection_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *learningServiceClient) RegisterPushToken(ctx context.Context, in *RegisterPushTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *learningServiceClient) ListCollections(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) ReorderCollections(ctx context.Context, in *ReorderCollectionsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, LearningService_RegisterPushToken_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, LearningService_ReorderCollections_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// LearningServiceServer is the server API for LearningService service.
-// All implementations must embed UnimplementedLearningServiceServer
-// for forward compatibility.
-type LearningServiceServer interface {
-	AddMaterial(context.Context, *AddMaterialRequest) (*AddMaterialResponse, error)
-	DeleteMaterial(context.Context, *DeleteMaterialRequest) (*emptypb.Empty, error)
-	GetDueMaterials(context.Context, *GetDueMaterialsRequest) (*GetDueMaterialsResponse, error)
-	GetDueFlashcards(context.Context, *GetDueFlashcardsRequest) (*FlashcardList, error)
-	CompleteReview(context.Context, *CompleteReviewRequest) (*emptypb.Empty, error)
-	FailReview(context.Context, *FailReviewRequest) (*emptypb.Empty, error)
-	GetAllTags(context.Context, *emptypb.Empty) (*GetAllTagsResponse, error)
-	GetNotificationStatus(context.Context, *emptypb.Empty) (*NotificationStatusResponse, error)
-	GetMaterialSummary(context.Context, *GetMaterialSummaryRequest) (*GetMaterialSummaryResponse, error)
-	UpdateFlashcard(context.Context, *UpdateFlashcardRequest) (*emptypb.Empty, error)
-	RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*emptypb.Empty, error)
-	mustEmbedUnimplementedLearningServiceServer()
+func (c *learningServiceClient) SetMaterialCollection(ctx context.Context, in *SetMaterialCollectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_SetMaterialCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedLearningServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedLearningServiceServer struct{}
+func (c *learningServiceClient) PublishDeck(ctx context.Context, in *PublishDeckRequest, opts ...grpc.CallOption) (*PublicDeck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicDeck)
+	err := c.cc.Invoke(ctx, LearningService_PublishDeck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedLearningServiceServer) AddMaterial(context.Context, *AddMaterialRequest) (*AddMaterialResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method AddMaterial not implemented")
+func (c *learningServiceClient) UnpublishDeck(ctx context.Context, in *UnpublishDeckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_UnpublishDeck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedLearningServiceServer) DeleteMaterial(context.Context, *DeleteMaterialRequest) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteMaterial not implemented")
+
+func (c *learningServiceClient) ListPublicDecks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPublicDecksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublicDecksResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListPublicDecks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedLearningServiceServer) GetDueMaterials(context.Context, *GetDueMaterialsRequest) (*GetDueMaterialsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetDueMaterials not implemented")
+
+func (c *learningServiceClient) CloneDeck(ctx context.Context, in *CloneDeckRequest, opts ...grpc.CallOption) (*CloneDeckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneDeckResponse)
+	err := c.cc.Invoke(ctx, LearningService_CloneDeck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedLearningServiceServer) GetDueFlashcards(context.Context, *GetDueFlashcardsRequest) (*FlashcardList, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetDueFlashcards not implemented")
+
+func (c *learningServiceClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, LearningService_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedLearningServiceServer) CompleteReview(context.Context, *CompleteReviewRequest) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method CompleteReview not implemented")
+
+func (c *learningServiceClient) ListOrganizations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationsResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListOrganizations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedLearningServiceServer) FailReview(context.Context, *FailReviewRequest) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method FailReview not implemented")
+
+func (c *learningServiceClient) DeleteOrganization(ctx context.Context, in *DeleteOrganizationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_DeleteOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedLearningServiceServer) GetAllTags(context.Context, *emptypb.Empty) (*GetAllTagsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetAllTags not implemented")
+
+func (c *learningServiceClient) GetOrganizationMembers(ctx context.Context, in *GetOrganizationMembersRequest, opts ...grpc.CallOption) (*OrganizationMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrganizationMembersResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetOrganizationMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedLearningServiceServer) GetNotificationStatus(context.Context, *emptypb.Empty) (*NotificationStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetNotificationStatus not implemented")
+
+func (c *learningServiceClient) AddOrganizationMember(ctx context.Context, in *AddOrganizationMemberRequest, opts ...grpc.CallOption) (*OrganizationMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrganizationMembersResponse)
+	err := c.cc.Invoke(ctx, LearningService_AddOrganizationMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) SetOrganizationMemberRole(ctx context.Context, in *SetOrganizationMemberRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_SetOrganizationMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) RemoveOrganizationMember(ctx context.Context, in *RemoveOrganizationMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_RemoveOrganizationMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) ShareCollection(ctx context.Context, in *ShareCollectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_ShareCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) ListOrganizationCollections(ctx context.Context, in *ListOrganizationCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListOrganizationCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetDeckProgressReport(ctx context.Context, in *GetDeckProgressReportRequest, opts ...grpc.CallOption) (*DeckProgressReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeckProgressReport)
+	err := c.cc.Invoke(ctx, LearningService_GetDeckProgressReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) SetReportingOptOut(ctx context.Context, in *SetReportingOptOutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_SetReportingOptOut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetConceptGraph(ctx context.Context, in *GetConceptGraphRequest, opts ...grpc.CallOption) (*ConceptGraph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConceptGraph)
+	err := c.cc.Invoke(ctx, LearningService_GetConceptGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetRelatedMaterials(ctx context.Context, in *GetRelatedMaterialsRequest, opts ...grpc.CallOption) (*GetRelatedMaterialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedMaterialsResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetRelatedMaterials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) ListTags(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagChangeResponse)
+	err := c.cc.Invoke(ctx, LearningService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*TagChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagChangeResponse)
+	err := c.cc.Invoke(ctx, LearningService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagChangeResponse)
+	err := c.cc.Invoke(ctx, LearningService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) SetMaterialTags(ctx context.Context, in *SetMaterialTagsRequest, opts ...grpc.CallOption) (*SetMaterialTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMaterialTagsResponse)
+	err := c.cc.Invoke(ctx, LearningService_SetMaterialTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetTagMergeSuggestions(ctx context.Context, in *GetTagMergeSuggestionsRequest, opts ...grpc.CallOption) (*GetTagMergeSuggestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTagMergeSuggestionsResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetTagMergeSuggestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) ResolveTagMergeSuggestion(ctx context.Context, in *ResolveTagMergeSuggestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_ResolveTagMergeSuggestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetNotificationStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NotificationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationStatusResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetNotificationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetMaterialSummary(ctx context.Context, in *GetMaterialSummaryRequest, opts ...grpc.CallOption) (*GetMaterialSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMaterialSummaryResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetMaterialSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) StreamMaterialSummary(ctx context.Context, in *GetMaterialSummaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MaterialSummaryChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LearningService_ServiceDesc.Streams[0], LearningService_StreamMaterialSummary_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetMaterialSummaryRequest, MaterialSummaryChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LearningService_StreamMaterialSummaryClient = grpc.ServerStreamingClient[MaterialSummaryChunk]
+
+func (c *learningServiceClient) UpdateFlashcard(ctx context.Context, in *UpdateFlashcardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_UpdateFlashcard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) SetFlashcardDependencies(ctx context.Context, in *SetFlashcardDependenciesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_SetFlashcardDependencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) SearchLibrary(ctx context.Context, in *SearchLibraryRequest, opts ...grpc.CallOption) (*SearchLibraryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchLibraryResponse)
+	err := c.cc.Invoke(ctx, LearningService_SearchLibrary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) AskLibrary(ctx context.Context, in *AskLibraryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AskLibraryChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LearningService_ServiceDesc.Streams[1], LearningService_AskLibrary_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AskLibraryRequest, AskLibraryChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LearningService_AskLibraryClient = grpc.ServerStreamingClient[AskLibraryChunk]
+
+func (c *learningServiceClient) CreateFlashcardsFromAnswer(ctx context.Context, in *CreateFlashcardsFromAnswerRequest, opts ...grpc.CallOption) (*AddMaterialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddMaterialResponse)
+	err := c.cc.Invoke(ctx, LearningService_CreateFlashcardsFromAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) RegisterPushToken(ctx context.Context, in *RegisterPushTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_RegisterPushToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) CreateSource(ctx context.Context, in *CreateSourceRequest, opts ...grpc.CallOption) (*Source, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Source)
+	err := c.cc.Invoke(ctx, LearningService_CreateSource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) ListSources(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSourcesResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) DeleteSource(ctx context.Context, in *DeleteSourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LearningService_DeleteSource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) StartImport(ctx context.Context, in *StartImportRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, LearningService_StartImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) ListImports(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListImportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListImportsResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListImports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetImport(ctx context.Context, in *GetImportRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, LearningService_GetImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) CancelImport(ctx context.Context, in *CancelImportRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, LearningService_CancelImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LearningServiceServer is the server API for LearningService service.
+// All implementations must embed UnimplementedLearningServiceServer
+// for forward compatibility.
+type LearningServiceServer interface {
+	AddMaterial(context.Context, *AddMaterialRequest) (*AddMaterialResponse, error)
+	MergeIntoMaterial(context.Context, *MergeIntoMaterialRequest) (*AddMaterialResponse, error)
+	DeleteMaterial(context.Context, *DeleteMaterialRequest) (*emptypb.Empty, error)
+	RefreshMaterial(context.Context, *RefreshMaterialRequest) (*RefreshMaterialResponse, error)
+	GetDueMaterials(context.Context, *GetDueMaterialsRequest) (*GetDueMaterialsResponse, error)
+	GetDueFlashcards(context.Context, *GetDueFlashcardsRequest) (*FlashcardList, error)
+	CompleteReview(context.Context, *CompleteReviewRequest) (*emptypb.Empty, error)
+	FailReview(context.Context, *FailReviewRequest) (*emptypb.Empty, error)
+	GetAllTags(context.Context, *emptypb.Empty) (*GetAllTagsResponse, error)
+	CreateCollection(context.Context, *Collection) (*Collection, error)
+	UpdateCollection(context.Context, *Collection) (*Collection, error)
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*emptypb.Empty, error)
+	ListCollections(context.Context, *emptypb.Empty) (*ListCollectionsResponse, error)
+	ReorderCollections(context.Context, *ReorderCollectionsRequest) (*emptypb.Empty, error)
+	SetMaterialCollection(context.Context, *SetMaterialCollectionRequest) (*emptypb.Empty, error)
+	PublishDeck(context.Context, *PublishDeckRequest) (*PublicDeck, error)
+	UnpublishDeck(context.Context, *UnpublishDeckRequest) (*emptypb.Empty, error)
+	ListPublicDecks(context.Context, *emptypb.Empty) (*ListPublicDecksResponse, error)
+	CloneDeck(context.Context, *CloneDeckRequest) (*CloneDeckResponse, error)
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*Organization, error)
+	ListOrganizations(context.Context, *emptypb.Empty) (*ListOrganizationsResponse, error)
+	DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*emptypb.Empty, error)
+	GetOrganizationMembers(context.Context, *GetOrganizationMembersRequest) (*OrganizationMembersResponse, error)
+	AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*OrganizationMembersResponse, error)
+	SetOrganizationMemberRole(context.Context, *SetOrganizationMemberRoleRequest) (*emptypb.Empty, error)
+	RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*emptypb.Empty, error)
+	ShareCollection(context.Context, *ShareCollectionRequest) (*emptypb.Empty, error)
+	ListOrganizationCollections(context.Context, *ListOrganizationCollectionsRequest) (*ListCollectionsResponse, error)
+	GetDeckProgressReport(context.Context, *GetDeckProgressReportRequest) (*DeckProgressReport, error)
+	SetReportingOptOut(context.Context, *SetReportingOptOutRequest) (*emptypb.Empty, error)
+	GetConceptGraph(context.Context, *GetConceptGraphRequest) (*ConceptGraph, error)
+	GetRelatedMaterials(context.Context, *GetRelatedMaterialsRequest) (*GetRelatedMaterialsResponse, error)
+	ListTags(context.Context, *emptypb.Empty) (*ListTagsResponse, error)
+	RenameTag(context.Context, *RenameTagRequest) (*TagChangeResponse, error)
+	MergeTags(context.Context, *MergeTagsRequest) (*TagChangeResponse, error)
+	DeleteTag(context.Context, *DeleteTagRequest) (*TagChangeResponse, error)
+	SetMaterialTags(context.Context, *SetMaterialTagsRequest) (*SetMaterialTagsResponse, error)
+	GetTagMergeSuggestions(context.Context, *GetTagMergeSuggestionsRequest) (*GetTagMergeSuggestionsResponse, error)
+	ResolveTagMergeSuggestion(context.Context, *ResolveTagMergeSuggestionRequest) (*emptypb.Empty, error)
+	GetNotificationStatus(context.Context, *emptypb.Empty) (*NotificationStatusResponse, error)
+	GetMaterialSummary(context.Context, *GetMaterialSummaryRequest) (*GetMaterialSummaryResponse, error)
+	StreamMaterialSummary(*GetMaterialSummaryRequest, grpc.ServerStreamingServer[MaterialSummaryChunk]) error
+	UpdateFlashcard(context.Context, *UpdateFlashcardRequest) (*emptypb.Empty, error)
+	SetFlashcardDependencies(context.Context, *SetFlashcardDependenciesRequest) (*emptypb.Empty, error)
+	SearchLibrary(context.Context, *SearchLibraryRequest) (*SearchLibraryResponse, error)
+	AskLibrary(*AskLibraryRequest, grpc.ServerStreamingServer[AskLibraryChunk]) error
+	CreateFlashcardsFromAnswer(context.Context, *CreateFlashcardsFromAnswerRequest) (*AddMaterialResponse, error)
+	RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*emptypb.Empty, error)
+	CreateSource(context.Context, *CreateSourceRequest) (*Source, error)
+	ListSources(context.Context, *emptypb.Empty) (*ListSourcesResponse, error)
+	DeleteSource(context.Context, *DeleteSourceRequest) (*emptypb.Empty, error)
+	StartImport(context.Context, *StartImportRequest) (*ImportJob, error)
+	ListImports(context.Context, *emptypb.Empty) (*ListImportsResponse, error)
+	GetImport(context.Context, *GetImportRequest) (*ImportJob, error)
+	CancelImport(context.Context, *CancelImportRequest) (*ImportJob, error)
+	mustEmbedUnimplementedLearningServiceServer()
+}
+
+// UnimplementedLearningServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLearningServiceServer struct{}
+
+func (UnimplementedLearningServiceServer) AddMaterial(context.Context, *AddMaterialRequest) (*AddMaterialResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddMaterial not implemented")
+}
+func (UnimplementedLearningServiceServer) MergeIntoMaterial(context.Context, *MergeIntoMaterialRequest) (*AddMaterialResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeIntoMaterial not implemented")
+}
+func (UnimplementedLearningServiceServer) DeleteMaterial(context.Context, *DeleteMaterialRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMaterial not implemented")
+}
+func (UnimplementedLearningServiceServer) RefreshMaterial(context.Context, *RefreshMaterialRequest) (*RefreshMaterialResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefreshMaterial not implemented")
+}
+func (UnimplementedLearningServiceServer) GetDueMaterials(context.Context, *GetDueMaterialsRequest) (*GetDueMaterialsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDueMaterials not implemented")
+}
+func (UnimplementedLearningServiceServer) GetDueFlashcards(context.Context, *GetDueFlashcardsRequest) (*FlashcardList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDueFlashcards not implemented")
+}
+func (UnimplementedLearningServiceServer) CompleteReview(context.Context, *CompleteReviewRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteReview not implemented")
+}
+func (UnimplementedLearningServiceServer) FailReview(context.Context, *FailReviewRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method FailReview not implemented")
+}
+func (UnimplementedLearningServiceServer) GetAllTags(context.Context, *emptypb.Empty) (*GetAllTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAllTags not implemented")
+}
+func (UnimplementedLearningServiceServer) CreateCollection(context.Context, *Collection) (*Collection, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedLearningServiceServer) UpdateCollection(context.Context, *Collection) (*Collection, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCollection not implemented")
+}
+func (UnimplementedLearningServiceServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedLearningServiceServer) ListCollections(context.Context, *emptypb.Empty) (*ListCollectionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedLearningServiceServer) ReorderCollections(context.Context, *ReorderCollectionsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReorderCollections not implemented")
+}
+func (UnimplementedLearningServiceServer) SetMaterialCollection(context.Context, *SetMaterialCollectionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMaterialCollection not implemented")
+}
+func (UnimplementedLearningServiceServer) PublishDeck(context.Context, *PublishDeckRequest) (*PublicDeck, error) {
+	return nil, status.Error(codes.Unimplemented, "method PublishDeck not implemented")
+}
+func (UnimplementedLearningServiceServer) UnpublishDeck(context.Context, *UnpublishDeckRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnpublishDeck not implemented")
+}
+func (UnimplementedLearningServiceServer) ListPublicDecks(context.Context, *emptypb.Empty) (*ListPublicDecksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPublicDecks not implemented")
+}
+func (UnimplementedLearningServiceServer) CloneDeck(context.Context, *CloneDeckRequest) (*CloneDeckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloneDeck not implemented")
+}
+func (UnimplementedLearningServiceServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*Organization, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedLearningServiceServer) ListOrganizations(context.Context, *emptypb.Empty) (*ListOrganizationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOrganizations not implemented")
+}
+func (UnimplementedLearningServiceServer) DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteOrganization not implemented")
+}
+func (UnimplementedLearningServiceServer) GetOrganizationMembers(context.Context, *GetOrganizationMembersRequest) (*OrganizationMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrganizationMembers not implemented")
+}
+func (UnimplementedLearningServiceServer) AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*OrganizationMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddOrganizationMember not implemented")
+}
+func (UnimplementedLearningServiceServer) SetOrganizationMemberRole(context.Context, *SetOrganizationMemberRoleRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOrganizationMemberRole not implemented")
+}
+func (UnimplementedLearningServiceServer) RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveOrganizationMember not implemented")
+}
+func (UnimplementedLearningServiceServer) ShareCollection(context.Context, *ShareCollectionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ShareCollection not implemented")
+}
+func (UnimplementedLearningServiceServer) ListOrganizationCollections(context.Context, *ListOrganizationCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOrganizationCollections not implemented")
+}
+func (UnimplementedLearningServiceServer) GetDeckProgressReport(context.Context, *GetDeckProgressReportRequest) (*DeckProgressReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeckProgressReport not implemented")
+}
+func (UnimplementedLearningServiceServer) SetReportingOptOut(context.Context, *SetReportingOptOutRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetReportingOptOut not implemented")
+}
+func (UnimplementedLearningServiceServer) GetConceptGraph(context.Context, *GetConceptGraphRequest) (*ConceptGraph, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConceptGraph not implemented")
+}
+func (UnimplementedLearningServiceServer) GetRelatedMaterials(context.Context, *GetRelatedMaterialsRequest) (*GetRelatedMaterialsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelatedMaterials not implemented")
+}
+func (UnimplementedLearningServiceServer) ListTags(context.Context, *emptypb.Empty) (*ListTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedLearningServiceServer) RenameTag(context.Context, *RenameTagRequest) (*TagChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedLearningServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*TagChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedLearningServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*TagChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedLearningServiceServer) SetMaterialTags(context.Context, *SetMaterialTagsRequest) (*SetMaterialTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMaterialTags not implemented")
+}
+func (UnimplementedLearningServiceServer) GetTagMergeSuggestions(context.Context, *GetTagMergeSuggestionsRequest) (*GetTagMergeSuggestionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTagMergeSuggestions not implemented")
+}
+func (UnimplementedLearningServiceServer) ResolveTagMergeSuggestion(context.Context, *ResolveTagMergeSuggestionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveTagMergeSuggestion not implemented")
+}
+func (UnimplementedLearningServiceServer) GetNotificationStatus(context.Context, *emptypb.Empty) (*NotificationStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotificationStatus not implemented")
+}
+func (UnimplementedLearningServiceServer) GetMaterialSummary(context.Context, *GetMaterialSummaryRequest) (*GetMaterialSummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMaterialSummary not implemented")
+}
+func (UnimplementedLearningServiceServer) StreamMaterialSummary(*GetMaterialSummaryRequest, grpc.ServerStreamingServer[MaterialSummaryChunk]) error {
+	return status.Error(codes.Unimplemented, "method StreamMaterialSummary not implemented")
+}
+func (UnimplementedLearningServiceServer) UpdateFlashcard(context.Context, *UpdateFlashcardRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateFlashcard not implemented")
+}
+func (UnimplementedLearningServiceServer) SetFlashcardDependencies(context.Context, *SetFlashcardDependenciesRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetFlashcardDependencies not implemented")
+}
+func (UnimplementedLearningServiceServer) SearchLibrary(context.Context, *SearchLibraryRequest) (*SearchLibraryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchLibrary not implemented")
+}
+func (UnimplementedLearningServiceServer) AskLibrary(*AskLibraryRequest, grpc.ServerStreamingServer[AskLibraryChunk]) error {
+	return status.Error(codes.Unimplemented, "method AskLibrary not implemented")
+}
+func (UnimplementedLearningServiceServer) CreateFlashcardsFromAnswer(context.Context, *CreateFlashcardsFromAnswerRequest) (*AddMaterialResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateFlashcardsFromAnswer not implemented")
+}
+func (UnimplementedLearningServiceServer) RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterPushToken not implemented")
+}
+func (UnimplementedLearningServiceServer) CreateSource(context.Context, *CreateSourceRequest) (*Source, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSource not implemented")
+}
+func (UnimplementedLearningServiceServer) ListSources(context.Context, *emptypb.Empty) (*ListSourcesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSources not implemented")
+}
+func (UnimplementedLearningServiceServer) DeleteSource(context.Context, *DeleteSourceRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSource not implemented")
+}
+func (UnimplementedLearningServiceServer) StartImport(context.Context, *StartImportRequest) (*ImportJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartImport not implemented")
+}
+func (UnimplementedLearningServiceServer) ListImports(context.Context, *emptypb.Empty) (*ListImportsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListImports not implemented")
+}
+func (UnimplementedLearningServiceServer) GetImport(context.Context, *GetImportRequest) (*ImportJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetImport not implemented")
+}
+func (UnimplementedLearningServiceServer) CancelImport(context.Context, *CancelImportRequest) (*ImportJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelImport not implemented")
+}
+func (UnimplementedLearningServiceServer) mustEmbedUnimplementedLearningServiceServer() {}
+func (UnimplementedLearningServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeLearningServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LearningServiceServer will
+// result in compilation errors.
+type UnsafeLearningServiceServer interface {
+	mustEmbedUnimplementedLearningServiceServer()
+}
+
+func RegisterLearningServiceServer(s grpc.ServiceRegistrar, srv LearningServiceServer) {
+	// If the following call panics, it indicates UnimplementedLearningServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LearningService_ServiceDesc, srv)
+}
+
+func _LearningService_AddMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).AddMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_AddMaterial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).AddMaterial(ctx, req.(*AddMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_MergeIntoMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeIntoMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).MergeIntoMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_MergeIntoMaterial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).MergeIntoMaterial(ctx, req.(*MergeIntoMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_DeleteMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).DeleteMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_DeleteMaterial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).DeleteMaterial(ctx, req.(*DeleteMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_RefreshMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).RefreshMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_RefreshMaterial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).RefreshMaterial(ctx, req.(*RefreshMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetDueMaterials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDueMaterialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetDueMaterials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetDueMaterials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetDueMaterials(ctx, req.(*GetDueMaterialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetDueFlashcards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDueFlashcardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetDueFlashcards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetDueFlashcards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetDueFlashcards(ctx, req.(*GetDueFlashcardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_CompleteReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).CompleteReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_CompleteReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).CompleteReview(ctx, req.(*CompleteReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_FailReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).FailReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_FailReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).FailReview(ctx, req.(*FailReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetAllTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetAllTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetAllTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetAllTags(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).CreateCollection(ctx, req.(*Collection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_UpdateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).UpdateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_UpdateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).UpdateCollection(ctx, req.(*Collection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ListCollections(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_ReorderCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ReorderCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ReorderCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ReorderCollections(ctx, req.(*ReorderCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_SetMaterialCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaterialCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).SetMaterialCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_SetMaterialCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).SetMaterialCollection(ctx, req.(*SetMaterialCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_PublishDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishDeckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).PublishDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_PublishDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).PublishDeck(ctx, req.(*PublishDeckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_UnpublishDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishDeckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).UnpublishDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_UnpublishDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).UnpublishDeck(ctx, req.(*UnpublishDeckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_ListPublicDecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ListPublicDecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ListPublicDecks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ListPublicDecks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_CloneDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneDeckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).CloneDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_CloneDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).CloneDeck(ctx, req.(*CloneDeckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_CreateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_ListOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ListOrganizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ListOrganizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ListOrganizations(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_DeleteOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).DeleteOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_DeleteOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).DeleteOrganization(ctx, req.(*DeleteOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetOrganizationMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetOrganizationMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetOrganizationMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetOrganizationMembers(ctx, req.(*GetOrganizationMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_AddOrganizationMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrganizationMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).AddOrganizationMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_AddOrganizationMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).AddOrganizationMember(ctx, req.(*AddOrganizationMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_SetOrganizationMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrganizationMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).SetOrganizationMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_SetOrganizationMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).SetOrganizationMemberRole(ctx, req.(*SetOrganizationMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_RemoveOrganizationMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveOrganizationMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).RemoveOrganizationMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_RemoveOrganizationMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).RemoveOrganizationMember(ctx, req.(*RemoveOrganizationMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_ShareCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ShareCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ShareCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ShareCollection(ctx, req.(*ShareCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_ListOrganizationCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ListOrganizationCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ListOrganizationCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ListOrganizationCollections(ctx, req.(*ListOrganizationCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetDeckProgressReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeckProgressReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetDeckProgressReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetDeckProgressReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetDeckProgressReport(ctx, req.(*GetDeckProgressReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_SetReportingOptOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReportingOptOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).SetReportingOptOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_SetReportingOptOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).SetReportingOptOut(ctx, req.(*SetReportingOptOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetConceptGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConceptGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetConceptGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetConceptGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetConceptGraph(ctx, req.(*GetConceptGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetRelatedMaterials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedMaterialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetRelatedMaterials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetRelatedMaterials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetRelatedMaterials(ctx, req.(*GetRelatedMaterialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ListTags(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_SetMaterialTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaterialTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).SetMaterialTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_SetMaterialTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).SetMaterialTags(ctx, req.(*SetMaterialTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLearningServiceServer) GetMaterialSummary(context.Context, *GetMaterialSummaryRequest) (*GetMaterialSummaryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetMaterialSummary not implemented")
+
+func _LearningService_GetTagMergeSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagMergeSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetTagMergeSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetTagMergeSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetTagMergeSuggestions(ctx, req.(*GetTagMergeSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLearningServiceServer) UpdateFlashcard(context.Context, *UpdateFlashcardRequest) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdateFlashcard not implemented")
+
+func _LearningService_ResolveTagMergeSuggestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveTagMergeSuggestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ResolveTagMergeSuggestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ResolveTagMergeSuggestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ResolveTagMergeSuggestion(ctx, req.(*ResolveTagMergeSuggestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLearningServiceServer) RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method RegisterPushToken not implemented")
+
+func _LearningService_GetNotificationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetNotificationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetNotificationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetNotificationStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLearningServiceServer) mustEmbedUnimplementedLearningServiceServer() {}
-func (UnimplementedLearningServiceServer) testEmbeddedByValue()                         {}
 
-// UnsafeLearningServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to LearningServiceServer will
-// result in compilation errors.
-type UnsafeLearningServiceServer interface {
-	mustEmbedUnimplementedLearningServiceServer()
+func _LearningService_GetMaterialSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaterialSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetMaterialSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetMaterialSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetMaterialSummary(ctx, req.(*GetMaterialSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterLearningServiceServer(s grpc.ServiceRegistrar, srv LearningServiceServer) {
-	// If the following call panics, it indicates UnimplementedLearningServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _LearningService_StreamMaterialSummary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetMaterialSummaryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	s.RegisterService(&LearningService_ServiceDesc, srv)
+	return srv.(LearningServiceServer).StreamMaterialSummary(m, &grpc.GenericServerStream[GetMaterialSummaryRequest, MaterialSummaryChunk]{ServerStream: stream})
 }
 
-func _LearningService_AddMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddMaterialRequest)
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LearningService_StreamMaterialSummaryServer = grpc.ServerStreamingServer[MaterialSummaryChunk]
+
+func _LearningService_UpdateFlashcard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFlashcardRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).AddMaterial(ctx, in)
+		return srv.(LearningServiceServer).UpdateFlashcard(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_AddMaterial_FullMethodName,
+		FullMethod: LearningService_UpdateFlashcard_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).AddMaterial(ctx, req.(*AddMaterialRequest))
+		return srv.(LearningServiceServer).UpdateFlashcard(ctx, req.(*UpdateFlashcardRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_DeleteMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteMaterialRequest)
+func _LearningService_SetFlashcardDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFlashcardDependenciesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).DeleteMaterial(ctx, in)
+		return srv.(LearningServiceServer).SetFlashcardDependencies(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_DeleteMaterial_FullMethodName,
+		FullMethod: LearningService_SetFlashcardDependencies_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).DeleteMaterial(ctx, req.(*DeleteMaterialRequest))
+		return srv.(LearningServiceServer).SetFlashcardDependencies(ctx, req.(*SetFlashcardDependenciesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_GetDueMaterials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDueMaterialsRequest)
+func _LearningService_SearchLibrary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchLibraryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).GetDueMaterials(ctx, in)
+		return srv.(LearningServiceServer).SearchLibrary(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_GetDueMaterials_FullMethodName,
+		FullMethod: LearningService_SearchLibrary_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).GetDueMaterials(ctx, req.(*GetDueMaterialsRequest))
+		return srv.(LearningServiceServer).SearchLibrary(ctx, req.(*SearchLibraryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_GetDueFlashcards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDueFlashcardsRequest)
+func _LearningService_AskLibrary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AskLibraryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LearningServiceServer).AskLibrary(m, &grpc.GenericServerStream[AskLibraryRequest, AskLibraryChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LearningService_AskLibraryServer = grpc.ServerStreamingServer[AskLibraryChunk]
+
+func _LearningService_CreateFlashcardsFromAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFlashcardsFromAnswerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).GetDueFlashcards(ctx, in)
+		return srv.(LearningServiceServer).CreateFlashcardsFromAnswer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_GetDueFlashcards_FullMethodName,
+		FullMethod: LearningService_CreateFlashcardsFromAnswer_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).GetDueFlashcards(ctx, req.(*GetDueFlashcardsRequest))
+		return srv.(LearningServiceServer).CreateFlashcardsFromAnswer(ctx, req.(*CreateFlashcardsFromAnswerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_CompleteReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CompleteReviewRequest)
+func _LearningService_RegisterPushToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPushTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).CompleteReview(ctx, in)
+		return srv.(LearningServiceServer).RegisterPushToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_CompleteReview_FullMethodName,
+		FullMethod: LearningService_RegisterPushToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).CompleteReview(ctx, req.(*CompleteReviewRequest))
+		return srv.(LearningServiceServer).RegisterPushToken(ctx, req.(*RegisterPushTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_FailReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FailReviewRequest)
+func _LearningService_CreateSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSourceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).FailReview(ctx, in)
+		return srv.(LearningServiceServer).CreateSource(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_FailReview_FullMethodName,
+		FullMethod: LearningService_CreateSource_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).FailReview(ctx, req.(*FailReviewRequest))
+		return srv.(LearningServiceServer).CreateSource(ctx, req.(*CreateSourceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_GetAllTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _LearningService_ListSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).GetAllTags(ctx, in)
+		return srv.(LearningServiceServer).ListSources(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_GetAllTags_FullMethodName,
+		FullMethod: LearningService_ListSources_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).GetAllTags(ctx, req.(*emptypb.Empty))
+		return srv.(LearningServiceServer).ListSources(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_GetNotificationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _LearningService_DeleteSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSourceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).GetNotificationStatus(ctx, in)
+		return srv.(LearningServiceServer).DeleteSource(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_GetNotificationStatus_FullMethodName,
+		FullMethod: LearningService_DeleteSource_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).GetNotificationStatus(ctx, req.(*emptypb.Empty))
+		return srv.(LearningServiceServer).DeleteSource(ctx, req.(*DeleteSourceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_GetMaterialSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMaterialSummaryRequest)
+func _LearningService_StartImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartImportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).GetMaterialSummary(ctx, in)
+		return srv.(LearningServiceServer).StartImport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_GetMaterialSummary_FullMethodName,
+		FullMethod: LearningService_StartImport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).GetMaterialSummary(ctx, req.(*GetMaterialSummaryRequest))
+		return srv.(LearningServiceServer).StartImport(ctx, req.(*StartImportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_UpdateFlashcard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateFlashcardRequest)
+func _LearningService_ListImports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).UpdateFlashcard(ctx, in)
+		return srv.(LearningServiceServer).ListImports(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_UpdateFlashcard_FullMethodName,
+		FullMethod: LearningService_ListImports_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).UpdateFlashcard(ctx, req.(*UpdateFlashcardRequest))
+		return srv.(LearningServiceServer).ListImports(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LearningService_RegisterPushToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterPushTokenRequest)
+func _LearningService_GetImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LearningServiceServer).RegisterPushToken(ctx, in)
+		return srv.(LearningServiceServer).GetImport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LearningService_RegisterPushToken_FullMethodName,
+		FullMethod: LearningService_GetImport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LearningServiceServer).RegisterPushToken(ctx, req.(*RegisterPushTokenRequest))
+		return srv.(LearningServiceServer).GetImport(ctx, req.(*GetImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_CancelImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).CancelImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_CancelImport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).CancelImport(ctx, req.(*CancelImportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -456,10 +1956,18 @@ var LearningService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddMaterial",
 			Handler:    _LearningService_AddMaterial_Handler,
 		},
+		{
+			MethodName: "MergeIntoMaterial",
+			Handler:    _LearningService_MergeIntoMaterial_Handler,
+		},
 		{
 			MethodName: "DeleteMaterial",
 			Handler:    _LearningService_DeleteMaterial_Handler,
 		},
+		{
+			MethodName: "RefreshMaterial",
+			Handler:    _LearningService_RefreshMaterial_Handler,
+		},
 		{
 			MethodName: "GetDueMaterials",
 			Handler:    _LearningService_GetDueMaterials_Handler,
@@ -480,6 +1988,126 @@ var LearningService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllTags",
 			Handler:    _LearningService_GetAllTags_Handler,
 		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _LearningService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "UpdateCollection",
+			Handler:    _LearningService_UpdateCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _LearningService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _LearningService_ListCollections_Handler,
+		},
+		{
+			MethodName: "ReorderCollections",
+			Handler:    _LearningService_ReorderCollections_Handler,
+		},
+		{
+			MethodName: "SetMaterialCollection",
+			Handler:    _LearningService_SetMaterialCollection_Handler,
+		},
+		{
+			MethodName: "PublishDeck",
+			Handler:    _LearningService_PublishDeck_Handler,
+		},
+		{
+			MethodName: "UnpublishDeck",
+			Handler:    _LearningService_UnpublishDeck_Handler,
+		},
+		{
+			MethodName: "ListPublicDecks",
+			Handler:    _LearningService_ListPublicDecks_Handler,
+		},
+		{
+			MethodName: "CloneDeck",
+			Handler:    _LearningService_CloneDeck_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _LearningService_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "ListOrganizations",
+			Handler:    _LearningService_ListOrganizations_Handler,
+		},
+		{
+			MethodName: "DeleteOrganization",
+			Handler:    _LearningService_DeleteOrganization_Handler,
+		},
+		{
+			MethodName: "GetOrganizationMembers",
+			Handler:    _LearningService_GetOrganizationMembers_Handler,
+		},
+		{
+			MethodName: "AddOrganizationMember",
+			Handler:    _LearningService_AddOrganizationMember_Handler,
+		},
+		{
+			MethodName: "SetOrganizationMemberRole",
+			Handler:    _LearningService_SetOrganizationMemberRole_Handler,
+		},
+		{
+			MethodName: "RemoveOrganizationMember",
+			Handler:    _LearningService_RemoveOrganizationMember_Handler,
+		},
+		{
+			MethodName: "ShareCollection",
+			Handler:    _LearningService_ShareCollection_Handler,
+		},
+		{
+			MethodName: "ListOrganizationCollections",
+			Handler:    _LearningService_ListOrganizationCollections_Handler,
+		},
+		{
+			MethodName: "GetDeckProgressReport",
+			Handler:    _LearningService_GetDeckProgressReport_Handler,
+		},
+		{
+			MethodName: "SetReportingOptOut",
+			Handler:    _LearningService_SetReportingOptOut_Handler,
+		},
+		{
+			MethodName: "GetConceptGraph",
+			Handler:    _LearningService_GetConceptGraph_Handler,
+		},
+		{
+			MethodName: "GetRelatedMaterials",
+			Handler:    _LearningService_GetRelatedMaterials_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _LearningService_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _LearningService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _LearningService_MergeTags_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _LearningService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "SetMaterialTags",
+			Handler:    _LearningService_SetMaterialTags_Handler,
+		},
+		{
+			MethodName: "GetTagMergeSuggestions",
+			Handler:    _LearningService_GetTagMergeSuggestions_Handler,
+		},
+		{
+			MethodName: "ResolveTagMergeSuggestion",
+			Handler:    _LearningService_ResolveTagMergeSuggestion_Handler,
+		},
 		{
 			MethodName: "GetNotificationStatus",
 			Handler:    _LearningService_GetNotificationStatus_Handler,
@@ -492,11 +2120,62 @@ var LearningService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateFlashcard",
 			Handler:    _LearningService_UpdateFlashcard_Handler,
 		},
+		{
+			MethodName: "SetFlashcardDependencies",
+			Handler:    _LearningService_SetFlashcardDependencies_Handler,
+		},
+		{
+			MethodName: "SearchLibrary",
+			Handler:    _LearningService_SearchLibrary_Handler,
+		},
+		{
+			MethodName: "CreateFlashcardsFromAnswer",
+			Handler:    _LearningService_CreateFlashcardsFromAnswer_Handler,
+		},
 		{
 			MethodName: "RegisterPushToken",
 			Handler:    _LearningService_RegisterPushToken_Handler,
 		},
+		{
+			MethodName: "CreateSource",
+			Handler:    _LearningService_CreateSource_Handler,
+		},
+		{
+			MethodName: "ListSources",
+			Handler:    _LearningService_ListSources_Handler,
+		},
+		{
+			MethodName: "DeleteSource",
+			Handler:    _LearningService_DeleteSource_Handler,
+		},
+		{
+			MethodName: "StartImport",
+			Handler:    _LearningService_StartImport_Handler,
+		},
+		{
+			MethodName: "ListImports",
+			Handler:    _LearningService_ListImports_Handler,
+		},
+		{
+			MethodName: "GetImport",
+			Handler:    _LearningService_GetImport_Handler,
+		},
+		{
+			MethodName: "CancelImport",
+			Handler:    _LearningService_CancelImport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMaterialSummary",
+			Handler:       _LearningService_StreamMaterialSummary_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AskLibrary",
+			Handler:       _LearningService_AskLibrary_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "backend/proto/learning/learning.proto",
 }
@@ -22,11 +22,13 @@ const (
 )
 
 type CreateSubscriptionOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`                // "PRO" or "FREE"
-	RedirectUrl   string                 `protobuf:"bytes,2,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"` // URL to redirect after payment success
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlanId         string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`                         // "PRO" or "FREE"
+	RedirectUrl    string                 `protobuf:"bytes,2,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`          // URL to redirect after payment success
+	OrganizationId string                 `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"` // Buy Pro seats for this organization (caller must be an owner)
+	Seats          int32                  `protobuf:"varint,4,opt,name=seats,proto3" json:"seats,omitempty"`                                        // Number of seats when organization_id is set
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateSubscriptionOrderRequest) Reset() {
@@ -73,6 +75,20 @@ func (x *CreateSubscriptionOrderRequest) GetRedirectUrl() string {
 	return ""
 }
 
+func (x *CreateSubscriptionOrderRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionOrderRequest) GetSeats() int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
 type CreateSubscriptionOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -153,10 +169,12 @@ var File_backend_proto_payment_payment_proto protoreflect.FileDescriptor
 
 const file_backend_proto_payment_payment_proto_rawDesc = "" +
 	"\n" +
-	"#backend/proto/payment/payment.proto\x12\apayment\"\\\n" +
+	"#backend/proto/payment/payment.proto\x12\apayment\"\x9b\x01\n" +
 	"\x1eCreateSubscriptionOrderRequest\x12\x17\n" +
 	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12!\n" +
-	"\fredirect_url\x18\x02 \x01(\tR\vredirectUrl\"\xaa\x01\n" +
+	"\fredirect_url\x18\x02 \x01(\tR\vredirectUrl\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\tR\x0eorganizationId\x12\x14\n" +
+	"\x05seats\x18\x04 \x01(\x05R\x05seats\"\xaa\x01\n" +
 	"\x1fCreateSubscriptionOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x02R\x06amount\x12\x1a\n" +
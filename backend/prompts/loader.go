@@ -33,3 +33,18 @@ var ToolEvaluateURLsBatchDesc string
 
 //go:embed url_batch_evaluation.txt
 var URLBatchEvaluation string
+
+//go:embed json_repair.txt
+var JSONRepair string
+
+//go:embed eval_judge.txt
+var EvalJudge string
+
+//go:embed ask_library.txt
+var AskLibrary string
+
+//go:embed tag_merges.txt
+var TagMerges string
+
+//go:embed concepts.txt
+var Concepts string
@@ -0,0 +1,90 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Names of prompts managed by the prompt registry
+const (
+	NameFlashcards = "flashcards"
+	NameSummary    = "summary"
+	NameOCR        = "ocr"
+	NameAskLibrary = "ask_library"
+	NameConcepts   = "concepts"
+)
+
+// FlashcardsData is the template data for the flashcards prompt
+type FlashcardsData struct {
+	ExistingTags string // Comma-separated tags the user already has
+	Content      string
+}
+
+// SummaryData is the template data for the summary prompt
+type SummaryData struct {
+	Content string
+}
+
+// OCRData is the template data for the OCR prompt, which has no fields
+type OCRData struct{}
+
+// AskLibraryData is the template data for the library Q&A prompt
+type AskLibraryData struct {
+	Question string
+	Sources  string // Numbered passages: "[n] Title (URL)\n<text>"
+}
+
+// TagMergesData is the template data for the tag merge suggestion prompt
+type TagMergesData struct {
+	Tags string // One tag per line: "name (N materials)"
+}
+
+// ConceptsData is the template data for the concept extraction prompt
+type ConceptsData struct {
+	ExistingConcepts string // One concept name per line
+	Flashcards       string // Numbered cards: "[n] Q: ... A: ..."
+	Content          string
+}
+
+// Builtin returns the embedded template for a registry-managed prompt
+// along with sample data used to validate new versions
+func Builtin(name string) (body string, sample interface{}, ok bool) {
+	switch name {
+	case NameFlashcards:
+		return Flashcards, FlashcardsData{ExistingTags: "go, databases", Content: "Sample text."}, true
+	case NameSummary:
+		return Summary, SummaryData{Content: "Sample text."}, true
+	case NameOCR:
+		return OCR, OCRData{}, true
+	case NameAskLibrary:
+		return AskLibrary, AskLibraryData{Question: "What is it?", Sources: "[1] Sample (https://example.com)\nSample text."}, true
+	case NameConcepts:
+		return Concepts, ConceptsData{ExistingConcepts: "Sample", Flashcards: "[1] Q: What? A: Sample.", Content: "Sample text."}, true
+	default:
+		return "", nil, false
+	}
+}
+
+// Render executes a text/template prompt; unknown fields are an error
+func Render(body string, data interface{}) (string, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// Validate checks that a template for the named prompt renders with that prompt's data
+func Validate(name, body string) error {
+	_, sample, ok := Builtin(name)
+	if !ok {
+		return fmt.Errorf("unknown prompt name '%s'", name)
+	}
+	_, err := Render(body, sample)
+	return err
+}